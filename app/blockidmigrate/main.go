@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"labyrinth/logger"
+	notebookLogic "labyrinth/notebook/logic/notebook"
+	"os"
+	"time"
+)
+
+// Перевод числовых ID блоков журналов в строки.
+// Использование: go run ./app/blockidmigrate [-dry-run]
+// Код выхода 1 - перенос не выполнен.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "только подсчитать журналы, не сохраняя изменения")
+	flag.Parse()
+
+	currentTime := time.Now()
+	dateDir := currentTime.Format("02_01_2006")
+	if err := os.MkdirAll(fmt.Sprintf("../logs/%s", dateDir), 0755); err != nil {
+		panic(fmt.Sprintf("Failed to create log directory: %v", err))
+	}
+	logger.InitFileLogger(fmt.Sprintf("../logs/%s/blockidmigrate_%s.log", dateDir, currentTime.Format("15_04")))
+
+	affected, err := notebookLogic.NewNotebookMongoLogic().MigrateBlockIds(*dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *dryRun {
+		fmt.Printf("%d notebooks have numeric block ids (dry run, nothing changed)\n", affected)
+	} else {
+		fmt.Printf("Converted block ids in %d notebooks\n", affected)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"labyrinth/logger"
+	chainLogic "labyrinth/notebook/logic/chain"
+	notebookLogic "labyrinth/notebook/logic/notebook"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Проверка цепочки хешей ревизий журналов: пересчитывает хеши и сообщает о любом разрыве.
+// Использование: go run ./app/chainverify (-notebook <uuid> | -company <uuid>)
+// Код выхода 2 - найдены нарушения, 1 - проверку не удалось выполнить.
+func main() {
+	notebookFlag := flag.String("notebook", "", "UUID журнала")
+	companyFlag := flag.String("company", "", "UUID компании: проверить все ее журналы")
+	flag.Parse()
+
+	if (*notebookFlag == "") == (*companyFlag == "") {
+		fmt.Fprintln(os.Stderr, "Specify exactly one of -notebook or -company")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	currentTime := time.Now()
+	dateDir := currentTime.Format("02_01_2006")
+	if err := os.MkdirAll(fmt.Sprintf("../logs/%s", dateDir), 0755); err != nil {
+		panic(fmt.Sprintf("Failed to create log directory: %v", err))
+	}
+	logger.InitFileLogger(fmt.Sprintf("../logs/%s/chainverify_%s.log", dateDir, currentTime.Format("15_04")))
+
+	logic := notebookLogic.NewNotebookMongoLogic()
+	var reports []chainLogic.Report
+	if *notebookFlag != "" {
+		notebookId, err := uuid.Parse(*notebookFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid notebook ID: %v\n", err)
+			os.Exit(1)
+		}
+		report, err := logic.VerifyChain(notebookId)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Verification failed: %v\n", err)
+			os.Exit(1)
+		}
+		reports = append(reports, *report)
+	} else {
+		companyId, err := uuid.Parse(*companyFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid company ID: %v\n", err)
+			os.Exit(1)
+		}
+		reports, err = logic.VerifyCompanyChain(companyId)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Verification failed: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	broken := 0
+	for _, r := range reports {
+		if r.Valid {
+			fmt.Printf("%s\tOK\trevisions=%d\tunchained=%d\thead=%s\n", r.NotebookID, r.Revisions, r.Unchained, r.Head)
+			continue
+		}
+		broken++
+		for _, b := range r.Breaks {
+			fmt.Printf("%s\tBROKEN\trevision=%d\t%s\t%s\n", r.NotebookID, b.Revision, b.Problem, b.Detail)
+		}
+	}
+
+	fmt.Printf("Checked %d notebooks, %d with broken chains\n", len(reports), broken)
+	if broken > 0 {
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"labyrinth/logger"
+	notebookLogic "labyrinth/notebook/logic/notebook"
+	"os"
+	"time"
+)
+
+// Перенос вложенных ответов на комментарии (sub_comments) в ветки по ParentId.
+// Использование: go run ./app/commentmigrate [-dry-run]
+// Код выхода 2 - часть журналов изменилась во время переноса, 1 - перенос не выполнен.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "только показать изменения, не сохраняя их")
+	flag.Parse()
+
+	currentTime := time.Now()
+	dateDir := currentTime.Format("02_01_2006")
+	if err := os.MkdirAll(fmt.Sprintf("../logs/%s", dateDir), 0755); err != nil {
+		panic(fmt.Sprintf("Failed to create log directory: %v", err))
+	}
+	logger.InitFileLogger(fmt.Sprintf("../logs/%s/commentmigrate_%s.log", dateDir, currentTime.Format("15_04")))
+
+	report, err := notebookLogic.NewNotebookMongoLogic().MigrateComments(*dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, id := range report.Updated {
+		fmt.Printf("%s\tUPDATED\n", id)
+	}
+	for _, id := range report.Skipped {
+		fmt.Printf("%s\tSKIPPED\n", id)
+	}
+
+	if *dryRun {
+		fmt.Printf("Scanned %d notebooks, %d would be updated (dry run, nothing changed)\n", report.Scanned, len(report.Updated))
+	} else {
+		fmt.Printf("Scanned %d notebooks, updated %d\n", report.Scanned, len(report.Updated))
+	}
+	if len(report.Skipped) > 0 {
+		fmt.Printf("%d notebooks changed during migration, run again to migrate them\n", len(report.Skipped))
+		os.Exit(2)
+	}
+}
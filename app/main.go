@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"labyrinth/config"
 	"labyrinth/logger"
+	"labyrinth/logic"
+	notebookLogic "labyrinth/notebook/logic"
 	"labyrinth/server"
 	"log"
 	"net/http"
@@ -47,11 +50,20 @@ func main() {
 
 	fmt.Printf("Server started on %s\n", httpServer.Addr)
 
+	// Периодический перенос присутствия сотрудников из Redis в PostgreSQL
+	flusherCtx, stopFlusher := context.WithCancel(context.Background())
+	defer stopFlusher()
+	go logic.NewBusinessLogic().Presence.RunPresenceFlusher(flusherCtx, config.Conf.Presence.FlushInterval)
+
+	// Окончательное удаление папок и журналов, срок хранения которых в корзине истек
+	go notebookLogic.NewFileSystem().Trash.RunTrashPurger(flusherCtx, config.Conf.Trash.PurgeInterval)
+
 	// Ожидание сигнала завершения
 	<-done
 	fmt.Println("\nServer is shutting down...")
 
 	// Graceful shutdown
+	stopFlusher()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
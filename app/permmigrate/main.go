@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"labyrinth/logger"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"os"
+	"time"
+)
+
+// Перевод списков разрешений журналов и папок с ID пользователей на ID сотрудников.
+// Использование: go run ./app/permmigrate [-dry-run]
+// Код выхода 2 - часть разрешений или ID перевести не удалось, 1 - перенос не выполнен.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "только показать изменения, не сохраняя их")
+	flag.Parse()
+
+	currentTime := time.Now()
+	dateDir := currentTime.Format("02_01_2006")
+	if err := os.MkdirAll(fmt.Sprintf("../logs/%s", dateDir), 0755); err != nil {
+		panic(fmt.Sprintf("Failed to create log directory: %v", err))
+	}
+	logger.InitFileLogger(fmt.Sprintf("../logs/%s/permmigrate_%s.log", dateDir, currentTime.Format("15_04")))
+
+	report, err := permissionLogic.NewPermissionMongoLogic().MigrateToEmployees(*dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, id := range report.Updated {
+		fmt.Printf("%s\tUPDATED\n", id)
+	}
+	for _, id := range report.Skipped {
+		fmt.Printf("%s\tSKIPPED\n", id)
+	}
+	for _, id := range report.Unresolved {
+		fmt.Printf("%s\tUNRESOLVED\n", id)
+	}
+
+	if *dryRun {
+		fmt.Printf("Scanned %d permissions, %d would be updated (dry run, nothing changed)\n", report.Scanned, len(report.Updated))
+	} else {
+		fmt.Printf("Scanned %d permissions, updated %d\n", report.Scanned, len(report.Updated))
+	}
+	if len(report.Skipped) > 0 || len(report.Unresolved) > 0 {
+		fmt.Printf("%d permissions skipped, %d IDs unresolved\n", len(report.Skipped), len(report.Unresolved))
+		os.Exit(2)
+	}
+}
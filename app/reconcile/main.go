@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"labyrinth/logger"
+	companylogic "labyrinth/logic/companyLogic"
+	"os"
+	"time"
+)
+
+// Сверка счетчика сотрудников компаний с фактическим числом активных сотрудников.
+// Использование: go run ./app/reconcile [-dry-run]
+func main() {
+	dryRun := flag.Bool("dry-run", false, "только показать расхождения, не исправляя их")
+	flag.Parse()
+
+	currentTime := time.Now()
+	dateDir := currentTime.Format("02_01_2006")
+	if err := os.MkdirAll(fmt.Sprintf("../logs/%s", dateDir), 0755); err != nil {
+		panic(fmt.Sprintf("Failed to create log directory: %v", err))
+	}
+	logger.InitFileLogger(fmt.Sprintf("../logs/%s/reconcile_%s.log", dateDir, currentTime.Format("15_04")))
+
+	discrepancies, err := companylogic.NewCompanyLogic().ReconcileEmployeesCount(*dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Reconciliation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(*discrepancies) == 0 {
+		fmt.Println("All company employee counters are consistent")
+		return
+	}
+
+	for _, d := range *discrepancies {
+		fmt.Printf("%s\t%s\tstored=%d\tactual=%d\n", d.CompanyID, d.Name, d.Stored, d.Actual)
+	}
+
+	if *dryRun {
+		fmt.Printf("Found %d discrepancies (dry run, nothing changed)\n", len(*discrepancies))
+		return
+	}
+	fmt.Printf("Fixed %d discrepancies\n", len(*discrepancies))
+}
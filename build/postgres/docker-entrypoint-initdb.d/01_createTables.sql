@@ -104,12 +104,59 @@ CREATE TABLE IF NOT EXISTS employee_department (
     position_id UUID,
     created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
-    is_active BOOLEAN DEFAULT true
+    is_active BOOLEAN DEFAULT true,
+    effective_from TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Начало членства в отделе
+    effective_to TIMESTAMPTZ                                       -- Окончание членства (NULL - действующее)
 );
 
+-- Базы, созданные до появления периодов членства: начало периода - создание связи,
+-- окончание у неактивных связей - их последнее изменение
+ALTER TABLE employee_department
+    ADD COLUMN IF NOT EXISTS effective_from TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS effective_to TIMESTAMPTZ;
+
+UPDATE employee_department
+SET effective_from = COALESCE(created_at, CURRENT_TIMESTAMP)
+WHERE effective_from IS NULL;
+
+UPDATE employee_department
+SET effective_to = COALESCE(updated_at, CURRENT_TIMESTAMP)
+WHERE is_active = false
+AND effective_to IS NULL;
+
+ALTER TABLE employee_department
+    ALTER COLUMN effective_from SET DEFAULT CURRENT_TIMESTAMP,
+    ALTER COLUMN effective_from SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS employee_department_period_idx
+    ON employee_department (department_id, effective_from, effective_to);
+
 CREATE TABLE IF NOT EXISTS department_positions (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     department_id UUID,
     level INTEGER NOT NULL,
     name TEXT
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,                 -- Получатель
+    company_id UUID NOT NULL,
+    kind VARCHAR(32) NOT NULL,             -- Вид уведомления: mention
+    actor_id UUID,                         -- Кто вызвал уведомление
+    notebook_id TEXT,
+    block_id TEXT,
+    comment_id TEXT,
+    title TEXT,
+    excerpt TEXT,
+    dedup_key TEXT NOT NULL,               -- Повторное упоминание в том же месте не создает новое уведомление
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    read_at TIMESTAMPTZ,                   -- NULL - не прочитано
+    UNIQUE (user_id, dedup_key)
+);
+
+CREATE INDEX IF NOT EXISTS notifications_inbox_idx
+    ON notifications (user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS notifications_unread_idx
+    ON notifications (user_id) WHERE read_at IS NULL;
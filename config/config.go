@@ -32,6 +32,18 @@ var Conf = &Config{
 		Password: "password",       // Пароль Redis
 		DB:       0,                // Номер базы данных
 	},
+	Presence: Presence{
+		TTL:           2 * time.Minute,  // Время, через которое сотрудник считается оффлайн
+		FlushInterval: 30 * time.Second, // Период сброса активности в PostgreSQL
+		TouchInterval: 30 * time.Second, // Запросы сотрудника отмечают присутствие не чаще этого периода
+	},
+	Mail: Mail{
+		Host:     "",                      // SMTP-сервер; пусто - письма только пишутся в лог
+		Port:     "587",                   // Порт SMTP
+		Username: "",                      // Имя пользователя SMTP
+		Password: "",                      // Пароль SMTP
+		From:     "labyrinth@example.com", // Адрес отправителя
+	},
 	Minio: Minio{
 		Endpoint:  "localhost:9000", // Адрес MinIO
 		AccessKey: "minioadmin",     // Ключ доступа
@@ -39,6 +51,10 @@ var Conf = &Config{
 		UseSSL:    false,            // Использование SSL
 		Bucket:    "mybucket",       // Имя бакета
 	},
+	Trash: Trash{
+		Retention:     30 * 24 * time.Hour, // Срок хранения удаленных папок и журналов
+		PurgeInterval: time.Hour,           // Период очистки корзины от просроченных элементов
+	},
 }
 
 type Config struct {
@@ -46,7 +62,10 @@ type Config struct {
 	PostgreSQL PostgreSQL `json:"postgresql"`
 	Mongo      Mongo      `json:"mongo"`
 	Redis      Redis      `json:"redis"`
+	Presence   Presence   `json:"presence"`
+	Mail       Mail       `json:"mail"`
 	Minio      Minio      `json:"minio"`
+	Trash      Trash      `json:"trash"`
 }
 
 type Network struct {
@@ -82,6 +101,20 @@ type Redis struct {
 	DB       int    `json:"db"`
 }
 
+type Presence struct {
+	TTL           time.Duration `json:"ttl"`
+	FlushInterval time.Duration `json:"flush_interval"`
+	TouchInterval time.Duration `json:"touch_interval"`
+}
+
+type Mail struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+}
+
 type Minio struct {
 	Endpoint  string `json:"endpoint"`
 	AccessKey string `json:"access_key"`
@@ -89,3 +122,8 @@ type Minio struct {
 	UseSSL    bool   `json:"use_ssl"`
 	Bucket    string `json:"bucket"`
 }
+
+type Trash struct {
+	Retention     time.Duration `json:"retention"`
+	PurgeInterval time.Duration `json:"purge_interval"`
+}
@@ -0,0 +1,30 @@
+package file
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// CopyFile копирует объект внутри хранилища без скачивания на сервер приложения
+func (f *FileMINIO) CopyFile(
+	ctx context.Context,
+	bucketName string,
+	srcObjectName string,
+	dstObjectName string,
+) error {
+	if bucketName == "" || srcObjectName == "" || dstObjectName == "" {
+		return fmt.Errorf("bucket and object names cannot be empty")
+	}
+
+	_, err := f.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucketName, Object: dstObjectName},
+		minio.CopySrcOptions{Bucket: bucketName, Object: srcObjectName},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to copy object: %w", err)
+	}
+
+	return nil
+}
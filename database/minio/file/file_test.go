@@ -119,6 +119,21 @@ func TestFileOperations(t *testing.T) {
 		}
 	})
 
+	t.Run("CopyFile", func(t *testing.T) {
+		copyName := fileName + "-copy"
+		if err := fileRepo.CopyFile(ctx, testBucket, fileName, copyName); err != nil {
+			t.Fatalf("CopyFile failed: %v", err)
+		}
+
+		exists, err := fileRepo.FileExists(ctx, testBucket, copyName)
+		if err != nil {
+			t.Fatalf("FileExists failed: %v", err)
+		}
+		if !exists {
+			t.Errorf("Expected copy %s to exist", copyName)
+		}
+	})
+
 	t.Run("DeleteFile", func(t *testing.T) {
 		err := fileRepo.DeleteFile(ctx, testBucket, fileName, minio.RemoveObjectOptions{})
 		if err != nil {
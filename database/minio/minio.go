@@ -2,11 +2,14 @@ package minio
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"labyrinth/config"
 	"labyrinth/database/minio/bucket"
 	"labyrinth/database/minio/file"
 
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
 type minioBucket interface {
@@ -57,6 +60,14 @@ type minioFile interface {
 		opts minio.RemoveObjectOptions,
 	) error
 
+	// CopyFile копирует файл внутри бакета
+	CopyFile(
+		ctx context.Context,
+		bucketName string,
+		srcObjectName string,
+		dstObjectName string,
+	) error
+
 	// FileExists проверяет существование файла
 	FileExists(
 		ctx context.Context,
@@ -76,3 +87,15 @@ func NewMinioDB(client *minio.Client) *MinioDB {
 		File:   file.NewFileMINIO(client),
 	}
 }
+
+// NewConnection создает клиент MinIO по настройкам из конфигурации
+func NewConnection() (*minio.Client, error) {
+	client, err := minio.New(config.Conf.Minio.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.Conf.Minio.AccessKey, config.Conf.Minio.SecretKey, ""),
+		Secure: config.Conf.Minio.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MinIO: %w", err)
+	}
+	return client, nil
+}
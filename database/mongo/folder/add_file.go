@@ -0,0 +1,56 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/directory"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AddFile добавляет ссылку на журнал в папку и увеличивает её ревизию.
+// В отличие от UpdateFolder не требует ожидаемой ревизии: добавление не затирает чужие правки.
+func (r *FolderMongo) AddFile(
+	ctx context.Context,
+	tx *mongo.Session,
+	folderId string,
+	file directory.File,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	if folderId == "" {
+		return 0, errors.New("folderId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": folderId}
+	update := bson.M{
+		"$push": bson.M{"files": file},
+		"$inc":  bson.M{"revision": 1},
+	}
+
+	var updated directory.Directory
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		err := r.collection.FindOneAndUpdate(
+			sc,
+			filter,
+			update,
+			options.FindOneAndUpdate().
+				SetProjection(bson.M{"revision": 1}).
+				SetReturnDocument(options.After),
+		).Decode(&updated)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("folder with id %s not found", folderId)
+		}
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to add file to folder: %w", err)
+	}
+
+	return updated.Revision, nil
+}
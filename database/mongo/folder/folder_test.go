@@ -2,10 +2,12 @@ package folder_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	m "labyrinth/database/mongo"
 	"labyrinth/database/mongo/folder"
 	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/revision"
 	"os"
 	"testing"
 	"time"
@@ -150,6 +152,8 @@ func TestFolderCRUD(t *testing.T) {
 
 	t.Run("UpdateFolder", func(t *testing.T) {
 		updatedDirectory := *testDirectory
+		updatedDirectory.Metadata.Title = "NEW TITLE"
+		updatedDirectory.Metadata.CompanyID = uuid.New().String()
 		updatedDirectory.Folders = append(updatedDirectory.Folders,
 			directory.Folder{
 				FolderID:    primitive.NewObjectID(),
@@ -163,10 +167,111 @@ func TestFolderCRUD(t *testing.T) {
 			Author: uuid.New().String(),
 		}
 
-		err = repo.UpdateFolder(ctx, &session, updatedDirectory.UuidID, &updatedDirectory)
+		newRevision, err := repo.UpdateFolder(ctx, &session, updatedDirectory.UuidID, &updatedDirectory, 0)
 		if err != nil {
 			t.Fatalf("UpdateFolder faield: %v\n", err)
 		}
+
+		if newRevision != 1 {
+			t.Errorf("Expected revision 1, got %d\n", newRevision)
+		}
+
+		fetched, err := repo.GetFolderByFolderId(ctx, &session, testDirectory.UuidID)
+		if err != nil {
+			t.Fatalf("GetFolderByFolderId failed: %v\n", err)
+		}
+		if fetched.Metadata.Title != "NEW TITLE" {
+			t.Errorf("Expected title to be updated, got %s\n", fetched.Metadata.Title)
+		}
+		if fetched.Metadata.CompanyID != testDirectory.Metadata.CompanyID || len(fetched.Folders) != len(testDirectory.Folders) {
+			t.Errorf("Expected company and subfolders to stay unchanged, got %s and %d subfolders\n",
+				fetched.Metadata.CompanyID, len(fetched.Folders))
+		}
+	})
+
+	t.Run("UpdateFolderStaleRevision", func(t *testing.T) {
+		_, err := repo.UpdateFolder(ctx, &session, testDirectory.UuidID, testDirectory, 0)
+
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("Expected revision conflict, got %v\n", err)
+		}
+
+		if conflict.Current != 1 {
+			t.Errorf("Expected current revision 1, got %d\n", conflict.Current)
+		}
+	})
+
+	t.Run("AddFile", func(t *testing.T) {
+		file := directory.NewFile(uuid.New(), "NOTEBOOK TITLE", "NOTEBOOK DESCRIPTION")
+
+		newRevision, err := repo.AddFile(ctx, &session, testDirectory.UuidID, file)
+		if err != nil {
+			t.Fatalf("AddFile failed: %v\n", err)
+		}
+
+		if newRevision != 2 {
+			t.Errorf("Expected revision 2, got %d\n", newRevision)
+		}
+
+		fetched, err := repo.GetFolderByFolderId(ctx, &session, testDirectory.UuidID)
+		if err != nil {
+			t.Fatalf("GetFolderByFolderId failed: %v\n", err)
+		}
+
+		if len(fetched.Files) != len(testDirectory.Files)+1 || fetched.Files[len(fetched.Files)-1].FileUUID != file.FileUUID {
+			t.Errorf("Expected file %s to be appended\n", file.FileUUID)
+		}
+	})
+
+	t.Run("GetFolderByFileIdAndRemoveFile", func(t *testing.T) {
+		fileId := testDirectory.Files[0].FileUUID
+
+		fetched, err := repo.GetFolderByFileId(ctx, &session, fileId)
+		if err != nil {
+			t.Fatalf("GetFolderByFileId failed: %v\n", err)
+		}
+		if fetched.UuidID != testDirectory.UuidID {
+			t.Errorf("Expected folder %s, got %s\n", testDirectory.UuidID, fetched.UuidID)
+		}
+
+		if err := repo.RemoveFile(ctx, &session, testDirectory.UuidID, fileId); err != nil {
+			t.Fatalf("RemoveFile failed: %v\n", err)
+		}
+
+		_, err = repo.GetFolderByFileId(ctx, &session, fileId)
+		if !errors.Is(err, directory.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound after RemoveFile, got %v\n", err)
+		}
+	})
+
+	t.Run("AddAndRemoveSubfolder", func(t *testing.T) {
+		subfolder := directory.NewFolder(uuid.New(), "SUBFOLDER TITLE", "")
+
+		if _, err := repo.AddSubfolder(ctx, &session, testDirectory.UuidID, subfolder); err != nil {
+			t.Fatalf("AddSubfolder failed: %v\n", err)
+		}
+
+		fetched, err := repo.GetFolderByFolderId(ctx, &session, testDirectory.UuidID)
+		if err != nil {
+			t.Fatalf("GetFolderByFolderId failed: %v\n", err)
+		}
+		count := len(fetched.Folders)
+		if count == 0 || fetched.Folders[count-1].FolderUUID != subfolder.FolderUUID {
+			t.Fatalf("Expected subfolder %s to be appended\n", subfolder.FolderUUID)
+		}
+
+		if err := repo.RemoveSubfolder(ctx, &session, testDirectory.UuidID, subfolder.FolderUUID); err != nil {
+			t.Fatalf("RemoveSubfolder failed: %v\n", err)
+		}
+
+		fetched, err = repo.GetFolderByFolderId(ctx, &session, testDirectory.UuidID)
+		if err != nil {
+			t.Fatalf("GetFolderByFolderId failed: %v\n", err)
+		}
+		if len(fetched.Folders) != count-1 {
+			t.Errorf("Expected subfolder %s to be removed\n", subfolder.FolderUUID)
+		}
 	})
 
 	t.Run("GetFoldersByParentId", func(t *testing.T) {
@@ -180,12 +285,70 @@ func TestFolderCRUD(t *testing.T) {
 		}
 
 		for _, value := range fetchedFolders {
-			if len(value.Folders) != 3 {
-				t.Errorf("Expected len 3, got %d\n", len(value.Folders))
+			if len(value.Folders) != 2 {
+				t.Errorf("Expected len 2, got %d\n", len(value.Folders))
 			}
 		}
 	})
 
+	t.Run("MoveFolder", func(t *testing.T) {
+		parentId := uuid.New().String()
+		divisionId := uuid.New().String()
+
+		if err := repo.MoveFolder(ctx, &session, testDirectory.UuidID, parentId, divisionId); err != nil {
+			t.Fatalf("MoveFolder failed: %v\n", err)
+		}
+
+		fetched, err := repo.GetFolderByFolderId(ctx, &session, testDirectory.UuidID)
+		if err != nil {
+			t.Fatalf("GetFolderByFolderId failed: %v\n", err)
+		}
+		if fetched.ParentId != parentId || fetched.Metadata.DivisionID != divisionId {
+			t.Errorf("Expected parent %s and division %s, got %s and %s\n",
+				parentId, divisionId, fetched.ParentId, fetched.Metadata.DivisionID)
+		}
+
+		err = repo.MoveFolder(ctx, &session, uuid.New().String(), parentId, divisionId)
+		if !errors.Is(err, directory.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for missing folder, got %v\n", err)
+		}
+	})
+
+	t.Run("SetDivision", func(t *testing.T) {
+		divisionId := uuid.New().String()
+
+		if err := repo.SetDivision(ctx, &session, []string{testDirectory.UuidID}, divisionId); err != nil {
+			t.Fatalf("SetDivision failed: %v\n", err)
+		}
+
+		fetched, err := repo.GetFolderByFolderId(ctx, &session, testDirectory.UuidID)
+		if err != nil {
+			t.Fatalf("GetFolderByFolderId failed: %v\n", err)
+		}
+		if fetched.Metadata.DivisionID != divisionId {
+			t.Errorf("Expected division %s, got %s\n", divisionId, fetched.Metadata.DivisionID)
+		}
+	})
+
+	t.Run("ReplaceAuthor", func(t *testing.T) {
+		newAuthor := uuid.New().String()
+		modified, err := repo.ReplaceAuthor(ctx, &session, testDirectory.Metadata.CompanyID, testDirectory.Metadata.Created.Author, newAuthor)
+		if err != nil {
+			t.Fatalf("ReplaceAuthor failed: %v\n", err)
+		}
+		if modified != 1 {
+			t.Errorf("Expected 1 replacement, got %d\n", modified)
+		}
+
+		fetched, err := repo.GetFolderByFolderId(ctx, &session, testDirectory.UuidID)
+		if err != nil {
+			t.Fatalf("GetFolderByFolderId failed: %v\n", err)
+		}
+		if fetched.Metadata.Created.Author != newAuthor {
+			t.Errorf("Expected author %s, got %s\n", newAuthor, fetched.Metadata.Created.Author)
+		}
+	})
+
 	t.Run("DeleteFolder", func(t *testing.T) {
 		err = repo.DeleteFolder(ctx, &session, testDirectory.UuidID)
 		if err != nil {
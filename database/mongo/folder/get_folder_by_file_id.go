@@ -0,0 +1,41 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/directory"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetFolderByFileId возвращает папку, в которой лежит журнал.
+// Журнал, созданный вне папки, ни в одной папке не числится: тогда возвращается directory.ErrNotFound.
+func (r *FolderMongo) GetFolderByFileId(
+	ctx context.Context,
+	tx *mongo.Session,
+	fileId string,
+) (*directory.Directory, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	if fileId == "" {
+		return nil, errors.New("fileId cannot be empty")
+	}
+
+	var result directory.Directory
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		err := r.collection.FindOne(sc, bson.M{"files.uuid_id": fileId}).Decode(&result)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return directory.ErrNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folder by file: %w", err)
+	}
+
+	return &result, nil
+}
@@ -40,7 +40,7 @@ func (r *FolderMongo) GetFolderByFolderId(
 		}
 
 		if len(results) == 0 {
-			return fmt.Errorf("folder not found")
+			return directory.ErrNotFound
 		}
 
 		return nil
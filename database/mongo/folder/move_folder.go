@@ -0,0 +1,51 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/directory"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MoveFolder меняет родителя и отдел папки и увеличивает её ревизию.
+// Ссылки в Folders старого и нового родителя обновляются отдельно, в той же транзакции.
+func (r *FolderMongo) MoveFolder(
+	ctx context.Context,
+	tx *mongo.Session,
+	folderId string,
+	parentId string,
+	divisionId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if folderId == "" || parentId == "" || divisionId == "" {
+		return errors.New("folderId, parentId and divisionId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": folderId}
+	update := bson.M{
+		"$set": bson.M{"parent_uuid_id": parentId, "metadata.division_id": divisionId},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return directory.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move folder: %w", err)
+	}
+
+	return nil
+}
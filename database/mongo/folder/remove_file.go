@@ -0,0 +1,43 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// RemoveFile убирает ссылку на журнал из папки.
+// Если папки нет или ссылки в ней нет, ничего не меняет.
+func (r *FolderMongo) RemoveFile(
+	ctx context.Context,
+	tx *mongo.Session,
+	folderId string,
+	fileId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if folderId == "" || fileId == "" {
+		return errors.New("folderId and fileId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": folderId, "files.uuid_id": fileId}
+	update := bson.M{
+		"$pull": bson.M{"files": bson.M{"uuid_id": fileId}},
+		"$inc":  bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		_, err := r.collection.UpdateOne(sc, filter, update)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove file from folder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// RemoveSubfolder убирает ссылку на вложенную папку из родительской папки.
+// Если родителя нет или ссылки в нем нет, ничего не меняет.
+func (r *FolderMongo) RemoveSubfolder(
+	ctx context.Context,
+	tx *mongo.Session,
+	folderId string,
+	subfolderId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if folderId == "" || subfolderId == "" {
+		return errors.New("folderId and subfolderId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": folderId, "folders.uuid_id": subfolderId}
+	update := bson.M{
+		"$pull": bson.M{"folders": bson.M{"uuid_id": subfolderId}},
+		"$inc":  bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		_, err := r.collection.UpdateOne(sc, filter, update)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove subfolder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReplaceAuthor заменяет ID автора from на to в папках компании: в создании и последнем изменении папки.
+// Используется при переводе авторов с ID пользователей на ID сотрудников. Возвращает число замен.
+func (r *FolderMongo) ReplaceAuthor(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	from string,
+	to string,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || from == "" || to == "" {
+		return 0, errors.New("companyId, from and to cannot be empty")
+	}
+
+	var modified int64
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		for _, field := range []string{"metadata.created.author", "metadata.last_update.author"} {
+			res, err := r.collection.UpdateMany(sc,
+				bson.M{"metadata.company_id": companyId, field: from},
+				bson.M{"$set": bson.M{field: to}},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to replace %s: %w", field, err)
+			}
+			modified += res.ModifiedCount
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace author: %w", err)
+	}
+
+	return modified, nil
+}
@@ -0,0 +1,46 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SetDivision переносит папки в другой отдел; используется для вложенных папок при перемещении поддерева
+func (r *FolderMongo) SetDivision(
+	ctx context.Context,
+	tx *mongo.Session,
+	folderIds []string,
+	divisionId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if divisionId == "" {
+		return errors.New("divisionId cannot be empty")
+	}
+
+	if len(folderIds) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"uuid_id": bson.M{"$in": folderIds}}
+	update := bson.M{
+		"$set": bson.M{"metadata.division_id": divisionId},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		_, err := r.collection.UpdateMany(sc, filter, update)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set folder division: %w", err)
+	}
+
+	return nil
+}
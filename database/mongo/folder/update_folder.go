@@ -5,74 +5,81 @@ import (
 	"errors"
 	"fmt"
 	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/revision"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"gopkg.in/mgo.v2/bson"
 )
 
+// UpdateFolder меняет название и описание папки, только если её ревизия равна expectedRevision.
+// Компания, отдел, теги и ссылки на вложенные папки и журналы меняются только своими методами.
+// Возвращает новую ревизию; при расхождении - *revision.ConflictError с текущей ревизией.
 func (r *FolderMongo) UpdateFolder(
 	ctx context.Context,
 	tx *mongo.Session,
 	folderId string,
 	updateData *directory.Directory,
-) error {
+	expectedRevision int64,
+) (int64, error) {
 	if tx == nil {
-		return errors.New("transaction session is required")
+		return 0, errors.New("transaction session is required")
 	}
 
 	if folderId == "" {
-		return errors.New("folderId cannot be empty")
+		return 0, errors.New("folderId cannot be empty")
 	}
 
 	if updateData == nil {
-		return errors.New("updateData cannot be nil")
+		return 0, errors.New("updateData cannot be nil")
 	}
 
-	filter := bson.M{"uuid_id": folderId}
+	filter := bson.M{"uuid_id": folderId, "revision": revision.Match(expectedRevision)}
 	update := bson.M{
 		"$set": bson.M{
-			"isPrimary": updateData.IsPrimary,
-			"version":   updateData.Version,
-			"metadata":  updateData.Metadata,
-			"folders":   updateData.Folders,
-			"files":     updateData.Files,
+			"metadata.title":       updateData.Metadata.Title,
+			"metadata.description": updateData.Metadata.Description,
+			"metadata.last_update": updateData.Metadata.LastUpdate,
 		},
+		"$inc": bson.M{"revision": 1},
 	}
 
+	var updated directory.Directory
 	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
-		count, err := r.collection.CountDocuments(
+		err := r.collection.FindOneAndUpdate(
 			sc,
 			filter,
-			options.Count().SetLimit(1),
-		)
-		if err != nil {
-			return fmt.Errorf("failed to check folder existence: %w", err)
+			update,
+			options.FindOneAndUpdate().
+				SetProjection(bson.M{"revision": 1}).
+				SetReturnDocument(options.After),
+		).Decode(&updated)
+		if err == nil {
+			return nil
 		}
-		if count == 0 {
-			return fmt.Errorf("folder with id %s not found", folderId)
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("failed to update folder: %w", err)
 		}
 
-		result, err := r.collection.UpdateOne(
+		var current directory.Directory
+		err = r.collection.FindOne(
 			sc,
-			filter,
-			update,
-			options.Update().SetUpsert(false),
-		)
+			bson.M{"uuid_id": folderId},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
 		if err != nil {
-			return fmt.Errorf("failed to update folder: %w", err)
-		}
-
-		if result.MatchedCount == 0 {
-			return fmt.Errorf("no folder matched for update with id %s", folderId)
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("folder with id %s not found", folderId)
+			}
+			return fmt.Errorf("failed to check folder existence: %w", err)
 		}
 
-		return nil
+		return &revision.ConflictError{Current: current.Revision}
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to execute folder update transaction: %w", err)
+		return 0, fmt.Errorf("failed to execute folder update transaction: %w", err)
 	}
 
-	return nil
+	return updated.Revision, nil
 }
@@ -7,9 +7,21 @@ import (
 	"labyrinth/database/mongo/folder"
 	"labyrinth/database/mongo/notebook"
 	mongoPerm "labyrinth/database/mongo/permission"
+	mongoRev "labyrinth/database/mongo/revision"
+	mongoSearch "labyrinth/database/mongo/search"
+	mongoTag "labyrinth/database/mongo/tag"
+	mongoTpl "labyrinth/database/mongo/template"
+	mongoTrash "labyrinth/database/mongo/trash"
+	mongoWorkflow "labyrinth/database/mongo/workflow"
 	"labyrinth/notebook/models/directory"
 	"labyrinth/notebook/models/journal"
 	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/search"
+	"labyrinth/notebook/models/tag"
+	"labyrinth/notebook/models/template"
+	"labyrinth/notebook/models/trash"
+	"labyrinth/notebook/models/workflow"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -24,13 +36,14 @@ type notebookMongo interface {
 		notebook *journal.Notebook,
 	) error
 
-	// UpdateNotebook
+	// UpdateNotebook обновляет журнал при совпадении ревизии и возвращает новую ревизию
 	UpdateNotebook(
 		ctx context.Context,
 		tx *mongo.Session,
 		notebookId string,
 		notebook *journal.Notebook,
-	) error
+		expectedRevision int64,
+	) (int64, error)
 
 	// ExistsNotebook
 	ExistsNotebook(
@@ -52,6 +65,150 @@ type notebookMongo interface {
 		tx *mongo.Session,
 		notebookId string,
 	) error
+
+	// InsertBlock вставляет блок в журнал на указанную позицию
+	InsertBlock(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		block *journal.Block,
+		position int,
+		lastUpdate journal.DateTimeAuthor,
+	) error
+
+	// UpdateBlock обновляет тип и содержимое блока по его ID
+	UpdateBlock(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		blockId string,
+		blockType string,
+		body map[string]any,
+		lastUpdate journal.DateTimeAuthor,
+	) error
+
+	// UpdateBlockAt обновляет блок, только если ревизия журнала не изменилась; возвращает новую ревизию
+	UpdateBlockAt(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		blockId string,
+		blockType string,
+		body map[string]any,
+		lastUpdate journal.DateTimeAuthor,
+		expectedRevision int64,
+	) (int64, error)
+
+	// MoveBlock переносит блок на новую позицию
+	MoveBlock(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		blockId string,
+		position int,
+		lastUpdate journal.DateTimeAuthor,
+	) error
+
+	// DeleteBlock удаляет блок по его ID
+	DeleteBlock(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		blockId string,
+		lastUpdate journal.DateTimeAuthor,
+	) error
+
+	// ConvertBlockIds переводит числовые ID блоков в строки и возвращает число затронутых журналов
+	ConvertBlockIds(
+		ctx context.Context,
+		tx *mongo.Session,
+		dryRun bool,
+	) (int64, error)
+
+	// GetLegacyComments возвращает журналы с комментариями без ID или с вложенными ответами
+	GetLegacyComments(
+		ctx context.Context,
+		tx *mongo.Session,
+	) ([]journal.LegacyNotebook, error)
+
+	// SetBlockComments заменяет комментарии блоков по позиции при совпадении ревизии, не увеличивая ее
+	SetBlockComments(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		comments map[int][]journal.Comment,
+		expectedRevision int64,
+	) error
+
+	// GetNotebookIds возвращает UUID всех журналов компании
+	GetNotebookIds(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+	) ([]string, error)
+
+	// AddSignature добавляет подпись к журналу той ревизии, по которой посчитан хеш
+	AddSignature(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		signature *journal.Signature,
+	) error
+
+	// SetDivision переносит журналы в другой отдел
+	SetDivision(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookIds []string,
+		divisionId string,
+	) error
+
+	// SetLifecycle сохраняет статус журнала после перехода при совпадении ревизии
+	SetLifecycle(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		lifecycle *journal.Lifecycle,
+		expectedRevision int64,
+	) error
+
+	// SetAuthors сохраняет поля журнала с авторами при совпадении ревизии
+	SetAuthors(
+		ctx context.Context,
+		tx *mongo.Session,
+		n *journal.Notebook,
+		expectedRevision int64,
+	) error
+
+	// AddComment добавляет комментарий или ответ в блок
+	AddComment(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		blockId string,
+		comment *journal.Comment,
+		lastUpdate journal.DateTimeAuthor,
+	) error
+
+	// UpdateComment заменяет комментарий блока по его ID
+	UpdateComment(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		blockId string,
+		comment *journal.Comment,
+		lastUpdate journal.DateTimeAuthor,
+	) error
+
+	// DeleteComment удаляет комментарий блока по его ID
+	DeleteComment(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		blockId string,
+		commentId string,
+		lastUpdate journal.DateTimeAuthor,
+	) error
 }
 
 type folderMongo interface {
@@ -62,12 +219,62 @@ type folderMongo interface {
 		folder *directory.Directory,
 	) error
 
-	// UpdateFolder обновляет существующую папку
+	// UpdateFolder обновляет существующую папку при совпадении ревизии
 	UpdateFolder(
 		ctx context.Context,
 		tx *mongo.Session,
 		folderId string,
 		updateData *directory.Directory,
+		expectedRevision int64,
+	) (int64, error)
+
+	// AddFile добавляет ссылку на журнал в папку
+	AddFile(
+		ctx context.Context,
+		tx *mongo.Session,
+		folderId string,
+		file directory.File,
+	) (int64, error)
+
+	// AddSubfolder добавляет ссылку на вложенную папку
+	AddSubfolder(
+		ctx context.Context,
+		tx *mongo.Session,
+		folderId string,
+		subfolder directory.Folder,
+	) (int64, error)
+
+	// RemoveSubfolder убирает ссылку на вложенную папку
+	RemoveSubfolder(
+		ctx context.Context,
+		tx *mongo.Session,
+		folderId string,
+		subfolderId string,
+	) error
+
+	// RemoveFile убирает ссылку на журнал из папки
+	RemoveFile(
+		ctx context.Context,
+		tx *mongo.Session,
+		folderId string,
+		fileId string,
+	) error
+
+	// MoveFolder меняет родителя и отдел папки
+	MoveFolder(
+		ctx context.Context,
+		tx *mongo.Session,
+		folderId string,
+		parentId string,
+		divisionId string,
+	) error
+
+	// SetDivision переносит папки в другой отдел
+	SetDivision(
+		ctx context.Context,
+		tx *mongo.Session,
+		folderIds []string,
+		divisionId string,
 	) error
 
 	GetFolderByFolderId(
@@ -76,6 +283,13 @@ type folderMongo interface {
 		folderId string,
 	) (*directory.Directory, error)
 
+	// GetFolderByFileId возвращает папку, в которой лежит журнал
+	GetFolderByFileId(
+		ctx context.Context,
+		tx *mongo.Session,
+		fileId string,
+	) (*directory.Directory, error)
+
 	// GetFoldersByParentId возвращает все папки по ID родительской папки
 	GetFoldersByParentId(
 		ctx context.Context,
@@ -97,6 +311,15 @@ type folderMongo interface {
 		sess *mongo.Session,
 		folderId string,
 	) (bool, error)
+
+	// ReplaceAuthor заменяет ID автора в папках компании
+	ReplaceAuthor(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		from string,
+		to string,
+	) (int64, error)
 }
 
 type permissionMongo interface {
@@ -107,13 +330,14 @@ type permissionMongo interface {
 		permission *permission.Permission,
 	) error
 
-	// UpdatePermission обновляет существующее разрешение
+	// UpdatePermission обновляет существующее разрешение при совпадении ревизии
 	UpdatePermission(
 		ctx context.Context,
 		tx *mongo.Session,
 		uuidId string,
 		updateData *permission.Permission,
-	) error
+		expectedRevision int64,
+	) (int64, error)
 
 	// GetPermissionByUuidId возвращает разрешение по UUID
 	GetPermissionByUuidId(
@@ -122,6 +346,21 @@ type permissionMongo interface {
 		uuidId string,
 	) (*permission.Permission, error)
 
+	// GetPermissionsByUuidIds возвращает разрешения нескольких ресурсов одним запросом
+	GetPermissionsByUuidIds(
+		ctx context.Context,
+		tx *mongo.Session,
+		uuidIds []string,
+	) ([]permission.Permission, error)
+
+	// ListPermissions возвращает страницу всех разрешений после afterUuid в порядке uuid_id
+	ListPermissions(
+		ctx context.Context,
+		tx *mongo.Session,
+		afterUuid string,
+		limit int64,
+	) ([]permission.Permission, error)
+
 	// DeletePermission удаляет разрешение
 	DeletePermission(
 		ctx context.Context,
@@ -136,12 +375,328 @@ type permissionMongo interface {
 		uuidId string,
 	) (bool, error)
 }
+
+type revisionMongo interface {
+	// CreateRevision сохраняет неизменяемый снимок журнала
+	CreateRevision(
+		ctx context.Context,
+		tx *mongo.Session,
+		rev *revision.Revision,
+	) error
+
+	// GetRevision возвращает ревизию журнала по номеру
+	GetRevision(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		number int64,
+	) (*revision.Revision, error)
+
+	// GetLatestRevision возвращает последнюю ревизию журнала
+	GetLatestRevision(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+	) (*revision.Revision, error)
+
+	// GetRevisions возвращает страницу истории журнала без снимков
+	GetRevisions(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+		limit, offset int64,
+	) ([]revision.Revision, int64, error)
+
+	// GetRevisionChain возвращает всю историю журнала со снимками для проверки цепочки хешей
+	GetRevisionChain(
+		ctx context.Context,
+		tx *mongo.Session,
+		notebookId string,
+	) ([]revision.Revision, error)
+}
+
+type templateMongo interface {
+	// CreateTemplate сохраняет новый шаблон журнала
+	CreateTemplate(
+		ctx context.Context,
+		tx *mongo.Session,
+		tpl *template.Template,
+	) error
+
+	// GetTemplateByUuidId возвращает шаблон по UUID
+	GetTemplateByUuidId(
+		ctx context.Context,
+		tx *mongo.Session,
+		templateId string,
+	) (*template.Template, error)
+
+	// GetTemplates возвращает шаблоны отдела и общие шаблоны компании
+	GetTemplates(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		divisionId string,
+	) ([]template.Template, error)
+
+	// UpdateTemplate обновляет шаблон при совпадении ревизии и возвращает новую ревизию
+	UpdateTemplate(
+		ctx context.Context,
+		tx *mongo.Session,
+		templateId string,
+		updateData *template.Template,
+		expectedRevision int64,
+	) (int64, error)
+
+	// DeleteTemplate удаляет шаблон
+	DeleteTemplate(
+		ctx context.Context,
+		tx *mongo.Session,
+		templateId string,
+	) error
+
+	// ReplaceAuthor заменяет ID автора в шаблонах компании
+	ReplaceAuthor(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		from string,
+		to string,
+	) (int64, error)
+}
+
+type searchMongo interface {
+	// EnsureIndexes создает текстовые индексы журналов и папок
+	EnsureIndexes(ctx context.Context) error
+
+	// SearchNotebooks ищет доступные сотруднику журналы; возвращает страницу и общее число совпадений
+	SearchNotebooks(
+		ctx context.Context,
+		tx *mongo.Session,
+		query search.Query,
+	) ([]search.Document, int64, error)
+
+	// SearchFolders ищет доступные сотруднику папки; возвращает страницу и общее число совпадений
+	SearchFolders(
+		ctx context.Context,
+		tx *mongo.Session,
+		query search.Query,
+	) ([]search.Document, int64, error)
+}
+
+type tagMongo interface {
+	// CreateTag добавляет тег в словарь компании
+	CreateTag(
+		ctx context.Context,
+		tx *mongo.Session,
+		t *tag.Tag,
+	) error
+
+	GetTagByUuidId(
+		ctx context.Context,
+		tx *mongo.Session,
+		tagId string,
+	) (*tag.Tag, error)
+
+	// GetTags возвращает теги компании, при непустом prefix - только начинающиеся с него
+	GetTags(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		prefix string,
+		limit int64,
+	) ([]tag.Tag, error)
+
+	// UpdateTag сохраняет имя и цвет тега
+	UpdateTag(
+		ctx context.Context,
+		tx *mongo.Session,
+		t *tag.Tag,
+	) error
+
+	// DeleteTag удаляет тег из словаря
+	DeleteTag(
+		ctx context.Context,
+		tx *mongo.Session,
+		tagId string,
+	) error
+
+	// ReplaceTagUsage заменяет имя тега в журналах, папках и шаблонах компании
+	ReplaceTagUsage(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		oldName string,
+		newName string,
+	) (int64, error)
+
+	// RemoveTagUsage снимает тег с журналов, папок и шаблонов компании
+	RemoveTagUsage(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		name string,
+	) (int64, error)
+
+	// TagResource отмечает журнал или папку тегом
+	TagResource(
+		ctx context.Context,
+		tx *mongo.Session,
+		kind string,
+		resourceId string,
+		name string,
+	) error
+
+	// UntagResource снимает тег с журнала или папки
+	UntagResource(
+		ctx context.Context,
+		tx *mongo.Session,
+		kind string,
+		resourceId string,
+		name string,
+	) error
+
+	// CountTags считает доступные сотруднику журналы по тегам
+	CountTags(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		employeeId string,
+	) (map[string]int64, error)
+
+	// GetTaggedNotebooks возвращает страницу доступных сотруднику журналов с тегом и их общее число
+	GetTaggedNotebooks(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		employeeId string,
+		name string,
+		limit int64,
+		offset int64,
+	) ([]tag.TaggedNotebook, int64, error)
+
+	// ReplaceAuthor заменяет ID автора в тегах компании
+	ReplaceAuthor(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		from string,
+		to string,
+	) (int64, error)
+}
+
+type workflowMongo interface {
+	// GetWorkflow возвращает процесс согласования компании или workflow.ErrNotFound
+	GetWorkflow(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+	) (*workflow.Workflow, error)
+
+	// SaveWorkflow создает или заменяет процесс согласования компании
+	SaveWorkflow(
+		ctx context.Context,
+		tx *mongo.Session,
+		w *workflow.Workflow,
+	) error
+
+	// GetReviewQueue возвращает журналы, ожидающие решения рецензента
+	GetReviewQueue(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		reviewerId string,
+	) ([]workflow.ReviewItem, error)
+
+	// ReplaceAuthor заменяет ID автора в процессе согласования компании
+	ReplaceAuthor(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		from string,
+		to string,
+	) (int64, error)
+}
+
+type trashMongo interface {
+	// CreateItem сохраняет элемент корзины вместе с документами удаленных папок и журналов
+	CreateItem(
+		ctx context.Context,
+		tx *mongo.Session,
+		item *trash.Item,
+		folders []directory.Directory,
+		notebooks []journal.Notebook,
+	) error
+
+	// GetItem возвращает элемент корзины или trash.ErrNotFound
+	GetItem(
+		ctx context.Context,
+		tx *mongo.Session,
+		itemId string,
+	) (*trash.Item, error)
+
+	// GetContents возвращает документы, убранные в корзину вместе с элементом
+	GetContents(
+		ctx context.Context,
+		tx *mongo.Session,
+		itemId string,
+	) ([]directory.Directory, []journal.Notebook, error)
+
+	// GetTrashedCompany возвращает компанию папки или журнала в корзине или trash.ErrNotFound
+	GetTrashedCompany(
+		ctx context.Context,
+		tx *mongo.Session,
+		resourceId string,
+	) (string, error)
+
+	// ListItems возвращает страницу корзины отдела и общее число элементов;
+	// при непустом employeeId - только элементы, доступные сотруднику для чтения
+	ListItems(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		divisionId string,
+		employeeId string,
+		limit int,
+		offset int,
+	) ([]trash.Item, int64, error)
+
+	// ListExpired возвращает элементы, срок хранения которых истек
+	ListExpired(
+		ctx context.Context,
+		tx *mongo.Session,
+		now time.Time,
+		limit int,
+	) ([]trash.Item, error)
+
+	// DeleteItem удаляет элемент корзины вместе с сохраненными документами
+	DeleteItem(
+		ctx context.Context,
+		tx *mongo.Session,
+		itemId string,
+	) error
+
+	// ReplaceAuthor заменяет ID автора в корзине компании
+	ReplaceAuthor(
+		ctx context.Context,
+		tx *mongo.Session,
+		companyId string,
+		from string,
+		to string,
+	) (int64, error)
+}
+
 type MongoDB struct {
 	Client     *mongo.Client
 	Database   *mongo.Database
 	Folder     folderMongo
 	Notebook   notebookMongo
 	Permission permissionMongo
+	Revision   revisionMongo
+	Template   templateMongo
+	Search     searchMongo
+	Tag        tagMongo
+	Workflow   workflowMongo
+	Trash      trashMongo
 }
 
 func NewMongoDB() (*MongoDB, error) {
@@ -156,6 +711,12 @@ func NewMongoDB() (*MongoDB, error) {
 		Folder:     folder.NewFolderMongo(db, "folder"),
 		Notebook:   notebook.NewNotebookMongo(db, "notebook"),
 		Permission: mongoPerm.NewPermissionMongo(db, "permission"),
+		Revision:   mongoRev.NewRevisionMongo(db, "notebook_revision"),
+		Template:   mongoTpl.NewTemplateMongo(db, "notebook_template"),
+		Search:     mongoSearch.NewSearchMongo(db, "notebook", "folder", "permission"),
+		Tag:        mongoTag.NewTagMongo(db, "notebook_tag", "notebook", "folder", "notebook_template", "permission"),
+		Workflow:   mongoWorkflow.NewWorkflowMongo(db, "notebook_workflow", "notebook"),
+		Trash:      mongoTrash.NewTrashMongo(db, "trash", "trash_folder", "trash_notebook", "permission"),
 	}, nil
 }
 
@@ -0,0 +1,65 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AddComment добавляет комментарий в блок; ответ добавляется, только если родительский комментарий есть в том же блоке
+func (r *NotebookMongo) AddComment(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	blockId string,
+	comment *journal.Comment,
+	lastUpdate journal.DateTimeAuthor,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" || blockId == "" {
+		return errors.New("uuidId and blockId cannot be empty")
+	}
+	if comment == nil || comment.Id == "" {
+		return errors.New("comment with ID is required")
+	}
+
+	match := bson.M{"id": blockId}
+	if comment.ParentId != "" {
+		match["comments.id"] = comment.ParentId
+	}
+
+	filter := bson.M{"uuid_id": uuidId, "blocks": bson.M{"$elemMatch": match}}
+	update := bson.M{
+		"$push": bson.M{"blocks.$[b].comments": comment},
+		"$set":  bson.M{"metadata.last_update": lastUpdate},
+		"$inc":  bson.M{"revision": 1},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"b.id": blockId}},
+	})
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update, opts)
+		if err != nil {
+			return fmt.Errorf("failed to add comment: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("block %s or parent comment not found in notebook %s", blockId, uuidId)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute comment insert: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AddSignature добавляет подпись, только если ревизия журнала равна signature.Revision:
+// так подписывается ровно то содержимое, по которому посчитан хеш.
+// При расхождении возвращает *revision.ConflictError с текущей ревизией.
+func (r *NotebookMongo) AddSignature(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	signature *journal.Signature,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" {
+		return errors.New("uuidId cannot be empty")
+	}
+	if signature == nil || signature.Id == "" || signature.Hash == "" {
+		return errors.New("signature with ID and hash is required")
+	}
+
+	filter := bson.M{"uuid_id": uuidId, "revision": revision.Match(signature.Revision)}
+	update := bson.M{
+		"$push": bson.M{"signatures": signature},
+		"$inc":  bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return fmt.Errorf("failed to add signature: %w", err)
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+
+		var current journal.Notebook
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": uuidId},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("notebook with uuid_id %s not found", uuidId)
+			}
+			return fmt.Errorf("failed to read notebook revision: %w", err)
+		}
+
+		return &revision.ConflictError{Current: current.Revision}
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute signature insert: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// numericBlockIds отбирает журналы, сохраненные до перехода на строковые ID блоков
+var numericBlockIds = bson.M{"blocks.id": bson.M{"$type": "number"}}
+
+// ConvertBlockIds переводит числовые ID блоков в строки и возвращает число затронутых журналов.
+// При dryRun журналы только подсчитываются.
+func (r *NotebookMongo) ConvertBlockIds(
+	ctx context.Context,
+	tx *mongo.Session,
+	dryRun bool,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	// Строковые ID остаются как есть, числовые заменяются своим строковым представлением
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"blocks": bson.M{"$map": bson.M{
+				"input": "$blocks",
+				"as":    "b",
+				"in": bson.M{"$mergeObjects": bson.A{
+					"$$b",
+					bson.M{"id": bson.M{"$cond": bson.A{
+						bson.M{"$isNumber": "$$b.id"},
+						bson.M{"$toString": "$$b.id"},
+						"$$b.id",
+					}}},
+				}},
+			}},
+		}}},
+	}
+
+	var affected int64
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		if dryRun {
+			count, err := r.collection.CountDocuments(sc, numericBlockIds)
+			if err != nil {
+				return fmt.Errorf("failed to count notebooks: %w", err)
+			}
+			affected = count
+			return nil
+		}
+
+		result, err := r.collection.UpdateMany(sc, numericBlockIds, update)
+		if err != nil {
+			return fmt.Errorf("failed to convert block ids: %w", err)
+		}
+		affected = result.ModifiedCount
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute block id conversion: %w", err)
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,51 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func (r *NotebookMongo) DeleteBlock(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	blockId string,
+	lastUpdate journal.DateTimeAuthor,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" || blockId == "" {
+		return errors.New("uuidId and blockId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": uuidId, "blocks.id": blockId}
+	update := bson.M{
+		"$pull": bson.M{"blocks": bson.M{"id": blockId}},
+		"$set":  bson.M{"metadata.last_update": lastUpdate},
+		"$inc":  bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return fmt.Errorf("failed to delete block: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("block %s not found in notebook %s", blockId, uuidId)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute block delete: %w", err)
+	}
+
+	return nil
+}
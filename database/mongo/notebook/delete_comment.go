@@ -0,0 +1,60 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DeleteComment удаляет комментарий из блока по его ID
+func (r *NotebookMongo) DeleteComment(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	blockId string,
+	commentId string,
+	lastUpdate journal.DateTimeAuthor,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" || blockId == "" || commentId == "" {
+		return errors.New("uuidId, blockId and commentId cannot be empty")
+	}
+
+	filter := bson.M{
+		"uuid_id": uuidId,
+		"blocks":  bson.M{"$elemMatch": bson.M{"id": blockId, "comments.id": commentId}},
+	}
+	update := bson.M{
+		"$pull": bson.M{"blocks.$[b].comments": bson.M{"id": commentId}},
+		"$set":  bson.M{"metadata.last_update": lastUpdate},
+		"$inc":  bson.M{"revision": 1},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"b.id": blockId}},
+	})
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update, opts)
+		if err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("comment %s not found in block %s", commentId, blockId)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute comment delete: %w", err)
+	}
+
+	return nil
+}
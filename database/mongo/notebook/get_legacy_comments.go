@@ -0,0 +1,49 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetLegacyComments возвращает журналы с комментариями в прежнем формате:
+// с вложенными ответами sub_comments или без ID
+func (r *NotebookMongo) GetLegacyComments(
+	ctx context.Context,
+	tx *mongo.Session,
+) ([]journal.LegacyNotebook, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	filter := bson.M{"$or": []bson.M{
+		{"blocks.comments.sub_comments.0": bson.M{"$exists": true}},
+		{"blocks": bson.M{"$elemMatch": bson.M{"comments": bson.M{"$elemMatch": bson.M{"id": bson.M{"$exists": false}}}}}},
+	}}
+	projection := bson.M{"uuid_id": 1, "revision": 1, "blocks.comments": 1}
+
+	var notebooks []journal.LegacyNotebook
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(sc, filter, options.Find().SetProjection(projection))
+		if err != nil {
+			return fmt.Errorf("failed to find notebooks: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if err := cursor.All(sc, &notebooks); err != nil {
+			return fmt.Errorf("failed to decode notebooks: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute legacy comments query: %w", err)
+	}
+
+	return notebooks, nil
+}
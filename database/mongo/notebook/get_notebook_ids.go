@@ -0,0 +1,54 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetNotebookIds возвращает UUID всех журналов компании
+func (r *NotebookMongo) GetNotebookIds(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+) ([]string, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if companyId == "" {
+		return nil, errors.New("companyId cannot be empty")
+	}
+
+	ids := []string{}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(
+			sc,
+			bson.M{"metadata.company_id": companyId},
+			options.Find().SetProjection(bson.M{"uuid_id": 1}).SetSort(bson.M{"uuid_id": 1}),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		for cursor.Next(sc) {
+			var doc struct {
+				UuidID string `bson:"uuid_id"`
+			}
+			if err := cursor.Decode(&doc); err != nil {
+				return fmt.Errorf("failed to decode notebook id: %w", err)
+			}
+			ids = append(ids, doc.UuidID)
+		}
+		return cursor.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notebook ids: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,60 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// InsertBlock вставляет блок в позицию position (position < 0 - в конец журнала)
+func (r *NotebookMongo) InsertBlock(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	block *journal.Block,
+	position int,
+	lastUpdate journal.DateTimeAuthor,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" {
+		return errors.New("uuidId cannot be empty")
+	}
+	if block == nil || block.Id == "" {
+		return errors.New("block with id is required")
+	}
+
+	push := bson.M{"$each": []journal.Block{*block}}
+	if position >= 0 {
+		push["$position"] = position
+	}
+
+	update := bson.M{
+		"$push": bson.M{"blocks": push},
+		"$set":  bson.M{"metadata.last_update": lastUpdate},
+		"$inc":  bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, bson.M{"uuid_id": uuidId}, update)
+		if err != nil {
+			return fmt.Errorf("failed to insert block: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("notebook with uuid_id %s not found", uuidId)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute block insert: %w", err)
+	}
+
+	return nil
+}
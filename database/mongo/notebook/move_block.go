@@ -0,0 +1,78 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MoveBlock переносит блок в позицию position (position < 0 - в конец журнала).
+// Извлечение и вставка выполняются двумя операциями, поэтому вызывать внутри транзакции.
+func (r *NotebookMongo) MoveBlock(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	blockId string,
+	position int,
+	lastUpdate journal.DateTimeAuthor,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" || blockId == "" {
+		return errors.New("uuidId and blockId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": uuidId, "blocks.id": blockId}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		// 1. Забираем блок вместе с удалением из массива
+		var before struct {
+			Blocks []journal.Block `bson:"blocks"`
+		}
+		err := r.collection.FindOneAndUpdate(
+			sc,
+			filter,
+			bson.M{"$pull": bson.M{"blocks": bson.M{"id": blockId}}},
+			options.FindOneAndUpdate().
+				SetProjection(bson.M{"blocks": bson.M{"$elemMatch": bson.M{"id": blockId}}}).
+				SetReturnDocument(options.Before),
+		).Decode(&before)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("block %s not found in notebook %s", blockId, uuidId)
+			}
+			return fmt.Errorf("failed to pull block: %w", err)
+		}
+		if len(before.Blocks) != 1 {
+			return fmt.Errorf("block %s not found in notebook %s", blockId, uuidId)
+		}
+
+		// 2. Вставляем блок на новую позицию
+		push := bson.M{"$each": before.Blocks}
+		if position >= 0 {
+			push["$position"] = position
+		}
+		_, err = r.collection.UpdateOne(sc, bson.M{"uuid_id": uuidId}, bson.M{
+			"$push": bson.M{"blocks": push},
+			"$set":  bson.M{"metadata.last_update": lastUpdate},
+			"$inc":  bson.M{"revision": 1},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to push block: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute block move: %w", err)
+	}
+
+	return nil
+}
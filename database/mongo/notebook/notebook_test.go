@@ -2,15 +2,18 @@ package notebook_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	m "labyrinth/database/mongo"
 	"labyrinth/database/mongo/notebook"
 	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -62,54 +65,54 @@ func setup() error {
 		},
 		Blocks: []journal.Block{
 			{
-				Id:   1,
+				Id:   "block-1",
 				Type: "title",
 				Body: map[string]any{
 					"text": "Заголовок документа",
 				},
 				Comment: []journal.Comment{
 					{
+						Id:         "comment-1",
 						EmployeeId: "emp-001",
 						CreatedAt:  time.Now().Add(-48 * time.Hour),
 						UpdatedAt:  time.Now().Add(-24 * time.Hour),
 						Comment:    "Нужно добавить раздел по маркетингу",
-						SubComment: []journal.Comment{
-							{
-								EmployeeId: "emp-002",
-								CreatedAt:  time.Now().Add(-12 * time.Hour),
-								UpdatedAt:  time.Now(),
-								Comment:    "Добавил раздел, проверьте",
-								SubComment: []journal.Comment{},
-							},
-						},
 					},
 					{
+						Id:         "comment-2",
+						ParentId:   "comment-1",
+						EmployeeId: "emp-002",
+						CreatedAt:  time.Now().Add(-12 * time.Hour),
+						UpdatedAt:  time.Now(),
+						Comment:    "Добавил раздел, проверьте",
+					},
+					{
+						Id:         "comment-3",
 						EmployeeId: "emp-003",
 						CreatedAt:  time.Now().Add(-1 * time.Hour),
 						UpdatedAt:  time.Now(),
 						Comment:    "Поправил заголовок",
-						SubComment: []journal.Comment{},
 					},
 				},
 			},
 			{
-				Id:   2,
+				Id:   "block-2",
 				Type: "text",
 				Body: map[string]any{
 					"content": "Основные показатели за год...",
 				},
 				Comment: []journal.Comment{
 					{
+						Id:         "comment-4",
 						EmployeeId: "emp-004",
 						CreatedAt:  time.Now().Add(-3 * time.Hour),
 						UpdatedAt:  time.Now().Add(-1 * time.Hour),
 						Comment:    "Нужно уточнить цифры",
-						SubComment: []journal.Comment{},
 					},
 				},
 			},
 			{
-				Id:   3,
+				Id:   "block-3",
 				Type: "table",
 				Body: map[string]any{
 					"columns": []string{"Показатель", "Значение"},
@@ -190,11 +193,37 @@ func TestNotebookCRUD(t *testing.T) {
 		updatedNotebook := *testNotebook
 		updatedNotebook.Metadata.Title = "UPDATED TITLE"
 		updatedNotebook.Metadata.Description = "UPDATED  DESCRIPTION"
+		updatedNotebook.Metadata.Tags = []string{"FORGED"}
 
-		err := repo.UpdateNotebook(ctx, &session, testNotebook.UuidID, &updatedNotebook)
+		newRevision, err := repo.UpdateNotebook(ctx, &session, testNotebook.UuidID, &updatedNotebook, 0)
 		if err != nil {
 			t.Fatalf("UpdateNotebook failed: %v\n", err)
 		}
+
+		if newRevision != 1 {
+			t.Errorf("Expected revision 1, got %d\n", newRevision)
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if len(fetchedNotebook.Metadata.Tags) != len(testNotebook.Metadata.Tags) {
+			t.Errorf("Expected tags %v to stay, got %v\n", testNotebook.Metadata.Tags, fetchedNotebook.Metadata.Tags)
+		}
+	})
+
+	t.Run("UpdateNotebookStaleRevision", func(t *testing.T) {
+		_, err := repo.UpdateNotebook(ctx, &session, testNotebook.UuidID, testNotebook, 0)
+
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("Expected revision conflict, got %v\n", err)
+		}
+
+		if conflict.Current != 1 {
+			t.Errorf("Expected current revision 1, got %d\n", conflict.Current)
+		}
 	})
 
 	t.Run("GetNotebookById", func(t *testing.T) {
@@ -208,6 +237,388 @@ func TestNotebookCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("GetNotebookIds", func(t *testing.T) {
+		ids, err := repo.GetNotebookIds(ctx, &session, testNotebook.Metadata.CompanyID)
+		if err != nil {
+			t.Fatalf("GetNotebookIds failed: %v\n", err)
+		}
+		if len(ids) != 1 || ids[0] != testNotebook.UuidID {
+			t.Errorf("Expected [%s], got %v\n", testNotebook.UuidID, ids)
+		}
+
+		ids, err = repo.GetNotebookIds(ctx, &session, uuid.New().String())
+		if err != nil || len(ids) != 0 {
+			t.Errorf("Expected no notebooks for another company, got %v (%v)\n", ids, err)
+		}
+	})
+
+	lastUpdate := journal.NewDateTimeAuthor(uuid.New().String())
+
+	t.Run("InsertBlock", func(t *testing.T) {
+		block := journal.NewBlock("text", map[string]any{"content": "Новый блок"})
+		err := repo.InsertBlock(ctx, &session, testNotebook.UuidID, &block, 0, lastUpdate)
+		if err != nil {
+			t.Fatalf("InsertBlock failed: %v\n", err)
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if len(fetchedNotebook.Blocks) != 4 || fetchedNotebook.Blocks[0].Id != block.Id {
+			t.Errorf("Expected block %s at position 0, got %+v\n", block.Id, fetchedNotebook.Blocks)
+		}
+	})
+
+	t.Run("UpdateBlock", func(t *testing.T) {
+		err := repo.UpdateBlock(ctx, &session, testNotebook.UuidID, "block-2", "text", map[string]any{"content": "UPDATED"}, lastUpdate)
+		if err != nil {
+			t.Fatalf("UpdateBlock failed: %v\n", err)
+		}
+
+		err = repo.UpdateBlock(ctx, &session, testNotebook.UuidID, "missing", "text", map[string]any{}, lastUpdate)
+		if err == nil {
+			t.Errorf("Expected error for missing block, got nil\n")
+		}
+	})
+
+	t.Run("UpdateBlockAt", func(t *testing.T) {
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+
+		newRevision, err := repo.UpdateBlockAt(ctx, &session, testNotebook.UuidID, "block-2", "text", map[string]any{"content": "AT REVISION"}, lastUpdate, fetchedNotebook.Revision)
+		if err != nil {
+			t.Fatalf("UpdateBlockAt failed: %v\n", err)
+		}
+		if newRevision != fetchedNotebook.Revision+1 {
+			t.Errorf("Expected revision %d, got %d\n", fetchedNotebook.Revision+1, newRevision)
+		}
+
+		_, err = repo.UpdateBlockAt(ctx, &session, testNotebook.UuidID, "block-2", "text", map[string]any{"content": "STALE"}, lastUpdate, fetchedNotebook.Revision)
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) || conflict.Current != newRevision {
+			t.Errorf("Expected revision conflict at %d, got %v\n", newRevision, err)
+		}
+
+		_, err = repo.UpdateBlockAt(ctx, &session, testNotebook.UuidID, "missing", "text", map[string]any{}, lastUpdate, newRevision)
+		if !errors.Is(err, journal.ErrBlockNotFound) {
+			t.Errorf("Expected ErrBlockNotFound, got %v\n", err)
+		}
+	})
+
+	t.Run("MoveBlock", func(t *testing.T) {
+		err := repo.MoveBlock(ctx, &session, testNotebook.UuidID, "block-3", 0, lastUpdate)
+		if err != nil {
+			t.Fatalf("MoveBlock failed: %v\n", err)
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if fetchedNotebook.Blocks[0].Id != "block-3" {
+			t.Errorf("Expected block-3 at position 0, got %s\n", fetchedNotebook.Blocks[0].Id)
+		}
+		if len(fetchedNotebook.Blocks[0].Comment) != 0 || fetchedNotebook.Blocks[0].Type != "table" {
+			t.Errorf("Moved block content changed: %+v\n", fetchedNotebook.Blocks[0])
+		}
+	})
+
+	rootComment := journal.NewComment(uuid.New().String(), "", "Проверьте навеску")
+
+	t.Run("AddComment", func(t *testing.T) {
+		err := repo.AddComment(ctx, &session, testNotebook.UuidID, "block-2", &rootComment, lastUpdate)
+		if err != nil {
+			t.Fatalf("AddComment failed: %v\n", err)
+		}
+
+		reply := journal.NewComment(uuid.New().String(), rootComment.Id, "Проверено")
+		err = repo.AddComment(ctx, &session, testNotebook.UuidID, "block-2", &reply, lastUpdate)
+		if err != nil {
+			t.Fatalf("AddComment reply failed: %v\n", err)
+		}
+
+		orphan := journal.NewComment(uuid.New().String(), "missing", "Нет родителя")
+		err = repo.AddComment(ctx, &session, testNotebook.UuidID, "block-2", &orphan, lastUpdate)
+		if err == nil {
+			t.Errorf("Expected error for missing parent comment, got nil\n")
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		block, err := fetchedNotebook.FindBlock("block-2")
+		if err != nil {
+			t.Fatalf("FindBlock failed: %v\n", err)
+		}
+		if len(block.Comment) != 3 || block.Comment[2].ParentId != rootComment.Id {
+			t.Errorf("Expected comment and reply, got %+v\n", block.Comment)
+		}
+	})
+
+	t.Run("UpdateComment", func(t *testing.T) {
+		rootComment.Comment = "Проверьте навеску повторно"
+		rootComment.Resolved = true
+		err := repo.UpdateComment(ctx, &session, testNotebook.UuidID, "block-2", &rootComment, lastUpdate)
+		if err != nil {
+			t.Fatalf("UpdateComment failed: %v\n", err)
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		block, _ := fetchedNotebook.FindBlock("block-2")
+		comment, err := block.FindComment(rootComment.Id)
+		if err != nil {
+			t.Fatalf("FindComment failed: %v\n", err)
+		}
+		if comment.Comment != rootComment.Comment || !comment.Resolved {
+			t.Errorf("Expected updated comment, got %+v\n", comment)
+		}
+	})
+
+	t.Run("DeleteComment", func(t *testing.T) {
+		err := repo.DeleteComment(ctx, &session, testNotebook.UuidID, "block-2", rootComment.Id, lastUpdate)
+		if err != nil {
+			t.Fatalf("DeleteComment failed: %v\n", err)
+		}
+
+		err = repo.DeleteComment(ctx, &session, testNotebook.UuidID, "block-2", rootComment.Id, lastUpdate)
+		if err == nil {
+			t.Errorf("Expected error for already deleted comment, got nil\n")
+		}
+	})
+
+	t.Run("AddSignature", func(t *testing.T) {
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+
+		signature := journal.NewSignature(journal.SignatureAuthor, uuid.New().String(), "Автор записи", "HASH", "", []string{"block-2"}, fetchedNotebook.Revision)
+		if err := repo.AddSignature(ctx, &session, testNotebook.UuidID, &signature); err != nil {
+			t.Fatalf("AddSignature failed: %v\n", err)
+		}
+
+		stale := journal.NewSignature(journal.SignatureAuthor, uuid.New().String(), "Автор записи", "HASH", "", nil, fetchedNotebook.Revision)
+		err = repo.AddSignature(ctx, &session, testNotebook.UuidID, &stale)
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) || conflict.Current != fetchedNotebook.Revision+1 {
+			t.Errorf("Expected revision conflict for stale signature, got %v\n", err)
+		}
+
+		fetchedNotebook, err = repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if len(fetchedNotebook.Signatures) != 1 || fetchedNotebook.Signatures[0].Id != signature.Id {
+			t.Errorf("Expected one signature, got %+v\n", fetchedNotebook.Signatures)
+		}
+	})
+
+	t.Run("SetLifecycle", func(t *testing.T) {
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+
+		lifecycle := journal.Lifecycle{Status: journal.StatusInReview, Reviewer: uuid.New().String(), ReadOnly: false}
+		if err := repo.SetLifecycle(ctx, &session, testNotebook.UuidID, &lifecycle, fetchedNotebook.Revision); err != nil {
+			t.Fatalf("SetLifecycle failed: %v\n", err)
+		}
+
+		err = repo.SetLifecycle(ctx, &session, testNotebook.UuidID, &lifecycle, fetchedNotebook.Revision)
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("Expected revision conflict for stale lifecycle, got %v\n", err)
+		}
+
+		fetchedNotebook, err = repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if fetchedNotebook.Lifecycle.Status != journal.StatusInReview || fetchedNotebook.Lifecycle.Reviewer != lifecycle.Reviewer {
+			t.Errorf("Expected lifecycle %+v, got %+v\n", lifecycle, fetchedNotebook.Lifecycle)
+		}
+	})
+
+	t.Run("SetAuthors", func(t *testing.T) {
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+
+		employeeId := uuid.New().String()
+		fetchedNotebook.Metadata.Created.Author = employeeId
+		fetchedNotebook.Lifecycle.Reviewer = employeeId
+		if err := repo.SetAuthors(ctx, &session, fetchedNotebook, fetchedNotebook.Revision); err != nil {
+			t.Fatalf("SetAuthors failed: %v\n", err)
+		}
+
+		err = repo.SetAuthors(ctx, &session, fetchedNotebook, fetchedNotebook.Revision)
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("Expected revision conflict for stale authors, got %v\n", err)
+		}
+
+		updated, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if updated.Metadata.Created.Author != employeeId || updated.Lifecycle.Reviewer != employeeId {
+			t.Errorf("Expected authors %s, got %q and %q\n", employeeId, updated.Metadata.Created.Author, updated.Lifecycle.Reviewer)
+		}
+		if updated.Revision != fetchedNotebook.Revision+1 {
+			t.Errorf("Expected revision %d, got %d\n", fetchedNotebook.Revision+1, updated.Revision)
+		}
+	})
+
+	t.Run("SetDivision", func(t *testing.T) {
+		before, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+
+		divisionId := uuid.New().String()
+		if err := repo.SetDivision(ctx, &session, []string{testNotebook.UuidID}, divisionId); err != nil {
+			t.Fatalf("SetDivision failed: %v\n", err)
+		}
+
+		after, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if after.Metadata.DivisionID != divisionId {
+			t.Errorf("Expected division %s, got %s\n", divisionId, after.Metadata.DivisionID)
+		}
+		if after.Revision != before.Revision {
+			t.Errorf("Expected revision %d to stay, got %d\n", before.Revision, after.Revision)
+		}
+	})
+
+	t.Run("DeleteBlock", func(t *testing.T) {
+		err := repo.DeleteBlock(ctx, &session, testNotebook.UuidID, "block-1", lastUpdate)
+		if err != nil {
+			t.Fatalf("DeleteBlock failed: %v\n", err)
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if len(fetchedNotebook.Blocks) != 3 {
+			t.Errorf("Expected 3 blocks, got %d\n", len(fetchedNotebook.Blocks))
+		}
+	})
+
+	t.Run("ConvertBlockIds", func(t *testing.T) {
+		legacyId := uuid.New().String()
+		err := mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+			_, err := testDB.Collection("notebook_test").InsertOne(sc, bson.M{
+				"uuid_id": legacyId,
+				"blocks": bson.A{
+					bson.M{"id": 1, "type": "text", "body": bson.M{"text": "first"}},
+					bson.M{"id": "block-2", "type": "text", "body": bson.M{"text": "second"}},
+				},
+			})
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Failed to insert legacy notebook: %v\n", err)
+		}
+
+		count, err := repo.ConvertBlockIds(ctx, &session, true)
+		if err != nil {
+			t.Fatalf("ConvertBlockIds dry run failed: %v\n", err)
+		}
+		if count != 1 {
+			t.Errorf("Expected 1 notebook to convert, got %d\n", count)
+		}
+
+		converted, err := repo.ConvertBlockIds(ctx, &session, false)
+		if err != nil {
+			t.Fatalf("ConvertBlockIds failed: %v\n", err)
+		}
+		if converted != 1 {
+			t.Errorf("Expected 1 converted notebook, got %d\n", converted)
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, legacyId)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if len(fetchedNotebook.Blocks) != 2 || fetchedNotebook.Blocks[0].Id != "1" || fetchedNotebook.Blocks[1].Id != "block-2" {
+			t.Errorf("Expected block ids [1 block-2], got %+v\n", fetchedNotebook.Blocks)
+		}
+
+		if err := repo.DeleteNotebook(ctx, &session, legacyId); err != nil {
+			t.Errorf("DeleteNotebook failed %v\n", err)
+		}
+	})
+
+	t.Run("LegacyComments", func(t *testing.T) {
+		legacyId := uuid.New().String()
+		err := mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
+			_, err := testDB.Collection("notebook_test").InsertOne(sc, bson.M{
+				"uuid_id": legacyId,
+				"blocks": bson.A{
+					bson.M{"id": "block-1", "type": "text", "body": bson.M{"text": "first"}, "comments": bson.A{
+						bson.M{"employee_id": "emp-001", "comment": "Вопрос", "sub_comments": bson.A{
+							bson.M{"employee_id": "emp-002", "comment": "Ответ"},
+						}},
+					}},
+				},
+			})
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Failed to insert legacy notebook: %v\n", err)
+		}
+
+		legacy, err := repo.GetLegacyComments(ctx, &session)
+		if err != nil {
+			t.Fatalf("GetLegacyComments failed: %v\n", err)
+		}
+		if len(legacy) != 1 || legacy[0].UuidID != legacyId || len(legacy[0].Blocks[0].Comments[0].Replies) != 1 {
+			t.Fatalf("Expected the legacy notebook with a nested reply, got %+v\n", legacy)
+		}
+
+		comments := map[int][]journal.Comment{0: {
+			{Id: "c1", EmployeeId: "emp-001", Comment: "Вопрос"},
+			{Id: "c2", ParentId: "c1", EmployeeId: "emp-002", Comment: "Ответ"},
+		}}
+		if err := repo.SetBlockComments(ctx, &session, legacyId, comments, 1); err == nil {
+			t.Errorf("Expected revision conflict for a stale revision\n")
+		}
+		if err := repo.SetBlockComments(ctx, &session, legacyId, comments, legacy[0].Revision); err != nil {
+			t.Fatalf("SetBlockComments failed: %v\n", err)
+		}
+
+		fetchedNotebook, err := repo.GetNotebookById(ctx, &session, legacyId)
+		if err != nil {
+			t.Fatalf("GetNotebookById failed: %v\n", err)
+		}
+		if len(fetchedNotebook.Blocks[0].Comment) != 2 || fetchedNotebook.Blocks[0].Comment[1].ParentId != "c1" {
+			t.Errorf("Expected flattened thread, got %+v\n", fetchedNotebook.Blocks[0].Comment)
+		}
+
+		legacy, err = repo.GetLegacyComments(ctx, &session)
+		if err != nil {
+			t.Fatalf("GetLegacyComments failed: %v\n", err)
+		}
+		if len(legacy) != 0 {
+			t.Errorf("Expected no legacy notebooks left, got %d\n", len(legacy))
+		}
+
+		if err := repo.DeleteNotebook(ctx, &session, legacyId); err != nil {
+			t.Errorf("DeleteNotebook failed %v\n", err)
+		}
+	})
+
 	t.Run("DeleteNotebook", func(t *testing.T) {
 		err := repo.DeleteNotebook(ctx, &session, testNotebook.UuidID)
 		if err != nil {
@@ -0,0 +1,73 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetAuthors сохраняет поля журнала n с авторами: создание и последнее изменение, блоки с комментариями,
+// подписи и согласование; только если ревизия журнала равна expectedRevision, и увеличивает ревизию.
+// При расхождении возвращает *revision.ConflictError с текущей ревизией.
+func (r *NotebookMongo) SetAuthors(
+	ctx context.Context,
+	tx *mongo.Session,
+	n *journal.Notebook,
+	expectedRevision int64,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if n == nil || n.UuidID == "" {
+		return errors.New("notebook with uuid_id is required")
+	}
+
+	filter := bson.M{"uuid_id": n.UuidID, "revision": revision.Match(expectedRevision)}
+	update := bson.M{
+		"$set": bson.M{
+			"metadata.created":     n.Metadata.Created,
+			"metadata.last_update": n.Metadata.LastUpdate,
+			"blocks":               n.Blocks,
+			"signatures":           n.Signatures,
+			"lifecycle":            n.Lifecycle,
+		},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return fmt.Errorf("failed to set authors: %w", err)
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+
+		var current journal.Notebook
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": n.UuidID},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("notebook with uuid_id %s not found", n.UuidID)
+			}
+			return fmt.Errorf("failed to read notebook revision: %w", err)
+		}
+
+		return &revision.ConflictError{Current: current.Revision}
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute authors update: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SetBlockComments заменяет комментарии блоков по их позиции, только если ревизия журнала равна expectedRevision;
+// иначе - *revision.ConflictError с текущей ревизией. Ревизия не увеличивается: меняется только формат комментариев.
+func (r *NotebookMongo) SetBlockComments(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	comments map[int][]journal.Comment,
+	expectedRevision int64,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" {
+		return errors.New("uuidId cannot be empty")
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+
+	set := bson.M{}
+	for position, blockComments := range comments {
+		set["blocks."+strconv.Itoa(position)+".comments"] = blockComments
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		filter := bson.M{"uuid_id": uuidId, "revision": revision.Match(expectedRevision)}
+		result, err := r.collection.UpdateOne(sc, filter, bson.M{"$set": set})
+		if err != nil {
+			return fmt.Errorf("failed to set block comments: %w", err)
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+
+		var current journal.Notebook
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": uuidId},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("notebook with uuid_id %s not found", uuidId)
+			}
+			return fmt.Errorf("failed to read notebook revision: %w", err)
+		}
+
+		return &revision.ConflictError{Current: current.Revision}
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute block comments update: %w", err)
+	}
+
+	return nil
+}
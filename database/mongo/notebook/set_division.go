@@ -0,0 +1,42 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SetDivision переносит журналы в другой отдел при перемещении между папками.
+// Ревизия журнала не меняется: содержимое и история остаются прежними.
+func (r *NotebookMongo) SetDivision(
+	ctx context.Context,
+	tx *mongo.Session,
+	notebookIds []string,
+	divisionId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if divisionId == "" {
+		return errors.New("divisionId cannot be empty")
+	}
+	if len(notebookIds) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"uuid_id": bson.M{"$in": notebookIds}}
+	update := bson.M{"$set": bson.M{"metadata.division_id": divisionId}}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		_, err := r.collection.UpdateMany(sc, filter, update)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set notebook division: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SetLifecycle сохраняет статус журнала после перехода, только если ревизия журнала равна expectedRevision.
+// При расхождении возвращает *revision.ConflictError с текущей ревизией.
+func (r *NotebookMongo) SetLifecycle(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	lifecycle *journal.Lifecycle,
+	expectedRevision int64,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" {
+		return errors.New("uuidId cannot be empty")
+	}
+	if lifecycle == nil || lifecycle.Status == "" {
+		return errors.New("lifecycle with status is required")
+	}
+
+	filter := bson.M{"uuid_id": uuidId, "revision": revision.Match(expectedRevision)}
+	update := bson.M{
+		"$set": bson.M{"lifecycle": lifecycle},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return fmt.Errorf("failed to set lifecycle: %w", err)
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+
+		var current journal.Notebook
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": uuidId},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("notebook with uuid_id %s not found", uuidId)
+			}
+			return fmt.Errorf("failed to read notebook revision: %w", err)
+		}
+
+		return &revision.ConflictError{Current: current.Revision}
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute lifecycle update: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// UpdateBlock заменяет тип и содержимое блока, не затрагивая остальные блоки и комментарии
+func (r *NotebookMongo) UpdateBlock(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	blockId string,
+	blockType string,
+	body map[string]any,
+	lastUpdate journal.DateTimeAuthor,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" || blockId == "" {
+		return errors.New("uuidId and blockId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": uuidId, "blocks.id": blockId}
+	update := bson.M{
+		"$set": bson.M{
+			"blocks.$.type":        blockType,
+			"blocks.$.body":        body,
+			"metadata.last_update": lastUpdate,
+		},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update)
+		if err != nil {
+			return fmt.Errorf("failed to update block: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("block %s not found in notebook %s", blockId, uuidId)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute block update: %w", err)
+	}
+
+	return nil
+}
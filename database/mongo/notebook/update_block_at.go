@@ -0,0 +1,89 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// UpdateBlockAt заменяет тип и содержимое блока, только если ревизия журнала равна expectedRevision.
+// Возвращает новую ревизию; при расхождении - *revision.ConflictError с текущей ревизией,
+// если блока нет - journal.ErrBlockNotFound.
+func (r *NotebookMongo) UpdateBlockAt(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	blockId string,
+	blockType string,
+	body map[string]any,
+	lastUpdate journal.DateTimeAuthor,
+	expectedRevision int64,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+	if uuidId == "" || blockId == "" {
+		return 0, errors.New("uuidId and blockId cannot be empty")
+	}
+
+	filter := bson.M{"uuid_id": uuidId, "blocks.id": blockId, "revision": revision.Match(expectedRevision)}
+	update := bson.M{
+		"$set": bson.M{
+			"blocks.$.type":        blockType,
+			"blocks.$.body":        body,
+			"metadata.last_update": lastUpdate,
+		},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	var updated journal.Notebook
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		err := r.collection.FindOneAndUpdate(
+			sc,
+			filter,
+			update,
+			options.FindOneAndUpdate().
+				SetProjection(bson.M{"revision": 1}).
+				SetReturnDocument(options.After),
+		).Decode(&updated)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("failed to update block: %w", err)
+		}
+
+		// Документ не совпал с фильтром: нет журнала, нет блока или ревизия устарела
+		var current journal.Notebook
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": uuidId},
+			options.FindOne().SetProjection(bson.M{"revision": 1, "blocks.id": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("notebook with uuid_id %s not found", uuidId)
+			}
+			return fmt.Errorf("failed to read notebook revision: %w", err)
+		}
+		for _, b := range current.Blocks {
+			if b.Id == blockId {
+				return &revision.ConflictError{Current: current.Revision}
+			}
+		}
+
+		return fmt.Errorf("%w: %s", journal.ErrBlockNotFound, blockId)
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute block update: %w", err)
+	}
+
+	return updated.Revision, nil
+}
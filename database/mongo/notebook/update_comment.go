@@ -0,0 +1,65 @@
+package notebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// UpdateComment заменяет комментарий блока по его ID, не затрагивая остальные комментарии
+func (r *NotebookMongo) UpdateComment(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidId string,
+	blockId string,
+	comment *journal.Comment,
+	lastUpdate journal.DateTimeAuthor,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if uuidId == "" || blockId == "" {
+		return errors.New("uuidId and blockId cannot be empty")
+	}
+	if comment == nil || comment.Id == "" {
+		return errors.New("comment with ID is required")
+	}
+
+	filter := bson.M{
+		"uuid_id": uuidId,
+		"blocks":  bson.M{"$elemMatch": bson.M{"id": blockId, "comments.id": comment.Id}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"blocks.$[b].comments.$[c]": comment,
+			"metadata.last_update":      lastUpdate,
+		},
+		"$inc": bson.M{"revision": 1},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"b.id": blockId}, bson.M{"c.id": comment.Id}},
+	})
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(sc, filter, update, opts)
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("comment %s not found in block %s", comment.Id, blockId)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute comment update: %w", err)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -12,54 +13,81 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
+// UpdateNotebook обновляет журнал, только если его ревизия равна expectedRevision.
+// Возвращает новую ревизию; при расхождении - *revision.ConflictError с текущей ревизией.
 func (r *NotebookMongo) UpdateNotebook(
 	ctx context.Context,
 	tx *mongo.Session,
 	uuidId string,
 	notebook *journal.Notebook,
-) error {
+	expectedRevision int64,
+) (int64, error) {
 	if tx == nil {
-		return errors.New("transaction session is required")
+		return 0, errors.New("transaction session is required")
 	}
 	if uuidId == "" {
-		return errors.New("uuidId cannot be empty")
+		return 0, errors.New("uuidId cannot be empty")
 	}
 	if notebook == nil {
-		return errors.New("notebook cannot be nil")
+		return 0, errors.New("notebook cannot be nil")
 	}
 
+	// Компания и отдел журнала меняются только перемещением (SetDivision), теги - через словарь тегов
+	// (TagResource, UntagResource) с их правами, поэтому не перезаписываются
 	update := bson.M{
 		"$set": bson.M{
-			"version":    notebook.Version,
-			"metadata":   notebook.Metadata,
-			"blocks":     notebook.Blocks,
-			"updated_at": time.Now(), // Автоматическое обновление времени
+			"version":              notebook.Version,
+			"metadata.title":       notebook.Metadata.Title,
+			"metadata.description": notebook.Metadata.Description,
+			"metadata.created":     notebook.Metadata.Created,
+			"metadata.last_update": notebook.Metadata.LastUpdate,
+			"metadata.links":       notebook.Metadata.Links,
+			"blocks":               notebook.Blocks,
+			"updated_at":           time.Now(), // Автоматическое обновление времени
 		},
+		"$inc": bson.M{"revision": 1},
 	}
 
+	var updated journal.Notebook
+
 	// Транзакционная операция
 	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
-		filter := bson.M{"uuid_id": uuidId}
-		result := r.collection.FindOneAndUpdate(
+		filter := bson.M{"uuid_id": uuidId, "revision": revision.Match(expectedRevision)}
+		err := r.collection.FindOneAndUpdate(
 			sc,
 			filter,
 			update,
 			options.FindOneAndUpdate().
+				SetProjection(bson.M{"revision": 1}).
 				SetReturnDocument(options.After), // Возвращаем обновленный документ
-		)
-		if result.Err() != nil {
-			if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		).Decode(&updated)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("failed to update notebook: %w", err)
+		}
+
+		// Документ не совпал с фильтром: либо его нет, либо ревизия устарела
+		var current journal.Notebook
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": uuidId},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
 				return fmt.Errorf("notebook with uuid_id %s not found", uuidId)
 			}
-			return fmt.Errorf("failed to update notebook: %w", result.Err())
+			return fmt.Errorf("failed to read notebook revision: %w", err)
 		}
 
-		return nil
+		return &revision.ConflictError{Current: current.Revision}
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to execute notebook update: %w", err)
+		return 0, fmt.Errorf("failed to execute notebook update: %w", err)
 	}
 
-	return nil
+	return updated.Revision, nil
 }
@@ -0,0 +1,41 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/permission"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetPermissionsByUuidIds возвращает разрешения ресурсов одним запросом; ресурсы без разрешения пропускаются
+func (r PermissionMongo) GetPermissionsByUuidIds(
+	ctx context.Context,
+	tx *mongo.Session,
+	uuidIds []string,
+) ([]permission.Permission, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if len(uuidIds) == 0 {
+		return []permission.Permission{}, nil
+	}
+
+	filter := bson.M{"uuid_id": bson.M{"$in": uuidIds}}
+	results := []permission.Permission{}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(sc, filter)
+		if err != nil {
+			return err
+		}
+		return cursor.All(sc, &results)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return results, nil
+}
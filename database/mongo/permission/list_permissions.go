@@ -0,0 +1,49 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/permission"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ListPermissions возвращает до limit разрешений с uuid_id больше afterUuid в порядке uuid_id.
+// Пустой afterUuid - начало коллекции; последняя uuid_id страницы служит курсором следующей.
+func (r PermissionMongo) ListPermissions(
+	ctx context.Context,
+	tx *mongo.Session,
+	afterUuid string,
+	limit int64,
+) ([]permission.Permission, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if limit <= 0 {
+		return nil, errors.New("limit must be positive")
+	}
+
+	filter := bson.M{}
+	if afterUuid != "" {
+		filter["uuid_id"] = bson.M{"$gt": afterUuid}
+	}
+	results := []permission.Permission{}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(sc, filter, options.Find().
+			SetSort(bson.M{"uuid_id": 1}).
+			SetLimit(limit))
+		if err != nil {
+			return err
+		}
+		return cursor.All(sc, &results)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return results, nil
+}
@@ -2,10 +2,12 @@ package permission_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	m "labyrinth/database/mongo"
 	"labyrinth/database/mongo/permission"
 	p "labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
 	"os"
 	"testing"
 	"time"
@@ -113,12 +115,30 @@ func TestPermissionCRUD(t *testing.T) {
 	t.Run("UpdatePermission", func(t *testing.T) {
 		updatedPermission := *testPermission
 		updatedPermission.ResourceType = "file"
+		updatedPermission.Inherit = true
 		updatedPermission.UpdatedAt = time.Now()
 
-		err = repo.UpdatePermission(ctx, &session, updatedPermission.UuidId, &updatedPermission)
+		newRevision, err := repo.UpdatePermission(ctx, &session, updatedPermission.UuidId, &updatedPermission, 0)
 		if err != nil {
 			t.Fatalf("UpdatePermission failed: %v\n", err)
 		}
+
+		if newRevision != 1 {
+			t.Errorf("Expected revision 1, got %d\n", newRevision)
+		}
+	})
+
+	t.Run("UpdatePermissionStaleRevision", func(t *testing.T) {
+		_, err := repo.UpdatePermission(ctx, &session, testPermission.UuidId, testPermission, 0)
+
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("Expected revision conflict, got %v\n", err)
+		}
+
+		if conflict.Current != 1 {
+			t.Errorf("Expected current revision 1, got %d\n", conflict.Current)
+		}
 	})
 
 	t.Run("GetPermissionByUuidId", func(t *testing.T) {
@@ -131,9 +151,44 @@ func TestPermissionCRUD(t *testing.T) {
 			t.Errorf("Expected ( file ), got ( %s )'\n", fetchedPermission.ResourceType)
 		}
 
+		if !fetchedPermission.Inherit {
+			t.Errorf("Expected inheritance to be switched on by the update\n")
+		}
+
 		fmt.Printf("UUID-ID => get: %s, want: %s\n", fetchedPermission.UuidId, testPermission.UuidId)
 	})
 
+	t.Run("GetPermissionsByUuidIds", func(t *testing.T) {
+		fetched, err := repo.GetPermissionsByUuidIds(ctx, &session, []string{testPermission.UuidId, uuid.New().String()})
+		if err != nil {
+			t.Fatalf("GetPermissionsByUuidIds failed: %v\n", err)
+		}
+
+		if len(fetched) != 1 || fetched[0].UuidId != testPermission.UuidId {
+			t.Errorf("Expected only ( %s ), got %+v\n", testPermission.UuidId, fetched)
+		}
+	})
+
+	t.Run("ListPermissions", func(t *testing.T) {
+		page, err := repo.ListPermissions(ctx, &session, "", 10)
+		if err != nil {
+			t.Fatalf("ListPermissions failed: %v\n", err)
+		}
+
+		if len(page) != 1 || page[0].UuidId != testPermission.UuidId {
+			t.Errorf("Expected only ( %s ), got %+v\n", testPermission.UuidId, page)
+		}
+
+		next, err := repo.ListPermissions(ctx, &session, testPermission.UuidId, 10)
+		if err != nil {
+			t.Fatalf("ListPermissions after cursor failed: %v\n", err)
+		}
+
+		if len(next) != 0 {
+			t.Errorf("Expected empty page after the last permission, got %+v\n", next)
+		}
+	})
+
 	t.Run("DeletePermission", func(t *testing.T) {
 		err := repo.DeletePermission(ctx, &session, testPermission.UuidId)
 		if err != nil {
@@ -0,0 +1,141 @@
+package permission
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// ReadableStages - стадии агрегации, оставляющие документы (журналы, папки), которые сотрудник может читать
+// по собственным правилам или по правилам папок, от которых документ наследует.
+// Документ связывается со своим разрешением по uuid_id = resource_uuid; permissions - коллекция разрешений,
+// folders - коллекция папок. Журнал наследует правила папки, в которой лежит, папка - родительской папки;
+// цепочка, как и в permissionLogic.LoadChain, обрывается на разрешении с inherit = false.
+func ReadableStages(permissions, folders, employeeId string) []bson.M {
+	// Ближайшая папка: для журнала - папка, в списке файлов которой он числится, для папки - родитель
+	parent := bson.M{"$ifNull": bson.A{
+		bson.M{"$arrayElemAt": bson.A{"$_container.uuid_id", 0}},
+		"$parent_uuid_id",
+	}}
+
+	// Разрешение каждой папки цепочки с ее удаленностью от документа (0 - ближайшая)
+	ancestors := bson.M{"$map": bson.M{
+		"input": "$_ancestors",
+		"as":    "a",
+		"in": bson.M{
+			"depth": "$$a._depth",
+			"perm": bson.M{"$arrayElemAt": bson.A{
+				bson.M{"$filter": bson.M{
+					"input": "$_inherited",
+					"as":    "p",
+					"cond":  bson.M{"$eq": bson.A{"$$p.resource_uuid", "$$a.uuid_id"}},
+				}},
+				0,
+			}},
+		},
+	}}
+
+	// Ближайшая папка, которая не наследует правила: выше нее права не поднимаются
+	cut := bson.M{"$min": bson.M{"$map": bson.M{
+		"input": bson.M{"$filter": bson.M{
+			"input": "$_chain",
+			"as":    "c",
+			"cond":  bson.M{"$ne": bson.A{"$$c.perm.inherit", true}},
+		}},
+		"as": "c",
+		"in": "$$c.depth",
+	}}}
+
+	inherited := bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+		"input": "$_chain",
+		"as":    "c",
+		"in": bson.M{"$and": bson.A{
+			readableExpr("$$c.perm", employeeId),
+			bson.M{"$or": bson.A{
+				bson.M{"$eq": bson.A{"$_cut", nil}},
+				bson.M{"$lte": bson.A{"$$c.depth", "$_cut"}},
+			}},
+		}},
+	}}}}
+
+	return []bson.M{
+		{"$lookup": bson.M{
+			"from":         permissions,
+			"localField":   "uuid_id",
+			"foreignField": "resource_uuid",
+			"as":           "permission",
+		}},
+		{"$lookup": bson.M{
+			"from":         folders,
+			"localField":   "uuid_id",
+			"foreignField": "files.uuid_id",
+			"as":           "_container",
+		}},
+		{"$graphLookup": bson.M{
+			"from":             folders,
+			"startWith":        parent,
+			"connectFromField": "parent_uuid_id",
+			"connectToField":   "uuid_id",
+			"as":               "_ancestors",
+			"depthField":       "_depth",
+		}},
+		{"$lookup": bson.M{
+			"from":         permissions,
+			"localField":   "_ancestors.uuid_id",
+			"foreignField": "resource_uuid",
+			"as":           "_inherited",
+		}},
+		{"$addFields": bson.M{
+			"_own":   bson.M{"$arrayElemAt": bson.A{"$permission", 0}},
+			"_chain": ancestors,
+		}},
+		{"$addFields": bson.M{"_cut": cut}},
+		{"$match": bson.M{"$expr": bson.M{"$or": bson.A{
+			readableExpr("$_own", employeeId),
+			bson.M{"$and": bson.A{bson.M{"$eq": bson.A{"$_own.inherit", true}}, inherited}},
+		}}}},
+		{"$project": bson.M{
+			"permission": 0, "_container": 0, "_ancestors": 0, "_inherited": 0,
+			"_own": 0, "_chain": 0, "_cut": 0,
+		}},
+	}
+}
+
+// readableExpr - выражение агрегации, повторяющее PermissionRules.CanRead для разрешения perm:
+// у закрытых (private) ресурсов списки CommentOnly и ReadOnly не действуют
+func readableExpr(perm, employeeId string) bson.M {
+	in := func(list string) bson.M {
+		return bson.M{"$in": bson.A{employeeId, bson.M{"$ifNull": bson.A{perm + ".rules." + list, bson.A{}}}}}
+	}
+	level := perm + ".rules.access_level"
+	return bson.M{"$or": bson.A{
+		in("access_allowed"),
+		bson.M{"$and": bson.A{
+			bson.M{"$ne": bson.A{level, "private"}},
+			bson.M{"$or": bson.A{in("comment_only"), in("read_only")}},
+		}},
+		bson.M{"$eq": bson.A{level, "public"}},
+	}}
+}
+
+// ReadableStagesOn - стадии для документов, ссылающихся на ресурс полем localField (например, элементы корзины).
+// Действуют только собственные правила ресурса: папки удаленного ресурса могли уже измениться.
+// Условия повторяют PermissionRules.CanRead: у закрытых (private) ресурсов списки CommentOnly и ReadOnly не действуют.
+func ReadableStagesOn(collection, localField, employeeId string) []bson.M {
+	return []bson.M{
+		{"$lookup": bson.M{
+			"from":         collection,
+			"localField":   localField,
+			"foreignField": "resource_uuid",
+			"as":           "permission",
+		}},
+		{"$match": bson.M{"$or": []bson.M{
+			{"permission.rules.access_allowed": employeeId},
+			{
+				"permission.rules.access_level": bson.M{"$ne": "private"},
+				"$or": []bson.M{
+					{"permission.rules.comment_only": employeeId},
+					{"permission.rules.read_only": employeeId},
+				},
+			},
+			{"permission.rules.access_level": "public"},
+		}}},
+		{"$project": bson.M{"permission": 0}},
+	}
+}
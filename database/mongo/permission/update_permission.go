@@ -5,59 +5,88 @@ import (
 	"errors"
 	"fmt"
 	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"gopkg.in/mgo.v2/bson"
 )
 
+// UpdatePermission обновляет правила доступа, только если ревизия равна expectedRevision.
+// Возвращает новую ревизию; при расхождении - *revision.ConflictError с текущей ревизией.
 func (r *PermissionMongo) UpdatePermission(
 	ctx context.Context,
 	tx *mongo.Session,
 	uuidId string,
 	updateData *permission.Permission,
-) error {
+	expectedRevision int64,
+) (int64, error) {
 	if tx == nil {
-		return errors.New("transaction session is required")
+		return 0, errors.New("transaction session is required")
 	}
 
 	if uuidId == "" {
-		return errors.New("uuid_id cannot be empty")
+		return 0, errors.New("uuid_id cannot be empty")
 	}
 
 	if updateData == nil {
-		return errors.New("update data cannot be nil")
+		return 0, errors.New("update data cannot be nil")
 	}
 
+	// Идентификаторы, автор и время создания не меняются при обновлении
 	update := bson.M{
-		"$set": updateData,
+		"$set": bson.M{
+			"resource_type": updateData.ResourceType,
+			"resource_id":   updateData.ResourceID,
+			"resource_uuid": updateData.ResourceUuid,
+			"rules":         updateData.Rules,
+			"inherit":       updateData.Inherit,
+			"version":       updateData.Version,
+			"updated_at":    time.Now(),
+		},
+		"$inc": bson.M{"revision": 1},
 	}
 
-	var result *mongo.UpdateResult
+	var updated permission.Permission
 	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
-		var err error
-		result, err = r.collection.UpdateOne(
+		err := r.collection.FindOneAndUpdate(
 			sc,
-			bson.M{"uuid_id": uuidId},
+			bson.M{"uuid_id": uuidId, "revision": revision.Match(expectedRevision)},
 			update,
-			options.Update().SetUpsert(false),
-		)
-		return err
+			options.FindOneAndUpdate().
+				SetProjection(bson.M{"revision": 1}).
+				SetReturnDocument(options.After),
+		).Decode(&updated)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+
+		var current permission.Permission
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": uuidId},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return fmt.Errorf("permission with uuid_id '%s' not found", uuidId)
+			}
+			return err
+		}
+
+		return &revision.ConflictError{Current: current.Revision}
 	})
 
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
-			return fmt.Errorf("duplicate key violation: %w", err)
+			return 0, fmt.Errorf("duplicate key violation: %w", err)
 		}
-		return fmt.Errorf("failed to update permission: %w", err)
-	}
-
-	switch {
-	case result.MatchedCount == 0:
-		return fmt.Errorf("permission with uuid_id '%s' not found", uuidId)
-	case result.ModifiedCount == 0:
-		return fmt.Errorf("permission data not modified (no changes)")
+		return 0, fmt.Errorf("failed to update permission: %w", err)
 	}
 
-	return nil
+	return updated.Revision, nil
 }
@@ -0,0 +1,55 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// CreateRevision сохраняет снимок журнала; ревизии неизменяемы, повторная запись номера запрещена
+func (r *RevisionMongo) CreateRevision(
+	ctx context.Context,
+	tx *mongo.Session,
+	rev *revision.Revision,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if rev == nil || rev.Snapshot == nil {
+		return errors.New("revision with snapshot is required")
+	}
+	if rev.NotebookID == "" {
+		return errors.New("notebook uuid is required")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		count, err := r.collection.CountDocuments(
+			sc,
+			bson.M{"notebook_uuid_id": rev.NotebookID, "revision": rev.Revision},
+			options.Count().SetLimit(1),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to check revision uniqueness: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("revision %d of notebook %s already exists", rev.Revision, rev.NotebookID)
+		}
+
+		if _, err = r.collection.InsertOne(sc, rev); err != nil {
+			return fmt.Errorf("failed to insert revision: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create revision: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetLatestRevision возвращает последнюю сохранённую ревизию журнала или nil, если истории нет
+func (r *RevisionMongo) GetLatestRevision(
+	ctx context.Context,
+	tx *mongo.Session,
+	notebookId string,
+) (*revision.Revision, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if notebookId == "" {
+		return nil, errors.New("notebookId cannot be empty")
+	}
+
+	var result revision.Revision
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		return r.collection.FindOne(
+			sc,
+			bson.M{"notebook_uuid_id": notebookId},
+			options.FindOne().SetSort(bson.M{"revision": -1}),
+		).Decode(&result)
+	})
+
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,42 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetRevision возвращает ревизию журнала вместе со снимком
+func (r *RevisionMongo) GetRevision(
+	ctx context.Context,
+	tx *mongo.Session,
+	notebookId string,
+	number int64,
+) (*revision.Revision, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if notebookId == "" {
+		return nil, errors.New("notebookId cannot be empty")
+	}
+
+	filter := bson.M{"notebook_uuid_id": notebookId, "revision": number}
+	var result revision.Revision
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		return r.collection.FindOne(sc, filter).Decode(&result)
+	})
+
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("revision %d of notebook %s: %w", number, notebookId, revision.ErrNotFound)
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &result, nil
+}
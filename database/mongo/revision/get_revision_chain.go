@@ -0,0 +1,49 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetRevisionChain возвращает всю историю журнала со снимками по возрастанию номера ревизии
+func (r *RevisionMongo) GetRevisionChain(
+	ctx context.Context,
+	tx *mongo.Session,
+	notebookId string,
+) ([]revision.Revision, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if notebookId == "" {
+		return nil, errors.New("notebookId cannot be empty")
+	}
+
+	results := []revision.Revision{}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(
+			sc,
+			bson.M{"notebook_uuid_id": notebookId},
+			options.Find().SetSort(bson.M{"revision": 1}),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if err = cursor.All(sc, &results); err != nil {
+			return fmt.Errorf("failed to decode results: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transactional query failed: %w", err)
+	}
+
+	return results, nil
+}
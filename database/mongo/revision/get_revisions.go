@@ -0,0 +1,62 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetRevisions возвращает страницу истории журнала (от новых к старым) без снимков и общее число ревизий
+func (r *RevisionMongo) GetRevisions(
+	ctx context.Context,
+	tx *mongo.Session,
+	notebookId string,
+	limit, offset int64,
+) ([]revision.Revision, int64, error) {
+	if tx == nil {
+		return nil, 0, errors.New("transaction session is required")
+	}
+	if notebookId == "" {
+		return nil, 0, errors.New("notebookId cannot be empty")
+	}
+
+	filter := bson.M{"notebook_uuid_id": notebookId}
+	findOpts := options.Find().
+		SetSort(bson.M{"revision": -1}).
+		SetProjection(bson.M{"snapshot": 0}).
+		SetSkip(offset).
+		SetLimit(limit)
+
+	results := []revision.Revision{}
+	var total int64
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		var err error
+		total, err = r.collection.CountDocuments(sc, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count revisions: %w", err)
+		}
+
+		cursor, err := r.collection.Find(sc, filter, findOpts)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if err = cursor.All(sc, &results); err != nil {
+			return fmt.Errorf("failed to decode results: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("transactional query failed: %w", err)
+	}
+
+	return results, total, nil
+}
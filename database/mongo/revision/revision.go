@@ -0,0 +1,13 @@
+package revision
+
+import "go.mongodb.org/mongo-driver/mongo"
+
+type RevisionMongo struct {
+	collection *mongo.Collection
+}
+
+func NewRevisionMongo(db *mongo.Database, collection string) *RevisionMongo {
+	return &RevisionMongo{
+		collection: db.Collection(collection),
+	}
+}
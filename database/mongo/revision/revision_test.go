@@ -0,0 +1,211 @@
+package revision_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	mongoRev "labyrinth/database/mongo/revision"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	client       *mongo.Client
+	testDB       *mongo.Database
+	testNotebook journal.Notebook
+)
+
+func setup() error {
+	var err error
+	client, err = m.NewConnection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	testDB = client.Database("revision_test")
+
+	testNotebook = journal.NewNotebook(
+		uuid.New().String(),
+		uuid.New().String(),
+		uuid.New().String(),
+		uuid.New().String(),
+		"TEST TITLE",
+		"TEST DESCRIPTION",
+	)
+	testNotebook.Blocks = []journal.Block{
+		{Id: "block-1", Type: "text", Body: map[string]any{"content": "first"}},
+		{Id: "block-2", Type: "text", Body: map[string]any{"content": "second"}},
+	}
+
+	return nil
+}
+
+func teardown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if testDB != nil {
+		testDB.Drop(ctx)
+	}
+
+	if client != nil {
+		client.Disconnect(ctx)
+	}
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	teardown()
+
+	os.Exit(code)
+}
+
+func TestRevisionHistory(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo := mongoRev.NewRevisionMongo(testDB, "revision_test")
+
+	session, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	err = session.StartTransaction()
+	if err != nil {
+		t.Fatalf("Failed to start transaction: %v\n", err)
+	}
+
+	author := uuid.New().String()
+
+	t.Run("GetLatestRevisionEmpty", func(t *testing.T) {
+		latest, err := repo.GetLatestRevision(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetLatestRevision failed: %v\n", err)
+		}
+
+		if latest != nil {
+			t.Errorf("Expected no revisions, got %d\n", latest.Revision)
+		}
+	})
+
+	t.Run("CreateRevision", func(t *testing.T) {
+		first := revision.NewRevision(testNotebook, revision.ActionCreate, author, []string{"block-1", "block-2"})
+		if err := repo.CreateRevision(ctx, &session, &first); err != nil {
+			t.Fatalf("CreateRevision failed: %v\n", err)
+		}
+
+		updated := testNotebook
+		updated.Revision = 1
+		updated.Blocks = []journal.Block{
+			{Id: "block-2", Type: "text", Body: map[string]any{"content": "second"}},
+			{Id: "block-1", Type: "text", Body: map[string]any{"content": "first, edited"}},
+		}
+		second := revision.NewRevision(updated, revision.ActionUpdate, author, []string{"block-1"})
+		if err := repo.CreateRevision(ctx, &session, &second); err != nil {
+			t.Fatalf("CreateRevision failed: %v\n", err)
+		}
+	})
+
+	t.Run("CreateRevisionDuplicate", func(t *testing.T) {
+		duplicate := revision.NewRevision(testNotebook, revision.ActionUpdate, author, nil)
+		if err := repo.CreateRevision(ctx, &session, &duplicate); err == nil {
+			t.Errorf("Expected error for duplicate revision number\n")
+		}
+	})
+
+	t.Run("GetLatestRevision", func(t *testing.T) {
+		latest, err := repo.GetLatestRevision(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetLatestRevision failed: %v\n", err)
+		}
+
+		if latest == nil || latest.Revision != 1 {
+			t.Fatalf("Expected latest revision 1, got %+v\n", latest)
+		}
+	})
+
+	t.Run("GetRevisions", func(t *testing.T) {
+		revisions, total, err := repo.GetRevisions(ctx, &session, testNotebook.UuidID, 10, 0)
+		if err != nil {
+			t.Fatalf("GetRevisions failed: %v\n", err)
+		}
+
+		if total != 2 || len(revisions) != 2 {
+			t.Fatalf("Expected 2 revisions, got %d (total %d)\n", len(revisions), total)
+		}
+
+		if revisions[0].Revision != 1 || revisions[0].Snapshot != nil {
+			t.Errorf("Expected newest revision first without snapshot, got %+v\n", revisions[0])
+		}
+	})
+
+	t.Run("GetRevision", func(t *testing.T) {
+		rev, err := repo.GetRevision(ctx, &session, testNotebook.UuidID, 0)
+		if err != nil {
+			t.Fatalf("GetRevision failed: %v\n", err)
+		}
+
+		if rev.Snapshot == nil || len(rev.Snapshot.Blocks) != 2 {
+			t.Fatalf("Expected snapshot with 2 blocks, got %+v\n", rev.Snapshot)
+		}
+
+		_, err = repo.GetRevision(ctx, &session, testNotebook.UuidID, 42)
+		if !errors.Is(err, revision.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v\n", err)
+		}
+	})
+
+	t.Run("GetRevisionChain", func(t *testing.T) {
+		chain, err := repo.GetRevisionChain(ctx, &session, testNotebook.UuidID)
+		if err != nil {
+			t.Fatalf("GetRevisionChain failed: %v\n", err)
+		}
+
+		if len(chain) != 2 || chain[0].Revision != 0 || chain[1].Revision != 1 {
+			t.Fatalf("Expected revisions 0 and 1 in ascending order, got %+v\n", chain)
+		}
+		if chain[0].Snapshot == nil || chain[1].Snapshot == nil {
+			t.Errorf("Expected revisions with snapshots\n")
+		}
+	})
+
+	t.Run("DiffBlocks", func(t *testing.T) {
+		from, err := repo.GetRevision(ctx, &session, testNotebook.UuidID, 0)
+		if err != nil {
+			t.Fatalf("GetRevision failed: %v\n", err)
+		}
+		to, err := repo.GetRevision(ctx, &session, testNotebook.UuidID, 1)
+		if err != nil {
+			t.Fatalf("GetRevision failed: %v\n", err)
+		}
+
+		changes := revision.DiffBlocks(from.Snapshot.Blocks, to.Snapshot.Blocks)
+		if len(changes) != 1 || changes[0].BlockID != "block-1" || changes[0].Change != revision.ChangeModified {
+			t.Errorf("Expected single modified block-1, got %+v\n", changes)
+		}
+	})
+
+	if !t.Failed() {
+		if err := session.CommitTransaction(ctx); err != nil {
+			t.Errorf("Failed to commit transaction: %v", err)
+		}
+	} else {
+		if err := session.AbortTransaction(ctx); err != nil {
+			t.Errorf("Failed to abort transaction: %v", err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes создает текстовые индексы журналов и папок, если их еще нет.
+// language_override переназначен: поле language в блоках кода - это язык программирования, а не текста.
+func (r *SearchMongo) EnsureIndexes(ctx context.Context) error {
+	notebookKeys := bson.D{
+		{Key: "metadata.title", Value: "text"},
+		{Key: "metadata.description", Value: "text"},
+		{Key: "metadata.tags", Value: "text"},
+	}
+	for _, field := range notebookTextFields {
+		notebookKeys = append(notebookKeys, bson.E{Key: "blocks.body." + field, Value: "text"})
+	}
+	notebookKeys = append(notebookKeys, bson.E{Key: "blocks.body.items.text", Value: "text"})
+
+	_, err := r.notebooks.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: notebookKeys,
+		Options: options.Index().
+			SetName("notebook_text").
+			SetDefaultLanguage("russian").
+			SetLanguageOverride("text_search_language").
+			SetWeights(bson.M{"metadata.title": 10, "metadata.tags": 6, "metadata.description": 4}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notebook text index: %w", err)
+	}
+
+	_, err = r.folders.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "metadata.title", Value: "text"},
+			{Key: "metadata.description", Value: "text"},
+			{Key: "metadata.tags", Value: "text"},
+			{Key: "files.title", Value: "text"},
+			{Key: "files.description", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("folder_text").
+			SetDefaultLanguage("russian").
+			SetLanguageOverride("text_search_language").
+			SetWeights(bson.M{"metadata.title": 10, "metadata.tags": 6, "files.title": 5, "metadata.description": 4}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create folder text index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// notebookTextFields - поля блоков, попадающие в текстовый индекс журналов.
+// Служебные поля (object_key, content_type, language) не индексируются.
+var notebookTextFields = []string{
+	"content", "text", "code", "caption", "title", "columns",
+	"quantity", "name", "method", "instrument", "file_name",
+}
+
+type SearchMongo struct {
+	notebooks   *mongo.Collection
+	folders     *mongo.Collection
+	permissions string
+}
+
+func NewSearchMongo(db *mongo.Database, notebooks, folders, permissions string) *SearchMongo {
+	return &SearchMongo{
+		notebooks:   db.Collection(notebooks),
+		folders:     db.Collection(folders),
+		permissions: permissions,
+	}
+}
+
+// blockText собирает индексируемый текст тела блока, включая вложенные пункты и столбцы
+func blockText(body map[string]any) string {
+	var parts []string
+	var walk func(key string, value any)
+	walk = func(key string, value any) {
+		switch v := value.(type) {
+		case string:
+			if isTextField(key) && strings.TrimSpace(v) != "" {
+				parts = append(parts, v)
+			}
+		case map[string]any:
+			for k, nested := range v {
+				walk(k, nested)
+			}
+		case bson.M:
+			for k, nested := range v {
+				walk(k, nested)
+			}
+		case bson.D:
+			for _, e := range v {
+				walk(e.Key, e.Value)
+			}
+		case []any:
+			for _, item := range v {
+				walk(key, item)
+			}
+		case bson.A:
+			for _, item := range v {
+				walk(key, item)
+			}
+		}
+	}
+	for _, key := range notebookTextFields {
+		walk(key, body[key])
+	}
+	if items, ok := body["items"]; ok {
+		walk("items", items)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func isTextField(key string) bool {
+	for _, f := range notebookTextFields {
+		if f == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/search"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type folderHit struct {
+	directory.Directory `bson:",inline"`
+	Score               float64 `bson:"score"`
+}
+
+// SearchFolders ищет папки компании по названию, описанию, тегам и названиям журналов внутри
+// среди тех, что сотрудник может читать. Результаты отсортированы по релевантности.
+func (r *SearchMongo) SearchFolders(
+	ctx context.Context,
+	tx *mongo.Session,
+	query search.Query,
+) ([]search.Document, int64, error) {
+	if tx == nil {
+		return nil, 0, errors.New("transaction session is required")
+	}
+	if query.CompanyID == "" || query.EmployeeID == "" {
+		return nil, 0, errors.New("companyId and employeeId cannot be empty")
+	}
+
+	var hits []folderHit
+	total, err := r.aggregate(ctx, tx, r.folders, query, &hits)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search folders: %w", err)
+	}
+
+	docs := make([]search.Document, 0, len(hits))
+	for _, h := range hits {
+		doc := search.Document{
+			Kind:       search.KindFolder,
+			UuidID:     h.UuidID,
+			DivisionID: h.Metadata.DivisionID,
+			Title:      h.Metadata.Title,
+			Author:     h.Metadata.Created.Author,
+			Tags:       h.Metadata.Tags,
+			Created:    h.Metadata.Created.Date,
+			Score:      h.Score,
+			Fields: []search.Field{
+				{Name: "title", Text: h.Metadata.Title},
+				{Name: "description", Text: h.Metadata.Description},
+				{Name: "tags", Text: strings.Join(h.Metadata.Tags, ", ")},
+			},
+		}
+		for _, f := range h.Files {
+			doc.Fields = append(doc.Fields, search.Field{Name: "file", ID: f.FileUUID, Text: strings.TrimSpace(f.Title + "\n" + f.Description)})
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, total, nil
+}
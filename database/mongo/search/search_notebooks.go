@@ -0,0 +1,142 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mongoPerm "labyrinth/database/mongo/permission"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/search"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type notebookHit struct {
+	journal.Notebook `bson:",inline"`
+	Score            float64 `bson:"score"`
+}
+
+// SearchNotebooks ищет журналы компании по названию, описанию, тегам и тексту блоков
+// среди тех, что сотрудник может читать. Результаты отсортированы по релевантности.
+func (r *SearchMongo) SearchNotebooks(
+	ctx context.Context,
+	tx *mongo.Session,
+	query search.Query,
+) ([]search.Document, int64, error) {
+	if tx == nil {
+		return nil, 0, errors.New("transaction session is required")
+	}
+	if query.CompanyID == "" || query.EmployeeID == "" {
+		return nil, 0, errors.New("companyId and employeeId cannot be empty")
+	}
+
+	var hits []notebookHit
+	total, err := r.aggregate(ctx, tx, r.notebooks, query, &hits)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search notebooks: %w", err)
+	}
+
+	docs := make([]search.Document, 0, len(hits))
+	for _, h := range hits {
+		doc := search.Document{
+			Kind:       search.KindNotebook,
+			UuidID:     h.UuidID,
+			DivisionID: h.Metadata.DivisionID,
+			Title:      h.Metadata.Title,
+			Author:     h.Metadata.Created.Author,
+			Tags:       h.Metadata.Tags,
+			Created:    h.Metadata.Created.Date,
+			Score:      h.Score,
+			Fields: []search.Field{
+				{Name: "title", Text: h.Metadata.Title},
+				{Name: "description", Text: h.Metadata.Description},
+				{Name: "tags", Text: strings.Join(h.Metadata.Tags, ", ")},
+			},
+		}
+		for _, b := range h.Blocks {
+			if text := blockText(b.Body); text != "" {
+				doc.Fields = append(doc.Fields, search.Field{Name: "block", ID: b.Id, Text: text})
+			}
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, total, nil
+}
+
+// aggregate выполняет полнотекстовый запрос с фильтрами, проверкой доступа и пагинацией
+func (r *SearchMongo) aggregate(
+	ctx context.Context,
+	tx *mongo.Session,
+	collection *mongo.Collection,
+	query search.Query,
+	results any,
+) (int64, error) {
+	match := bson.M{
+		"$text":               bson.M{"$search": query.Text},
+		"metadata.company_id": query.CompanyID,
+	}
+	if query.DivisionID != "" {
+		match["metadata.division_id"] = query.DivisionID
+	}
+	if query.Author != "" {
+		match["metadata.created.author"] = query.Author
+	}
+	if query.Tag != "" {
+		match["metadata.tags"] = query.Tag
+	}
+	if !query.From.IsZero() || !query.To.IsZero() {
+		created := bson.M{}
+		if !query.From.IsZero() {
+			created["$gte"] = query.From
+		}
+		if !query.To.IsZero() {
+			created["$lte"] = query.To
+		}
+		match["metadata.created.date"] = created
+	}
+
+	pipeline := []bson.M{{"$match": match}}
+	pipeline = append(pipeline, mongoPerm.ReadableStages(r.permissions, r.folders.Name(), query.EmployeeID)...)
+	pipeline = append(pipeline,
+		bson.M{"$project": bson.M{"blocks.comments": 0}},
+		bson.M{"$addFields": bson.M{"score": bson.M{"$meta": "textScore"}}},
+		bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "uuid_id", Value: 1}}},
+		bson.M{"$facet": bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"data":  bson.A{bson.M{"$skip": query.Offset}, bson.M{"$limit": query.Limit}},
+		}},
+	)
+
+	var page struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Data bson.RawValue `bson:"data"`
+	}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := collection.Aggregate(sc, pipeline)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if !cursor.Next(sc) {
+			return cursor.Err()
+		}
+		if err := cursor.Decode(&page); err != nil {
+			return fmt.Errorf("failed to decode results: %w", err)
+		}
+		return page.Data.Unmarshal(results)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(page.Total) == 0 {
+		return 0, nil
+	}
+	return page.Total[0].Count, nil
+}
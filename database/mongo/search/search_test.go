@@ -0,0 +1,256 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	mongoSearch "labyrinth/database/mongo/search"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/search"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	client     *mongo.Client
+	testDB     *mongo.Database
+	companyId  string
+	divisionId string
+	reader     string
+	stranger   string
+)
+
+func setup() error {
+	var err error
+	client, err = m.NewConnection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	testDB = client.Database("search_test")
+	companyId = uuid.New().String()
+	divisionId = uuid.New().String()
+	reader = uuid.New().String()
+	stranger = uuid.New().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	visible := journal.NewNotebook(reader, companyId, divisionId, uuid.New().String(), "Синтез аспирина", "Протокол")
+	visible.Metadata.Tags = []string{"synthesis"}
+	visible.Blocks = []journal.Block{
+		journal.NewBlock("text", map[string]any{"content": "Салициловая кислота растворена в уксусном ангидриде"}),
+		journal.NewBlock("code", map[string]any{"code": "print('titration')", "language": "python"}),
+	}
+	hidden := journal.NewNotebook(stranger, companyId, divisionId, uuid.New().String(), "Секретный синтез", "")
+	otherCompany := journal.NewNotebook(reader, uuid.New().String(), divisionId, uuid.New().String(), "Синтез в другой компании", "")
+
+	folder := directory.NewDirectory(uuid.MustParse(reader), uuid.MustParse(companyId), uuid.MustParse(divisionId), uuid.New(), uuid.Nil, "1.0.0", false, "Архив", "")
+	folder.Files = []directory.File{directory.NewFile(uuid.MustParse(visible.UuidID), "Титрование образцов", "")}
+
+	// Журналы незнакомца в общей папке, которую читатель может читать: доступ наследуется и через вложенную папку,
+	// кроме журнала, который не наследует правила папки
+	shared := directory.NewDirectory(uuid.MustParse(stranger), uuid.MustParse(companyId), uuid.MustParse(divisionId), uuid.New(), uuid.New(), "1.0.0", false, "Общая", "")
+	nested := directory.NewDirectory(uuid.MustParse(stranger), uuid.MustParse(companyId), uuid.MustParse(divisionId), uuid.New(), uuid.MustParse(shared.UuidID), "1.0.0", false, "Вложенная", "")
+	inherited := journal.NewNotebook(stranger, companyId, divisionId, uuid.New().String(), "Перекристаллизация из этанола", "")
+	overridden := journal.NewNotebook(stranger, companyId, divisionId, uuid.New().String(), "Перекристаллизация черновик", "")
+	shared.Files = []directory.File{directory.NewFile(uuid.MustParse(overridden.UuidID), "", "")}
+	nested.Files = []directory.File{directory.NewFile(uuid.MustParse(inherited.UuidID), "", "")}
+
+	if _, err := testDB.Collection("notebook").InsertMany(ctx, []any{visible, hidden, otherCompany, inherited, overridden}); err != nil {
+		return fmt.Errorf("failed to insert notebooks: %w", err)
+	}
+	if _, err := testDB.Collection("folder").InsertMany(ctx, []any{folder, shared, nested}); err != nil {
+		return fmt.Errorf("failed to insert folder: %w", err)
+	}
+
+	var perms []any
+	for _, p := range []struct{ owner, id string }{
+		{reader, visible.UuidID}, {stranger, hidden.UuidID}, {reader, otherCompany.UuidID}, {reader, folder.UuidID},
+	} {
+		perms = append(perms, permission.NewPermission(p.owner, p.id, p.id, "file", visible.ID))
+	}
+	sharedPerm := permission.NewPermission(stranger, shared.UuidID, shared.UuidID, "folder", shared.ID)
+	sharedPerm.Rules.ReadOnly = []string{reader}
+	overriddenPerm := permission.NewPermission(stranger, overridden.UuidID, overridden.UuidID, "file", overridden.ID)
+	overriddenPerm.Inherit = false
+	perms = append(perms, sharedPerm, overriddenPerm,
+		permission.NewPermission(stranger, nested.UuidID, nested.UuidID, "folder", nested.ID),
+		permission.NewPermission(stranger, inherited.UuidID, inherited.UuidID, "file", inherited.ID),
+	)
+	if _, err := testDB.Collection("permission").InsertMany(ctx, perms); err != nil {
+		return fmt.Errorf("failed to insert permissions: %w", err)
+	}
+
+	return mongoSearch.NewSearchMongo(testDB, "notebook", "folder", "permission").EnsureIndexes(ctx)
+}
+
+func teardown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if testDB != nil {
+		testDB.Drop(ctx)
+	}
+
+	if client != nil {
+		client.Disconnect(ctx)
+	}
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	teardown()
+
+	os.Exit(code)
+}
+
+func TestSearch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo := mongoSearch.NewSearchMongo(testDB, "notebook", "folder", "permission")
+
+	session, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	query := func(text string) search.Query {
+		return search.Query{Text: text, CompanyID: companyId, EmployeeID: reader, Limit: 10}
+	}
+
+	t.Run("SearchNotebooksByTitle", func(t *testing.T) {
+		docs, total, err := repo.SearchNotebooks(ctx, &session, query("синтез"))
+		if err != nil {
+			t.Fatalf("SearchNotebooks failed: %v\n", err)
+		}
+
+		if total != 1 || len(docs) != 1 {
+			t.Fatalf("Expected only the readable notebook of the company, got %d\n", total)
+		}
+
+		if docs[0].Title != "Синтез аспирина" || docs[0].Score <= 0 {
+			t.Errorf("Unexpected hit %+v\n", docs[0])
+		}
+	})
+
+	t.Run("SearchNotebooksByBlockText", func(t *testing.T) {
+		docs, _, err := repo.SearchNotebooks(ctx, &session, query("ангидрид"))
+		if err != nil {
+			t.Fatalf("SearchNotebooks failed: %v\n", err)
+		}
+
+		if len(docs) != 1 {
+			t.Fatalf("Expected 1 hit, got %d\n", len(docs))
+		}
+
+		found := false
+		for _, f := range docs[0].Fields {
+			if f.Name == "block" && strings.Contains(f.Text, "ангидриде") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected block text in fields\n")
+		}
+	})
+
+	t.Run("SearchNotebooksFilters", func(t *testing.T) {
+		q := query("синтез")
+		q.Tag = "missing"
+		_, total, err := repo.SearchNotebooks(ctx, &session, q)
+		if err != nil {
+			t.Fatalf("SearchNotebooks failed: %v\n", err)
+		}
+		if total != 0 {
+			t.Errorf("Expected tag filter to exclude the notebook, got %d\n", total)
+		}
+
+		q = query("синтез")
+		q.From = time.Now().Add(time.Hour)
+		_, total, err = repo.SearchNotebooks(ctx, &session, q)
+		if err != nil {
+			t.Fatalf("SearchNotebooks failed: %v\n", err)
+		}
+		if total != 0 {
+			t.Errorf("Expected date filter to exclude the notebook, got %d\n", total)
+		}
+	})
+
+	t.Run("SearchNotebooksOtherEmployee", func(t *testing.T) {
+		q := query("синтез")
+		q.EmployeeID = stranger
+		docs, _, err := repo.SearchNotebooks(ctx, &session, q)
+		if err != nil {
+			t.Fatalf("SearchNotebooks failed: %v\n", err)
+		}
+
+		if len(docs) != 1 || docs[0].Title != "Секретный синтез" {
+			t.Errorf("Expected only the stranger's notebook, got %+v\n", docs)
+		}
+	})
+
+	t.Run("SearchNotebooksInheritedFromFolder", func(t *testing.T) {
+		docs, total, err := repo.SearchNotebooks(ctx, &session, query("перекристаллизация"))
+		if err != nil {
+			t.Fatalf("SearchNotebooks failed: %v\n", err)
+		}
+
+		if total != 1 || docs[0].Title != "Перекристаллизация из этанола" {
+			t.Errorf("Expected only the notebook inheriting folder access, got %+v\n", docs)
+		}
+	})
+
+	t.Run("SearchFoldersByFileTitle", func(t *testing.T) {
+		docs, total, err := repo.SearchFolders(ctx, &session, query("титрование"))
+		if err != nil {
+			t.Fatalf("SearchFolders failed: %v\n", err)
+		}
+
+		if total != 1 || docs[0].Title != "Архив" {
+			t.Fatalf("Expected folder with matching file, got %+v\n", docs)
+		}
+	})
+	t.Run("SearchByAuthor", func(t *testing.T) {
+		q := query("синтез архив")
+		q.Author = reader
+		notebooks, _, err := repo.SearchNotebooks(ctx, &session, q)
+		if err != nil {
+			t.Fatalf("SearchNotebooks failed: %v\n", err)
+		}
+		folders, _, err := repo.SearchFolders(ctx, &session, q)
+		if err != nil {
+			t.Fatalf("SearchFolders failed: %v\n", err)
+		}
+
+		if len(notebooks) != 1 || notebooks[0].Author != reader {
+			t.Errorf("Expected the notebook created by the employee, got %+v\n", notebooks)
+		}
+		if len(folders) != 1 || folders[0].Author != reader {
+			t.Errorf("Expected the folder created by the employee, got %+v\n", folders)
+		}
+
+		q.Author = stranger
+		_, total, err := repo.SearchFolders(ctx, &session, q)
+		if err != nil {
+			t.Fatalf("SearchFolders failed: %v\n", err)
+		}
+		if total != 0 {
+			t.Errorf("Expected author filter to exclude the folder, got %d\n", total)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mongoPerm "labyrinth/database/mongo/permission"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CountTags считает журналы компании по тегам среди тех, что сотрудник может читать
+func (r *TagMongo) CountTags(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	employeeId string,
+) (map[string]int64, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || employeeId == "" {
+		return nil, errors.New("companyId and employeeId cannot be empty")
+	}
+
+	pipeline := []bson.M{{"$match": bson.M{"metadata.company_id": companyId, "metadata.tags.0": bson.M{"$exists": true}}}}
+	pipeline = append(pipeline, mongoPerm.ReadableStages(r.permissions, r.folders.Name(), employeeId)...)
+	pipeline = append(pipeline,
+		bson.M{"$unwind": "$metadata.tags"},
+		bson.M{"$group": bson.M{"_id": "$metadata.tags", "count": bson.M{"$sum": 1}}},
+	)
+
+	counts := map[string]int64{}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.notebooks.Aggregate(sc, pipeline)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		for cursor.Next(sc) {
+			var row struct {
+				Name  string `bson:"_id"`
+				Count int64  `bson:"count"`
+			}
+			if err := cursor.Decode(&row); err != nil {
+				return fmt.Errorf("failed to decode results: %w", err)
+			}
+			counts[row.Name] = row.Count
+		}
+		return cursor.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	return counts, nil
+}
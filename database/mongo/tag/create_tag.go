@@ -0,0 +1,51 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/tag"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateTag добавляет тег в словарь компании; имя уникально без учета регистра
+func (r *TagMongo) CreateTag(
+	ctx context.Context,
+	tx *mongo.Session,
+	t *tag.Tag,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if t == nil {
+		return errors.New("tag cannot be nil")
+	}
+
+	if t.UuidID == "" || t.CompanyID == "" || t.Key == "" {
+		return errors.New("uuid_id, company_id and name are required")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		count, err := r.collection.CountDocuments(sc, bson.M{"company_id": t.CompanyID, "key": t.Key})
+		if err != nil {
+			return fmt.Errorf("failed to check tag name: %w", err)
+		}
+		if count > 0 {
+			return tag.ErrDuplicate
+		}
+
+		if _, err := r.collection.InsertOne(sc, t); err != nil {
+			return fmt.Errorf("failed to insert tag: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/tag"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeleteTag удаляет тег из словаря; имя тега в объектах удаляет RemoveTagUsage
+func (r *TagMongo) DeleteTag(
+	ctx context.Context,
+	tx *mongo.Session,
+	tagId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if tagId == "" {
+		return errors.New("tagId cannot be empty")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		res, err := r.collection.DeleteOne(sc, bson.M{"uuid_id": tagId})
+		if err != nil {
+			return err
+		}
+		if res.DeletedCount == 0 {
+			return tag.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/tag"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (r *TagMongo) GetTagByUuidId(
+	ctx context.Context,
+	tx *mongo.Session,
+	tagId string,
+) (*tag.Tag, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	if tagId == "" {
+		return nil, errors.New("tagId cannot be empty")
+	}
+
+	var result tag.Tag
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		return r.collection.FindOne(sc, bson.M{"uuid_id": tagId}).Decode(&result)
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, tag.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,84 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mongoPerm "labyrinth/database/mongo/permission"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/tag"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetTaggedNotebooks возвращает страницу журналов компании с тегом, доступных сотруднику,
+// начиная с последних измененных, и общее число таких журналов
+func (r *TagMongo) GetTaggedNotebooks(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	employeeId string,
+	name string,
+	limit int64,
+	offset int64,
+) ([]tag.TaggedNotebook, int64, error) {
+	if tx == nil {
+		return nil, 0, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || employeeId == "" || name == "" {
+		return nil, 0, errors.New("companyId, employeeId and name cannot be empty")
+	}
+
+	pipeline := []bson.M{{"$match": bson.M{"metadata.company_id": companyId, "metadata.tags": name}}}
+	pipeline = append(pipeline, mongoPerm.ReadableStages(r.permissions, r.folders.Name(), employeeId)...)
+	pipeline = append(pipeline,
+		bson.M{"$project": bson.M{"blocks": 0}},
+		bson.M{"$sort": bson.D{{Key: "metadata.last_update.date", Value: -1}, {Key: "uuid_id", Value: 1}}},
+		bson.M{"$facet": bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"data":  bson.A{bson.M{"$skip": offset}, bson.M{"$limit": limit}},
+		}},
+	)
+
+	var page struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Data []journal.Notebook `bson:"data"`
+	}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.notebooks.Aggregate(sc, pipeline)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if !cursor.Next(sc) {
+			return cursor.Err()
+		}
+		return cursor.Decode(&page)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tagged notebooks: %w", err)
+	}
+
+	notebooks := make([]tag.TaggedNotebook, 0, len(page.Data))
+	for _, n := range page.Data {
+		notebooks = append(notebooks, tag.TaggedNotebook{
+			UuidID:     n.UuidID,
+			DivisionID: n.Metadata.DivisionID,
+			Title:      n.Metadata.Title,
+			Tags:       n.Metadata.Tags,
+			Created:    n.Metadata.Created,
+			LastUpdate: n.Metadata.LastUpdate,
+		})
+	}
+
+	var total int64
+	if len(page.Total) > 0 {
+		total = page.Total[0].Count
+	}
+	return notebooks, total, nil
+}
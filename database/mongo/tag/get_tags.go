@@ -0,0 +1,61 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/tag"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetTags возвращает теги компании по алфавиту. Непустой prefix оставляет теги,
+// имя которых начинается с него без учета регистра (автодополнение); limit <= 0 - без ограничения.
+func (r *TagMongo) GetTags(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	prefix string,
+	limit int64,
+) ([]tag.Tag, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	if companyId == "" {
+		return nil, errors.New("companyId cannot be empty")
+	}
+
+	filter := bson.M{"company_id": companyId}
+	if prefix != "" {
+		filter["key"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(tag.Key(prefix))}
+	}
+	findOpts := options.Find().SetSort(bson.M{"key": 1})
+	if limit > 0 {
+		findOpts.SetLimit(limit)
+	}
+
+	results := []tag.Tag{}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(sc, filter, findOpts)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if err = cursor.All(sc, &results); err != nil {
+			return fmt.Errorf("failed to decode results: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transactional query failed: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,46 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RemoveTagUsage снимает тег с журналов, папок и шаблонов компании
+func (r *TagMongo) RemoveTagUsage(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	name string,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || name == "" {
+		return 0, errors.New("companyId and name cannot be empty")
+	}
+
+	var modified int64
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		for _, u := range r.usages() {
+			res, err := u.collection.UpdateMany(sc,
+				bson.M{u.companyField: companyId, u.tagsField: name},
+				bson.M{"$pull": bson.M{u.tagsField: name}},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to remove tag from %s: %w", u.collection.Name(), err)
+			}
+			modified += res.ModifiedCount
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove tag usage: %w", err)
+	}
+
+	return modified, nil
+}
@@ -0,0 +1,63 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReplaceTagUsage заменяет имя тега oldName на newName в журналах, папках и шаблонах компании.
+// Используется при переименовании и слиянии: если newName уже стоит у объекта, дубль не появляется,
+// порядок остальных тегов сохраняется. Возвращает число измененных объектов.
+func (r *TagMongo) ReplaceTagUsage(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	oldName string,
+	newName string,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || oldName == "" || newName == "" {
+		return 0, errors.New("companyId, oldName and newName cannot be empty")
+	}
+
+	var modified int64
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		for _, u := range r.usages() {
+			renamed := bson.M{"$map": bson.M{
+				"input": "$" + u.tagsField,
+				"in":    bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$$this", oldName}}, newName, "$$this"}},
+			}}
+			deduplicated := bson.M{"$reduce": bson.M{
+				"input":        renamed,
+				"initialValue": bson.A{},
+				"in": bson.M{"$cond": bson.A{
+					bson.M{"$in": bson.A{"$$this", "$$value"}},
+					"$$value",
+					bson.M{"$concatArrays": bson.A{"$$value", bson.A{"$$this"}}},
+				}},
+			}}
+
+			res, err := u.collection.UpdateMany(sc,
+				bson.M{u.companyField: companyId, u.tagsField: oldName},
+				bson.A{bson.M{"$set": bson.M{u.tagsField: deduplicated}}},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to rename tag in %s: %w", u.collection.Name(), err)
+			}
+			modified += res.ModifiedCount
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace tag usage: %w", err)
+	}
+
+	return modified, nil
+}
@@ -0,0 +1,53 @@
+package tag
+
+import (
+	"fmt"
+	"labyrinth/notebook/models/tag"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TagMongo struct {
+	collection  *mongo.Collection
+	notebooks   *mongo.Collection
+	folders     *mongo.Collection
+	templates   *mongo.Collection
+	permissions string
+}
+
+func NewTagMongo(db *mongo.Database, collection, notebooks, folders, templates, permissions string) *TagMongo {
+	return &TagMongo{
+		collection:  db.Collection(collection),
+		notebooks:   db.Collection(notebooks),
+		folders:     db.Collection(folders),
+		templates:   db.Collection(templates),
+		permissions: permissions,
+	}
+}
+
+// usage описывает, где в коллекции лежат компания и имена тегов
+type usage struct {
+	collection   *mongo.Collection
+	companyField string
+	tagsField    string
+}
+
+// usages - все коллекции, объекты которых ссылаются на теги по имени
+func (r *TagMongo) usages() []usage {
+	return []usage{
+		{r.notebooks, "metadata.company_id", "metadata.tags"},
+		{r.folders, "metadata.company_id", "metadata.tags"},
+		{r.templates, "company_id", "tags"},
+	}
+}
+
+// resource возвращает коллекцию объектов, которые можно отмечать тегом
+func (r *TagMongo) resource(kind string) (*mongo.Collection, error) {
+	switch kind {
+	case tag.ResourceNotebook:
+		return r.notebooks, nil
+	case tag.ResourceFolder:
+		return r.folders, nil
+	}
+	return nil, fmt.Errorf("unknown tagged resource %q", kind)
+}
@@ -0,0 +1,70 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TagResource отмечает журнал или папку тегом; повторная отметка ничего не меняет.
+// Теги не входят в историю ревизий, поэтому ревизия объекта не увеличивается.
+func (r *TagMongo) TagResource(
+	ctx context.Context,
+	tx *mongo.Session,
+	kind string,
+	resourceId string,
+	name string,
+) error {
+	return r.changeResourceTags(ctx, tx, kind, resourceId, bson.M{"$addToSet": bson.M{"metadata.tags": name}})
+}
+
+// UntagResource снимает тег с журнала или папки
+func (r *TagMongo) UntagResource(
+	ctx context.Context,
+	tx *mongo.Session,
+	kind string,
+	resourceId string,
+	name string,
+) error {
+	return r.changeResourceTags(ctx, tx, kind, resourceId, bson.M{"$pull": bson.M{"metadata.tags": name}})
+}
+
+func (r *TagMongo) changeResourceTags(
+	ctx context.Context,
+	tx *mongo.Session,
+	kind string,
+	resourceId string,
+	update bson.M,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if resourceId == "" {
+		return errors.New("resourceId cannot be empty")
+	}
+
+	collection, err := r.resource(kind)
+	if err != nil {
+		return err
+	}
+
+	err = mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		res, err := collection.UpdateOne(sc, bson.M{"uuid_id": resourceId}, update)
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return fmt.Errorf("%s with id %s not found", kind, resourceId)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change %s tags: %w", kind, err)
+	}
+
+	return nil
+}
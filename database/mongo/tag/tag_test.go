@@ -0,0 +1,236 @@
+package tag_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	mongoTag "labyrinth/database/mongo/tag"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/tag"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	client     *mongo.Client
+	testDB     *mongo.Database
+	companyId  string
+	employeeId string
+	notebookId string
+)
+
+func setup() error {
+	var err error
+	client, err = m.NewConnection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	testDB = client.Database("tag_test")
+	companyId = uuid.New().String()
+	employeeId = uuid.New().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n := journal.NewNotebook(employeeId, companyId, uuid.New().String(), uuid.New().String(), "Синтез", "")
+	n.Metadata.Tags = []string{"hplc", "синтез"}
+	notebookId = n.UuidID
+	if _, err := testDB.Collection("notebook").InsertOne(ctx, n); err != nil {
+		return fmt.Errorf("failed to insert notebook: %w", err)
+	}
+
+	perm := permission.NewPermission(employeeId, n.UuidID, n.UuidID, "file", n.ID)
+	if _, err := testDB.Collection("permission").InsertOne(ctx, perm); err != nil {
+		return fmt.Errorf("failed to insert permission: %w", err)
+	}
+
+	return nil
+}
+
+func teardown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if testDB != nil {
+		testDB.Drop(ctx)
+	}
+
+	if client != nil {
+		client.Disconnect(ctx)
+	}
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	teardown()
+
+	os.Exit(code)
+}
+
+func TestTagCRUD(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo := mongoTag.NewTagMongo(testDB, "notebook_tag", "notebook", "folder", "notebook_template", "permission")
+
+	session, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	hplc, _ := tag.NewTag(employeeId, companyId, uuid.New().String(), "HPLC", "#1e88e5")
+	synthesis, _ := tag.NewTag(employeeId, companyId, uuid.New().String(), "синтез", "")
+
+	notebookTags := func(t *testing.T) []string {
+		t.Helper()
+		var n journal.Notebook
+		if err := testDB.Collection("notebook").FindOne(ctx, bson.M{"uuid_id": notebookId}).Decode(&n); err != nil {
+			t.Fatalf("Failed to fetch notebook: %v\n", err)
+		}
+		return n.Metadata.Tags
+	}
+
+	t.Run("CreateTag", func(t *testing.T) {
+		for _, tg := range []*tag.Tag{&hplc, &synthesis} {
+			if err := repo.CreateTag(ctx, &session, tg); err != nil {
+				t.Fatalf("CreateTag failed: %v\n", err)
+			}
+		}
+	})
+
+	t.Run("CreateTagDuplicate", func(t *testing.T) {
+		duplicate, _ := tag.NewTag(employeeId, companyId, uuid.New().String(), "hplc", "")
+		if err := repo.CreateTag(ctx, &session, &duplicate); !errors.Is(err, tag.ErrDuplicate) {
+			t.Errorf("Expected ErrDuplicate, got %v\n", err)
+		}
+	})
+
+	t.Run("GetTagsByPrefix", func(t *testing.T) {
+		tags, err := repo.GetTags(ctx, &session, companyId, "СИН", 10)
+		if err != nil {
+			t.Fatalf("GetTags failed: %v\n", err)
+		}
+		if len(tags) != 1 || tags[0].UuidID != synthesis.UuidID {
+			t.Errorf("Expected only the synthesis tag, got %+v\n", tags)
+		}
+	})
+
+	t.Run("ReplaceAuthor", func(t *testing.T) {
+		newAuthor := uuid.New().String()
+		modified, err := repo.ReplaceAuthor(ctx, &session, companyId, employeeId, newAuthor)
+		if err != nil {
+			t.Fatalf("ReplaceAuthor failed: %v\n", err)
+		}
+		if modified != 2 {
+			t.Errorf("Expected 2 modified tags, got %d\n", modified)
+		}
+
+		fetched, err := repo.GetTagByUuidId(ctx, &session, hplc.UuidID)
+		if err != nil {
+			t.Fatalf("GetTagByUuidId failed: %v\n", err)
+		}
+		if fetched.CreatedBy != newAuthor {
+			t.Errorf("Expected author %s, got %s\n", newAuthor, fetched.CreatedBy)
+		}
+	})
+
+	t.Run("ReplaceTagUsage", func(t *testing.T) {
+		modified, err := repo.ReplaceTagUsage(ctx, &session, companyId, "hplc", "HPLC")
+		if err != nil {
+			t.Fatalf("ReplaceTagUsage failed: %v\n", err)
+		}
+		if modified != 1 {
+			t.Errorf("Expected 1 modified notebook, got %d\n", modified)
+		}
+		if tags := notebookTags(t); fmt.Sprint(tags) != "[HPLC синтез]" {
+			t.Errorf("Unexpected tags after rename: %v\n", tags)
+		}
+	})
+
+	t.Run("MergeKeepsTagsUnique", func(t *testing.T) {
+		if _, err := repo.ReplaceTagUsage(ctx, &session, companyId, "синтез", "HPLC"); err != nil {
+			t.Fatalf("ReplaceTagUsage failed: %v\n", err)
+		}
+		if tags := notebookTags(t); fmt.Sprint(tags) != "[HPLC]" {
+			t.Errorf("Expected merged tags without duplicates, got %v\n", tags)
+		}
+	})
+
+	t.Run("TagResource", func(t *testing.T) {
+		if err := repo.TagResource(ctx, &session, tag.ResourceNotebook, notebookId, "синтез"); err != nil {
+			t.Fatalf("TagResource failed: %v\n", err)
+		}
+		if err := repo.TagResource(ctx, &session, tag.ResourceNotebook, notebookId, "синтез"); err != nil {
+			t.Fatalf("TagResource (repeat) failed: %v\n", err)
+		}
+		if tags := notebookTags(t); fmt.Sprint(tags) != "[HPLC синтез]" {
+			t.Errorf("Unexpected tags: %v\n", tags)
+		}
+	})
+
+	t.Run("CountTags", func(t *testing.T) {
+		counts, err := repo.CountTags(ctx, &session, companyId, employeeId)
+		if err != nil {
+			t.Fatalf("CountTags failed: %v\n", err)
+		}
+		if counts["HPLC"] != 1 || counts["синтез"] != 1 {
+			t.Errorf("Unexpected counts %v\n", counts)
+		}
+
+		counts, err = repo.CountTags(ctx, &session, companyId, uuid.New().String())
+		if err != nil {
+			t.Fatalf("CountTags failed: %v\n", err)
+		}
+		if len(counts) != 0 {
+			t.Errorf("Expected no counts for an employee without access, got %v\n", counts)
+		}
+	})
+
+	t.Run("GetTaggedNotebooks", func(t *testing.T) {
+		notebooks, total, err := repo.GetTaggedNotebooks(ctx, &session, companyId, employeeId, "HPLC", 10, 0)
+		if err != nil {
+			t.Fatalf("GetTaggedNotebooks failed: %v\n", err)
+		}
+		if total != 1 || len(notebooks) != 1 || notebooks[0].UuidID != notebookId {
+			t.Errorf("Expected the tagged notebook, got %d %+v\n", total, notebooks)
+		}
+	})
+
+	t.Run("UntagResource", func(t *testing.T) {
+		if err := repo.UntagResource(ctx, &session, tag.ResourceNotebook, notebookId, "синтез"); err != nil {
+			t.Fatalf("UntagResource failed: %v\n", err)
+		}
+		if tags := notebookTags(t); fmt.Sprint(tags) != "[HPLC]" {
+			t.Errorf("Unexpected tags: %v\n", tags)
+		}
+	})
+
+	t.Run("RemoveTagUsageAndDelete", func(t *testing.T) {
+		if _, err := repo.RemoveTagUsage(ctx, &session, companyId, "HPLC"); err != nil {
+			t.Fatalf("RemoveTagUsage failed: %v\n", err)
+		}
+		if err := repo.DeleteTag(ctx, &session, hplc.UuidID); err != nil {
+			t.Fatalf("DeleteTag failed: %v\n", err)
+		}
+		if _, err := repo.GetTagByUuidId(ctx, &session, hplc.UuidID); !errors.Is(err, tag.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v\n", err)
+		}
+		if tags := notebookTags(t); len(tags) != 0 {
+			t.Errorf("Expected no tags, got %v\n", tags)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/tag"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UpdateTag сохраняет имя и цвет тега; новое имя не должно совпадать с другим тегом компании
+func (r *TagMongo) UpdateTag(
+	ctx context.Context,
+	tx *mongo.Session,
+	t *tag.Tag,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if t == nil || t.UuidID == "" {
+		return errors.New("tag with uuid_id is required")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		count, err := r.collection.CountDocuments(sc, bson.M{
+			"company_id": t.CompanyID,
+			"key":        t.Key,
+			"uuid_id":    bson.M{"$ne": t.UuidID},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check tag name: %w", err)
+		}
+		if count > 0 {
+			return tag.ErrDuplicate
+		}
+
+		res, err := r.collection.UpdateOne(sc, bson.M{"uuid_id": t.UuidID}, bson.M{"$set": bson.M{
+			"name":        t.Name,
+			"key":         t.Key,
+			"color":       t.Color,
+			"last_update": t.LastUpdate,
+		}})
+		if err != nil {
+			return fmt.Errorf("failed to update tag: %w", err)
+		}
+		if res.MatchedCount == 0 {
+			return tag.ErrNotFound
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/template"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (r *TemplateMongo) CreateTemplate(
+	ctx context.Context,
+	tx *mongo.Session,
+	tpl *template.Template,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if tpl == nil {
+		return errors.New("template cannot be nil")
+	}
+
+	if tpl.UuidID == "" {
+		return errors.New("uuid_id is required")
+	}
+
+	if tpl.CompanyID == "" {
+		return errors.New("company_id is required")
+	}
+
+	if tpl.Title == "" {
+		return errors.New("title is required")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		if _, err := r.collection.InsertOne(sc, tpl); err != nil {
+			return fmt.Errorf("failed to insert template: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	return nil
+}
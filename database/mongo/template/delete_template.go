@@ -0,0 +1,41 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/template"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func (r *TemplateMongo) DeleteTemplate(
+	ctx context.Context,
+	tx *mongo.Session,
+	templateId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+	if templateId == "" {
+		return errors.New("templateId cannot be empty")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		result, err := r.collection.DeleteOne(sc, bson.M{"uuid_id": templateId})
+		if err != nil {
+			return fmt.Errorf("failed to delete template: %w", err)
+		}
+		if result.DeletedCount == 0 {
+			return template.ErrNotFound
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to execute delete operation: %w", err)
+	}
+
+	return nil
+}
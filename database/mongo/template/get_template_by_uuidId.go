@@ -0,0 +1,39 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/template"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetTemplateByUuidId возвращает шаблон по UUID или template.ErrNotFound
+func (r *TemplateMongo) GetTemplateByUuidId(
+	ctx context.Context,
+	tx *mongo.Session,
+	templateId string,
+) (*template.Template, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if templateId == "" {
+		return nil, errors.New("templateId cannot be empty")
+	}
+
+	var tpl template.Template
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		return r.collection.FindOne(sc, bson.M{"uuid_id": templateId}).Decode(&tpl)
+	})
+
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, template.ErrNotFound
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &tpl, nil
+}
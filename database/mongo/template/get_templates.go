@@ -0,0 +1,53 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/template"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetTemplates возвращает шаблоны отдела вместе с общими шаблонами компании, отсортированные по названию
+func (r *TemplateMongo) GetTemplates(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	divisionId string,
+) ([]template.Template, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+	if companyId == "" {
+		return nil, errors.New("companyId cannot be empty")
+	}
+
+	filter := bson.M{
+		"company_id":  companyId,
+		"division_id": bson.M{"$in": []string{divisionId, ""}},
+	}
+	findOpts := options.Find().SetSort(bson.M{"title": 1})
+
+	results := []template.Template{}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(sc, filter, findOpts)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if err = cursor.All(sc, &results); err != nil {
+			return fmt.Errorf("failed to decode results: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transactional query failed: %w", err)
+	}
+
+	return results, nil
+}
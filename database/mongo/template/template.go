@@ -0,0 +1,13 @@
+package template
+
+import "go.mongodb.org/mongo-driver/mongo"
+
+type TemplateMongo struct {
+	collection *mongo.Collection
+}
+
+func NewTemplateMongo(db *mongo.Database, collection string) *TemplateMongo {
+	return &TemplateMongo{
+		collection: db.Collection(collection),
+	}
+}
@@ -0,0 +1,218 @@
+package template_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	mongoTpl "labyrinth/database/mongo/template"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/template"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	client       *mongo.Client
+	testDB       *mongo.Database
+	testTemplate *template.Template
+	companyId    string
+	divisionId   string
+)
+
+func setup() error {
+	var err error
+	client, err = m.NewConnection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	testDB = client.Database("template_test")
+
+	companyId = uuid.New().String()
+	divisionId = uuid.New().String()
+	author := uuid.New().String()
+
+	source := journal.NewNotebook(author, companyId, divisionId, uuid.New().String(), "Синтез", "Протокол")
+	source.Blocks = []journal.Block{
+		journal.NewBlock("heading", map[string]any{"text": "Образец {{sample_id}}", "level": 1}),
+		journal.NewBlock("text", map[string]any{"text": "Дата: {{date}}, автор: {{author}}"}),
+	}
+
+	tpl := template.NewTemplate(author, companyId, divisionId, uuid.New().String(), &source, "Протокол синтеза {{date}}", "", []string{"synthesis"})
+	testTemplate = &tpl
+	return nil
+}
+
+func teardown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if testDB != nil {
+		testDB.Drop(ctx)
+	}
+
+	if client != nil {
+		client.Disconnect(ctx)
+	}
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	teardown()
+
+	os.Exit(code)
+}
+
+func TestTemplateCRUD(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo := mongoTpl.NewTemplateMongo(testDB, "template_test")
+
+	session, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	err = session.StartTransaction()
+	if err != nil {
+		t.Fatalf("Failed to start transaction: %v\n", err)
+	}
+
+	t.Run("CreateTemplate", func(t *testing.T) {
+		if err := repo.CreateTemplate(ctx, &session, testTemplate); err != nil {
+			t.Fatalf("CreateTemplate failed: %v\n", err)
+		}
+
+		companyWide := *testTemplate
+		companyWide.ID = primitive.NewObjectID()
+		companyWide.UuidID = uuid.New().String()
+		companyWide.DivisionID = ""
+		companyWide.Title = "Общий протокол"
+		if err := repo.CreateTemplate(ctx, &session, &companyWide); err != nil {
+			t.Fatalf("CreateTemplate (company-wide) failed: %v\n", err)
+		}
+	})
+
+	t.Run("GetTemplateByUuidId", func(t *testing.T) {
+		fetched, err := repo.GetTemplateByUuidId(ctx, &session, testTemplate.UuidID)
+		if err != nil {
+			t.Fatalf("GetTemplateByUuidId failed: %v\n", err)
+		}
+
+		if len(fetched.Blocks) != 2 {
+			t.Errorf("Expected 2 blocks, got %d\n", len(fetched.Blocks))
+		}
+
+		expected := []string{"author", "date", "sample_id"}
+		if fmt.Sprint(fetched.Variables) != fmt.Sprint(expected) {
+			t.Errorf("Expected variables %v, got %v\n", expected, fetched.Variables)
+		}
+	})
+
+	t.Run("GetTemplateNotFound", func(t *testing.T) {
+		_, err := repo.GetTemplateByUuidId(ctx, &session, uuid.New().String())
+		if !errors.Is(err, template.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v\n", err)
+		}
+	})
+
+	t.Run("GetTemplates", func(t *testing.T) {
+		templates, err := repo.GetTemplates(ctx, &session, companyId, divisionId)
+		if err != nil {
+			t.Fatalf("GetTemplates failed: %v\n", err)
+		}
+		if len(templates) != 2 {
+			t.Errorf("Expected 2 templates, got %d\n", len(templates))
+		}
+
+		other, err := repo.GetTemplates(ctx, &session, companyId, uuid.New().String())
+		if err != nil {
+			t.Fatalf("GetTemplates failed: %v\n", err)
+		}
+		if len(other) != 1 {
+			t.Errorf("Expected only the company-wide template, got %d\n", len(other))
+		}
+	})
+
+	t.Run("UpdateTemplate", func(t *testing.T) {
+		updated := *testTemplate
+		updated.Title = "Протокол синтеза v2"
+
+		newRevision, err := repo.UpdateTemplate(ctx, &session, updated.UuidID, &updated, 0)
+		if err != nil {
+			t.Fatalf("UpdateTemplate failed: %v\n", err)
+		}
+
+		if newRevision != 1 {
+			t.Errorf("Expected revision 1, got %d\n", newRevision)
+		}
+	})
+
+	t.Run("UpdateTemplateStaleRevision", func(t *testing.T) {
+		_, err := repo.UpdateTemplate(ctx, &session, testTemplate.UuidID, testTemplate, 0)
+
+		var conflict *revision.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("Expected revision conflict, got %v\n", err)
+		}
+
+		if conflict.Current != 1 {
+			t.Errorf("Expected current revision 1, got %d\n", conflict.Current)
+		}
+	})
+
+	t.Run("ReplaceAuthor", func(t *testing.T) {
+		newAuthor := uuid.New().String()
+		modified, err := repo.ReplaceAuthor(ctx, &session, companyId, testTemplate.Created.Author, newAuthor)
+		if err != nil {
+			t.Fatalf("ReplaceAuthor failed: %v\n", err)
+		}
+		// создание и последнее изменение обоих шаблонов
+		if modified != 4 {
+			t.Errorf("Expected 4 replacements, got %d\n", modified)
+		}
+
+		fetched, err := repo.GetTemplateByUuidId(ctx, &session, testTemplate.UuidID)
+		if err != nil {
+			t.Fatalf("GetTemplateByUuidId failed: %v\n", err)
+		}
+		if fetched.Created.Author != newAuthor || fetched.LastUpdate.Author != newAuthor {
+			t.Errorf("Expected author %s, got %q and %q\n", newAuthor, fetched.Created.Author, fetched.LastUpdate.Author)
+		}
+	})
+
+	t.Run("DeleteTemplate", func(t *testing.T) {
+		if err := repo.DeleteTemplate(ctx, &session, testTemplate.UuidID); err != nil {
+			t.Fatalf("DeleteTemplate failed: %v\n", err)
+		}
+
+		err := repo.DeleteTemplate(ctx, &session, testTemplate.UuidID)
+		if !errors.Is(err, template.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound on second delete, got %v\n", err)
+		}
+	})
+
+	if !t.Failed() {
+		if err := session.CommitTransaction(ctx); err != nil {
+			t.Errorf("Failed to commit transaction: %v", err)
+		}
+	} else {
+		if err := session.AbortTransaction(ctx); err != nil {
+			t.Errorf("Failed to abort transaction: %v", err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/template"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// UpdateTemplate обновляет шаблон, только если его ревизия равна expectedRevision.
+// Возвращает новую ревизию; при расхождении - *revision.ConflictError с текущей ревизией.
+func (r *TemplateMongo) UpdateTemplate(
+	ctx context.Context,
+	tx *mongo.Session,
+	templateId string,
+	updateData *template.Template,
+	expectedRevision int64,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	if templateId == "" {
+		return 0, errors.New("templateId cannot be empty")
+	}
+
+	if updateData == nil {
+		return 0, errors.New("updateData cannot be nil")
+	}
+
+	filter := bson.M{"uuid_id": templateId, "revision": revision.Match(expectedRevision)}
+	update := bson.M{
+		"$set": bson.M{
+			"division_id": updateData.DivisionID,
+			"title":       updateData.Title,
+			"description": updateData.Description,
+			"tags":        updateData.Tags,
+			"blocks":      updateData.Blocks,
+			"variables":   updateData.Variables,
+			"last_update": updateData.LastUpdate,
+		},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	var updated template.Template
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		err := r.collection.FindOneAndUpdate(
+			sc,
+			filter,
+			update,
+			options.FindOneAndUpdate().
+				SetProjection(bson.M{"revision": 1}).
+				SetReturnDocument(options.After),
+		).Decode(&updated)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("failed to update template: %w", err)
+		}
+
+		var current template.Template
+		err = r.collection.FindOne(
+			sc,
+			bson.M{"uuid_id": templateId},
+			options.FindOne().SetProjection(bson.M{"revision": 1}),
+		).Decode(&current)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return template.ErrNotFound
+			}
+			return fmt.Errorf("failed to check template existence: %w", err)
+		}
+
+		return &revision.ConflictError{Current: current.Revision}
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute template update transaction: %w", err)
+	}
+
+	return updated.Revision, nil
+}
@@ -0,0 +1,61 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/trash"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateItem сохраняет элемент корзины вместе с документами удаленных папок и журналов
+func (r *TrashMongo) CreateItem(
+	ctx context.Context,
+	tx *mongo.Session,
+	item *trash.Item,
+	folders []directory.Directory,
+	notebooks []journal.Notebook,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if item == nil || item.ID == "" {
+		return errors.New("trash item with ID is required")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		if _, err := r.collection.InsertOne(sc, item); err != nil {
+			return err
+		}
+
+		if len(folders) > 0 {
+			docs := make([]any, 0, len(folders))
+			for _, f := range folders {
+				docs = append(docs, trash.Folder{ItemID: item.ID, Document: f})
+			}
+			if _, err := r.folders.InsertMany(sc, docs); err != nil {
+				return err
+			}
+		}
+
+		if len(notebooks) > 0 {
+			docs := make([]any, 0, len(notebooks))
+			for _, n := range notebooks {
+				docs = append(docs, trash.Notebook{ItemID: item.ID, Document: n})
+			}
+			if _, err := r.notebooks.InsertMany(sc, docs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create trash item: %w", err)
+	}
+
+	return nil
+}
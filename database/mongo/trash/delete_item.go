@@ -0,0 +1,41 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DeleteItem удаляет элемент корзины вместе с сохраненными документами
+func (r *TrashMongo) DeleteItem(
+	ctx context.Context,
+	tx *mongo.Session,
+	itemId string,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if itemId == "" {
+		return errors.New("itemId cannot be empty")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		if _, err := r.folders.DeleteMany(sc, bson.M{"item_id": itemId}); err != nil {
+			return err
+		}
+		if _, err := r.notebooks.DeleteMany(sc, bson.M{"item_id": itemId}); err != nil {
+			return err
+		}
+		_, err := r.collection.DeleteOne(sc, bson.M{"uuid_id": itemId})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete trash item: %w", err)
+	}
+
+	return nil
+}
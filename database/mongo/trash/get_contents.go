@@ -0,0 +1,62 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/trash"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetContents возвращает документы папок и журналов, убранных в корзину вместе с элементом
+func (r *TrashMongo) GetContents(
+	ctx context.Context,
+	tx *mongo.Session,
+	itemId string,
+) ([]directory.Directory, []journal.Notebook, error) {
+	if tx == nil {
+		return nil, nil, errors.New("transaction session is required")
+	}
+
+	if itemId == "" {
+		return nil, nil, errors.New("itemId cannot be empty")
+	}
+
+	var folders []trash.Folder
+	var notebooks []trash.Notebook
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.folders.Find(sc, bson.M{"item_id": itemId})
+		if err != nil {
+			return fmt.Errorf("failed to execute folder query: %w", err)
+		}
+		if err := cursor.All(sc, &folders); err != nil {
+			return fmt.Errorf("failed to decode folders: %w", err)
+		}
+
+		cursor, err = r.notebooks.Find(sc, bson.M{"item_id": itemId})
+		if err != nil {
+			return fmt.Errorf("failed to execute notebook query: %w", err)
+		}
+		if err := cursor.All(sc, &notebooks); err != nil {
+			return fmt.Errorf("failed to decode notebooks: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get trash contents: %w", err)
+	}
+
+	dirs := make([]directory.Directory, 0, len(folders))
+	for _, f := range folders {
+		dirs = append(dirs, f.Document)
+	}
+	nbs := make([]journal.Notebook, 0, len(notebooks))
+	for _, n := range notebooks {
+		nbs = append(nbs, n.Document)
+	}
+	return dirs, nbs, nil
+}
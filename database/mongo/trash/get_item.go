@@ -0,0 +1,40 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/trash"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetItem возвращает элемент корзины по ID
+func (r *TrashMongo) GetItem(
+	ctx context.Context,
+	tx *mongo.Session,
+	itemId string,
+) (*trash.Item, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	if itemId == "" {
+		return nil, errors.New("itemId cannot be empty")
+	}
+
+	var item trash.Item
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		err := r.collection.FindOne(sc, bson.M{"uuid_id": itemId}).Decode(&item)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return trash.ErrNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash item: %w", err)
+	}
+
+	return &item, nil
+}
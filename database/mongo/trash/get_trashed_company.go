@@ -0,0 +1,51 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/trash"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetTrashedCompany возвращает компанию папки или журнала, лежащих в корзине;
+// если такого документа в корзине нет - trash.ErrNotFound
+func (r *TrashMongo) GetTrashedCompany(
+	ctx context.Context,
+	tx *mongo.Session,
+	resourceId string,
+) (string, error) {
+	if tx == nil {
+		return "", errors.New("transaction session is required")
+	}
+
+	if resourceId == "" {
+		return "", errors.New("resourceId cannot be empty")
+	}
+
+	var stored struct {
+		Document struct {
+			Metadata struct {
+				CompanyID string `bson:"company_id"`
+			} `bson:"metadata"`
+		} `bson:"document"`
+	}
+	filter := bson.M{"document.uuid_id": resourceId}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		err := r.folders.FindOne(sc, filter).Decode(&stored)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = r.notebooks.FindOne(sc, filter).Decode(&stored)
+		}
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return trash.ErrNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to find trashed document: %w", err)
+	}
+
+	return stored.Document.Metadata.CompanyID, nil
+}
@@ -0,0 +1,43 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/trash"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ListExpired возвращает элементы корзины всех компаний, срок хранения которых истек к моменту now
+func (r *TrashMongo) ListExpired(
+	ctx context.Context,
+	tx *mongo.Session,
+	now time.Time,
+	limit int,
+) ([]trash.Item, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"purge_at": 1}).
+		SetLimit(int64(limit))
+
+	items := []trash.Item{}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Find(sc, bson.M{"purge_at": bson.M{"$lte": now}}, opts)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		return cursor.All(sc, &items)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired trash items: %w", err)
+	}
+
+	return items, nil
+}
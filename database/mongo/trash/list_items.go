@@ -0,0 +1,76 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mongoPerm "labyrinth/database/mongo/permission"
+	"labyrinth/notebook/models/trash"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ListItems возвращает страницу корзины отдела, начиная с недавно удаленных, и общее число элементов.
+// Если employeeId не пуст, остаются только элементы, которые сотрудник может читать по правам удаленного объекта.
+func (r *TrashMongo) ListItems(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	divisionId string,
+	employeeId string,
+	limit int,
+	offset int,
+) ([]trash.Item, int64, error) {
+	if tx == nil {
+		return nil, 0, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || divisionId == "" {
+		return nil, 0, errors.New("companyId and divisionId cannot be empty")
+	}
+
+	pipeline := []bson.M{{"$match": bson.M{"company_id": companyId, "division_id": divisionId}}}
+	if employeeId != "" {
+		pipeline = append(pipeline, mongoPerm.ReadableStagesOn(r.permissions, "resource_id", employeeId)...)
+	}
+	pipeline = append(pipeline,
+		bson.M{"$sort": bson.D{{Key: "deleted_at", Value: -1}, {Key: "uuid_id", Value: 1}}},
+		bson.M{"$facet": bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"data":  bson.A{bson.M{"$skip": offset}, bson.M{"$limit": limit}},
+		}},
+	)
+
+	var page struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Data []trash.Item `bson:"data"`
+	}
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.collection.Aggregate(sc, pipeline)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+
+		if !cursor.Next(sc) {
+			return cursor.Err()
+		}
+		return cursor.Decode(&page)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list trash items: %w", err)
+	}
+
+	items := page.Data
+	if items == nil {
+		items = []trash.Item{}
+	}
+	var total int64
+	if len(page.Total) > 0 {
+		total = page.Total[0].Count
+	}
+	return items, total, nil
+}
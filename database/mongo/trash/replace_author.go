@@ -0,0 +1,98 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// authorField - поле документа с ID автора. Поле внутри массивов обновляется через фильтр элемента:
+// path адресует поле для поиска, set - для замены, element - фильтр элемента массива (пусто - поле вне массивов)
+type authorField struct {
+	path    string
+	set     string
+	element string
+}
+
+// folderAuthors - поля с авторами в документах папок
+var folderAuthors = []authorField{
+	{path: "metadata.created.author", set: "metadata.created.author"},
+	{path: "metadata.last_update.author", set: "metadata.last_update.author"},
+}
+
+// notebookAuthors - поля с авторами в документах журналов: создание и изменение, комментарии, подписи и согласование
+var notebookAuthors = []authorField{
+	{path: "metadata.created.author", set: "metadata.created.author"},
+	{path: "metadata.last_update.author", set: "metadata.last_update.author"},
+	{path: "blocks.comments.employee_id", set: "blocks.$[].comments.$[e].employee_id", element: "e.employee_id"},
+	{path: "blocks.comments.resolved_by", set: "blocks.$[].comments.$[e].resolved_by", element: "e.resolved_by"},
+	{path: "signatures.signer_id", set: "signatures.$[e].signer_id", element: "e.signer_id"},
+	{path: "lifecycle.reviewer", set: "lifecycle.reviewer"},
+	{path: "lifecycle.history.by", set: "lifecycle.history.$[e].by", element: "e.by"},
+	{path: "lifecycle.history.reviewer", set: "lifecycle.history.$[e].reviewer", element: "e.reviewer"},
+}
+
+// ReplaceAuthor заменяет ID автора from на to в корзине компании: в элементах корзины и в сохраненных
+// документах удаленных папок и журналов. Используется при переводе авторов с ID пользователей на ID сотрудников.
+// Возвращает число замен.
+func (r *TrashMongo) ReplaceAuthor(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	from string,
+	to string,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || from == "" || to == "" {
+		return 0, errors.New("companyId, from and to cannot be empty")
+	}
+
+	var modified int64
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		res, err := r.collection.UpdateMany(sc,
+			bson.M{"company_id": companyId, "deleted_by": from},
+			bson.M{"$set": bson.M{"deleted_by": to}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to replace deleted_by: %w", err)
+		}
+		modified += res.ModifiedCount
+
+		for _, c := range []struct {
+			collection *mongo.Collection
+			fields     []authorField
+		}{
+			{r.folders, folderAuthors},
+			{r.notebooks, notebookAuthors},
+		} {
+			for _, f := range c.fields {
+				opts := options.Update()
+				if f.element != "" {
+					opts.SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{f.element: from}}})
+				}
+				res, err := c.collection.UpdateMany(sc,
+					bson.M{"document.metadata.company_id": companyId, "document." + f.path: from},
+					bson.M{"$set": bson.M{"document." + f.set: to}},
+					opts,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to replace %s in %s: %w", f.path, c.collection.Name(), err)
+				}
+				modified += res.ModifiedCount
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace author: %w", err)
+	}
+
+	return modified, nil
+}
@@ -0,0 +1,19 @@
+package trash
+
+import "go.mongodb.org/mongo-driver/mongo"
+
+type TrashMongo struct {
+	collection  *mongo.Collection
+	folders     *mongo.Collection
+	notebooks   *mongo.Collection
+	permissions string
+}
+
+func NewTrashMongo(db *mongo.Database, collection, folders, notebooks, permissions string) *TrashMongo {
+	return &TrashMongo{
+		collection:  db.Collection(collection),
+		folders:     db.Collection(folders),
+		notebooks:   db.Collection(notebooks),
+		permissions: permissions,
+	}
+}
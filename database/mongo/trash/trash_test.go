@@ -0,0 +1,226 @@
+package trash_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	mongoTrash "labyrinth/database/mongo/trash"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/trash"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	client *mongo.Client
+	testDB *mongo.Database
+)
+
+func setup() error {
+	var err error
+	client, err = m.NewConnection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	testDB = client.Database("trash_test")
+	return nil
+}
+
+func teardown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if testDB != nil {
+		testDB.Drop(ctx)
+	}
+
+	if client != nil {
+		client.Disconnect(ctx)
+	}
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	teardown()
+
+	os.Exit(code)
+}
+
+func TestTrashMongo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	repo := mongoTrash.NewTrashMongo(testDB, "trash", "trash_folder", "trash_notebook", "permission")
+
+	session, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	author := uuid.New()
+	companyId := uuid.New()
+	divisionId := uuid.New()
+	folderId := uuid.New()
+
+	folder := directory.NewDirectory(author, companyId, divisionId, folderId, divisionId, "1.0.0", false, "Синтезы", "")
+	notebook := journal.NewNotebook(author.String(), companyId.String(), divisionId.String(), uuid.New().String(), "Синтез аспирина", "")
+	folder.Files = append(folder.Files, directory.NewFile(uuid.MustParse(notebook.UuidID), notebook.Metadata.Title, ""))
+	notebook.Blocks = []journal.Block{{Id: "a", Type: "text", Comment: []journal.Comment{{Id: "c1", EmployeeId: author.String()}}}}
+	notebook.Signatures = []journal.Signature{{Id: "s1", SignerID: author.String()}}
+
+	item := trash.NewItem(trash.KindFolder, folderId.String(), companyId.String(), divisionId.String(), "Синтезы", divisionId.String(), author.String(), time.Hour)
+	expired := trash.NewItem(trash.KindNotebook, uuid.New().String(), companyId.String(), divisionId.String(), "Старый", divisionId.String(), author.String(), -time.Hour)
+
+	t.Run("CreateItem", func(t *testing.T) {
+		if err := repo.CreateItem(ctx, &session, &item, []directory.Directory{folder}, []journal.Notebook{notebook}); err != nil {
+			t.Fatalf("CreateItem failed: %v", err)
+		}
+		if err := repo.CreateItem(ctx, &session, &expired, nil, nil); err != nil {
+			t.Fatalf("CreateItem without contents failed: %v", err)
+		}
+	})
+
+	t.Run("GetItem", func(t *testing.T) {
+		stored, err := repo.GetItem(ctx, &session, item.ID)
+		if err != nil {
+			t.Fatalf("GetItem failed: %v", err)
+		}
+		if stored.ResourceID != folderId.String() || stored.DeletedBy != author.String() {
+			t.Errorf("Expected stored item %+v, got %+v", item, stored)
+		}
+
+		_, err = repo.GetItem(ctx, &session, uuid.New().String())
+		if !errors.Is(err, trash.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("GetContents", func(t *testing.T) {
+		folders, notebooks, err := repo.GetContents(ctx, &session, item.ID)
+		if err != nil {
+			t.Fatalf("GetContents failed: %v", err)
+		}
+		if len(folders) != 1 || folders[0].UuidID != folderId.String() || len(folders[0].Files) != 1 {
+			t.Errorf("Expected stored folder with its notebook link, got %+v", folders)
+		}
+		if len(notebooks) != 1 || notebooks[0].UuidID != notebook.UuidID {
+			t.Errorf("Expected stored notebook, got %+v", notebooks)
+		}
+	})
+
+	t.Run("ReplaceAuthor", func(t *testing.T) {
+		newAuthor := uuid.New().String()
+		modified, err := repo.ReplaceAuthor(ctx, &session, companyId.String(), author.String(), newAuthor)
+		if err != nil {
+			t.Fatalf("ReplaceAuthor failed: %v", err)
+		}
+		// два элемента корзины, два поля папки и четыре поля журнала: создание, изменение, комментарий и подпись
+		if modified != 8 {
+			t.Errorf("Expected 8 replacements, got %d", modified)
+		}
+
+		stored, err := repo.GetItem(ctx, &session, item.ID)
+		if err != nil {
+			t.Fatalf("GetItem failed: %v", err)
+		}
+		if stored.DeletedBy != newAuthor {
+			t.Errorf("Expected deleted_by %s, got %s", newAuthor, stored.DeletedBy)
+		}
+
+		folders, notebooks, err := repo.GetContents(ctx, &session, item.ID)
+		if err != nil {
+			t.Fatalf("GetContents failed: %v", err)
+		}
+		if folders[0].Metadata.Created.Author != newAuthor {
+			t.Errorf("Expected folder author %s, got %s", newAuthor, folders[0].Metadata.Created.Author)
+		}
+		n := notebooks[0]
+		if n.Metadata.Created.Author != newAuthor || n.Blocks[0].Comment[0].EmployeeId != newAuthor || n.Signatures[0].SignerID != newAuthor {
+			t.Errorf("Expected notebook authors %s, got %+v", newAuthor, n)
+		}
+	})
+
+	t.Run("GetTrashedCompany", func(t *testing.T) {
+		for _, id := range []string{folderId.String(), notebook.UuidID} {
+			company, err := repo.GetTrashedCompany(ctx, &session, id)
+			if err != nil {
+				t.Fatalf("GetTrashedCompany failed: %v", err)
+			}
+			if company != companyId.String() {
+				t.Errorf("Expected company %s, got %s", companyId, company)
+			}
+		}
+
+		_, err := repo.GetTrashedCompany(ctx, &session, uuid.New().String())
+		if !errors.Is(err, trash.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("ListItems", func(t *testing.T) {
+		items, total, err := repo.ListItems(ctx, &session, companyId.String(), divisionId.String(), "", 1, 0)
+		if err != nil {
+			t.Fatalf("ListItems failed: %v", err)
+		}
+		if total != 2 || len(items) != 1 || items[0].ID != expired.ID {
+			t.Errorf("Expected the most recently deleted item of two, got %d %+v", total, items)
+		}
+
+		perm := permission.NewPermission(author.String(), folderId.String(), "", "folder", primitive.NewObjectID())
+		if _, err := testDB.Collection("permission").InsertOne(ctx, perm); err != nil {
+			t.Fatalf("Failed to insert permission: %v", err)
+		}
+
+		items, total, err = repo.ListItems(ctx, &session, companyId.String(), divisionId.String(), author.String(), 10, 0)
+		if err != nil {
+			t.Fatalf("ListItems for employee failed: %v", err)
+		}
+		if total != 1 || len(items) != 1 || items[0].ID != item.ID {
+			t.Errorf("Expected only the readable item, got %d %+v", total, items)
+		}
+	})
+
+	t.Run("ListExpired", func(t *testing.T) {
+		items, err := repo.ListExpired(ctx, &session, time.Now(), 10)
+		if err != nil {
+			t.Fatalf("ListExpired failed: %v", err)
+		}
+		if len(items) != 1 || items[0].ID != expired.ID {
+			t.Errorf("Expected only the expired item, got %+v", items)
+		}
+	})
+
+	t.Run("DeleteItem", func(t *testing.T) {
+		if err := repo.DeleteItem(ctx, &session, item.ID); err != nil {
+			t.Fatalf("DeleteItem failed: %v", err)
+		}
+
+		_, err := repo.GetItem(ctx, &session, item.ID)
+		if !errors.Is(err, trash.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound after DeleteItem, got %v", err)
+		}
+
+		folders, notebooks, err := repo.GetContents(ctx, &session, item.ID)
+		if err != nil {
+			t.Fatalf("GetContents failed: %v", err)
+		}
+		if len(folders) != 0 || len(notebooks) != 0 {
+			t.Errorf("Expected contents to be deleted, got %d folders and %d notebooks", len(folders), len(notebooks))
+		}
+	})
+}
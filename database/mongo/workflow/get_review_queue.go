@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/workflow"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetReviewQueue возвращает журналы компании, ожидающие решения рецензента, начиная с давно отправленных
+func (r *WorkflowMongo) GetReviewQueue(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	reviewerId string,
+) ([]workflow.ReviewItem, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || reviewerId == "" {
+		return nil, errors.New("companyId and reviewerId cannot be empty")
+	}
+
+	filter := bson.M{
+		"metadata.company_id": companyId,
+		"lifecycle.status":    journal.StatusInReview,
+		"lifecycle.reviewer":  reviewerId,
+	}
+	opts := options.Find().SetProjection(bson.M{"blocks": 0, "signatures": 0})
+
+	var notebooks []journal.Notebook
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		cursor, err := r.notebooks.Find(sc, filter, opts)
+		if err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+		defer cursor.Close(sc)
+		return cursor.All(sc, &notebooks)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	items := make([]workflow.ReviewItem, 0, len(notebooks))
+	for _, n := range notebooks {
+		item := workflow.ReviewItem{
+			NotebookID: n.UuidID,
+			DivisionID: n.Metadata.DivisionID,
+			Title:      n.Metadata.Title,
+			Author:     n.Metadata.Created.Author,
+			Revision:   n.Revision,
+		}
+		// последний переход в статус проверки - это отправка, по которой назначен рецензент
+		for i := len(n.Lifecycle.History) - 1; i >= 0; i-- {
+			if change := n.Lifecycle.History[i]; change.To == journal.StatusInReview {
+				item.SubmittedBy = change.By
+				item.SubmittedAt = change.At
+				item.Note = change.Note
+				break
+			}
+		}
+		items = append(items, item)
+	}
+
+	slices.SortFunc(items, func(a, b workflow.ReviewItem) int {
+		return a.SubmittedAt.Compare(b.SubmittedAt)
+	})
+	return items, nil
+}
@@ -0,0 +1,39 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/workflow"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetWorkflow возвращает процесс согласования компании или workflow.ErrNotFound, если он не настроен
+func (r *WorkflowMongo) GetWorkflow(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+) (*workflow.Workflow, error) {
+	if tx == nil {
+		return nil, errors.New("transaction session is required")
+	}
+
+	if companyId == "" {
+		return nil, errors.New("companyId cannot be empty")
+	}
+
+	var result workflow.Workflow
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		return r.collection.FindOne(sc, bson.M{"company_id": companyId}).Decode(&result)
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, workflow.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,46 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ReplaceAuthor заменяет ID автора from на to в процессе согласования компании.
+// Используется при переводе авторов с ID пользователей на ID сотрудников. Возвращает число замен.
+func (r *WorkflowMongo) ReplaceAuthor(
+	ctx context.Context,
+	tx *mongo.Session,
+	companyId string,
+	from string,
+	to string,
+) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction session is required")
+	}
+
+	if companyId == "" || from == "" || to == "" {
+		return 0, errors.New("companyId, from and to cannot be empty")
+	}
+
+	var modified int64
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		res, err := r.collection.UpdateMany(sc,
+			bson.M{"company_id": companyId, "updated_by": from},
+			bson.M{"$set": bson.M{"updated_by": to}},
+		)
+		if err != nil {
+			return err
+		}
+		modified = res.ModifiedCount
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace author: %w", err)
+	}
+
+	return modified, nil
+}
@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/workflow"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveWorkflow создает или заменяет процесс согласования компании
+func (r *WorkflowMongo) SaveWorkflow(
+	ctx context.Context,
+	tx *mongo.Session,
+	w *workflow.Workflow,
+) error {
+	if tx == nil {
+		return errors.New("transaction session is required")
+	}
+
+	if w == nil || w.CompanyID == "" {
+		return errors.New("workflow with company_id is required")
+	}
+
+	err := mongo.WithSession(ctx, *tx, func(sc mongo.SessionContext) error {
+		_, err := r.collection.UpdateOne(sc, bson.M{"company_id": w.CompanyID}, bson.M{"$set": bson.M{
+			"transitions": w.Transitions,
+			"editable":    w.Editable,
+			"updated_at":  w.UpdatedAt,
+			"updated_by":  w.UpdatedBy,
+		}}, options.Update().SetUpsert(true))
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package workflow
+
+import "go.mongodb.org/mongo-driver/mongo"
+
+type WorkflowMongo struct {
+	collection *mongo.Collection
+	notebooks  *mongo.Collection
+}
+
+func NewWorkflowMongo(db *mongo.Database, collection, notebooks string) *WorkflowMongo {
+	return &WorkflowMongo{
+		collection: db.Collection(collection),
+		notebooks:  db.Collection(notebooks),
+	}
+}
@@ -0,0 +1,167 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	mongoWorkflow "labyrinth/database/mongo/workflow"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/workflow"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	client     *mongo.Client
+	testDB     *mongo.Database
+	companyId  string
+	reviewerId string
+	notebookId string
+)
+
+func setup() error {
+	var err error
+	client, err = m.NewConnection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	testDB = client.Database("workflow_test")
+	companyId = uuid.New().String()
+	reviewerId = uuid.New().String()
+	author := uuid.New().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n := journal.NewNotebook(author, companyId, uuid.New().String(), uuid.New().String(), "Синтез", "")
+	n.Lifecycle = journal.Lifecycle{
+		Status:   journal.StatusInReview,
+		Reviewer: reviewerId,
+		History: []journal.StatusChange{{
+			Action: workflow.ActionSubmit, From: journal.StatusDraft, To: journal.StatusInReview,
+			By: author, Reviewer: reviewerId, Note: "Прошу проверить", At: time.Now(),
+		}},
+	}
+	notebookId = n.UuidID
+	if _, err := testDB.Collection("notebook").InsertOne(ctx, n); err != nil {
+		return fmt.Errorf("failed to insert notebook: %w", err)
+	}
+
+	draft := journal.NewNotebook(author, companyId, uuid.New().String(), uuid.New().String(), "Черновик", "")
+	if _, err := testDB.Collection("notebook").InsertOne(ctx, draft); err != nil {
+		return fmt.Errorf("failed to insert notebook: %w", err)
+	}
+
+	return nil
+}
+
+func teardown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if testDB != nil {
+		testDB.Drop(ctx)
+	}
+
+	if client != nil {
+		client.Disconnect(ctx)
+	}
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	teardown()
+
+	os.Exit(code)
+}
+
+func TestWorkflowMongo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	repo := mongoWorkflow.NewWorkflowMongo(testDB, "notebook_workflow", "notebook")
+
+	session, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	t.Run("GetWorkflowNotConfigured", func(t *testing.T) {
+		_, err := repo.GetWorkflow(ctx, &session, companyId)
+		if !errors.Is(err, workflow.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("SaveWorkflow", func(t *testing.T) {
+		w := workflow.Default(companyId)
+		w.Editable = []string{journal.StatusDraft}
+		w.UpdatedAt = time.Now()
+		if err := repo.SaveWorkflow(ctx, &session, &w); err != nil {
+			t.Fatalf("SaveWorkflow failed: %v", err)
+		}
+
+		w.Transitions = w.Transitions[:2]
+		w.UpdatedBy = reviewerId
+		if err := repo.SaveWorkflow(ctx, &session, &w); err != nil {
+			t.Fatalf("SaveWorkflow replace failed: %v", err)
+		}
+
+		stored, err := repo.GetWorkflow(ctx, &session, companyId)
+		if err != nil {
+			t.Fatalf("GetWorkflow failed: %v", err)
+		}
+		if len(stored.Transitions) != 2 || len(stored.Editable) != 1 {
+			t.Errorf("Expected replaced workflow, got %+v", stored)
+		}
+	})
+
+	t.Run("ReplaceAuthor", func(t *testing.T) {
+		newAuthor := uuid.New().String()
+		modified, err := repo.ReplaceAuthor(ctx, &session, companyId, reviewerId, newAuthor)
+		if err != nil {
+			t.Fatalf("ReplaceAuthor failed: %v", err)
+		}
+		if modified != 1 {
+			t.Errorf("Expected 1 modified workflow, got %d", modified)
+		}
+
+		stored, err := repo.GetWorkflow(ctx, &session, companyId)
+		if err != nil {
+			t.Fatalf("GetWorkflow failed: %v", err)
+		}
+		if stored.UpdatedBy != newAuthor {
+			t.Errorf("Expected author %s, got %s", newAuthor, stored.UpdatedBy)
+		}
+	})
+
+	t.Run("GetReviewQueue", func(t *testing.T) {
+		items, err := repo.GetReviewQueue(ctx, &session, companyId, reviewerId)
+		if err != nil {
+			t.Fatalf("GetReviewQueue failed: %v", err)
+		}
+		if len(items) != 1 || items[0].NotebookID != notebookId || items[0].Note != "Прошу проверить" {
+			t.Errorf("Expected one notebook awaiting review, got %+v", items)
+		}
+
+		items, err = repo.GetReviewQueue(ctx, &session, companyId, uuid.New().String())
+		if err != nil {
+			t.Fatalf("GetReviewQueue failed: %v", err)
+		}
+		if len(items) != 0 {
+			t.Errorf("Expected empty queue for another reviewer, got %+v", items)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package company
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (r PostgresCompany) AdjustEmployeesCount(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	companyID uuid.UUID,
+	delta int,
+) error {
+	if sharedTx == nil {
+		return errors.New("start transaction before query")
+	}
+	if delta == 0 {
+		return nil
+	}
+
+	query := `
+        UPDATE companies
+        SET employees = GREATEST(COALESCE(employees, 0) + $2, 0),
+            updated_at = NOW()
+        WHERE id = $1
+    `
+
+	result, err := sharedTx.ExecContext(ctx, query, companyID, delta)
+	if err != nil {
+		return fmt.Errorf("failed to adjust employees count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("company not found")
+	}
+
+	return nil
+}
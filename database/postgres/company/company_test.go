@@ -138,6 +138,49 @@ func TestCompanyCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("AdjustEmployeesCount", func(t *testing.T) {
+		err := pc.AdjustEmployeesCount(ctx, tx, testCompany.ID, 1)
+		if err != nil {
+			t.Fatalf("Failed to adjust employees count: %v", err)
+		}
+
+		fetched, err := pc.GetCompanyByID(ctx, tx, testCompany.ID)
+		if err != nil {
+			t.Fatalf("Failed to verify adjustment: %v", err)
+		}
+		if fetched.Employees != testCompany.Employees+1 {
+			t.Errorf("Expected %d employees, got %d", testCompany.Employees+1, fetched.Employees)
+		}
+	})
+
+	t.Run("ReconcileEmployeesCount", func(t *testing.T) {
+		discrepancies, err := pc.ReconcileEmployeesCount(ctx, tx)
+		if err != nil {
+			t.Fatalf("Failed to reconcile employees count: %v", err)
+		}
+
+		found := false
+		for _, d := range *discrepancies {
+			if d.CompanyID == testCompany.ID {
+				found = true
+				if d.Actual != 0 {
+					t.Errorf("Expected 0 actual employees, got %d", d.Actual)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Expected discrepancy for company %v", testCompany.ID)
+		}
+
+		fetched, err := pc.GetCompanyByID(ctx, tx, testCompany.ID)
+		if err != nil {
+			t.Fatalf("Failed to verify reconciliation: %v", err)
+		}
+		if fetched.Employees != 0 {
+			t.Errorf("Expected 0 employees after reconciliation, got %d", fetched.Employees)
+		}
+	})
+
 	t.Run("DeleteCompany", func(t *testing.T) {
 		err := pc.DeleteCompany(ctx, tx, testCompany.ID)
 		if err != nil {
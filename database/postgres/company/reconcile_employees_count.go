@@ -0,0 +1,58 @@
+package company
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/models/company"
+)
+
+func (r PostgresCompany) ReconcileEmployeesCount(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+) (*[]company.EmployeesDiscrepancy, error) {
+	if sharedTx == nil {
+		return nil, errors.New("start transaction before query")
+	}
+
+	query := `
+        WITH actual AS (
+            SELECT
+                c.id,
+                COALESCE(c.employees, 0) AS stored,
+                COUNT(e.id) FILTER (WHERE e.is_active = true) AS actual
+            FROM companies c
+            LEFT JOIN employee_company e ON e.company_id = c.id
+            GROUP BY c.id
+        )
+        UPDATE companies c
+        SET employees = a.actual,
+            updated_at = NOW()
+        FROM actual a
+        WHERE c.id = a.id
+        AND a.stored <> a.actual
+        RETURNING c.id, c.name, a.stored, a.actual
+    `
+
+	rows, err := sharedTx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile employees count: %w", err)
+	}
+	defer rows.Close()
+
+	discrepancies := []company.EmployeesDiscrepancy{}
+	for rows.Next() {
+		var d company.EmployeesDiscrepancy
+		if err := rows.Scan(&d.CompanyID, &d.Name, &d.Stored, &d.Actual); err != nil {
+			return nil, fmt.Errorf("failed to scan discrepancy: %w", err)
+		}
+		discrepancies = append(discrepancies, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &discrepancies, nil
+}
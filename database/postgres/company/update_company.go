@@ -27,16 +27,15 @@ func (r PostgresCompany) UpdateCompany(
             description = $3,
             logo_url = $4,
             industry = $5,
-            employees = $6,
-            is_verified = $7,
-            is_active = $8,
-            founded_date = $9,
-            address = $10,
-            phone = $11,
-            email = $12,
-            tax_number = $13,
+            is_verified = $6,
+            is_active = $7,
+            founded_date = $8,
+            address = $9,
+            phone = $10,
+            email = $11,
+            tax_number = $12,
             updated_at = NOW()
-        WHERE id = $14
+        WHERE id = $13
     `
 
 	result, err := sharedTx.ExecContext(
@@ -47,7 +46,6 @@ func (r PostgresCompany) UpdateCompany(
 		company.Description,
 		company.LogoURL,
 		company.Industry,
-		company.Employees,
 		company.IsVerified,
 		company.IsActive,
 		company.FoundedDate,
@@ -50,7 +50,7 @@ func (p PostgresDepartment) GetDepartmentById(
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("department not found (id: %s)", departmentId)
+			return nil, fmt.Errorf("department not found (id: %s): %w", departmentId, err)
 		}
 		return nil, fmt.Errorf("failed to get department: %w", err)
 	}
@@ -0,0 +1,55 @@
+package depemployee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (p PostgresEmployeeDepartment) CloseEmployeeDepartment(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	depemployeeID uuid.UUID,
+	effectiveTo time.Time,
+) error {
+	if sharedTx == nil {
+		return errors.New("transaction must be started before query")
+	}
+
+	query := `
+        UPDATE employee_department
+        SET
+            is_active = false,
+            effective_to = $1,
+            updated_at = $2
+        WHERE id = $3
+        AND effective_to IS NULL
+        AND effective_from <= $1
+    `
+
+	result, err := sharedTx.ExecContext(
+		ctx,
+		query,
+		effectiveTo,
+		time.Now(),
+		depemployeeID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to close employee department link: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("open employee department link not found (id: %s)", depemployeeID)
+	}
+
+	return nil
+}
@@ -27,8 +27,10 @@ func (p PostgresEmployeeDepartment) CreateEmployeeDepartment(
             position_id,
             is_active,
             created_at,
-            updated_at
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7)
+            updated_at,
+            effective_from,
+            effective_to
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
     `
 
 	_, err := sharedTx.ExecContext(
@@ -41,6 +43,8 @@ func (p PostgresEmployeeDepartment) CreateEmployeeDepartment(
 		de.IsActive,
 		de.CreatedAt,
 		de.UpdatedAt,
+		de.EffectiveFrom,
+		de.EffectiveTo,
 	)
 
 	if err != nil {
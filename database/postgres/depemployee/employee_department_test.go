@@ -27,13 +27,14 @@ func setup() error {
 		return fmt.Errorf("failed to connect to db  during test user: %w", err)
 	}
 	testDepEmployee = &d.DepartmentEmployee{
-		ID:           uuid.New(),
-		EmployeeID:   uuid.New(),
-		DepartmentID: uuid.New(),
-		PositionID:   uuid.New(),
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-		IsActive:     true,
+		ID:            uuid.New(),
+		EmployeeID:    uuid.New(),
+		DepartmentID:  uuid.New(),
+		PositionID:    uuid.New(),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		IsActive:      true,
+		EffectiveFrom: time.Now(),
 	}
 
 	return nil
@@ -123,6 +124,85 @@ func TestDepartmentEmployeeCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("GetEmployeesDepartmentByDepartmentIdAt", func(t *testing.T) {
+		fetchedEmployees, err := pde.GetEmployeesDepartmentByDepartmentIdAt(ctx, tx, testDepEmployee.DepartmentID, time.Now())
+		if err != nil {
+			t.Fatalf("GetEmployeesDepartmentByDepartmentIdAt failed: %v\n", err)
+		}
+
+		if len(*fetchedEmployees) != 1 {
+			t.Errorf("Expected 1, got %d\n", len(*fetchedEmployees))
+		}
+
+		fetchedEmployees, err = pde.GetEmployeesDepartmentByDepartmentIdAt(ctx, tx, testDepEmployee.DepartmentID, testDepEmployee.EffectiveFrom.Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("GetEmployeesDepartmentByDepartmentIdAt failed: %v\n", err)
+		}
+
+		if len(*fetchedEmployees) != 0 {
+			t.Errorf("Expected 0, got %d\n", len(*fetchedEmployees))
+		}
+	})
+
+	t.Run("CloseEmployeeDepartment", func(t *testing.T) {
+		closedAt := time.Now()
+		err = pde.CloseEmployeeDepartment(ctx, tx, testDepEmployee.ID, closedAt)
+		if err != nil {
+			t.Fatalf("CloseEmployeeDepartment failed: %v\n", err)
+		}
+
+		fetchedEmployees, err := pde.GetEmployeesDepartmentByDepartmentIdAt(ctx, tx, testDepEmployee.DepartmentID, closedAt.Add(time.Second))
+		if err != nil {
+			t.Fatalf("GetEmployeesDepartmentByDepartmentIdAt failed: %v\n", err)
+		}
+
+		if len(*fetchedEmployees) != 0 {
+			t.Errorf("Expected 0, got %d\n", len(*fetchedEmployees))
+		}
+	})
+
+	t.Run("GetEmployeeDepartmentHistory", func(t *testing.T) {
+		history, err := pde.GetEmployeeDepartmentHistory(ctx, tx, testDepEmployee.EmployeeID)
+		if err != nil {
+			t.Fatalf("GetEmployeeDepartmentHistory failed: %v\n", err)
+		}
+
+		if len(*history) != 1 {
+			t.Fatalf("Expected 1, got %d\n", len(*history))
+		}
+
+		if (*history)[0].EffectiveTo == nil {
+			t.Errorf("Expected closed membership, got open\n")
+		}
+	})
+
+	t.Run("ReactivateEmployeeDepartment", func(t *testing.T) {
+		reactivated := *testDepEmployee
+		reactivated.IsActive = true
+		reactivated.UpdatedAt = time.Now().Add(time.Minute)
+
+		err = pde.UpdateEmployeeDepartment(ctx, tx, &reactivated)
+		if err != nil {
+			t.Fatalf("UpdateEmployeeDepartment failed: %v\n", err)
+		}
+
+		history, err := pde.GetEmployeeDepartmentHistory(ctx, tx, testDepEmployee.EmployeeID)
+		if err != nil {
+			t.Fatalf("GetEmployeeDepartmentHistory failed: %v\n", err)
+		}
+		if len(*history) != 2 {
+			t.Fatalf("Expected closed and reopened periods, got %d\n", len(*history))
+		}
+
+		fetchedEmployees, err := pde.GetEmployeesDepartmentByDepartmentIdAt(ctx, tx, testDepEmployee.DepartmentID, reactivated.UpdatedAt.Add(time.Second))
+		if err != nil {
+			t.Fatalf("GetEmployeesDepartmentByDepartmentIdAt failed: %v\n", err)
+		}
+		if len(*fetchedEmployees) != 1 {
+			t.Errorf("Expected 1, got %d\n", len(*fetchedEmployees))
+		}
+	})
+
 	t.Run("DeleteEmployeeDepartment", func(t *testing.T) {
 		err = pde.DeleteEmployeeDepartment(ctx, tx, testDepEmployee.ID)
 		if err != nil {
@@ -28,10 +28,13 @@ func (p PostgresEmployeeDepartment) GetEmployeeDepartmentByEmployeeId(
             position_id,
             created_at,
             updated_at,
-            is_active
+            is_active,
+            effective_from,
+            effective_to
         FROM employee_department
         WHERE employee_id = $1
         AND department_id = $2
+        ORDER BY is_active DESC, effective_from DESC
         LIMIT 1
     `
 
@@ -44,6 +47,8 @@ func (p PostgresEmployeeDepartment) GetEmployeeDepartmentByEmployeeId(
 		&de.CreatedAt,
 		&de.UpdatedAt,
 		&de.IsActive,
+		&de.EffectiveFrom,
+		&de.EffectiveTo,
 	)
 
 	if err != nil {
@@ -0,0 +1,68 @@
+package depemployee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/models/depemployee"
+
+	"github.com/google/uuid"
+)
+
+func (p PostgresEmployeeDepartment) GetEmployeeDepartmentHistory(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	employeeID uuid.UUID,
+) (*[]depemployee.DepartmentEmployee, error) {
+	if sharedTx == nil {
+		return nil, errors.New("transaction must be started before query")
+	}
+
+	query := `
+        SELECT 
+            id,
+            employee_id,
+            department_id,
+            position_id,
+            is_active,
+            created_at,
+            updated_at,
+            effective_from,
+            effective_to
+        FROM employee_department
+        WHERE employee_id = $1
+        ORDER BY effective_from ASC
+    `
+
+	rows, err := sharedTx.QueryContext(ctx, query, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query employee department history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []depemployee.DepartmentEmployee
+	for rows.Next() {
+		var de depemployee.DepartmentEmployee
+		if err := rows.Scan(
+			&de.ID,
+			&de.EmployeeID,
+			&de.DepartmentID,
+			&de.PositionID,
+			&de.IsActive,
+			&de.CreatedAt,
+			&de.UpdatedAt,
+			&de.EffectiveFrom,
+			&de.EffectiveTo,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan employee department: %w", err)
+		}
+		history = append(history, de)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &history, nil
+}
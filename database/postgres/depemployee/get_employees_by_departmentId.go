@@ -27,9 +27,12 @@ func (p PostgresEmployeeDepartment) GetEmployeesDepartmentByDepartmentId(
             position_id,
             is_active,
             created_at,
-            updated_at
+            updated_at,
+            effective_from,
+            effective_to
         FROM employee_department
         WHERE department_id = $1
+        AND effective_to IS NULL
         ORDER BY created_at DESC
     `
 
@@ -50,6 +53,8 @@ func (p PostgresEmployeeDepartment) GetEmployeesDepartmentByDepartmentId(
 			&de.IsActive,
 			&de.CreatedAt,
 			&de.UpdatedAt,
+			&de.EffectiveFrom,
+			&de.EffectiveTo,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan employee department: %w", err)
 		}
@@ -0,0 +1,72 @@
+package depemployee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/models/depemployee"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (p PostgresEmployeeDepartment) GetEmployeesDepartmentByDepartmentIdAt(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	departmentID uuid.UUID,
+	at time.Time,
+) (*[]depemployee.DepartmentEmployee, error) {
+	if sharedTx == nil {
+		return nil, errors.New("transaction must be started before query")
+	}
+
+	query := `
+        SELECT 
+            id,
+            employee_id,
+            department_id,
+            position_id,
+            is_active,
+            created_at,
+            updated_at,
+            effective_from,
+            effective_to
+        FROM employee_department
+        WHERE department_id = $1
+        AND effective_from <= $2
+        AND (effective_to IS NULL OR effective_to > $2)
+        ORDER BY effective_from ASC
+    `
+
+	rows, err := sharedTx.QueryContext(ctx, query, departmentID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query department employees at date: %w", err)
+	}
+	defer rows.Close()
+
+	var employees []depemployee.DepartmentEmployee
+	for rows.Next() {
+		var de depemployee.DepartmentEmployee
+		if err := rows.Scan(
+			&de.ID,
+			&de.EmployeeID,
+			&de.DepartmentID,
+			&de.PositionID,
+			&de.IsActive,
+			&de.CreatedAt,
+			&de.UpdatedAt,
+			&de.EffectiveFrom,
+			&de.EffectiveTo,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan employee department: %w", err)
+		}
+		employees = append(employees, de)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &employees, nil
+}
@@ -24,9 +24,11 @@ func (p PostgresEmployeeDepartment) UpdateEmployeeDepartment(
         SET
             position_id = $1,
             is_active = $2,
-            updated_at = $3
+            updated_at = $3,
+            effective_to = CASE WHEN $2 THEN NULL ELSE $3::timestamptz END
         WHERE employee_id = $4
         AND department_id = $5
+        AND effective_to IS NULL
     `
 
 	// Используем ExecContext вместо QueryRowContext для UPDATE
@@ -54,6 +56,63 @@ func (p PostgresEmployeeDepartment) UpdateEmployeeDepartment(
 		return fmt.Errorf("failed to check affected rows: %w", err)
 	}
 
+	if rowsAffected > 0 {
+		return nil
+	}
+	if !de.IsActive {
+		return fmt.Errorf("active employee department link not found (employee: %s, department: %s)",
+			de.EmployeeID, de.DepartmentID)
+	}
+
+	// Действующего периода нет: повторная активация открывает новый период, закрытые остаются в истории
+	reactivateQuery := `
+        INSERT INTO employee_department (
+            employee_id,
+            department_id,
+            position_id,
+            is_active,
+            created_at,
+            updated_at,
+            effective_from,
+            effective_to
+        )
+        SELECT $1, $2, $3, true, $4, $4, $4, NULL
+        WHERE EXISTS (
+            SELECT 1 FROM employee_department
+            WHERE employee_id = $1
+            AND department_id = $2
+        )
+        AND NOT EXISTS (
+            SELECT 1 FROM employee_department
+            WHERE employee_id = $1
+            AND department_id = $2
+            AND effective_to IS NULL
+        )
+    `
+
+	result, err = sharedTx.ExecContext(
+		ctx,
+		reactivateQuery,
+		de.EmployeeID,
+		de.DepartmentID,
+		de.PositionID,
+		de.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Constraint {
+			case "employee_department_position_id_fkey":
+				return fmt.Errorf("position %s does not exist", de.PositionID)
+			}
+		}
+		return fmt.Errorf("failed to reactivate employee department: %w", err)
+	}
+
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+
 	if rowsAffected == 0 {
 		return fmt.Errorf("employee department link not found (employee: %s, department: %s)",
 			de.EmployeeID, de.DepartmentID)
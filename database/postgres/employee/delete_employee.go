@@ -24,6 +24,7 @@ func (p PostgresEmployee) DeleteEmployee(
             is_active = false,
             updated_at = CURRENT_TIMESTAMP
         WHERE id = $1
+        AND is_active = true
     `
 
 	result, err := sharedTx.ExecContext(
@@ -42,7 +43,7 @@ func (p PostgresEmployee) DeleteEmployee(
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("employee not found (id: %s)", employeeId)
+		return fmt.Errorf("employee not found or already inactive (id: %s)", employeeId)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package employee_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"labyrinth/database/postgres"
 	"labyrinth/database/postgres/employee"
@@ -103,6 +104,41 @@ func TestEmployeeCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("TouchEmployeeActivity", func(t *testing.T) {
+		err = pe.TouchEmployeeActivity(ctx, tx, testEmployee.ID, time.Now())
+		if err != nil {
+			t.Fatalf("TouchEmployeeActivity failed: %v\n", err)
+		}
+	})
+
+	t.Run("GetOnlineEmployeeIds", func(t *testing.T) {
+		ids, err := pe.GetOnlineEmployeeIds(ctx, tx)
+		if err != nil {
+			t.Fatalf("GetOnlineEmployeeIds failed: %v\n", err)
+		}
+
+		found := false
+		for _, id := range ids {
+			if id == testEmployee.ID {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected employee %s to be online\n", testEmployee.ID)
+		}
+	})
+
+	t.Run("SetEmployeesOffline", func(t *testing.T) {
+		affected, err := pe.SetEmployeesOffline(ctx, tx, []uuid.UUID{testEmployee.ID})
+		if err != nil {
+			t.Fatalf("SetEmployeesOffline failed: %v\n", err)
+		}
+
+		if affected != 1 {
+			t.Errorf("Expected 1 affected row, got %d\n", affected)
+		}
+	})
+
 	t.Run("GetEmployeesByCompanyId", func(t *testing.T) {
 		fetchedEmployees, err := pe.GetEmployeesByCompanyId(ctx, tx, testEmployee.CompanyID)
 		if err != nil {
@@ -114,15 +150,81 @@ func TestEmployeeCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("GetAllEmployees", func(t *testing.T) {
+		fetchedEmployees, err := pe.GetAllEmployees(ctx, tx)
+		if err != nil {
+			t.Fatalf("GetAllEmployees failed: %v\n", err)
+		}
+
+		found := false
+		for _, empl := range *fetchedEmployees {
+			if empl.ID == testEmployee.ID {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected employee %s among all employees\n", testEmployee.ID)
+		}
+	})
+
+	t.Run("SearchEmployeeDirectory", func(t *testing.T) {
+		entries, total, err := pe.SearchEmployeeDirectory(ctx, tx, testEmployee.CompanyID, e.DirectoryFilter{Limit: 10})
+		if err != nil {
+			t.Fatalf("SearchEmployeeDirectory failed: %v\n", err)
+		}
+
+		// тестовый сотрудник не связан с пользователем, поэтому в справочник не попадает
+		if total != len(*entries) {
+			t.Errorf("Expected total %d, got %d\n", len(*entries), total)
+		}
+	})
+
+	t.Run("GetMentionTargets", func(t *testing.T) {
+		targets, err := pe.GetMentionTargets(ctx, tx, testEmployee.CompanyID, []string{testEmployee.ID.String()})
+		if err != nil {
+			t.Fatalf("GetMentionTargets failed: %v\n", err)
+		}
+
+		// тестовый сотрудник не связан с пользователем, поэтому упомянуть его нельзя
+		if len(*targets) != 0 {
+			t.Errorf("Expected no mention targets, got %d\n", len(*targets))
+		}
+	})
+
 	t.Run("UpdateEmployee", func(t *testing.T) {
 		updatedEmployee := *testEmployee
 		updatedEmployee.UpdatedAt = time.Now()
 		updatedEmployee.IsActive = false
 
-		err = pe.UpdateEmployee(ctx, tx, &updatedEmployee)
+		wasActive, err := pe.UpdateEmployee(ctx, tx, &updatedEmployee)
+		if err != nil {
+			t.Fatalf("UpdateEmployee failed: %v\n", err)
+		}
+		if !wasActive {
+			t.Errorf("Expected previous state active, got inactive\n")
+		}
+
+		wasActive, err = pe.UpdateEmployee(ctx, tx, &updatedEmployee)
 		if err != nil {
 			t.Fatalf("UpdateEmployee failed: %v\n", err)
 		}
+		if wasActive {
+			t.Errorf("Expected previous state inactive, got active\n")
+		}
+	})
+
+	t.Run("GetEmployeeById", func(t *testing.T) {
+		fetchedEmployee, err := pe.GetEmployeeById(ctx, tx, testEmployee.ID)
+		if err != nil {
+			t.Fatalf("GetEmployeeById failed: %v\n", err)
+		}
+		if fetchedEmployee.UserID != testEmployee.UserID {
+			t.Errorf("Expected %s, got %s\n", testEmployee.UserID, fetchedEmployee.UserID)
+		}
+
+		if _, err := pe.GetEmployeeById(ctx, tx, uuid.New()); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("Expected sql.ErrNoRows for unknown employee, got %v\n", err)
+		}
 	})
 
 	t.Run("GetEmployeeByUserId", func(t *testing.T) {
@@ -0,0 +1,58 @@
+package employee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/models/employee"
+
+	"github.com/google/uuid"
+)
+
+func (p PostgresEmployee) GetEmployeeById(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	employeeId uuid.UUID,
+) (*employee.Employee, error) {
+	if sharedTx == nil {
+		return nil, errors.New("start transaction before query")
+	}
+
+	query := `
+        SELECT 
+            id,
+            user_id,
+            company_id,
+            position_id,
+            is_active,
+            is_online,
+            last_activity_at,
+            created_at,
+            updated_at
+        FROM employee_company
+        WHERE id = $1
+    `
+
+	var empl employee.Employee
+	err := sharedTx.QueryRowContext(ctx, query, employeeId).Scan(
+		&empl.ID,
+		&empl.UserID,
+		&empl.CompanyID,
+		&empl.PositionID,
+		&empl.IsActive,
+		&empl.IsOnline,
+		&empl.LastActivityAt,
+		&empl.CreatedAt,
+		&empl.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("employee not found: %s: %w", employeeId, err)
+		}
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	return &empl, nil
+}
@@ -0,0 +1,77 @@
+package employee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/models/employee"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// GetMentionTargets находит активных сотрудников компании по упоминаниям: имя пользователя Telegram,
+// часть email до @, ID сотрудника или ID пользователя. Упоминания ожидаются в нижнем регистре.
+func (p PostgresEmployee) GetMentionTargets(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	companyId uuid.UUID,
+	handles []string,
+) (*[]employee.MentionTarget, error) {
+	if sharedTx == nil {
+		return nil, errors.New("start transaction before query")
+	}
+
+	targets := []employee.MentionTarget{}
+	if len(handles) == 0 {
+		return &targets, nil
+	}
+
+	query := `
+        SELECT
+            ec.id,
+            ec.user_id,
+            u.email,
+            COALESCE(u.telegram_username, ''),
+            COALESCE(u.first_name, ''),
+            COALESCE(u.last_name, '')
+        FROM employee_company ec
+        JOIN users u ON u.id = ec.user_id
+        WHERE ec.company_id = $1
+        AND ec.is_active = true
+        AND (
+            LOWER(LTRIM(u.telegram_username, '@')) = ANY($2)
+            OR LOWER(SPLIT_PART(u.email, '@', 1)) = ANY($2)
+            OR ec.id::text = ANY($2)
+            OR ec.user_id::text = ANY($2)
+        )
+    `
+
+	rows, err := sharedTx.QueryContext(ctx, query, companyId, pq.Array(handles))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mention targets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var target employee.MentionTarget
+		if err := rows.Scan(
+			&target.EmployeeID,
+			&target.UserID,
+			&target.Email,
+			&target.TelegramUsername,
+			&target.FirstName,
+			&target.LastName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan mention target: %w", err)
+		}
+		targets = append(targets, target)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &targets, nil
+}
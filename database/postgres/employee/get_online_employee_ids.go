@@ -0,0 +1,46 @@
+package employee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (p PostgresEmployee) GetOnlineEmployeeIds(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+) ([]uuid.UUID, error) {
+	if sharedTx == nil {
+		return nil, errors.New("start transaction before query")
+	}
+
+	query := `
+        SELECT id
+        FROM employee_company
+        WHERE is_online = true
+    `
+
+	rows, err := sharedTx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query online employees: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan employee id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return ids, nil
+}
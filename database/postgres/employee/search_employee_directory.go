@@ -0,0 +1,169 @@
+package employee
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"labyrinth/models/employee"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// directorySortColumns - допустимые поля сортировки справочника сотрудников
+var directorySortColumns = map[string]string{
+	"name":          "u.last_name %[1]s, u.first_name %[1]s",
+	"email":         "u.email %[1]s",
+	"position":      "p.lvl %[1]s NULLS LAST, p.name %[1]s",
+	"created_at":    "ec.created_at %[1]s",
+	"last_activity": "ec.last_activity_at %[1]s NULLS LAST",
+}
+
+func (p PostgresEmployee) SearchEmployeeDirectory(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	companyId uuid.UUID,
+	filter employee.DirectoryFilter,
+) (*[]employee.DirectoryEntry, int, error) {
+	if sharedTx == nil {
+		return nil, 0, errors.New("start transaction before query")
+	}
+
+	conditions := []string{"ec.company_id = $1"}
+	args := []any{companyId}
+
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		args = append(args, "%"+escapeLike(search)+"%")
+		n := len(args)
+		conditions = append(conditions, fmt.Sprintf(`(
+            u.first_name ILIKE $%[1]d
+            OR u.last_name ILIKE $%[1]d
+            OR CONCAT_WS(' ', u.first_name, u.last_name) ILIKE $%[1]d
+            OR CONCAT_WS(' ', u.last_name, u.first_name) ILIKE $%[1]d
+            OR u.email ILIKE $%[1]d
+            OR u.telegram_username ILIKE $%[1]d
+        )`, n))
+	}
+	if filter.DepartmentID != uuid.Nil {
+		args = append(args, filter.DepartmentID)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+            SELECT 1 FROM employee_department fed
+            WHERE fed.employee_id = ec.id
+            AND fed.department_id = $%d
+            AND fed.is_active = true
+            AND fed.effective_to IS NULL
+        )`, len(args)))
+	}
+	if filter.PositionID != uuid.Nil {
+		args = append(args, filter.PositionID)
+		conditions = append(conditions, fmt.Sprintf("ec.position_id = $%d", len(args)))
+	}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("ec.is_active = $%d", len(args)))
+	}
+
+	from := `
+        FROM employee_company ec
+        JOIN users u ON u.id = ec.user_id
+        LEFT JOIN positions p ON p.id = ec.position_id
+        WHERE ` + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := sharedTx.QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count directory entries: %w", err)
+	}
+
+	sortColumn, ok := directorySortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = directorySortColumns["name"]
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := `
+        SELECT
+            ec.id,
+            ec.user_id,
+            COALESCE(u.first_name, ''),
+            COALESCE(u.last_name, ''),
+            u.email,
+            COALESCE(u.telegram_username, ''),
+            COALESCE(u.avatar_url, ''),
+            ec.position_id,
+            COALESCE(p.name, ''),
+            COALESCE(p.lvl, 0),
+            ec.is_active,
+            ec.is_online,
+            COALESCE(ec.last_activity_at, ec.created_at),
+            COALESCE((
+                SELECT json_agg(json_build_object(
+                    'department_id', d.id,
+                    'name', d.name,
+                    'position_id', ed.position_id
+                ) ORDER BY d.name)
+                FROM employee_department ed
+                JOIN departments d ON d.id = ed.department_id
+                WHERE ed.employee_id = ec.id
+                AND ed.is_active = true
+                AND ed.effective_to IS NULL
+            ), '[]')` + from +
+		fmt.Sprintf(`
+        ORDER BY %s, ec.id
+        LIMIT $%d OFFSET $%d
+    `, fmt.Sprintf(sortColumn, direction), len(args)-1, len(args))
+
+	rows, err := sharedTx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query directory: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []employee.DirectoryEntry{}
+	for rows.Next() {
+		var (
+			entry       employee.DirectoryEntry
+			departments []byte
+			positionId  uuid.NullUUID
+		)
+		if err := rows.Scan(
+			&entry.EmployeeID,
+			&entry.UserID,
+			&entry.FirstName,
+			&entry.LastName,
+			&entry.Email,
+			&entry.TelegramUsername,
+			&entry.AvatarURL,
+			&positionId,
+			&entry.PositionName,
+			&entry.PositionLvl,
+			&entry.IsActive,
+			&entry.IsOnline,
+			&entry.LastActivityAt,
+			&departments,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan directory entry: %w", err)
+		}
+		entry.PositionID = positionId.UUID
+
+		if err := json.Unmarshal(departments, &entry.Departments); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode departments: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &entries, total, nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,48 @@
+package employee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (p PostgresEmployee) SetEmployeesOffline(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	employeeIds []uuid.UUID,
+) (int64, error) {
+	if sharedTx == nil {
+		return 0, errors.New("start transaction before query")
+	}
+	if len(employeeIds) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(employeeIds))
+	for _, id := range employeeIds {
+		ids = append(ids, id.String())
+	}
+
+	query := `
+        UPDATE employee_company
+        SET is_online = false
+        WHERE id = ANY($1::uuid[])
+        AND is_online = true
+    `
+
+	result, err := sharedTx.ExecContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to set employees offline: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,51 @@
+package employee
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (p PostgresEmployee) TouchEmployeeActivity(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	employeeId uuid.UUID,
+	lastActivityAt time.Time,
+) error {
+	if sharedTx == nil {
+		return errors.New("start transaction before query")
+	}
+
+	query := `
+        UPDATE employee_company
+        SET
+            is_online = true,
+            last_activity_at = GREATEST(COALESCE(last_activity_at, $1), $1)
+        WHERE id = $2
+    `
+
+	result, err := sharedTx.ExecContext(
+		ctx,
+		query,
+		lastActivityAt,
+		employeeId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to touch employee activity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("employee not found (id: %s)", employeeId)
+	}
+
+	return nil
+}
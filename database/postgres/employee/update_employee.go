@@ -14,13 +14,19 @@ func (p PostgresEmployee) UpdateEmployee(
 	ctx context.Context,
 	sharedTx *sql.Tx,
 	empl *employee.Employee,
-) error {
+) (bool, error) {
 	if sharedTx == nil {
-		return errors.New("start transaction before query")
+		return false, errors.New("start transaction before query")
 	}
 
 	query := `
-        UPDATE employee_company
+        WITH current AS (
+            SELECT id, is_active
+            FROM employee_company
+            WHERE id = $8
+            FOR UPDATE
+        )
+        UPDATE employee_company e
         SET
             user_id = $1,
             company_id = $2,
@@ -29,10 +35,13 @@ func (p PostgresEmployee) UpdateEmployee(
             is_online = $5,
             last_activity_at = $6,
             updated_at = $7
-        WHERE id = $8
+        FROM current
+        WHERE e.id = current.id
+        RETURNING current.is_active
     `
 
-	result, err := sharedTx.ExecContext(
+	var wasActive bool
+	err := sharedTx.QueryRowContext(
 		ctx,
 		query,
 		empl.UserID,
@@ -43,28 +52,22 @@ func (p PostgresEmployee) UpdateEmployee(
 		empl.LastActivityAt,
 		empl.UpdatedAt,
 		empl.ID,
-	)
+	).Scan(&wasActive)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("employee not found (id: %s): %w", empl.ID, err)
+		}
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Constraint {
 			case "employee_company_user_id_company_id_key":
-				return fmt.Errorf("user %s already exists in company %s", empl.UserID, empl.CompanyID)
+				return false, fmt.Errorf("user %s already exists in company %s", empl.UserID, empl.CompanyID)
 			case "employee_company_position_id_fkey":
-				return fmt.Errorf("position %s does not exist", empl.PositionID)
+				return false, fmt.Errorf("position %s does not exist", empl.PositionID)
 			}
 		}
-		return fmt.Errorf("failed to update employee: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to check affected rows: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("employee not found (id: %s)", empl.ID)
+		return false, fmt.Errorf("failed to update employee: %w", err)
 	}
 
-	return nil
+	return wasActive, nil
 }
@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (p PostgresNotification) CountUnreadNotifications(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	userId uuid.UUID,
+) (int, error) {
+	if sharedTx == nil {
+		return 0, errors.New("start transaction before query")
+	}
+
+	query := `
+        SELECT COUNT(*)
+        FROM notifications
+        WHERE user_id = $1
+        AND read_at IS NULL
+    `
+
+	var count int
+	if err := sharedTx.QueryRowContext(ctx, query, userId).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
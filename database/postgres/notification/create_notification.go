@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/models/notification"
+)
+
+// CreateNotification сохраняет уведомление. Если у получателя уже есть уведомление с тем же ключом,
+// новое не создается и возвращается false.
+func (p PostgresNotification) CreateNotification(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	n *notification.Notification,
+) (bool, error) {
+	if sharedTx == nil {
+		return false, errors.New("start transaction before query")
+	}
+
+	query := `
+        INSERT INTO notifications (
+            id, user_id, company_id, kind, actor_id,
+            notebook_id, block_id, comment_id, title, excerpt,
+            dedup_key, created_at
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+        ON CONFLICT (user_id, dedup_key) DO NOTHING
+    `
+
+	result, err := sharedTx.ExecContext(ctx, query,
+		n.ID,
+		n.UserID,
+		n.CompanyID,
+		n.Kind,
+		n.ActorID,
+		n.NotebookID,
+		n.BlockID,
+		n.CommentID,
+		n.Title,
+		n.Excerpt,
+		n.DedupKey,
+		n.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
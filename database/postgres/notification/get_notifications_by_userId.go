@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/models/notification"
+
+	"github.com/google/uuid"
+)
+
+// GetNotificationsByUserId возвращает страницу уведомлений пользователя (новые первыми) и их общее число
+func (p PostgresNotification) GetNotificationsByUserId(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	userId uuid.UUID,
+	unreadOnly bool,
+	limit, offset int,
+) (*[]notification.Notification, int, error) {
+	if sharedTx == nil {
+		return nil, 0, errors.New("start transaction before query")
+	}
+
+	where := "WHERE user_id = $1"
+	if unreadOnly {
+		where += " AND read_at IS NULL"
+	}
+
+	var total int
+	if err := sharedTx.QueryRowContext(ctx, "SELECT COUNT(*) FROM notifications "+where, userId).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	query := `
+        SELECT
+            id, user_id, company_id, kind, actor_id,
+            COALESCE(notebook_id, ''), COALESCE(block_id, ''), COALESCE(comment_id, ''),
+            COALESCE(title, ''), COALESCE(excerpt, ''), dedup_key, created_at, read_at
+        FROM notifications
+        ` + where + `
+        ORDER BY created_at DESC, id
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := sharedTx.QueryContext(ctx, query, userId, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	items := []notification.Notification{}
+	for rows.Next() {
+		var (
+			n       notification.Notification
+			actorId uuid.NullUUID
+			readAt  sql.NullTime
+		)
+		if err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.CompanyID,
+			&n.Kind,
+			&actorId,
+			&n.NotebookID,
+			&n.BlockID,
+			&n.CommentID,
+			&n.Title,
+			&n.Excerpt,
+			&n.DedupKey,
+			&n.CreatedAt,
+			&readAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		n.ActorID = actorId.UUID
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+		items = append(items, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &items, total, nil
+}
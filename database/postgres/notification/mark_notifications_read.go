@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// MarkNotificationsRead отмечает уведомления пользователя прочитанными; пустой список - все уведомления
+func (p PostgresNotification) MarkNotificationsRead(
+	ctx context.Context,
+	sharedTx *sql.Tx,
+	userId uuid.UUID,
+	notificationIds []uuid.UUID,
+	readAt time.Time,
+) (int64, error) {
+	if sharedTx == nil {
+		return 0, errors.New("start transaction before query")
+	}
+
+	query := `
+        UPDATE notifications
+        SET read_at = $2
+        WHERE user_id = $1
+        AND read_at IS NULL
+    `
+	args := []any{userId, readAt}
+
+	if len(notificationIds) > 0 {
+		ids := make([]string, 0, len(notificationIds))
+		for _, id := range notificationIds {
+			ids = append(ids, id.String())
+		}
+		query += " AND id = ANY($3::uuid[])"
+		args = append(args, pq.Array(ids))
+	}
+
+	result, err := sharedTx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,7 @@
+package notification
+
+type PostgresNotification struct{}
+
+func NewPostgresNotification() PostgresNotification {
+	return PostgresNotification{}
+}
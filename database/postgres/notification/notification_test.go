@@ -0,0 +1,145 @@
+package notification_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/database/postgres/notification"
+	n "labyrinth/models/notification"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	db               *sql.DB
+	testNotification *n.Notification
+)
+
+func setup() error {
+	var connection string = postgres.GetConnection()
+	var err error
+	db, err = sql.Open("postgres", connection)
+	if err != nil {
+		return fmt.Errorf("failed to connect to db  during test notification: %w", err)
+	}
+	testNotification = n.NewMention(
+		uuid.New(),
+		uuid.New(),
+		uuid.New(),
+		uuid.New().String(),
+		uuid.New().String(),
+		"",
+		"Синтез аспирина",
+		"@ivanov проверь навеску",
+	)
+
+	return nil
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+	code := m.Run()
+
+	teardown()
+	os.Exit(code)
+}
+
+func teardown() {
+	if db != nil {
+		db.Close()
+	}
+}
+
+func TestNotificationInbox(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pn := notification.NewPostgresNotification()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v\n", err)
+	}
+	defer func() {
+		if t.Failed() {
+			tx.Rollback()
+		}
+	}()
+
+	t.Run("CreateNotification", func(t *testing.T) {
+		created, err := pn.CreateNotification(ctx, tx, testNotification)
+		if err != nil {
+			t.Fatalf("CreateNotification failed: %v\n", err)
+		}
+		if !created {
+			t.Errorf("Expected notification to be created\n")
+		}
+	})
+
+	t.Run("CreateNotificationDuplicate", func(t *testing.T) {
+		duplicate := *testNotification
+		duplicate.ID = uuid.New()
+
+		created, err := pn.CreateNotification(ctx, tx, &duplicate)
+		if err != nil {
+			t.Fatalf("CreateNotification failed: %v\n", err)
+		}
+		if created {
+			t.Errorf("Expected duplicate mention to be skipped\n")
+		}
+	})
+
+	t.Run("CountUnreadNotifications", func(t *testing.T) {
+		count, err := pn.CountUnreadNotifications(ctx, tx, testNotification.UserID)
+		if err != nil {
+			t.Fatalf("CountUnreadNotifications failed: %v\n", err)
+		}
+		if count != 1 {
+			t.Errorf("Expected 1 unread notification, got %d\n", count)
+		}
+	})
+
+	t.Run("GetNotificationsByUserId", func(t *testing.T) {
+		items, total, err := pn.GetNotificationsByUserId(ctx, tx, testNotification.UserID, true, 10, 0)
+		if err != nil {
+			t.Fatalf("GetNotificationsByUserId failed: %v\n", err)
+		}
+		if total != 1 || len(*items) != 1 {
+			t.Fatalf("Expected 1 notification, got total %d, items %d\n", total, len(*items))
+		}
+		if (*items)[0].Excerpt != testNotification.Excerpt || (*items)[0].ReadAt != nil {
+			t.Errorf("Unexpected notification: %+v\n", (*items)[0])
+		}
+	})
+
+	t.Run("MarkNotificationsRead", func(t *testing.T) {
+		affected, err := pn.MarkNotificationsRead(ctx, tx, testNotification.UserID, []uuid.UUID{testNotification.ID}, time.Now())
+		if err != nil {
+			t.Fatalf("MarkNotificationsRead failed: %v\n", err)
+		}
+		if affected != 1 {
+			t.Errorf("Expected 1 affected row, got %d\n", affected)
+		}
+
+		count, err := pn.CountUnreadNotifications(ctx, tx, testNotification.UserID)
+		if err != nil {
+			t.Fatalf("CountUnreadNotifications failed: %v\n", err)
+		}
+		if count != 0 {
+			t.Errorf("Expected 0 unread notifications, got %d\n", count)
+		}
+	})
+
+	if !t.Failed() {
+		if err = tx.Rollback(); err != nil {
+			t.Errorf("Failed to rollback transaction: %v\n", err)
+		}
+	}
+}
@@ -10,14 +10,17 @@ import (
 	"labyrinth/models/depemployee"
 	"labyrinth/models/depposition"
 	"labyrinth/models/employee"
+	"labyrinth/models/notification"
 	"labyrinth/models/position"
 	"labyrinth/models/user"
+	"time"
 
 	dbCompnay "labyrinth/database/postgres/company"
 	dbDepartment "labyrinth/database/postgres/department"
 	dbDepemployee "labyrinth/database/postgres/depemployee"
 	dbDepPosition "labyrinth/database/postgres/depposition"
 	dbEmployee "labyrinth/database/postgres/employee"
+	dbNotification "labyrinth/database/postgres/notification"
 	dbPosition "labyrinth/database/postgres/position"
 	dbUser "labyrinth/database/postgres/user"
 	dbUuidvalidation "labyrinth/database/postgres/uuidValidation"
@@ -125,6 +128,20 @@ type companyDB interface {
 		sharedTx *sql.Tx,
 		companyID uuid.UUID,
 	) error
+
+	// AdjustEmployeesCount изменяет счетчик сотрудников компании на delta
+	AdjustEmployeesCount(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		companyID uuid.UUID,
+		delta int,
+	) error
+
+	// ReconcileEmployeesCount пересчитывает счетчики сотрудников всех компаний и возвращает расхождения
+	ReconcileEmployeesCount(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+	) (*[]company.EmployeesDiscrepancy, error)
 }
 
 type employeeDB interface {
@@ -135,12 +152,13 @@ type employeeDB interface {
 		empl *employee.Employee,
 	) error
 
-	// UpdateEmployee обновляет данные сотрудника.
+	// UpdateEmployee обновляет данные сотрудника и возвращает его активность до обновления.
+	// Строка блокируется до конца транзакции, поэтому параллельные обновления видят результат друг друга.
 	UpdateEmployee(
 		ctx context.Context,
 		sharedTx *sql.Tx,
 		empl *employee.Employee,
-	) error
+	) (bool, error)
 
 	// GetEmployee возвращает сотрудника по его ID.
 	GetEmployeeByUserId(
@@ -150,6 +168,13 @@ type employeeDB interface {
 		companyId uuid.UUID,
 	) (*employee.Employee, error)
 
+	// GetEmployeeById возвращает сотрудника по его ID.
+	GetEmployeeById(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		employeeId uuid.UUID,
+	) (*employee.Employee, error)
+
 	// GetEmployeesByCompanyId возвращает список сотрудников компании.
 	GetEmployeesByCompanyId(
 		ctx context.Context,
@@ -157,6 +182,12 @@ type employeeDB interface {
 		companyId uuid.UUID,
 	) (*[]employee.Employee, error)
 
+	// GetAllEmployees возвращает сотрудников всех компаний, в том числе уволенных.
+	GetAllEmployees(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+	) (*[]employee.Employee, error)
+
 	// DeleteEmployee is_active = false
 	DeleteEmployee(
 		ctx context.Context,
@@ -177,6 +208,43 @@ type employeeDB interface {
 		sharedTx *sql.Tx,
 		companyId uuid.UUID,
 	) (int, error)
+
+	// TouchEmployeeActivity отмечает сотрудника онлайн и обновляет время последней активности.
+	TouchEmployeeActivity(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		employeeId uuid.UUID,
+		lastActivityAt time.Time,
+	) error
+
+	// SetEmployeesOffline отмечает сотрудников оффлайн.
+	SetEmployeesOffline(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		employeeIds []uuid.UUID,
+	) (int64, error)
+
+	// GetOnlineEmployeeIds возвращает ID сотрудников, отмеченных онлайн.
+	GetOnlineEmployeeIds(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+	) ([]uuid.UUID, error)
+
+	// SearchEmployeeDirectory возвращает страницу справочника сотрудников компании и общее число найденных.
+	SearchEmployeeDirectory(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		companyId uuid.UUID,
+		filter employee.DirectoryFilter,
+	) (*[]employee.DirectoryEntry, int, error)
+
+	// GetMentionTargets находит активных сотрудников компании по @-упоминаниям.
+	GetMentionTargets(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		companyId uuid.UUID,
+		handles []string,
+	) (*[]employee.MentionTarget, error)
 }
 
 type positionDB interface {
@@ -268,7 +336,8 @@ type departmentEmployeeDB interface {
 		departmentID uuid.UUID,
 	) (bool, error)
 
-	// UpdateEmployeeDepartment обновляет данные связи
+	// UpdateEmployeeDepartment обновляет действующий период связи; повторная активация закрытой связи
+	// открывает новый период с UpdatedAt
 	UpdateEmployeeDepartment(
 		ctx context.Context,
 		sharedTx *sql.Tx,
@@ -296,6 +365,29 @@ type departmentEmployeeDB interface {
 		sharedTx *sql.Tx,
 		depemployeeID uuid.UUID,
 	) error
+
+	// CloseEmployeeDepartment завершает членство сотрудника в отделе на указанную дату
+	CloseEmployeeDepartment(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		depemployeeID uuid.UUID,
+		effectiveTo time.Time,
+	) error
+
+	// GetEmployeesDepartmentByDepartmentIdAt возвращает состав отдела на указанную дату
+	GetEmployeesDepartmentByDepartmentIdAt(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		departmentID uuid.UUID,
+		at time.Time,
+	) (*[]depemployee.DepartmentEmployee, error)
+
+	// GetEmployeeDepartmentHistory возвращает историю членства сотрудника в отделах
+	GetEmployeeDepartmentHistory(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		employeeID uuid.UUID,
+	) (*[]depemployee.DepartmentEmployee, error)
 }
 
 type departmentEmployeePositionDB interface {
@@ -342,6 +434,40 @@ type departmentEmployeePositionDB interface {
 	) (bool, error)
 }
 
+type notificationDB interface {
+	// CreateNotification сохраняет уведомление, если у получателя еще нет уведомления с тем же ключом
+	CreateNotification(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		n *notification.Notification,
+	) (bool, error)
+
+	// GetNotificationsByUserId возвращает страницу уведомлений пользователя и их общее число
+	GetNotificationsByUserId(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		userId uuid.UUID,
+		unreadOnly bool,
+		limit, offset int,
+	) (*[]notification.Notification, int, error)
+
+	// CountUnreadNotifications возвращает число непрочитанных уведомлений
+	CountUnreadNotifications(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		userId uuid.UUID,
+	) (int, error)
+
+	// MarkNotificationsRead отмечает уведомления прочитанными (пустой список - все)
+	MarkNotificationsRead(
+		ctx context.Context,
+		sharedTx *sql.Tx,
+		userId uuid.UUID,
+		notificationIds []uuid.UUID,
+		readAt time.Time,
+	) (int64, error)
+}
+
 type uuidValidation interface {
 	CheckAndReserveUUID(
 		ctx context.Context,
@@ -357,6 +483,7 @@ type PostgresDB struct {
 	Department                 departmentDB
 	DepartmentEmployee         departmentEmployeeDB
 	DepartmentEmployeePosition departmentEmployeePositionDB
+	Notification               notificationDB
 	UuidValidation             uuidValidation
 }
 
@@ -369,6 +496,7 @@ func NewPostgresDB() PostgresDB {
 		Department:                 dbDepartment.NewPostgresDepartment(),
 		DepartmentEmployee:         dbDepemployee.NewPostgresEmployeeDepartment(),
 		DepartmentEmployeePosition: dbDepPosition.NewPostgresDepPosition(),
+		Notification:               dbNotification.NewPostgresNotification(),
 		UuidValidation:             dbUuidvalidation.NewDBUuidValidation(),
 	}
 }
@@ -0,0 +1,17 @@
+package notification
+
+import "github.com/redis/go-redis/v9"
+
+const (
+	channelPrefix string = "notifications:user:"
+)
+
+type NotificationRedis struct {
+	client *redis.Client
+}
+
+func NewNotificationRedis(client *redis.Client) *NotificationRedis {
+	return &NotificationRedis{
+		client: client,
+	}
+}
@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PublishNotification отправляет уведомление в канал пользователя; доставка - всем открытым WebSocket-соединениям
+func (n *NotificationRedis) PublishNotification(
+	ctx context.Context,
+	userId string,
+	payload []byte,
+) error {
+	if userId == "" {
+		return errors.New("userId cannot be empty")
+	}
+
+	if err := n.client.Publish(ctx, channelPrefix+userId, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish notification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SubscribeNotifications подписывается на канал уведомлений пользователя. Подписку нужно закрыть вызовом Close.
+func (n *NotificationRedis) SubscribeNotifications(
+	ctx context.Context,
+	userId string,
+) (*redis.PubSub, error) {
+	if userId == "" {
+		return nil, errors.New("userId cannot be empty")
+	}
+
+	sub := n.client.Subscribe(ctx, channelPrefix+userId)
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("failed to subscribe to notifications: %w", err)
+	}
+
+	return sub, nil
+}
@@ -0,0 +1,43 @@
+package presence
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ackPendingScript удаляет сотрудника из очереди, только если его активность не обновилась после чтения
+var ackPendingScript = redis.NewScript(`
+local removed = 0
+for i = 1, #ARGV, 2 do
+	local score = redis.call('ZSCORE', KEYS[1], ARGV[i])
+	if score and tonumber(score) <= tonumber(ARGV[i + 1]) then
+		removed = removed + redis.call('ZREM', KEYS[1], ARGV[i])
+	end
+end
+return removed
+`)
+
+func (p *PresenceRedis) AckPendingActivity(
+	ctx context.Context,
+	activity map[string]time.Time,
+) (int64, error) {
+	if len(activity) == 0 {
+		return 0, nil
+	}
+
+	args := make([]interface{}, 0, len(activity)*2)
+	for employeeId, at := range activity {
+		args = append(args, employeeId, strconv.FormatInt(at.Unix(), 10))
+	}
+
+	removed, err := ackPendingScript.Run(ctx, p.client, []string{pendingKey}, args...).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to ack pending activity: %w", err)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,86 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func (p *PresenceRedis) CacheEmployeeId(
+	ctx context.Context,
+	userId string,
+	companyId string,
+	employeeId string,
+	ttl time.Duration,
+) error {
+	if userId == "" || companyId == "" || employeeId == "" {
+		return errors.New("userId, companyId and employeeId cannot be empty")
+	}
+
+	err := p.client.Set(ctx, userKeyPrefix+companyId+":"+userId, employeeId, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to cache employee id: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PresenceRedis) GetCachedEmployeeId(
+	ctx context.Context,
+	userId string,
+	companyId string,
+) (string, error) {
+	if userId == "" || companyId == "" {
+		return "", errors.New("userId and companyId cannot be empty")
+	}
+
+	employeeId, err := p.client.Get(ctx, userKeyPrefix+companyId+":"+userId).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get cached employee id: %w", err)
+	}
+
+	return employeeId, nil
+}
+
+func (p *PresenceRedis) InvalidateEmployeeId(
+	ctx context.Context,
+	userId string,
+	companyId string,
+) error {
+	if userId == "" || companyId == "" {
+		return errors.New("userId and companyId cannot be empty")
+	}
+
+	if err := p.client.Del(ctx, userKeyPrefix+companyId+":"+userId).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate employee id: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PresenceRedis) InvalidateCompanyEmployeeIds(
+	ctx context.Context,
+	companyId string,
+) error {
+	if companyId == "" {
+		return errors.New("companyId cannot be empty")
+	}
+
+	iter := p.client.Scan(ctx, 0, userKeyPrefix+companyId+":*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := p.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return fmt.Errorf("failed to invalidate employee id: %w", err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan employee cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package presence
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (p *PresenceRedis) GetPendingActivity(
+	ctx context.Context,
+) (map[string]time.Time, error) {
+	pending, err := p.client.ZRangeWithScores(ctx, pendingKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending activity: %w", err)
+	}
+
+	activity := make(map[string]time.Time, len(pending))
+	for _, z := range pending {
+		employeeId, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		activity[employeeId] = time.Unix(int64(z.Score), 0)
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,42 @@
+package presence
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func (p *PresenceRedis) GetPresence(
+	ctx context.Context,
+	employeeIds []string,
+) (map[string]time.Time, error) {
+	online := make(map[string]time.Time)
+	if len(employeeIds) == 0 {
+		return online, nil
+	}
+
+	keys := make([]string, 0, len(employeeIds))
+	for _, id := range employeeIds {
+		keys = append(keys, employeeKeyPrefix+id)
+	}
+
+	values, err := p.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presence: %w", err)
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		unix, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid presence value for %s: %w", employeeIds[i], err)
+		}
+		online[employeeIds[i]] = time.Unix(unix, 0)
+	}
+
+	return online, nil
+}
@@ -0,0 +1,19 @@
+package presence
+
+import "github.com/redis/go-redis/v9"
+
+const (
+	employeeKeyPrefix string = "presence:employee:"
+	userKeyPrefix     string = "presence:user:"
+	pendingKey        string = "presence:pending"
+)
+
+type PresenceRedis struct {
+	client *redis.Client
+}
+
+func NewPresenceRedis(client *redis.Client) *PresenceRedis {
+	return &PresenceRedis{
+		client: client,
+	}
+}
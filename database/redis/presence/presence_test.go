@@ -0,0 +1,182 @@
+package presence_test
+
+import (
+	"context"
+	"fmt"
+	r "labyrinth/database/redis"
+	"labyrinth/database/redis/presence"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	client         *redis.Client
+	testEmployeeId string
+	testUserId     string
+	testCompanyId  string
+)
+
+func setup() error {
+	var err error
+	client, err = r.NewConnection()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	testEmployeeId = uuid.New().String()
+	testUserId = uuid.New().String()
+	testCompanyId = uuid.New().String()
+
+	return nil
+}
+
+func teardown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if client != nil {
+		client.Del(ctx,
+			"presence:employee:"+testEmployeeId,
+			"presence:user:"+testCompanyId+":"+testUserId,
+		)
+		client.Close()
+	}
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Printf("Test setup failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	teardown()
+
+	os.Exit(code)
+}
+
+func TestPresence(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	repo := presence.NewPresenceRedis(client)
+	now := time.Now()
+
+	t.Run("TouchPresence", func(t *testing.T) {
+		err := repo.TouchPresence(ctx, testEmployeeId, now, time.Minute)
+		if err != nil {
+			t.Fatalf("TouchPresence failed: %v\n", err)
+		}
+	})
+
+	t.Run("GetPresence", func(t *testing.T) {
+		online, err := repo.GetPresence(ctx, []string{testEmployeeId, uuid.New().String()})
+		if err != nil {
+			t.Fatalf("GetPresence failed: %v\n", err)
+		}
+
+		if len(online) != 1 {
+			t.Fatalf("Expected 1 online employee, got %d\n", len(online))
+		}
+
+		if online[testEmployeeId].Unix() != now.Unix() {
+			t.Errorf("Expected %d, got %d\n", now.Unix(), online[testEmployeeId].Unix())
+		}
+	})
+
+	t.Run("GetPendingActivity", func(t *testing.T) {
+		activity, err := repo.GetPendingActivity(ctx)
+		if err != nil {
+			t.Fatalf("GetPendingActivity failed: %v\n", err)
+		}
+
+		if _, ok := activity[testEmployeeId]; !ok {
+			t.Errorf("Expected pending activity for %s\n", testEmployeeId)
+		}
+	})
+
+	t.Run("AckPendingActivity", func(t *testing.T) {
+		// Активность, отмеченная после чтения, не должна удаляться
+		stale := map[string]time.Time{testEmployeeId: now.Add(-time.Minute)}
+		if _, err := repo.AckPendingActivity(ctx, stale); err != nil {
+			t.Fatalf("AckPendingActivity failed: %v\n", err)
+		}
+
+		activity, err := repo.GetPendingActivity(ctx)
+		if err != nil {
+			t.Fatalf("GetPendingActivity failed: %v\n", err)
+		}
+		if _, ok := activity[testEmployeeId]; !ok {
+			t.Fatalf("Expected newer activity to stay pending\n")
+		}
+
+		if _, err := repo.AckPendingActivity(ctx, map[string]time.Time{testEmployeeId: now}); err != nil {
+			t.Fatalf("AckPendingActivity failed: %v\n", err)
+		}
+
+		activity, err = repo.GetPendingActivity(ctx)
+		if err != nil {
+			t.Fatalf("GetPendingActivity failed: %v\n", err)
+		}
+		if _, ok := activity[testEmployeeId]; ok {
+			t.Errorf("Expected pending activity to be acknowledged\n")
+		}
+	})
+
+	t.Run("CacheEmployeeId", func(t *testing.T) {
+		err := repo.CacheEmployeeId(ctx, testUserId, testCompanyId, testEmployeeId, time.Minute)
+		if err != nil {
+			t.Fatalf("CacheEmployeeId failed: %v\n", err)
+		}
+
+		cached, err := repo.GetCachedEmployeeId(ctx, testUserId, testCompanyId)
+		if err != nil {
+			t.Fatalf("GetCachedEmployeeId failed: %v\n", err)
+		}
+
+		if cached != testEmployeeId {
+			t.Errorf("Expected %s, got %s\n", testEmployeeId, cached)
+		}
+	})
+
+	t.Run("InvalidateEmployeeId", func(t *testing.T) {
+		if err := repo.InvalidateEmployeeId(ctx, testUserId, testCompanyId); err != nil {
+			t.Fatalf("InvalidateEmployeeId failed: %v\n", err)
+		}
+
+		cached, err := repo.GetCachedEmployeeId(ctx, testUserId, testCompanyId)
+		if err != nil {
+			t.Fatalf("GetCachedEmployeeId failed: %v\n", err)
+		}
+		if cached != "" {
+			t.Errorf("Expected cache to be empty, got %s\n", cached)
+		}
+	})
+
+	t.Run("InvalidateCompanyEmployeeIds", func(t *testing.T) {
+		otherUserId := uuid.New().String()
+		for _, userId := range []string{testUserId, otherUserId} {
+			if err := repo.CacheEmployeeId(ctx, userId, testCompanyId, testEmployeeId, time.Minute); err != nil {
+				t.Fatalf("CacheEmployeeId failed: %v\n", err)
+			}
+		}
+
+		if err := repo.InvalidateCompanyEmployeeIds(ctx, testCompanyId); err != nil {
+			t.Fatalf("InvalidateCompanyEmployeeIds failed: %v\n", err)
+		}
+
+		for _, userId := range []string{testUserId, otherUserId} {
+			cached, err := repo.GetCachedEmployeeId(ctx, userId, testCompanyId)
+			if err != nil {
+				t.Fatalf("GetCachedEmployeeId failed: %v\n", err)
+			}
+			if cached != "" {
+				t.Errorf("Expected cache for %s to be empty, got %s\n", userId, cached)
+			}
+		}
+	})
+}
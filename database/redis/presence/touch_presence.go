@@ -0,0 +1,38 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func (p *PresenceRedis) TouchPresence(
+	ctx context.Context,
+	employeeId string,
+	at time.Time,
+	ttl time.Duration,
+) error {
+	if employeeId == "" {
+		return errors.New("employeeId cannot be empty")
+	}
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	_, err := p.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, employeeKeyPrefix+employeeId, at.Unix(), ttl)
+		pipe.ZAdd(ctx, pendingKey, redis.Z{
+			Score:  float64(at.Unix()),
+			Member: employeeId,
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to touch presence: %w", err)
+	}
+
+	return nil
+}
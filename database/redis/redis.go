@@ -0,0 +1,147 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"labyrinth/config"
+	"labyrinth/database/redis/notification"
+	"labyrinth/database/redis/presence"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type presenceRedis interface {
+	// TouchPresence отмечает активность сотрудника и продлевает TTL присутствия
+	TouchPresence(
+		ctx context.Context,
+		employeeId string,
+		at time.Time,
+		ttl time.Duration,
+	) error
+
+	// GetPresence возвращает время последней активности для сотрудников, которые сейчас онлайн
+	GetPresence(
+		ctx context.Context,
+		employeeIds []string,
+	) (map[string]time.Time, error)
+
+	// GetPendingActivity возвращает накопленную активность для сброса в PostgreSQL, не удаляя ее
+	GetPendingActivity(
+		ctx context.Context,
+	) (map[string]time.Time, error)
+
+	// AckPendingActivity удаляет из очереди сброшенную активность; сотрудники,
+	// отметившиеся после чтения, остаются в очереди до следующего сброса
+	AckPendingActivity(
+		ctx context.Context,
+		activity map[string]time.Time,
+	) (int64, error)
+
+	// CacheEmployeeId сохраняет соответствие пользователя и сотрудника компании
+	CacheEmployeeId(
+		ctx context.Context,
+		userId string,
+		companyId string,
+		employeeId string,
+		ttl time.Duration,
+	) error
+
+	// GetCachedEmployeeId возвращает сотрудника компании по пользователю из кэша
+	GetCachedEmployeeId(
+		ctx context.Context,
+		userId string,
+		companyId string,
+	) (string, error)
+
+	// InvalidateEmployeeId удаляет из кэша сотрудника компании для пользователя
+	InvalidateEmployeeId(
+		ctx context.Context,
+		userId string,
+		companyId string,
+	) error
+
+	// InvalidateCompanyEmployeeIds удаляет из кэша всех сотрудников компании
+	InvalidateCompanyEmployeeIds(
+		ctx context.Context,
+		companyId string,
+	) error
+}
+
+type notificationRedis interface {
+	// PublishNotification отправляет уведомление в канал пользователя
+	PublishNotification(
+		ctx context.Context,
+		userId string,
+		payload []byte,
+	) error
+
+	// SubscribeNotifications подписывается на канал уведомлений пользователя
+	SubscribeNotifications(
+		ctx context.Context,
+		userId string,
+	) (*redis.PubSub, error)
+}
+
+type RedisDB struct {
+	Client       *redis.Client
+	Presence     presenceRedis
+	Notification notificationRedis
+}
+
+func NewRedisDB() (*RedisDB, error) {
+	client, err := NewConnection()
+	if err != nil {
+		return nil, err
+	}
+	return &RedisDB{
+		Client:       client,
+		Presence:     presence.NewPresenceRedis(client),
+		Notification: notification.NewNotificationRedis(client),
+	}, nil
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   *RedisDB
+)
+
+// SharedRedisDB возвращает общее для процесса подключение к Redis, создавая его при первом вызове.
+// Закрывать его не нужно: для частых операций (присутствие) не открывается новый клиент на каждый запрос.
+func SharedRedisDB() (*RedisDB, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if shared != nil {
+		return shared, nil
+	}
+
+	rd, err := NewRedisDB()
+	if err != nil {
+		return nil, err
+	}
+	shared = rd
+
+	return shared, nil
+}
+
+func NewConnection() (*redis.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conf := config.Conf.Redis
+	client := redis.NewClient(&redis.Options{
+		Addr:        conf.Addr,
+		Password:    conf.Password,
+		DB:          conf.DB,
+		DialTimeout: 5 * time.Second,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	return client, nil
+}
@@ -86,6 +86,36 @@ func TestCompany(t *testing.T) {
 		if updatedCompany.Name != "updateMyCompany" {
 			t.Errorf("Expected updateMyCompany, got %s", updatedCompany.Name)
 		}
+		if updatedCompany.Employees != 1 {
+			t.Errorf("Expected 1 employee, got %d", updatedCompany.Employees)
+		}
+	})
+
+	t.Run("SelectCompany", func(t *testing.T) {
+		selectedEmployee, err := comp.SelectCompany(userId, companyId)
+		if err != nil {
+			t.Fatalf("Failed SelectCompany: %v", err)
+		}
+		if selectedEmployee.CompanyID != companyId {
+			t.Errorf("Expected company id %s, got %s", companyId, selectedEmployee.CompanyID)
+		}
+
+		_, err = comp.SelectCompany(userId, uuid.New())
+		if err == nil {
+			t.Errorf("Expected error for unknown company, got nil")
+		}
+	})
+
+	t.Run("ReconcileEmployeesCount", func(t *testing.T) {
+		discrepancies, err := comp.ReconcileEmployeesCount(true)
+		if err != nil {
+			t.Fatalf("Failed ReconcileEmployeesCount: %v", err)
+		}
+		for _, d := range *discrepancies {
+			if d.CompanyID == companyId {
+				t.Errorf("Unexpected discrepancy for company %s: stored %d, actual %d", companyId, d.Stored, d.Actual)
+			}
+		}
 	})
 
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"labyrinth/database/postgres"
+	"labyrinth/database/redis"
 	"labyrinth/logger"
 	"time"
 
@@ -102,7 +103,20 @@ func (c CompanyLogic) DeleteCompany(companyId uuid.UUID) error {
 		return fmt.Errorf("ошибка подтверждения транзакции: %w", err)
 	}
 
-	// 8. Логирование успешного удаления
+	// 8. Сброс кэша сотрудников для присутствия: запросы бывших сотрудников больше не отмечают их онлайн
+	rd, cacheErr := redis.SharedRedisDB()
+	if cacheErr == nil {
+		cacheErr = rd.Presence.InvalidateCompanyEmployeeIds(ctx, companyId.String())
+	}
+	if cacheErr != nil {
+		logger.NewWarnMessage("Не удалось сбросить кэш сотрудников компании",
+			zap.Error(cacheErr),
+			zap.String("operation", "DeleteCompany"),
+			zap.String("company_id", companyId.String()),
+		)
+	}
+
+	// 9. Логирование успешного удаления
 	logger.NewInfoMessage("Компания успешно удалена",
 		zap.String("company_id", companyId.String()),
 		zap.Time("deleted_at", time.Now()),
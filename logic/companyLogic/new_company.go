@@ -178,8 +178,8 @@ func (c CompanyLogic) NewCompany(userId uuid.UUID, name, description string) (uu
 
 	// Создание корневой папки компании в файловой системе
 	fileSystem := notebookLogic.NewFileSystem()
-	err = fileSystem.Folder.CreateFolder(
-		employeeUUID,
+	_, err = fileSystem.Folder.CreateFolder(
+		newEmployee,
 		newCompanyUUID,
 		newCompanyUUID,
 		newCompanyUUID,
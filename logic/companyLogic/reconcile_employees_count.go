@@ -0,0 +1,82 @@
+package companylogic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/company"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ReconcileEmployeesCount пересчитывает счетчик сотрудников всех компаний.
+// При dryRun расхождения только возвращаются, изменения откатываются.
+func (c CompanyLogic) ReconcileEmployeesCount(dryRun bool) (*[]company.EmployeesDiscrepancy, error) {
+	// 1. Подключение к базе данных
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Ошибка подключения к БД",
+			zap.Error(err),
+			zap.String("operation", "ReconcileEmployeesCount"),
+		)
+		return nil, fmt.Errorf("ошибка подключения к БД: %w", err)
+	}
+	defer db.Close()
+
+	// 2. Создание контекста с таймаутом (60 секунд, сверка затрагивает все компании)
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// 3. Начало транзакции
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: false})
+	if err != nil {
+		logger.NewErrMessage("Ошибка начала транзакции",
+			zap.Error(err),
+			zap.String("operation", "ReconcileEmployeesCount"),
+		)
+		return nil, fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 4. Пересчет счетчиков
+	ps := postgres.NewPostgresDB()
+	discrepancies, err := ps.Company.ReconcileEmployeesCount(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("Ошибка пересчета сотрудников",
+			zap.Error(err),
+			zap.String("operation", "ReconcileEmployeesCount"),
+		)
+		return nil, fmt.Errorf("ошибка пересчета сотрудников: %w", err)
+	}
+
+	for _, d := range *discrepancies {
+		logger.NewWarnMessage("Расхождение счетчика сотрудников",
+			zap.String("operation", "ReconcileEmployeesCount"),
+			zap.String("company_id", d.CompanyID.String()),
+			zap.Int("stored", d.Stored),
+			zap.Int("actual", d.Actual),
+		)
+	}
+
+	// 5. Коммит транзакции (кроме пробного запуска)
+	if !dryRun {
+		if err = tx.Commit(); err != nil {
+			logger.NewErrMessage("Ошибка коммита транзакции",
+				zap.Error(err),
+				zap.String("operation", "ReconcileEmployeesCount"),
+			)
+			return nil, fmt.Errorf("ошибка коммита транзакции: %w", err)
+		}
+	}
+
+	logger.NewInfoMessage("Сверка счетчиков сотрудников завершена",
+		zap.String("operation", "ReconcileEmployeesCount"),
+		zap.Int("discrepancies", len(*discrepancies)),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	return discrepancies, nil
+}
@@ -0,0 +1,131 @@
+package companylogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SelectCompany проверяет, что пользователь может работать в компании от имени сотрудника,
+// и возвращает данные сотрудника для выпуска токена активной компании.
+func (c CompanyLogic) SelectCompany(userId, companyId uuid.UUID) (*employee.Employee, error) {
+	// 1. Валидация входных параметров
+	if userId == uuid.Nil {
+		logger.NewWarnMessage("Empty user id provided",
+			zap.String("operation", "SelectCompany"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, errors.New("user id cannot be empty")
+	}
+
+	if companyId == uuid.Nil {
+		logger.NewWarnMessage("Empty company id provided",
+			zap.String("operation", "SelectCompany"),
+			zap.String("user_id", userId.String()),
+			zap.Time("time", time.Now()),
+		)
+		return nil, errors.New("company id cannot be empty")
+	}
+
+	// 2. Инициализация подключения к БД
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "SelectCompany"),
+			zap.String("user_id", userId.String()),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Настройка контекста с таймаутом
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Начало read-only транзакции
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "SelectCompany"),
+			zap.String("user_id", userId.String()),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Проверка, что компания существует и активна
+	ps := postgres.NewPostgresDB()
+	foundCompany, err := ps.Company.GetCompanyByID(ctx, tx, companyId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.NewWarnMessage("Company not found",
+				zap.String("company_id", companyId.String()),
+				zap.String("user_id", userId.String()),
+			)
+			return nil, fmt.Errorf("company not found")
+		}
+
+		logger.NewErrMessage("Failed to fetch company",
+			zap.Error(err),
+			zap.String("company_id", companyId.String()),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, fmt.Errorf("failed to fetch company: %w", err)
+	}
+
+	if !foundCompany.IsActive {
+		logger.NewWarnMessage("Company is not active",
+			zap.String("company_id", companyId.String()),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, errors.New("company is not active")
+	}
+
+	// 6. Проверка, что пользователь является активным сотрудником компании
+	foundEmployee, err := ps.Employee.GetEmployeeByUserId(ctx, tx, userId, companyId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.NewWarnMessage("Employee not found",
+				zap.String("company_id", companyId.String()),
+				zap.String("user_id", userId.String()),
+			)
+			return nil, fmt.Errorf("employee not found")
+		}
+
+		logger.NewErrMessage("Failed to fetch employee",
+			zap.Error(err),
+			zap.String("company_id", companyId.String()),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, fmt.Errorf("failed to fetch employee: %w", err)
+	}
+
+	if !foundEmployee.IsActive {
+		logger.NewWarnMessage("Employee is not active",
+			zap.String("employee_id", foundEmployee.ID.String()),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, errors.New("employee is not active")
+	}
+
+	// 7. Логирование успешного выполнения
+	logger.NewInfoMessage("Active company selected",
+		zap.String("company_id", companyId.String()),
+		zap.String("user_id", userId.String()),
+		zap.String("employee_id", foundEmployee.ID.String()),
+	)
+
+	return foundEmployee, nil
+}
@@ -15,6 +15,7 @@ import (
 	"labyrinth/models/user"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -151,3 +152,100 @@ func TestDepartment(t *testing.T) {
 		}
 	})
 }
+
+func TestTransferDepEmployee(t *testing.T) {
+	var (
+		employeeId      uuid.UUID
+		sourceDepId     uuid.UUID
+		sourcePosId     uuid.UUID
+		joinedAt        time.Time
+		transferredAt   time.Time
+		newDepEmployeId uuid.UUID
+	)
+
+	t.Run("PrepareSourceDepartment", func(t *testing.T) {
+		var err error
+		sourceDepId, _, sourcePosId, err = dep.NewDepartment(fetched1User.ID, fetchedCompany.ID, fetchedCompany.ID, "mySourceDepartment", "mySourceDepartment")
+		if err != nil {
+			t.Fatalf("Failed TransferDepEmployee => NewDepartment: %v", err)
+		}
+
+		res, err := emp.GetEmployee(fetched2User.ID, fetchedCompany.ID)
+		if err != nil {
+			t.Fatalf("Failed TransferDepEmployee => GetEmployee: %v", err)
+		}
+		employeeId = res.ID
+
+		err = depemp.NewDepemployee(employeeId, sourceDepId, sourcePosId)
+		if err != nil {
+			t.Fatalf("Failed TransferDepEmployee => NewDepemployee: %v", err)
+		}
+		joinedAt = time.Now()
+	})
+
+	t.Run("TransferDepEmployee", func(t *testing.T) {
+		var err error
+		transferredAt = time.Now()
+		newDepEmployeId, err = depemp.TransferDepEmployee(employeeId, sourceDepId, departmenId, depPositionId, transferredAt)
+		if err != nil {
+			t.Fatalf("Failed TransferDepEmployee: %v", err)
+		}
+		if newDepEmployeId == uuid.Nil {
+			t.Errorf("Expected new department employee id, got nil")
+		}
+	})
+
+	t.Run("GetDepEmployeesAt", func(t *testing.T) {
+		before, err := depemp.GetDepEmployeesAt(sourceDepId, joinedAt)
+		if err != nil {
+			t.Fatalf("Failed GetDepEmployeesAt: %v", err)
+		}
+		if !containsEmployee(before, employeeId) {
+			t.Errorf("Expected employee %s in source department before transfer", employeeId)
+		}
+
+		after, err := depemp.GetDepEmployeesAt(sourceDepId, transferredAt.Add(time.Second))
+		if err != nil {
+			t.Fatalf("Failed GetDepEmployeesAt: %v", err)
+		}
+		if containsEmployee(after, employeeId) {
+			t.Errorf("Expected employee %s to leave source department after transfer", employeeId)
+		}
+
+		target, err := depemp.GetDepEmployeesAt(departmenId, transferredAt.Add(time.Second))
+		if err != nil {
+			t.Fatalf("Failed GetDepEmployeesAt: %v", err)
+		}
+		if !containsEmployee(target, employeeId) {
+			t.Errorf("Expected employee %s in target department after transfer", employeeId)
+		}
+	})
+
+	t.Run("GetDepEmployeeHistory", func(t *testing.T) {
+		history, err := depemp.GetDepEmployeeHistory(employeeId)
+		if err != nil {
+			t.Fatalf("Failed GetDepEmployeeHistory: %v", err)
+		}
+		var closed, opened bool
+		for _, value := range *history {
+			if value.DepartmentID == sourceDepId && value.EffectiveTo != nil {
+				closed = true
+			}
+			if value.ID == newDepEmployeId && value.EffectiveTo == nil {
+				opened = true
+			}
+		}
+		if !closed || !opened {
+			t.Errorf("Expected closed source membership and open target membership, got closed=%t opened=%t", closed, opened)
+		}
+	})
+}
+
+func containsEmployee(members *[]depemployee.DepartmentEmployee, employeeId uuid.UUID) bool {
+	for _, value := range *members {
+		if value.EmployeeID == employeeId {
+			return true
+		}
+	}
+	return false
+}
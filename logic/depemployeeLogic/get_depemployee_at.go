@@ -0,0 +1,104 @@
+package depemployeelogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/depemployee"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (d DepemployeeLogic) GetDepEmployeesAt(
+	departmentId uuid.UUID,
+	at time.Time,
+) (*[]depemployee.DepartmentEmployee, error) {
+	// 1. Validate input parameters
+	if departmentId == uuid.Nil {
+		logger.NewWarnMessage("Empty department ID provided",
+			zap.String("operation", "GetDepEmployeesAt"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, errors.New("department ID cannot be empty")
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	// 2. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepEmployeesAt"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin read-only transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepEmployeesAt"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+
+	// Ensure proper transaction handling
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logger.NewErrMessage("Transaction rollback failed",
+					zap.Error(rbErr),
+					zap.String("operation", "GetDepEmployeesAt"),
+				)
+			}
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			logger.NewErrMessage("Transaction commit failed",
+				zap.Error(err),
+				zap.String("operation", "GetDepEmployeesAt"),
+			)
+		}
+	}()
+
+	// 5. Fetch department members on the requested date
+	ps := postgres.NewPostgresDB()
+	fetchedDepEmplo, err := ps.DepartmentEmployee.GetEmployeesDepartmentByDepartmentIdAt(ctx, tx, departmentId, at)
+	if err != nil {
+		logger.NewErrMessage("Failed to get department employees at date",
+			zap.Error(err),
+			zap.String("operation", "GetDepEmployeesAt"),
+			zap.String("department_id", departmentId.String()),
+			zap.Time("at", at),
+		)
+		return nil, fmt.Errorf("failed to get department employees at date: %w", err)
+	}
+
+	if len(*fetchedDepEmplo) == 0 {
+		return &[]depemployee.DepartmentEmployee{}, nil
+	}
+
+	logger.NewInfoMessage("Successfully retrieved department employees at date",
+		zap.String("operation", "GetDepEmployeesAt"),
+		zap.String("department_id", departmentId.String()),
+		zap.Time("at", at),
+		zap.Int("employee_count", len(*fetchedDepEmplo)),
+	)
+
+	return fetchedDepEmplo, nil
+}
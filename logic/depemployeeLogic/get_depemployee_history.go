@@ -0,0 +1,98 @@
+package depemployeelogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/depemployee"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (d DepemployeeLogic) GetDepEmployeeHistory(
+	employeeId uuid.UUID,
+) (*[]depemployee.DepartmentEmployee, error) {
+	// 1. Validate input parameters
+	if employeeId == uuid.Nil {
+		logger.NewWarnMessage("Empty employee ID provided",
+			zap.String("operation", "GetDepEmployeeHistory"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, errors.New("employee ID cannot be empty")
+	}
+
+	// 2. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepEmployeeHistory"),
+			zap.String("employee_id", employeeId.String()),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin read-only transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepEmployeeHistory"),
+			zap.String("employee_id", employeeId.String()),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+
+	// Ensure proper transaction handling
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logger.NewErrMessage("Transaction rollback failed",
+					zap.Error(rbErr),
+					zap.String("operation", "GetDepEmployeeHistory"),
+				)
+			}
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			logger.NewErrMessage("Transaction commit failed",
+				zap.Error(err),
+				zap.String("operation", "GetDepEmployeeHistory"),
+			)
+		}
+	}()
+
+	// 5. Fetch membership history
+	ps := postgres.NewPostgresDB()
+	history, err := ps.DepartmentEmployee.GetEmployeeDepartmentHistory(ctx, tx, employeeId)
+	if err != nil {
+		logger.NewErrMessage("Failed to get department membership history",
+			zap.Error(err),
+			zap.String("operation", "GetDepEmployeeHistory"),
+			zap.String("employee_id", employeeId.String()),
+		)
+		return nil, fmt.Errorf("failed to get department membership history: %w", err)
+	}
+
+	if len(*history) == 0 {
+		return &[]depemployee.DepartmentEmployee{}, nil
+	}
+
+	logger.NewInfoMessage("Successfully retrieved department membership history",
+		zap.String("operation", "GetDepEmployeeHistory"),
+		zap.String("employee_id", employeeId.String()),
+		zap.Int("membership_count", len(*history)),
+	)
+
+	return history, nil
+}
@@ -0,0 +1,226 @@
+package depemployeelogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/depemployee"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (d DepemployeeLogic) TransferDepEmployee(
+	employeeId,
+	fromDepartmentId,
+	toDepartmentId,
+	positionId uuid.UUID,
+	effectiveAt time.Time,
+) (uuid.UUID, error) {
+	// 1. Validate input parameters
+	if employeeId == uuid.Nil {
+		logger.NewWarnMessage("Empty employee ID provided",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.Time("time", time.Now()),
+		)
+		return uuid.Nil, errors.New("employee ID cannot be empty")
+	}
+	if fromDepartmentId == uuid.Nil || toDepartmentId == uuid.Nil {
+		logger.NewWarnMessage("Empty department ID provided",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.Time("time", time.Now()),
+		)
+		return uuid.Nil, errors.New("department ID cannot be empty")
+	}
+	if fromDepartmentId == toDepartmentId {
+		logger.NewWarnMessage("Transfer to the same department",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("department_id", fromDepartmentId.String()),
+		)
+		return uuid.Nil, errors.New("source and target departments must differ")
+	}
+	if positionId == uuid.Nil {
+		logger.NewWarnMessage("Empty position ID provided",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.Time("time", time.Now()),
+		)
+		return uuid.Nil, errors.New("position ID cannot be empty")
+	}
+	if effectiveAt.IsZero() {
+		effectiveAt = time.Now()
+	}
+
+	// 2. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: false})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+
+	// 5. Ensure proper transaction handling
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logger.NewErrMessage("Transaction rollback failed",
+					zap.Error(rbErr),
+					zap.String("operation", "TransferDepEmployee"),
+				)
+			}
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			logger.NewErrMessage("Transaction commit failed",
+				zap.Error(err),
+				zap.String("operation", "TransferDepEmployee"),
+			)
+		}
+	}()
+
+	// 6. Fetch current membership in source department
+	ps := postgres.NewPostgresDB()
+	currentDepEmployee, err := ps.DepartmentEmployee.GetEmployeeDepartmentByEmployeeId(ctx, tx, employeeId, fromDepartmentId)
+	if err != nil {
+		logger.NewErrMessage("Failed to get current department membership",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+			zap.String("department_id", fromDepartmentId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to get current department membership: %w", err)
+	}
+	if !currentDepEmployee.IsActive || currentDepEmployee.EffectiveTo != nil {
+		logger.NewWarnMessage("Employee is not an active member of source department",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+			zap.String("department_id", fromDepartmentId.String()),
+		)
+		err = errors.New("employee is not an active member of source department")
+		return uuid.Nil, err
+	}
+	if effectiveAt.Before(currentDepEmployee.EffectiveFrom) {
+		logger.NewWarnMessage("Transfer date precedes current membership",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.Time("effective_at", effectiveAt),
+			zap.Time("effective_from", currentDepEmployee.EffectiveFrom),
+		)
+		err = errors.New("transfer date cannot precede current membership start")
+		return uuid.Nil, err
+	}
+
+	// 7. Verify employee is not already in target department
+	exists, err := ps.DepartmentEmployee.ExistsEmployeeDepartment(ctx, tx, employeeId, toDepartmentId)
+	if err != nil {
+		logger.NewErrMessage("Failed to check target department membership",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+			zap.String("department_id", toDepartmentId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to check target department membership: %w", err)
+	}
+	if exists {
+		logger.NewWarnMessage("Employee already in target department",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+			zap.String("department_id", toDepartmentId.String()),
+		)
+		err = errors.New("employee is already a member of target department")
+		return uuid.Nil, err
+	}
+
+	// 8. Verify position belongs to target department
+	fetchedPosition, err := ps.DepartmentEmployeePosition.GetDepartmentPositionById(ctx, tx, positionId)
+	if err != nil {
+		logger.NewErrMessage("Failed to verify position",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("position_id", positionId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to verify position: %w", err)
+	}
+	if fetchedPosition.DepartmentId != toDepartmentId {
+		logger.NewWarnMessage("Position doesn't belong to department",
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("position_department_id", fetchedPosition.DepartmentId.String()),
+			zap.String("expected_department_id", toDepartmentId.String()),
+		)
+		err = errors.New("position doesn't belong to target department")
+		return uuid.Nil, err
+	}
+
+	// 9. Close current membership
+	err = ps.DepartmentEmployee.CloseEmployeeDepartment(ctx, tx, currentDepEmployee.ID, effectiveAt)
+	if err != nil {
+		logger.NewErrMessage("Failed to close current department membership",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("depemployee_id", currentDepEmployee.ID.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to close current department membership: %w", err)
+	}
+
+	// 10. Open membership in target department
+	generatedId, err := ps.UuidValidation.CheckAndReserveUUID(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("Failed to generate UUID",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	newDepEmployee := depemployee.NewDepartmentEmployee(
+		generatedId,
+		employeeId,
+		toDepartmentId,
+		positionId,
+	)
+	newDepEmployee.EffectiveFrom = effectiveAt
+
+	err = ps.DepartmentEmployee.CreateEmployeeDepartment(ctx, tx, newDepEmployee)
+	if err != nil {
+		logger.NewErrMessage("Failed to create target department membership",
+			zap.Error(err),
+			zap.String("operation", "TransferDepEmployee"),
+			zap.String("employee_id", employeeId.String()),
+			zap.String("department_id", toDepartmentId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to create target department membership: %w", err)
+	}
+
+	logger.NewInfoMessage("Successfully transferred department employee",
+		zap.String("operation", "TransferDepEmployee"),
+		zap.String("employee_id", employeeId.String()),
+		zap.String("from_department_id", fromDepartmentId.String()),
+		zap.String("to_department_id", toDepartmentId.String()),
+		zap.String("new_depemployee_id", generatedId.String()),
+		zap.Time("effective_at", effectiveAt),
+	)
+
+	return generatedId, nil
+}
@@ -139,7 +139,7 @@ func (e EmployeeLogic) DeleteEmployee(
 	}
 
 	// 9. Verify target employee exists and belongs to company
-	targetEmployee, err := ps.Employee.GetEmployeeByUserId(ctx, tx, userId, companyId)
+	targetEmployee, err := ps.Employee.GetEmployeeById(ctx, tx, employeeId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			logger.NewWarnMessage("Target employee not found",
@@ -174,7 +174,18 @@ func (e EmployeeLogic) DeleteEmployee(
 		return fmt.Errorf("failed to delete employee: %w", err)
 	}
 
-	// 11. Commit transaction
+	// 11. Update company employees counter
+	err = ps.Company.AdjustEmployeesCount(ctx, tx, companyId, -1)
+	if err != nil {
+		logger.NewErrMessage("Failed to update employees counter",
+			zap.Error(err),
+			zap.String("operation", "DeleteEmployee"),
+			zap.String("company_id", companyId.String()),
+		)
+		return fmt.Errorf("failed to update employees counter: %w", err)
+	}
+
+	// 12. Commit transaction
 	if err = tx.Commit(); err != nil {
 		logger.NewErrMessage("Transaction commit failed",
 			zap.Error(err),
@@ -184,7 +195,10 @@ func (e EmployeeLogic) DeleteEmployee(
 		return fmt.Errorf("transaction commit failed: %w", err)
 	}
 
-	// 12. Log successful deletion
+	// 13. Stop tracking presence of the deactivated employee
+	invalidatePresence("DeleteEmployee", targetEmployee.UserID, companyId)
+
+	// 14. Log successful deletion
 	logger.NewInfoMessage("Employee deleted successfully",
 		zap.String("employee_id", employeeId.String()),
 		zap.String("deleted_by", userId.String()),
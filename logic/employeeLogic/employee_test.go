@@ -115,6 +115,34 @@ func TestEmployee(t *testing.T) {
 		}
 	})
 
+	t.Run("EmployeesCounter", func(t *testing.T) {
+		updatedCompany, err := comp.GetCompany(fetchedUser.ID, fetchedCompany.ID)
+		if err != nil {
+			t.Fatalf("Failed GetCompany: %v", err)
+		}
+
+		if updatedCompany.Employees != 2 {
+			t.Errorf("Expected 2 employees in company counter, got %d", updatedCompany.Employees)
+		}
+	})
+
+	t.Run("SearchDirectory", func(t *testing.T) {
+		entries, total, err := emp.SearchDirectory(fetchedUser.ID, fetchedCompany.ID, employee.DirectoryFilter{
+			Search: newUser.Email,
+		})
+		if err != nil {
+			t.Fatalf("Failed SearchDirectory: %v", err)
+		}
+
+		if total != 1 || len(*entries) != 1 {
+			t.Fatalf("Expected 1 directory entry, got %d (total %d)", len(*entries), total)
+		}
+
+		if (*entries)[0].UserID != targetUser.ID {
+			t.Errorf("Expected user %s, got %s", targetUser.ID, (*entries)[0].UserID)
+		}
+	})
+
 	t.Run("UpdateEmployee", func(t *testing.T) {
 		futureEmployee.IsOnline = false
 		err := emp.UpdateEmployee(fetchedUser.ID, fetchedCompany.ID, futureEmployee)
@@ -0,0 +1,31 @@
+package employeelogic
+
+import (
+	"context"
+	"labyrinth/database/redis"
+	"labyrinth/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// invalidatePresence удаляет закэшированного для присутствия сотрудника деактивированного пользователя,
+// чтобы его запросы больше не отмечали сотрудника онлайн. Ошибка только логируется: запись истечет по TTL.
+func invalidatePresence(operation string, userId, companyId uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rd, err := redis.SharedRedisDB()
+	if err == nil {
+		err = rd.Presence.InvalidateEmployeeId(ctx, userId.String(), companyId.String())
+	}
+	if err != nil {
+		logger.NewWarnMessage("Failed to invalidate employee presence cache",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("user_id", userId.String()),
+			zap.String("company_id", companyId.String()),
+		)
+	}
+}
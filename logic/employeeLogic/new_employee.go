@@ -159,7 +159,18 @@ func (e EmployeeLogic) NewEmployee(employeeId, userId, companyId, positionId uui
 		return fmt.Errorf("failed to create employee: %w", err)
 	}
 
-	// 9. Commit transaction
+	// 9. Update company employees counter
+	err = ps.Company.AdjustEmployeesCount(ctx, tx, companyId, 1)
+	if err != nil {
+		logger.NewErrMessage("Failed to update employees counter",
+			zap.Error(err),
+			zap.String("operation", "NewEmployee"),
+			zap.String("company_id", companyId.String()),
+		)
+		return fmt.Errorf("failed to update employees counter: %w", err)
+	}
+
+	// 10. Commit transaction
 	if err = tx.Commit(); err != nil {
 		logger.NewErrMessage("Transaction commit failed",
 			zap.Error(err),
@@ -169,7 +180,7 @@ func (e EmployeeLogic) NewEmployee(employeeId, userId, companyId, positionId uui
 		return fmt.Errorf("transaction commit failed: %w", err)
 	}
 
-	// 10. Log successful creation
+	// 11. Log successful creation
 	logger.NewInfoMessage("Employee created successfully",
 		zap.String("employee_id", generatedId.String()),
 		zap.String("user_id", userId.String()),
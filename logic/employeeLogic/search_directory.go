@@ -0,0 +1,114 @@
+package employeelogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	directoryDefaultLimit = 50
+	directoryMaxLimit     = 200
+)
+
+func (e EmployeeLogic) SearchDirectory(
+	userId, companyId uuid.UUID,
+	filter employee.DirectoryFilter,
+) (*[]employee.DirectoryEntry, int, error) {
+	// 1. Validate input
+	if userId == uuid.Nil {
+		logger.NewWarnMessage("Empty user ID provided",
+			zap.String("operation", "SearchDirectory"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, 0, errors.New("user ID cannot be empty")
+	}
+	if companyId == uuid.Nil {
+		logger.NewWarnMessage("Empty company ID provided",
+			zap.String("operation", "SearchDirectory"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, 0, errors.New("company ID cannot be empty")
+	}
+	if filter.Offset < 0 {
+		return nil, 0, errors.New("offset cannot be negative")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = directoryDefaultLimit
+	}
+	if filter.Limit > directoryMaxLimit {
+		filter.Limit = directoryMaxLimit
+	}
+
+	// 2. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "SearchDirectory"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, 0, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin read-only transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "SearchDirectory"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, 0, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Check that requester is an active employee of the company
+	ps := postgres.NewPostgresDB()
+	requester, err := ps.Employee.GetEmployeeByUserId(ctx, tx, userId, companyId)
+	if err != nil {
+		logger.NewWarnMessage("Requester is not an employee",
+			zap.Error(err),
+			zap.String("operation", "SearchDirectory"),
+			zap.String("user_id", userId.String()),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, 0, fmt.Errorf("user is not an employee of the company: %w", err)
+	}
+	if !requester.IsActive {
+		return nil, 0, errors.New("user is not an active employee of the company")
+	}
+
+	// 6. Search directory
+	entries, total, err := ps.Employee.SearchEmployeeDirectory(ctx, tx, companyId, filter)
+	if err != nil {
+		logger.NewErrMessage("Failed to search directory",
+			zap.Error(err),
+			zap.String("operation", "SearchDirectory"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, 0, fmt.Errorf("failed to search directory: %w", err)
+	}
+
+	logger.NewInfoMessage("Directory search completed",
+		zap.String("operation", "SearchDirectory"),
+		zap.String("company_id", companyId.String()),
+		zap.Int("count", len(*entries)),
+		zap.Int("total", total),
+	)
+
+	return entries, total, nil
+}
@@ -163,8 +163,27 @@ func (e EmployeeLogic) UpdateEmployee(
 		return errors.New("employee doesn't belong to specified company")
 	}
 
-	// 7. Update employee data
-	err = ps.Employee.UpdateEmployee(ctx, tx, updatedEmployee)
+	// 7. Fetch current state of updated employee
+	currentEmployee, err := ps.Employee.GetEmployeeByUserId(ctx, tx, updatedEmployee.UserID, companyId)
+	if err != nil {
+		logger.NewErrMessage("Failed to fetch updated employee",
+			zap.Error(err),
+			zap.String("employee_id", updatedEmployee.ID.String()),
+		)
+		return fmt.Errorf("failed to fetch updated employee: %w", err)
+	}
+
+	if currentEmployee.ID != updatedEmployee.ID {
+		logger.NewWarnMessage("Employee ID doesn't match user",
+			zap.String("employee_id", updatedEmployee.ID.String()),
+			zap.String("user_employee_id", currentEmployee.ID.String()),
+		)
+		return errors.New("employee ID doesn't match employee user")
+	}
+
+	// 8. Update employee data; the row is locked, so the previous state is the one this update replaced
+	var wasActive bool
+	wasActive, err = ps.Employee.UpdateEmployee(ctx, tx, updatedEmployee)
 	if err != nil {
 		logger.NewErrMessage("Failed to update employee",
 			zap.Error(err),
@@ -173,7 +192,26 @@ func (e EmployeeLogic) UpdateEmployee(
 		return fmt.Errorf("failed to update employee: %w", err)
 	}
 
-	// 8. Commit transaction
+	// 9. Update company employees counter on deactivation/reactivation
+	delta := 0
+	switch {
+	case wasActive && !updatedEmployee.IsActive:
+		delta = -1
+	case !wasActive && updatedEmployee.IsActive:
+		delta = 1
+	}
+
+	err = ps.Company.AdjustEmployeesCount(ctx, tx, companyId, delta)
+	if err != nil {
+		logger.NewErrMessage("Failed to update employees counter",
+			zap.Error(err),
+			zap.String("operation", "UpdateEmployee"),
+			zap.String("company_id", companyId.String()),
+		)
+		return fmt.Errorf("failed to update employees counter: %w", err)
+	}
+
+	// 10. Commit transaction
 	if err = tx.Commit(); err != nil {
 		logger.NewErrMessage("Transaction commit failed",
 			zap.Error(err),
@@ -183,7 +221,12 @@ func (e EmployeeLogic) UpdateEmployee(
 		return fmt.Errorf("transaction commit failed: %w", err)
 	}
 
-	// 9. Log successful update
+	// 11. Stop tracking presence of a deactivated employee
+	if wasActive && !updatedEmployee.IsActive {
+		invalidatePresence("UpdateEmployee", updatedEmployee.UserID, companyId)
+	}
+
+	// 12. Log successful update
 	logger.NewInfoMessage("Employee updated successfully",
 		zap.String("employee_id", updatedEmployee.ID.String()),
 		zap.String("user_id", userId.String()),
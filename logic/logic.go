@@ -1,21 +1,26 @@
 package logic
 
 import (
+	"context"
 	authlogic "labyrinth/logic/authLogic"
 	companylogic "labyrinth/logic/companyLogic"
 	departmentlogic "labyrinth/logic/departmentLogic"
 	depemployeelogic "labyrinth/logic/depemployeeLogic"
 	depemployeeposlogic "labyrinth/logic/depemployeeposLogic"
 	employeelogic "labyrinth/logic/employeeLogic"
+	notificationlogic "labyrinth/logic/notificationLogic"
 	positionlogic "labyrinth/logic/positionLogic"
+	presencelogic "labyrinth/logic/presenceLogic"
 	userlogic "labyrinth/logic/userLogic"
 	"labyrinth/models/company"
 	"labyrinth/models/department"
 	"labyrinth/models/depemployee"
 	"labyrinth/models/depposition"
 	"labyrinth/models/employee"
+	"labyrinth/models/notification"
 	"labyrinth/models/position"
 	"labyrinth/models/user"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
@@ -37,6 +42,8 @@ type companyLogic interface {
 	GetUserCompanies(userId uuid.UUID) (*[]company.Company, error)
 	NewCompany(userId uuid.UUID, name, description string) (uuid.UUID, error)
 	UpdateCompany(comp *company.Company, companyId, employeeId uuid.UUID) error
+	ReconcileEmployeesCount(dryRun bool) (*[]company.EmployeesDiscrepancy, error)
+	SelectCompany(userId, companyId uuid.UUID) (*employee.Employee, error)
 }
 
 type employeeLogic interface {
@@ -45,6 +52,7 @@ type employeeLogic interface {
 	GetEmployee(userId, companyId uuid.UUID) (*employee.Employee, error)
 	NewEmployee(employeeId, userId, companyId, positionId uuid.UUID) error
 	UpdateEmployee(userId, companyId uuid.UUID, updatedEmployee *employee.Employee) error
+	SearchDirectory(userId, companyId uuid.UUID, filter employee.DirectoryFilter) (*[]employee.DirectoryEntry, int, error)
 }
 
 type positionLogic interface {
@@ -66,6 +74,9 @@ type departmentEmployeeLogic interface {
 	GetDepartmentEmployee(employeeId, departmentId uuid.UUID) (*depemployee.DepartmentEmployee, error)
 	NewDepemployee(employeeId, departmentId, positionId uuid.UUID) error
 	UpdateDepEmployee(employeeId, departmentId uuid.UUID, updatedDepEmployee *depemployee.DepartmentEmployee) error
+	TransferDepEmployee(employeeId, fromDepartmentId, toDepartmentId, positionId uuid.UUID, effectiveAt time.Time) (uuid.UUID, error)
+	GetDepEmployeesAt(departmentId uuid.UUID, at time.Time) (*[]depemployee.DepartmentEmployee, error)
+	GetDepEmployeeHistory(employeeId uuid.UUID) (*[]depemployee.DepartmentEmployee, error)
 }
 
 type departmentEmployeePosLogic interface {
@@ -75,6 +86,20 @@ type departmentEmployeePosLogic interface {
 	UpdateDepEmployeePos(currentlvl int, employeeId, departmentId uuid.UUID, position *depposition.DepPosition) error
 }
 
+type presenceLogic interface {
+	TouchPresence(userId, companyId uuid.UUID) (uuid.UUID, error)
+	GetDepartmentOnline(userId, companyId, departmentId uuid.UUID) (*[]employee.Presence, error)
+	FlushPresence() (int, int64, error)
+	RunPresenceFlusher(ctx context.Context, interval time.Duration)
+}
+
+type notificationLogic interface {
+	NotifyMentions(event notification.MentionEvent) (int, error)
+	ListNotifications(userId uuid.UUID, unreadOnly bool, limit, offset int) (*notification.Page, error)
+	MarkRead(userId uuid.UUID, notificationIds []uuid.UUID) (int64, error)
+	Subscribe(ctx context.Context, userId uuid.UUID) (<-chan []byte, error)
+}
+
 type jwtLogic interface {
 	NewToken(settings jwt.MapClaims) string
 	VerifyToken(tokenString string) (jwt.MapClaims, error)
@@ -90,6 +115,8 @@ type BusinessLogic struct {
 	Department                 departmentLogic
 	DepartmentEmployee         departmentEmployeeLogic
 	DepartmentEmployeePosition departmentEmployeePosLogic
+	Presence                   presenceLogic
+	Notification               notificationLogic
 }
 
 func NewBusinessLogic() *BusinessLogic {
@@ -103,5 +130,7 @@ func NewBusinessLogic() *BusinessLogic {
 		Department:                 departmentlogic.NewDepartmentLogic(),
 		DepartmentEmployee:         depemployeelogic.NewDepemployeeLogic(),
 		DepartmentEmployeePosition: depemployeeposlogic.NewDepemploeePosLogic(),
+		Presence:                   presencelogic.NewPresenceLogic(),
+		Notification:               notificationlogic.NewNotificationLogic(),
 	}
 }
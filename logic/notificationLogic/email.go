@@ -0,0 +1,72 @@
+package notificationlogic
+
+import (
+	"context"
+	"fmt"
+	"labyrinth/config"
+	"labyrinth/logger"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// EmailSender доставляет уведомления по почте
+type EmailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// NewEmailSender возвращает SMTP-отправителя или, если SMTP не настроен, отправителя, который только пишет письма в лог
+func NewEmailSender(conf config.Mail) EmailSender {
+	if conf.Host == "" {
+		return logEmailSender{}
+	}
+	return smtpEmailSender{conf: conf}
+}
+
+type smtpEmailSender struct {
+	conf config.Mail
+}
+
+func (s smtpEmailSender) Send(ctx context.Context, to, subject, body string) error {
+	if to == "" {
+		return fmt.Errorf("recipient address is empty")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.conf.Username != "" {
+		auth = smtp.PlainAuth("", s.conf.Username, s.conf.Password, s.conf.Host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + s.conf.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	addr := net.JoinHostPort(s.conf.Host, s.conf.Port)
+	if err := smtp.SendMail(addr, auth, s.conf.From, []string{to}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+type logEmailSender struct{}
+
+func (logEmailSender) Send(_ context.Context, to, subject, _ string) error {
+	logger.NewInfoMessage("Email delivery is not configured, message skipped",
+		zap.String("operation", "SendEmail"),
+		zap.String("to", to),
+		zap.String("subject", subject),
+	)
+	return nil
+}
@@ -0,0 +1,91 @@
+package notificationlogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/notification"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListNotifications возвращает страницу входящих уведомлений пользователя и число непрочитанных
+func (n NotificationLogic) ListNotifications(userId uuid.UUID, unreadOnly bool, limit, offset int) (*notification.Page, error) {
+	// 1. Validate input parameters
+	if userId == uuid.Nil {
+		logger.NewWarnMessage("Empty user id provided",
+			zap.String("operation", "ListNotifications"),
+		)
+		return nil, errors.New("user id cannot be empty")
+	}
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 2. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "ListNotifications"),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin read-only transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "ListNotifications"),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Fetch page and unread counter
+	ps := postgres.NewPostgresDB()
+	items, total, err := ps.Notification.GetNotificationsByUserId(ctx, tx, userId, unreadOnly, limit, offset)
+	if err != nil {
+		logger.NewErrMessage("Failed to fetch notifications",
+			zap.Error(err),
+			zap.String("operation", "ListNotifications"),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, fmt.Errorf("failed to fetch notifications: %w", err)
+	}
+
+	unread, err := ps.Notification.CountUnreadNotifications(ctx, tx, userId)
+	if err != nil {
+		logger.NewErrMessage("Failed to count unread notifications",
+			zap.Error(err),
+			zap.String("operation", "ListNotifications"),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &notification.Page{
+		Items:  *items,
+		Total:  total,
+		Unread: unread,
+	}, nil
+}
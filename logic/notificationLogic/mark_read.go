@@ -0,0 +1,82 @@
+package notificationlogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MarkRead отмечает уведомления пользователя прочитанными; пустой список - все непрочитанные
+func (n NotificationLogic) MarkRead(userId uuid.UUID, notificationIds []uuid.UUID) (int64, error) {
+	// 1. Validate input parameters
+	if userId == uuid.Nil {
+		logger.NewWarnMessage("Empty user id provided",
+			zap.String("operation", "MarkRead"),
+		)
+		return 0, errors.New("user id cannot be empty")
+	}
+
+	// 2. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "MarkRead"),
+			zap.String("user_id", userId.String()),
+		)
+		return 0, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin transaction
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "MarkRead"),
+			zap.String("user_id", userId.String()),
+		)
+		return 0, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Mark notifications read
+	affected, err := postgres.NewPostgresDB().Notification.MarkNotificationsRead(ctx, tx, userId, notificationIds, time.Now())
+	if err != nil {
+		logger.NewErrMessage("Failed to mark notifications read",
+			zap.Error(err),
+			zap.String("operation", "MarkRead"),
+			zap.String("user_id", userId.String()),
+		)
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	// 6. Commit transaction
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "MarkRead"),
+			zap.String("user_id", userId.String()),
+		)
+		return 0, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	logger.NewInfoMessage("Notifications marked read",
+		zap.String("operation", "MarkRead"),
+		zap.String("user_id", userId.String()),
+		zap.Int64("count", affected),
+	)
+
+	return affected, nil
+}
@@ -0,0 +1,77 @@
+package notificationlogic
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// excerptLength - длина фрагмента текста в уведомлении
+const excerptLength = 200
+
+// mentionPattern находит @упоминания; @ внутри слова (например, в email) упоминанием не считается
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([\p{L}\p{N}_][\p{L}\p{N}_.\-]*)`)
+
+// ParseMentions возвращает уникальные упоминания из текста в нижнем регистре
+func ParseMentions(text string) []string {
+	seen := map[string]struct{}{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		handle := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if handle != "" {
+			seen[handle] = struct{}{}
+		}
+	}
+
+	handles := make([]string, 0, len(seen))
+	for handle := range seen {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	return handles
+}
+
+// ExtractText собирает все строки из тела блока, включая вложенные списки и объекты
+func ExtractText(body map[string]any) string {
+	var parts []string
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case string:
+			parts = append(parts, v)
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				walk(v[key])
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		case primitive.M:
+			walk(map[string]any(v))
+		case primitive.D:
+			walk(v.Map())
+		case primitive.A:
+			walk([]any(v))
+		}
+	}
+	walk(body)
+	return strings.Join(parts, "\n")
+}
+
+// excerpt обрезает текст для уведомления по границе символа
+func excerpt(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= excerptLength {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:excerptLength]) + "…"
+}
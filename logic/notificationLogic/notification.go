@@ -0,0 +1,18 @@
+package notificationlogic
+
+import "labyrinth/config"
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type NotificationLogic struct {
+	email EmailSender
+}
+
+func NewNotificationLogic() NotificationLogic {
+	return NotificationLogic{
+		email: NewEmailSender(config.Conf.Mail),
+	}
+}
@@ -0,0 +1,52 @@
+package notificationlogic_test
+
+import (
+	notificationlogic "labyrinth/logic/notificationLogic"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMentions(t *testing.T) {
+	t.Run("ParseMentions", func(t *testing.T) {
+		text := "@Ivanov, проверь навеску. Копия @petrova.a и снова @ivanov."
+		got := notificationlogic.ParseMentions(text)
+		want := []string{"ivanov", "petrova.a"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v\n", want, got)
+		}
+	})
+
+	t.Run("ParseMentionsIgnoresEmail", func(t *testing.T) {
+		got := notificationlogic.ParseMentions("пишите на lab@example.com")
+		if len(got) != 0 {
+			t.Errorf("Expected no mentions in email address, got %v\n", got)
+		}
+	})
+
+	t.Run("ParseMentionsCyrillic", func(t *testing.T) {
+		got := notificationlogic.ParseMentions("(@сидоров)")
+		if len(got) != 1 || got[0] != "сидоров" {
+			t.Errorf("Expected [сидоров], got %v\n", got)
+		}
+	})
+
+	t.Run("ExtractText", func(t *testing.T) {
+		body := map[string]any{
+			"title": "План",
+			"items": primitive.A{
+				primitive.D{{Key: "text", Value: "@ivanov взвесить"}, {Key: "checked", Value: false}},
+				map[string]any{"text": "растворить"},
+			},
+		}
+
+		text := notificationlogic.ExtractText(body)
+		for _, part := range []string{"План", "@ivanov взвесить", "растворить"} {
+			if !strings.Contains(text, part) {
+				t.Errorf("Expected text to contain %q, got %q\n", part, text)
+			}
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package notificationlogic
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/database/redis"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/models/notification"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// NotifyMentions находит упомянутых в тексте сотрудников компании, сохраняет им уведомления
+// и доставляет новые уведомления через WebSocket и почту. Возвращает число созданных уведомлений.
+func (n NotificationLogic) NotifyMentions(event notification.MentionEvent) (int, error) {
+	// 1. Parse mentions
+	handles := ParseMentions(event.Text)
+	if len(handles) == 0 {
+		return 0, nil
+	}
+	if event.CompanyID == uuid.Nil {
+		return 0, fmt.Errorf("company ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// 3. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "NotifyMentions"),
+			zap.String("notebook_id", event.NotebookID),
+		)
+		return 0, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 4. Begin transaction
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "NotifyMentions"),
+			zap.String("notebook_id", event.NotebookID),
+		)
+		return 0, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Resolve mentioned employees
+	ps := postgres.NewPostgresDB()
+	targets, err := ps.Employee.GetMentionTargets(ctx, tx, event.CompanyID, handles)
+	if err != nil {
+		logger.NewErrMessage("Failed to resolve mentions",
+			zap.Error(err),
+			zap.String("operation", "NotifyMentions"),
+			zap.String("company_id", event.CompanyID.String()),
+		)
+		return 0, fmt.Errorf("failed to resolve mentions: %w", err)
+	}
+
+	// 6. Store one notification per recipient; repeated mentions in the same place are skipped
+	type delivery struct {
+		target employee.MentionTarget
+		item   *notification.Notification
+	}
+	var created []delivery
+	seen := map[uuid.UUID]struct{}{}
+	for _, target := range *targets {
+		if target.UserID == event.ActorID {
+			continue
+		}
+		if _, ok := seen[target.UserID]; ok {
+			continue
+		}
+		seen[target.UserID] = struct{}{}
+
+		item := notification.NewMention(
+			target.UserID,
+			event.CompanyID,
+			event.ActorID,
+			event.NotebookID,
+			event.BlockID,
+			event.CommentID,
+			event.NotebookTitle,
+			excerpt(event.Text),
+		)
+		isNew, err := ps.Notification.CreateNotification(ctx, tx, item)
+		if err != nil {
+			logger.NewErrMessage("Failed to store notification",
+				zap.Error(err),
+				zap.String("operation", "NotifyMentions"),
+				zap.String("user_id", target.UserID.String()),
+			)
+			return 0, fmt.Errorf("failed to store notification: %w", err)
+		}
+		if isNew {
+			created = append(created, delivery{target: target, item: item})
+		}
+	}
+
+	// 7. Commit transaction
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "NotifyMentions"),
+		)
+		return 0, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	// 8. Deliver: failures are logged, the inbox stays the source of truth
+	if len(created) > 0 {
+		rd, err := redis.NewRedisDB()
+		if err != nil {
+			logger.NewWarnMessage("Redis unavailable, WebSocket push skipped",
+				zap.Error(err),
+				zap.String("operation", "NotifyMentions"),
+			)
+		} else {
+			defer rd.Client.Close()
+		}
+
+		for _, d := range created {
+			if rd != nil {
+				payload, err := json.Marshal(d.item)
+				if err == nil {
+					err = rd.Notification.PublishNotification(ctx, d.item.UserID.String(), payload)
+				}
+				if err != nil {
+					logger.NewWarnMessage("Failed to push notification",
+						zap.Error(err),
+						zap.String("operation", "NotifyMentions"),
+						zap.String("user_id", d.item.UserID.String()),
+					)
+				}
+			}
+
+			subject := fmt.Sprintf("Вас упомянули в журнале «%s»", event.NotebookTitle)
+			if err := n.email.Send(ctx, d.target.Email, subject, d.item.Excerpt); err != nil {
+				logger.NewWarnMessage("Failed to send notification email",
+					zap.Error(err),
+					zap.String("operation", "NotifyMentions"),
+					zap.String("user_id", d.item.UserID.String()),
+				)
+			}
+		}
+	}
+
+	logger.NewInfoMessage("Mentions processed",
+		zap.String("operation", "NotifyMentions"),
+		zap.String("notebook_id", event.NotebookID),
+		zap.Int("mentions", len(handles)),
+		zap.Int("notifications", len(created)),
+	)
+
+	return len(created), nil
+}
@@ -0,0 +1,66 @@
+package notificationlogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/database/redis"
+	"labyrinth/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Subscribe открывает поток новых уведомлений пользователя. Поток закрывается при отмене ctx.
+func (n NotificationLogic) Subscribe(ctx context.Context, userId uuid.UUID) (<-chan []byte, error) {
+	if userId == uuid.Nil {
+		return nil, errors.New("user id cannot be empty")
+	}
+
+	rd, err := redis.NewRedisDB()
+	if err != nil {
+		logger.NewErrMessage("Redis initialization failed",
+			zap.Error(err),
+			zap.String("operation", "SubscribeNotifications"),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize Redis: %w", err)
+	}
+
+	sub, err := rd.Notification.SubscribeNotifications(ctx, userId.String())
+	if err != nil {
+		rd.Client.Close()
+		logger.NewErrMessage("Failed to subscribe to notifications",
+			zap.Error(err),
+			zap.String("operation", "SubscribeNotifications"),
+			zap.String("user_id", userId.String()),
+		)
+		return nil, err
+	}
+
+	out := make(chan []byte)
+	go func() {
+		defer close(out)
+		defer rd.Client.Close()
+		defer sub.Close()
+
+		messages := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case out <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
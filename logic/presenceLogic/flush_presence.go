@@ -0,0 +1,181 @@
+package presencelogic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/database/redis"
+	"labyrinth/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FlushPresence переносит накопленную в Redis активность в employee_company
+// и снимает флаг is_online с сотрудников, у которых истек TTL присутствия.
+// Очередь активности в Redis очищается только после фиксации транзакции.
+func (p PresenceLogic) FlushPresence() (int, int64, error) {
+	// 1. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 2. Initialize Redis
+	rd, err := redis.SharedRedisDB()
+	if err != nil {
+		logger.NewErrMessage("Redis initialization failed",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("failed to initialize Redis: %w", err)
+	}
+
+	// 3. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 4. Begin transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: false})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logger.NewErrMessage("Transaction rollback failed",
+					zap.Error(rbErr),
+					zap.String("operation", "FlushPresence"),
+				)
+			}
+		}
+	}()
+
+	ps := postgres.NewPostgresDB()
+
+	// 5. Flush pending activity (the queue is acknowledged only after commit)
+	activity, err := rd.Presence.GetPendingActivity(ctx)
+	if err != nil {
+		logger.NewErrMessage("Failed to get pending activity",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("failed to get pending activity: %w", err)
+	}
+
+	touched := 0
+	for rawId, lastActivityAt := range activity {
+		employeeId, parseErr := uuid.Parse(rawId)
+		if parseErr != nil {
+			continue
+		}
+		if err = ps.Employee.TouchEmployeeActivity(ctx, tx, employeeId, lastActivityAt); err != nil {
+			logger.NewErrMessage("Failed to flush employee activity",
+				zap.Error(err),
+				zap.String("operation", "FlushPresence"),
+				zap.String("employee_id", rawId),
+			)
+			return 0, 0, fmt.Errorf("failed to flush employee activity: %w", err)
+		}
+		touched++
+	}
+
+	// 6. Mark expired employees offline
+	onlineIds, err := ps.Employee.GetOnlineEmployeeIds(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("Failed to get online employees",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("failed to get online employees: %w", err)
+	}
+
+	rawOnlineIds := make([]string, 0, len(onlineIds))
+	for _, id := range onlineIds {
+		rawOnlineIds = append(rawOnlineIds, id.String())
+	}
+
+	present, err := rd.Presence.GetPresence(ctx, rawOnlineIds)
+	if err != nil {
+		logger.NewErrMessage("Failed to get presence",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("failed to get presence: %w", err)
+	}
+
+	var expiredIds []uuid.UUID
+	for _, id := range onlineIds {
+		if _, ok := present[id.String()]; !ok {
+			expiredIds = append(expiredIds, id)
+		}
+	}
+
+	wentOffline, err := ps.Employee.SetEmployeesOffline(ctx, tx, expiredIds)
+	if err != nil {
+		logger.NewErrMessage("Failed to set employees offline",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("failed to set employees offline: %w", err)
+	}
+
+	// 7. Commit transaction
+	if err = tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "FlushPresence"),
+		)
+		return 0, 0, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	// 8. Remove flushed activity from the queue; a failure only means it is flushed again next time
+	if _, ackErr := rd.Presence.AckPendingActivity(ctx, activity); ackErr != nil {
+		logger.NewWarnMessage("Failed to acknowledge pending activity",
+			zap.Error(ackErr),
+			zap.String("operation", "FlushPresence"),
+		)
+	}
+
+	return touched, wentOffline, nil
+}
+
+// RunPresenceFlusher периодически вызывает FlushPresence до отмены контекста.
+func (p PresenceLogic) RunPresenceFlusher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			touched, wentOffline, err := p.FlushPresence()
+			if err != nil {
+				logger.NewErrMessage("Presence flush failed",
+					zap.Error(err),
+					zap.String("operation", "RunPresenceFlusher"),
+				)
+				continue
+			}
+			if touched > 0 || wentOffline > 0 {
+				logger.NewInfoMessage("Presence flushed",
+					zap.String("operation", "RunPresenceFlusher"),
+					zap.Int("touched", touched),
+					zap.Int64("went_offline", wentOffline),
+				)
+			}
+		}
+	}
+}
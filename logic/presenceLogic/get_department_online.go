@@ -0,0 +1,191 @@
+package presencelogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/database/redis"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetDepartmentOnline возвращает сотрудников отдела в сети.
+// Вызывающий должен быть активным сотрудником компании и состоять в отделе, иначе employee.ErrNotDepartmentMember.
+func (p PresenceLogic) GetDepartmentOnline(userId, companyId, departmentId uuid.UUID) (*[]employee.Presence, error) {
+	// 1. Validate input parameters
+	if userId == uuid.Nil {
+		logger.NewWarnMessage("Empty user ID provided",
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if companyId == uuid.Nil {
+		logger.NewWarnMessage("Empty company ID provided",
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, errors.New("company ID cannot be empty")
+	}
+	if departmentId == uuid.Nil {
+		logger.NewWarnMessage("Empty department ID provided",
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.Time("time", time.Now()),
+		)
+		return nil, errors.New("department ID cannot be empty")
+	}
+
+	// 2. Initialize database connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin read-only transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	ps := postgres.NewPostgresDB()
+
+	// 5. Verify the caller is an active employee of the company and a member of the department
+	caller, err := ps.Employee.GetEmployeeByUserId(ctx, tx, userId, companyId)
+	if err != nil || !caller.IsActive {
+		logger.NewWarnMessage("Caller is not an active employee of the company",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("user_id", userId.String()),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, employee.ErrNotDepartmentMember
+	}
+
+	fetchedDep, err := ps.Department.GetDepartmentById(ctx, tx, departmentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.NewWarnMessage("Department not found",
+				zap.String("operation", "GetDepartmentOnline"),
+				zap.String("department_id", departmentId.String()),
+			)
+			return nil, fmt.Errorf("department not found: %w", err)
+		}
+
+		logger.NewErrMessage("Failed to fetch department",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("failed to fetch department: %w", err)
+	}
+	if fetchedDep.CompanyID != companyId {
+		logger.NewWarnMessage("Department doesn't belong to company",
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("department_id", departmentId.String()),
+			zap.String("department_company_id", fetchedDep.CompanyID.String()),
+			zap.String("requested_company_id", companyId.String()),
+		)
+		return nil, employee.ErrNotDepartmentMember
+	}
+
+	isMember, err := ps.DepartmentEmployee.ExistsEmployeeDepartment(ctx, tx, caller.ID, departmentId)
+	if err != nil {
+		logger.NewErrMessage("Failed to check department membership",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("employee_id", caller.ID.String()),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("failed to check department membership: %w", err)
+	}
+	if !isMember {
+		logger.NewWarnMessage("Caller is not a member of the department",
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("employee_id", caller.ID.String()),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, employee.ErrNotDepartmentMember
+	}
+
+	// 6. Fetch current department members
+	members, err := ps.DepartmentEmployee.GetEmployeesDepartmentByDepartmentId(ctx, tx, departmentId)
+	if err != nil {
+		logger.NewErrMessage("Failed to get department employees",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("failed to get department employees: %w", err)
+	}
+
+	employeeIds := make([]string, 0, len(*members))
+	for _, member := range *members {
+		if member.IsActive {
+			employeeIds = append(employeeIds, member.EmployeeID.String())
+		}
+	}
+
+	// 7. Check presence in Redis
+	rd, err := redis.SharedRedisDB()
+	if err != nil {
+		logger.NewErrMessage("Redis initialization failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize Redis: %w", err)
+	}
+
+	online, err := rd.Presence.GetPresence(ctx, employeeIds)
+	if err != nil {
+		logger.NewErrMessage("Failed to get presence",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentOnline"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("failed to get presence: %w", err)
+	}
+
+	result := make([]employee.Presence, 0, len(online))
+	for _, id := range employeeIds {
+		lastActivityAt, ok := online[id]
+		if !ok {
+			continue
+		}
+		result = append(result, employee.Presence{
+			EmployeeID:     uuid.MustParse(id),
+			IsOnline:       true,
+			LastActivityAt: lastActivityAt,
+		})
+	}
+
+	logger.NewInfoMessage("Successfully retrieved department presence",
+		zap.String("operation", "GetDepartmentOnline"),
+		zap.String("department_id", departmentId.String()),
+		zap.Int("online_count", len(result)),
+	)
+
+	return &result, nil
+}
@@ -0,0 +1,10 @@
+package presencelogic
+
+import "time"
+
+// employeeCacheTTL - время жизни соответствия пользователь/компания -> сотрудник в Redis
+const employeeCacheTTL = time.Hour
+
+type PresenceLogic struct{}
+
+func NewPresenceLogic() PresenceLogic { return PresenceLogic{} }
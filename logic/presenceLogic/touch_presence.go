@@ -0,0 +1,110 @@
+package presencelogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/config"
+	"labyrinth/database/postgres"
+	"labyrinth/database/redis"
+	"labyrinth/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (p PresenceLogic) TouchPresence(userId, companyId uuid.UUID) (uuid.UUID, error) {
+	// 1. Validate input parameters
+	if userId == uuid.Nil {
+		return uuid.Nil, errors.New("user ID cannot be empty")
+	}
+	if companyId == uuid.Nil {
+		return uuid.Nil, errors.New("company ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 3. Initialize Redis
+	rd, err := redis.SharedRedisDB()
+	if err != nil {
+		logger.NewErrMessage("Redis initialization failed",
+			zap.Error(err),
+			zap.String("operation", "TouchPresence"),
+			zap.String("user_id", userId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to initialize Redis: %w", err)
+	}
+
+	// 4. Resolve employee ID (Redis cache first, PostgreSQL on miss)
+	cachedId, err := rd.Presence.GetCachedEmployeeId(ctx, userId.String(), companyId.String())
+	if err != nil {
+		logger.NewWarnMessage("Failed to read employee cache",
+			zap.Error(err),
+			zap.String("operation", "TouchPresence"),
+			zap.String("user_id", userId.String()),
+		)
+	}
+
+	employeeId, parseErr := uuid.Parse(cachedId)
+	if cachedId == "" || parseErr != nil {
+		employeeId, err = resolveEmployeeId(ctx, userId, companyId)
+		if err != nil {
+			logger.NewWarnMessage("Failed to resolve employee",
+				zap.Error(err),
+				zap.String("operation", "TouchPresence"),
+				zap.String("user_id", userId.String()),
+				zap.String("company_id", companyId.String()),
+			)
+			return uuid.Nil, err
+		}
+
+		if err = rd.Presence.CacheEmployeeId(ctx, userId.String(), companyId.String(), employeeId.String(), employeeCacheTTL); err != nil {
+			logger.NewWarnMessage("Failed to cache employee ID",
+				zap.Error(err),
+				zap.String("operation", "TouchPresence"),
+				zap.String("employee_id", employeeId.String()),
+			)
+		}
+	}
+
+	// 5. Mark presence
+	err = rd.Presence.TouchPresence(ctx, employeeId.String(), time.Now(), config.Conf.Presence.TTL)
+	if err != nil {
+		logger.NewErrMessage("Failed to touch presence",
+			zap.Error(err),
+			zap.String("operation", "TouchPresence"),
+			zap.String("employee_id", employeeId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to touch presence: %w", err)
+	}
+
+	return employeeId, nil
+}
+
+func resolveEmployeeId(ctx context.Context, userId, companyId uuid.UUID) (uuid.UUID, error) {
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	fetchedEmployee, err := postgres.NewPostgresDB().Employee.GetEmployeeByUserId(ctx, tx, userId, companyId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+	if !fetchedEmployee.IsActive {
+		return uuid.Nil, errors.New("employee is not active")
+	}
+
+	return fetchedEmployee.ID, nil
+}
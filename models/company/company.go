@@ -45,3 +45,10 @@ func NewCompany(ownerId uuid.UUID, name, description, address, phone, email stri
 		TaxNumber:   "",
 	}
 }
+
+type EmployeesDiscrepancy struct {
+	CompanyID uuid.UUID `json:"company_id"` // ID компании
+	Name      string    `json:"name"`       // Название компании
+	Stored    int       `json:"stored"`     // Значение счетчика до сверки
+	Actual    int       `json:"actual"`     // Фактическое число активных сотрудников
+}
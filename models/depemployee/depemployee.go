@@ -7,13 +7,15 @@ import (
 )
 
 type DepartmentEmployee struct {
-	ID           uuid.UUID `json:"id"`
-	EmployeeID   uuid.UUID `json:"employee_id"`
-	DepartmentID uuid.UUID `json:"department_id"`
-	PositionID   uuid.UUID `json:"position_id"`
-	CreatedAt    time.Time `json: "created_at"`
-	UpdatedAt    time.Time `json: "updated_at"`
-	IsActive     bool      `json:"is_active"`
+	ID            uuid.UUID  `json:"id"`
+	EmployeeID    uuid.UUID  `json:"employee_id"`
+	DepartmentID  uuid.UUID  `json:"department_id"`
+	PositionID    uuid.UUID  `json:"position_id"`
+	CreatedAt     time.Time  `json: "created_at"`
+	UpdatedAt     time.Time  `json: "updated_at"`
+	IsActive      bool       `json:"is_active"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
 }
 
 func NewDepartmentEmployee(
@@ -23,12 +25,14 @@ func NewDepartmentEmployee(
 	positionId uuid.UUID,
 ) *DepartmentEmployee {
 	return &DepartmentEmployee{
-		ID:           generatedId,
-		EmployeeID:   employeeId,
-		DepartmentID: departmentId,
-		PositionID:   positionId,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-		IsActive:     true,
+		ID:            generatedId,
+		EmployeeID:    employeeId,
+		DepartmentID:  departmentId,
+		PositionID:    positionId,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+		IsActive:      true,
+		EffectiveFrom: time.Now(),
+		EffectiveTo:   nil,
 	}
 }
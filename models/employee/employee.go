@@ -1,6 +1,7 @@
 package employee
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,3 +37,56 @@ func NewEmployee(
 		UpdatedAt:      time.Now(),
 	}
 }
+
+// ErrNotDepartmentMember - присутствие отдела видно только его активным сотрудникам
+var ErrNotDepartmentMember = errors.New("caller is not an active employee of the department")
+
+type Presence struct {
+	EmployeeID     uuid.UUID `json:"employee_id"`
+	IsOnline       bool      `json:"is_online"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
+
+type DirectoryDepartment struct {
+	DepartmentID uuid.UUID `json:"department_id"`
+	Name         string    `json:"name"`
+	PositionID   uuid.UUID `json:"position_id"`
+}
+
+type DirectoryEntry struct {
+	EmployeeID       uuid.UUID             `json:"employee_id"`
+	UserID           uuid.UUID             `json:"user_id"`
+	FirstName        string                `json:"first_name"`
+	LastName         string                `json:"last_name"`
+	Email            string                `json:"email"`
+	TelegramUsername string                `json:"telegram_username"`
+	AvatarURL        string                `json:"avatar_url"`
+	PositionID       uuid.UUID             `json:"position_id"`
+	PositionName     string                `json:"position_name"`
+	PositionLvl      int                   `json:"position_lvl"`
+	IsActive         bool                  `json:"is_active"`
+	IsOnline         bool                  `json:"is_online"`
+	LastActivityAt   time.Time             `json:"last_activity_at"`
+	Departments      []DirectoryDepartment `json:"departments"`
+}
+
+type DirectoryFilter struct {
+	Search       string
+	DepartmentID uuid.UUID
+	PositionID   uuid.UUID
+	IsActive     *bool
+	SortBy       string
+	SortDesc     bool
+	Limit        int
+	Offset       int
+}
+
+// MentionTarget - сотрудник компании, которого можно упомянуть через @
+type MentionTarget struct {
+	EmployeeID       uuid.UUID `json:"employee_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Email            string    `json:"email"`
+	TelegramUsername string    `json:"telegram_username"`
+	FirstName        string    `json:"first_name"`
+	LastName         string    `json:"last_name"`
+}
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Виды уведомлений
+const (
+	KindMention = "mention"
+)
+
+type Notification struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`    // Получатель
+	CompanyID  uuid.UUID  `json:"company_id"` // Компания, в которой произошло событие
+	Kind       string     `json:"kind"`
+	ActorID    uuid.UUID  `json:"actor_id"` // Пользователь, который упомянул получателя
+	NotebookID string     `json:"notebook_id"`
+	BlockID    string     `json:"block_id"`
+	CommentID  string     `json:"comment_id"`
+	Title      string     `json:"title"`
+	Excerpt    string     `json:"excerpt"`
+	DedupKey   string     `json:"-"` // Ключ, по которому одно и то же упоминание не создает повторных уведомлений
+	CreatedAt  time.Time  `json:"created_at"`
+	ReadAt     *time.Time `json:"read_at"`
+}
+
+func NewMention(userId, companyId, actorId uuid.UUID, notebookId, blockId, commentId, title, excerpt string) *Notification {
+	return &Notification{
+		ID:         uuid.New(),
+		UserID:     userId,
+		CompanyID:  companyId,
+		Kind:       KindMention,
+		ActorID:    actorId,
+		NotebookID: notebookId,
+		BlockID:    blockId,
+		CommentID:  commentId,
+		Title:      title,
+		Excerpt:    excerpt,
+		DedupKey:   KindMention + ":" + notebookId + ":" + blockId + ":" + commentId,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Page - страница входящих уведомлений пользователя
+type Page struct {
+	Items  []Notification `json:"items"`
+	Total  int            `json:"total"`
+	Unread int            `json:"unread"`
+}
+
+// MentionEvent - запись в журнал или комментарий, в тексте которой ищутся упоминания
+type MentionEvent struct {
+	CompanyID     uuid.UUID
+	ActorID       uuid.UUID
+	NotebookID    string
+	NotebookTitle string
+	BlockID       string
+	CommentID     string
+	Text          string
+}
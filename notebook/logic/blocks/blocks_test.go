@@ -0,0 +1,101 @@
+package blocksLogic
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBlockTypes(t *testing.T) {
+	t.Run("ValidBodies", func(t *testing.T) {
+		bodies := map[string]string{
+			TypeText:        `{"content": "Синтез начат в 10:00", "format": "markdown"}`,
+			TypeHeading:     `{"text": "Методика", "level": 2}`,
+			TypeChecklist:   `{"items": [{"text": "Взвесить навеску", "checked": true}, {"text": "Растворить"}]}`,
+			TypeTable:       `{"columns": ["Образец", "Масса"], "rows": [["A1", 1.25], ["A2", null]]}`,
+			TypeCode:        `{"code": "print(42)", "language": "python"}`,
+			TypeFormula:     `{"latex": "E = mc^2", "display": true}`,
+			TypeImage:       `{"object_key": "img/1.png", "content_type": "image/png", "width": 640}`,
+			TypeAttachment:  `{"object_key": "files/1", "file_name": "spectrum.csv", "size": 1024}`,
+			TypeMeasurement: `{"quantity": "Масса", "value": 12.5, "unit": "mg", "uncertainty": 0.1}`,
+			TypeChemical:    `{"smiles": "CC(=O)Oc1ccccc1C(=O)O", "name": "Аспирин"}`,
+		}
+
+		for blockType, raw := range bodies {
+			var body map[string]any
+			if err := json.Unmarshal([]byte(raw), &body); err != nil {
+				t.Fatalf("Failed to decode %s body: %v\n", blockType, err)
+			}
+			if err := Validate(blockType, body); err != nil {
+				t.Errorf("Expected valid %s block, got %v\n", blockType, err)
+			}
+		}
+	})
+
+	t.Run("EveryKindHasSchema", func(t *testing.T) {
+		kinds := Kinds()
+		if len(kinds) != 10 {
+			t.Errorf("Expected 10 block types, got %d\n", len(kinds))
+		}
+		for _, kind := range kinds {
+			if kind.Schema["type"] != "object" {
+				t.Errorf("Expected object schema for %s\n", kind.Type)
+			}
+		}
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		err := Validate("video", map[string]any{})
+		if !errors.Is(err, ErrUnknownType) {
+			t.Errorf("Expected ErrUnknownType, got %v\n", err)
+		}
+	})
+
+	t.Run("InvalidBodies", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			blockType string
+			body      map[string]any
+		}{
+			{"MissingRequired", TypeHeading, map[string]any{"text": "Методика"}},
+			{"LevelOutOfRange", TypeHeading, map[string]any{"text": "Методика", "level": float64(7)}},
+			{"LevelNotInteger", TypeHeading, map[string]any{"text": "Методика", "level": 1.5}},
+			{"UnknownProperty", TypeCode, map[string]any{"code": "x", "theme": "dark"}},
+			{"WrongItemType", TypeChecklist, map[string]any{"items": []any{"не объект"}}},
+			{"EmptyColumns", TypeTable, map[string]any{"columns": []any{}, "rows": []any{}}},
+			{"UnknownUnit", TypeMeasurement, map[string]any{"quantity": "Масса", "value": 1.0, "unit": "pood"}},
+			{"InvalidSmiles", TypeChemical, map[string]any{"smiles": "C C"}},
+			{"NotImage", TypeImage, map[string]any{"object_key": "a", "content_type": "application/pdf"}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := Validate(tc.blockType, tc.body)
+				var invalid *ValidationError
+				if !errors.As(err, &invalid) {
+					t.Fatalf("Expected ValidationError, got %v\n", err)
+				}
+				if len(invalid.Problems) == 0 {
+					t.Errorf("Expected validation problems to be reported\n")
+				}
+			})
+		}
+	})
+
+	t.Run("BSONValues", func(t *testing.T) {
+		body := map[string]any{
+			"columns": primitive.A{"Образец"},
+			"rows":    primitive.A{primitive.A{"A1"}},
+		}
+		if err := Validate(TypeTable, body); err != nil {
+			t.Errorf("Expected BSON arrays to be accepted, got %v\n", err)
+		}
+
+		measurement := map[string]any{"quantity": "Объём", "value": int32(5), "unit": "mL"}
+		if err := Validate(TypeMeasurement, measurement); err != nil {
+			t.Errorf("Expected BSON integers to be accepted, got %v\n", err)
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package blocksLogic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Типы блоков журнала
+const (
+	TypeText        = "text"
+	TypeHeading     = "heading"
+	TypeChecklist   = "checklist"
+	TypeTable       = "table"
+	TypeCode        = "code"
+	TypeFormula     = "formula"
+	TypeImage       = "image"
+	TypeAttachment  = "attachment"
+	TypeMeasurement = "measurement"
+	TypeChemical    = "chemical_structure"
+)
+
+// ErrUnknownType возвращается для типа блока, которого нет в реестре
+var ErrUnknownType = errors.New("unknown block type")
+
+// ValidationError описывает тело блока, не прошедшее проверку схемы
+type ValidationError struct {
+	Type     string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s block: %s", e.Type, strings.Join(e.Problems, "; "))
+}
+
+// Kind описывает тип блока и JSON-схему его тела
+type Kind struct {
+	Type        string         `json:"type"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Schema      map[string]any `json:"schema"`
+}
+
+// Units - единицы измерения, допустимые в блоке measurement
+var Units = []any{
+	"", "%", "ppm",
+	"mg", "g", "kg", "µg",
+	"µL", "mL", "L",
+	"mmol", "mol", "µmol",
+	"M", "mM", "µM", "mg/mL", "g/L",
+	"°C", "K",
+	"Pa", "kPa", "MPa", "bar", "atm", "mmHg",
+	"s", "min", "h", "d",
+	"nm", "µm", "mm", "cm", "m",
+	"rpm", "Hz", "V", "mA", "A", "J", "kJ", "kJ/mol", "W",
+	"g/mol", "g/cm³", "cP",
+}
+
+var kinds = []Kind{
+	{
+		Type:        TypeText,
+		Title:       "Текст",
+		Description: "Абзац форматированного текста",
+		Schema: object([]string{"content"}, map[string]any{
+			"content": map[string]any{"type": "string"},
+			"format":  map[string]any{"type": "string", "enum": []any{"plain", "markdown", "html"}},
+		}),
+	},
+	{
+		Type:        TypeHeading,
+		Title:       "Заголовок",
+		Description: "Заголовок раздела уровня 1-6",
+		Schema: object([]string{"text", "level"}, map[string]any{
+			"text":  map[string]any{"type": "string", "minLength": 1, "maxLength": 500},
+			"level": map[string]any{"type": "integer", "minimum": 1, "maximum": 6},
+		}),
+	},
+	{
+		Type:        TypeChecklist,
+		Title:       "Чек-лист",
+		Description: "Список пунктов с отметкой выполнения",
+		Schema: object([]string{"items"}, map[string]any{
+			"title": map[string]any{"type": "string"},
+			"items": map[string]any{
+				"type": "array",
+				"items": object([]string{"text"}, map[string]any{
+					"text":    map[string]any{"type": "string"},
+					"checked": map[string]any{"type": "boolean"},
+				}),
+			},
+		}),
+	},
+	{
+		Type:        TypeTable,
+		Title:       "Таблица",
+		Description: "Таблица с заголовками столбцов и строками значений",
+		Schema: object([]string{"columns", "rows"}, map[string]any{
+			"caption": map[string]any{"type": "string"},
+			"columns": map[string]any{
+				"type":     "array",
+				"minItems": 1,
+				"items":    map[string]any{"type": "string"},
+			},
+			"rows": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":  "array",
+					"items": map[string]any{"type": []string{"string", "number", "boolean", "null"}},
+				},
+			},
+		}),
+	},
+	{
+		Type:        TypeCode,
+		Title:       "Код",
+		Description: "Фрагмент исходного кода",
+		Schema: object([]string{"code"}, map[string]any{
+			"code":     map[string]any{"type": "string"},
+			"language": map[string]any{"type": "string", "maxLength": 50},
+		}),
+	},
+	{
+		Type:        TypeFormula,
+		Title:       "Формула",
+		Description: "Формула в нотации LaTeX",
+		Schema: object([]string{"latex"}, map[string]any{
+			"latex":   map[string]any{"type": "string", "minLength": 1},
+			"display": map[string]any{"type": "boolean"},
+		}),
+	},
+	{
+		Type:        TypeImage,
+		Title:       "Изображение",
+		Description: "Изображение, загруженное в файловое хранилище",
+		Schema: object([]string{"object_key"}, map[string]any{
+			"object_key":   map[string]any{"type": "string", "minLength": 1},
+			"file_name":    map[string]any{"type": "string"},
+			"content_type": map[string]any{"type": "string", "pattern": `^image/[a-z0-9.+-]+$`},
+			"caption":      map[string]any{"type": "string"},
+			"width":        map[string]any{"type": "integer", "minimum": 1},
+			"height":       map[string]any{"type": "integer", "minimum": 1},
+		}),
+	},
+	{
+		Type:        TypeAttachment,
+		Title:       "Вложение",
+		Description: "Произвольный файл, загруженный в файловое хранилище",
+		Schema: object([]string{"object_key", "file_name"}, map[string]any{
+			"object_key":   map[string]any{"type": "string", "minLength": 1},
+			"file_name":    map[string]any{"type": "string", "minLength": 1},
+			"content_type": map[string]any{"type": "string"},
+			"size":         map[string]any{"type": "integer", "minimum": 0},
+		}),
+	},
+	{
+		Type:        TypeMeasurement,
+		Title:       "Измерение",
+		Description: "Измеренная величина с единицами и погрешностью",
+		Schema: object([]string{"quantity", "value", "unit"}, map[string]any{
+			"quantity":    map[string]any{"type": "string", "minLength": 1},
+			"value":       map[string]any{"type": "number"},
+			"unit":        map[string]any{"type": "string", "enum": Units},
+			"uncertainty": map[string]any{"type": "number", "minimum": 0},
+			"method":      map[string]any{"type": "string"},
+			"instrument":  map[string]any{"type": "string"},
+		}),
+	},
+	{
+		Type:        TypeChemical,
+		Title:       "Химическая структура",
+		Description: "Структура вещества в нотации SMILES",
+		Schema: object([]string{"smiles"}, map[string]any{
+			"smiles":  map[string]any{"type": "string", "minLength": 1, "pattern": `^[A-Za-z0-9@+\-\[\]()\\/%=#$.:*~]+$`},
+			"name":    map[string]any{"type": "string"},
+			"formula": map[string]any{"type": "string"},
+		}),
+	},
+}
+
+// Kinds возвращает все зарегистрированные типы блоков
+func Kinds() []Kind {
+	result := make([]Kind, len(kinds))
+	copy(result, kinds)
+	return result
+}
+
+// Lookup возвращает тип блока по имени
+func Lookup(blockType string) (Kind, bool) {
+	for _, kind := range kinds {
+		if kind.Type == blockType {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// Validate проверяет тело блока по схеме его типа
+func Validate(blockType string, body map[string]any) error {
+	kind, ok := Lookup(blockType)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownType, blockType)
+	}
+	if body == nil {
+		body = map[string]any{}
+	}
+
+	if problems := validateSchema(kind.Schema, body, "body"); len(problems) > 0 {
+		return &ValidationError{Type: blockType, Problems: problems}
+	}
+	return nil
+}
+
+func object(required []string, properties map[string]any) map[string]any {
+	return map[string]any{
+		"type":                 "object",
+		"required":             required,
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,190 @@
+package blocksLogic
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// validateSchema проверяет значение по подмножеству JSON Schema, которым описаны типы блоков:
+// type (строка или список), properties, required, additionalProperties (bool), items,
+// enum, minLength/maxLength, pattern, minimum/maximum, minItems/maxItems.
+func validateSchema(schema map[string]any, value any, path string) []string {
+	value = normalize(value)
+	var problems []string
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		return []string{fmt.Sprintf("%s: expected %v, got %s", path, types, typeName(value))}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: value %v is not one of %v", path, value, enum))
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if min, ok := schema["minLength"].(int); ok && length < min {
+			problems = append(problems, fmt.Sprintf("%s: must be at least %d characters", path, min))
+		}
+		if max, ok := schema["maxLength"].(int); ok && length > max {
+			problems = append(problems, fmt.Sprintf("%s: must be at most %d characters", path, max))
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(v) {
+			problems = append(problems, fmt.Sprintf("%s: does not match pattern %s", path, pattern))
+		}
+
+	case float64:
+		if min, ok := number(schema["minimum"]); ok && v < min {
+			problems = append(problems, fmt.Sprintf("%s: must be >= %v", path, min))
+		}
+		if max, ok := number(schema["maximum"]); ok && v > max {
+			problems = append(problems, fmt.Sprintf("%s: must be <= %v", path, max))
+		}
+
+	case []any:
+		if min, ok := schema["minItems"].(int); ok && len(v) < min {
+			problems = append(problems, fmt.Sprintf("%s: must contain at least %d items", path, min))
+		}
+		if max, ok := schema["maxItems"].(int); ok && len(v) > max {
+			problems = append(problems, fmt.Sprintf("%s: must contain at most %d items", path, max))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				problems = append(problems, validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]string); ok {
+			for _, name := range required {
+				if _, present := v[name]; !present {
+					problems = append(problems, fmt.Sprintf("%s.%s: is required", path, name))
+				}
+			}
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			propSchema, known := properties[name].(map[string]any)
+			if !known {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					problems = append(problems, fmt.Sprintf("%s.%s: unknown property", path, name))
+				}
+				continue
+			}
+			problems = append(problems, validateSchema(propSchema, v[name], path+"."+name)...)
+		}
+	}
+
+	return problems
+}
+
+// normalize приводит значения, прочитанные из JSON или BSON, к одному набору Go-типов
+func normalize(value any) any {
+	switch v := value.(type) {
+	case primitive.M:
+		return map[string]any(v)
+	case primitive.D:
+		return v.Map()
+	case primitive.A:
+		return []any(v)
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+func matchesType(types any, value any) bool {
+	switch t := types.(type) {
+	case string:
+		return matchesSingleType(t, value)
+	case []string:
+		for _, name := range t {
+			if matchesSingleType(name, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesSingleType(name string, value any) bool {
+	switch name {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", value), "*")
+}
+
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,175 @@
+package chainLogic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Виды нарушений цепочки
+const (
+	ProblemHashMismatch    = "hash_mismatch"    // содержимое ревизии не совпадает с ее хешем
+	ProblemBrokenLink      = "broken_link"      // prev_hash не равен хешу предыдущей ревизии
+	ProblemMissingRevision = "missing_revision" // пропущены номера ревизий
+	ProblemUnsealed        = "unsealed"         // ревизия без хеша после начала цепочки
+	ProblemHeadMismatch    = "head_mismatch"    // журнал не совпадает с последней ревизией
+)
+
+// chainedRevision - канонический вид ревизии для хеширования
+type chainedRevision struct {
+	NotebookID    string            `json:"notebook_id"`
+	Revision      int64             `json:"revision"`
+	Action        string            `json:"action"`
+	Author        string            `json:"author"`
+	CreatedAt     string            `json:"created_at"`
+	ChangedBlocks []string          `json:"changed_blocks"`
+	RestoredFrom  *int64            `json:"restored_from"`
+	Snapshot      *journal.Notebook `json:"snapshot"`
+	PrevHash      string            `json:"prev_hash"`
+}
+
+// Break - нарушение цепочки на ревизии
+type Break struct {
+	Revision int64  `json:"revision"`
+	Problem  string `json:"problem"`
+	Detail   string `json:"detail"`
+}
+
+// Report - результат проверки цепочки ревизий журнала
+type Report struct {
+	NotebookID string  `json:"notebook_id"`
+	Revisions  int     `json:"revisions"` // проверено ревизий
+	Unchained  int     `json:"unchained"` // ревизии, записанные до появления цепочки
+	Head       string  `json:"head"`      // хеш последней ревизии
+	Valid      bool    `json:"valid"`
+	Breaks     []Break `json:"breaks"`
+}
+
+// Hash возвращает SHA-256 ревизии вместе с PrevHash. Ревизия предварительно проходит через BSON,
+// чтобы хеш до записи в базу совпадал с хешем, пересчитанным по прочитанной ревизии
+// (время округляется до миллисекунд и приводится к UTC, типы значений блоков - к типам драйвера).
+func Hash(rev *revision.Revision) (string, error) {
+	raw, err := bson.Marshal(rev)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode revision: %w", err)
+	}
+	var stored revision.Revision
+	if err := bson.Unmarshal(raw, &stored); err != nil {
+		return "", fmt.Errorf("failed to decode revision: %w", err)
+	}
+
+	data, err := json.Marshal(chainedRevision{
+		NotebookID:    stored.NotebookID,
+		Revision:      stored.Revision,
+		Action:        stored.Action,
+		Author:        stored.Author,
+		CreatedAt:     stored.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ChangedBlocks: stored.ChangedBlocks,
+		RestoredFrom:  stored.RestoredFrom,
+		Snapshot:      stored.Snapshot,
+		PrevHash:      stored.PrevHash,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode chained revision: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Seal связывает новую ревизию с предыдущей (previous может быть nil) и считает ее хеш
+func Seal(rev, previous *revision.Revision) error {
+	rev.PrevHash = ""
+	if previous != nil {
+		rev.PrevHash = previous.Hash
+	}
+	hash, err := Hash(rev)
+	if err != nil {
+		return err
+	}
+	rev.Hash = hash
+	return nil
+}
+
+// Verify пересчитывает цепочку ревизий журнала (revs - по возрастанию номера) и сверяет
+// ее конец с текущим журналом; notebook может быть nil, если журнал удален
+func Verify(notebookId string, notebook *journal.Notebook, revs []revision.Revision) Report {
+	report := Report{NotebookID: notebookId, Revisions: len(revs), Breaks: []Break{}}
+
+	var prev *revision.Revision
+	chained := false
+	for i := range revs {
+		rev := &revs[i]
+		if prev != nil && rev.Revision != prev.Revision+1 {
+			report.Breaks = append(report.Breaks, Break{
+				Revision: rev.Revision,
+				Problem:  ProblemMissingRevision,
+				Detail:   fmt.Sprintf("expected revision %d, found %d", prev.Revision+1, rev.Revision),
+			})
+		}
+
+		if rev.Hash == "" {
+			if chained {
+				report.Breaks = append(report.Breaks, Break{Revision: rev.Revision, Problem: ProblemUnsealed, Detail: "revision has no hash"})
+			} else {
+				report.Unchained++
+			}
+			prev = rev
+			continue
+		}
+		chained = true
+
+		expectedPrev := ""
+		if prev != nil {
+			expectedPrev = prev.Hash
+		}
+		if rev.PrevHash != expectedPrev {
+			report.Breaks = append(report.Breaks, Break{
+				Revision: rev.Revision,
+				Problem:  ProblemBrokenLink,
+				Detail:   fmt.Sprintf("prev_hash %q does not match previous revision hash %q", rev.PrevHash, expectedPrev),
+			})
+		}
+
+		if hash, err := Hash(rev); err != nil || hash != rev.Hash {
+			report.Breaks = append(report.Breaks, Break{Revision: rev.Revision, Problem: ProblemHashMismatch, Detail: "revision content does not match its hash"})
+		}
+		prev = rev
+	}
+
+	if prev != nil {
+		report.Head = prev.Hash
+		if notebook != nil {
+			switch {
+			case notebook.Revision != prev.Revision:
+				report.Breaks = append(report.Breaks, Break{
+					Revision: notebook.Revision,
+					Problem:  ProblemHeadMismatch,
+					Detail:   fmt.Sprintf("notebook is at revision %d, history ends at %d", notebook.Revision, prev.Revision),
+				})
+			case prev.Snapshot != nil && !sameContent(prev.Snapshot, notebook):
+				report.Breaks = append(report.Breaks, Break{
+					Revision: notebook.Revision,
+					Problem:  ProblemHeadMismatch,
+					Detail:   "notebook content differs from its latest revision",
+				})
+			}
+		}
+	}
+
+	report.Valid = len(report.Breaks) == 0
+	return report
+}
+
+// sameContent сравнивает то, что меняется только вместе с записью ревизии: название, описание и блоки.
+// Теги меняются без ревизии и не сравниваются.
+func sameContent(snapshot, notebook *journal.Notebook) bool {
+	return snapshot.Metadata.Title == notebook.Metadata.Title &&
+		snapshot.Metadata.Description == notebook.Metadata.Description &&
+		len(revision.DiffBlocks(snapshot.Blocks, notebook.Blocks)) == 0
+}
@@ -0,0 +1,134 @@
+package chainLogic
+
+import (
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// history строит журнал и запечатанную цепочку из n ревизий, в каждой из которых добавлен блок
+func history(t *testing.T, n int) (*journal.Notebook, []revision.Revision) {
+	t.Helper()
+	notebook := journal.NewNotebook("employee", "company", "division", "notebook", "Синтез аспирина", "Методика")
+	revs := make([]revision.Revision, 0, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			notebook.Blocks = append(notebook.Blocks, journal.NewBlock("text", map[string]any{"content": "шаг", "step": i}))
+			notebook.Revision++
+		}
+		rev := revision.NewRevision(notebook, revision.ActionUpdate, "employee", nil)
+		snapshot := *rev.Snapshot
+		snapshot.Blocks = append([]journal.Block(nil), notebook.Blocks...)
+		rev.Snapshot = &snapshot
+
+		var previous *revision.Revision
+		if len(revs) > 0 {
+			previous = &revs[len(revs)-1]
+		}
+		if err := Seal(&rev, previous); err != nil {
+			t.Fatalf("Seal failed: %v\n", err)
+		}
+		revs = append(revs, rev)
+	}
+	return &notebook, revs
+}
+
+func problems(report Report) map[int64]string {
+	found := map[int64]string{}
+	for _, b := range report.Breaks {
+		found[b.Revision] = b.Problem
+	}
+	return found
+}
+
+func TestChain(t *testing.T) {
+	t.Run("ValidChain", func(t *testing.T) {
+		notebook, revs := history(t, 4)
+		report := Verify(notebook.UuidID, notebook, revs)
+		if !report.Valid || report.Revisions != 4 || report.Head != revs[3].Hash {
+			t.Errorf("Expected valid chain of 4 revisions, got %+v\n", report)
+		}
+		if revs[0].PrevHash != "" || revs[1].PrevHash != revs[0].Hash {
+			t.Errorf("Expected revisions to be linked by prev_hash\n")
+		}
+	})
+
+	t.Run("HashSurvivesStorage", func(t *testing.T) {
+		_, revs := history(t, 2)
+		raw, err := bson.Marshal(revs[1])
+		if err != nil {
+			t.Fatalf("Marshal failed: %v\n", err)
+		}
+		var stored revision.Revision
+		if err := bson.Unmarshal(raw, &stored); err != nil {
+			t.Fatalf("Unmarshal failed: %v\n", err)
+		}
+		if hash, err := Hash(&stored); err != nil || hash != revs[1].Hash {
+			t.Errorf("Expected hash to survive BSON round trip, got %q (%v)\n", hash, err)
+		}
+	})
+
+	t.Run("AlteredContent", func(t *testing.T) {
+		notebook, revs := history(t, 4)
+		revs[2].Snapshot.Blocks[0].Body = map[string]any{"content": "исправлено задним числом"}
+		report := Verify(notebook.UuidID, nil, revs)
+		if report.Valid || problems(report)[2] != ProblemHashMismatch {
+			t.Errorf("Expected hash mismatch at revision 2, got %+v\n", report.Breaks)
+		}
+	})
+
+	t.Run("RehashedRevision", func(t *testing.T) {
+		notebook, revs := history(t, 4)
+		revs[1].Author = "someone else"
+		revs[1].Hash, _ = Hash(&revs[1])
+		report := Verify(notebook.UuidID, nil, revs)
+		if problems(report)[2] != ProblemBrokenLink {
+			t.Errorf("Expected broken link at revision 2, got %+v\n", report.Breaks)
+		}
+	})
+
+	t.Run("DeletedRevision", func(t *testing.T) {
+		notebook, revs := history(t, 4)
+		revs = append(revs[:2], revs[3:]...)
+		report := Verify(notebook.UuidID, nil, revs)
+		if report.Valid || len(report.Breaks) != 2 {
+			t.Errorf("Expected missing revision and broken link, got %+v\n", report.Breaks)
+		}
+	})
+
+	t.Run("LegacyPrefix", func(t *testing.T) {
+		notebook, revs := history(t, 3)
+		revs[0].Hash = ""
+		revs[1].PrevHash = ""
+		revs[1].Hash, _ = Hash(&revs[1])
+		revs[2].PrevHash = revs[1].Hash
+		revs[2].Hash, _ = Hash(&revs[2])
+		report := Verify(notebook.UuidID, notebook, revs)
+		if !report.Valid || report.Unchained != 1 {
+			t.Errorf("Expected valid chain with one legacy revision, got %+v\n", report)
+		}
+
+		revs[2].Hash = ""
+		report = Verify(notebook.UuidID, notebook, revs)
+		if problems(report)[2] != ProblemUnsealed {
+			t.Errorf("Expected unsealed revision 2, got %+v\n", report.Breaks)
+		}
+	})
+
+	t.Run("HeadMismatch", func(t *testing.T) {
+		notebook, revs := history(t, 3)
+		notebook.Blocks[0].Body = map[string]any{"content": "правка в базе в обход истории"}
+		report := Verify(notebook.UuidID, notebook, revs)
+		if problems(report)[notebook.Revision] != ProblemHeadMismatch {
+			t.Errorf("Expected head mismatch, got %+v\n", report.Breaks)
+		}
+
+		notebook, revs = history(t, 3)
+		report = Verify(notebook.UuidID, notebook, revs[:2])
+		if problems(report)[notebook.Revision] != ProblemHeadMismatch {
+			t.Errorf("Expected head mismatch for dropped latest revision, got %+v\n", report.Breaks)
+		}
+	})
+}
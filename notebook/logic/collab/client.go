@@ -0,0 +1,195 @@
+package collabLogic
+
+import (
+	"errors"
+	"fmt"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+// inbound - сообщение редактора. Поля используются в зависимости от Type:
+// op, block_insert, block_update, block_move, block_delete, cursor, ping.
+type inbound struct {
+	Type      string         `json:"type"`
+	Ref       string         `json:"ref,omitempty"` // возвращается отправителю для сопоставления ответа
+	BlockID   string         `json:"block_id,omitempty"`
+	Field     string         `json:"field,omitempty"`
+	Base      int64          `json:"base"`
+	Ops       TextOp         `json:"ops,omitempty"`
+	BlockType string         `json:"block_type,omitempty"`
+	Body      map[string]any `json:"body,omitempty"`
+	Position  *int           `json:"position,omitempty"`
+	Offset    int            `json:"offset"`
+	Length    int            `json:"length"`
+}
+
+// errViewOnly - редактор без права изменения журнала может только смотреть и двигать курсор
+var errViewOnly = fmt.Errorf("edit access required: %w", permission.ErrForbidden)
+
+type cursor struct {
+	BlockID string `json:"block_id"`
+	Field   string `json:"field"`
+	Offset  int    `json:"offset"`
+	Length  int    `json:"length"`
+}
+
+type client struct {
+	id       string
+	caller   *employee.Employee
+	canEdit  bool
+	joinedAt time.Time
+	cursor   *cursor // защищён мьютексом комнаты
+
+	ws     *websocket.Conn
+	mu     sync.Mutex
+	send   chan map[string]interface{}
+	closed bool
+}
+
+func (c *client) presence() map[string]interface{} {
+	return map[string]interface{}{
+		"client":    c.id,
+		"user_id":   c.caller.UserID,
+		"joined_at": c.joinedAt,
+		"cursor":    c.cursor,
+	}
+}
+
+// transformCursor сдвигает курсор редактора через чужую операцию; вызывается под мьютексом комнаты
+func (c *client) transformCursor(blockId, field string, op TextOp) {
+	if c.cursor == nil || c.cursor.BlockID != blockId || c.cursor.Field != field {
+		return
+	}
+	start := TransformIndex(c.cursor.Offset, op)
+	end := TransformIndex(c.cursor.Offset+c.cursor.Length, op)
+	c.cursor.Offset = start
+	c.cursor.Length = max(end-start, 0)
+}
+
+// enqueue не блокирует комнату: редактор, не успевающий читать, отключается
+func (c *client) enqueue(msg map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	select {
+	case c.send <- msg:
+	default:
+		c.closeLocked()
+	}
+}
+
+func (c *client) shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+}
+
+func (c *client) closeLocked() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.send)
+	c.ws.Close()
+}
+
+func (c *client) writeLoop() {
+	for msg := range c.send {
+		c.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := websocket.JSON.Send(c.ws, msg); err != nil {
+			c.shutdown()
+			return
+		}
+	}
+}
+
+// Serve обслуживает WebSocket-соединение редактора журнала до его закрытия.
+// Без canEdit редактор получает изменения и присутствие, но его правки отклоняются.
+func (h *Hub) Serve(ws *websocket.Conn, notebookId uuid.UUID, caller *employee.Employee, canEdit bool) {
+	ws.MaxPayloadBytes = maxPayload
+
+	c := &client{
+		id:       uuid.New().String(),
+		caller:   caller,
+		canEdit:  canEdit,
+		joinedAt: time.Now(),
+		ws:       ws,
+		send:     make(chan map[string]interface{}, sendBuffer),
+	}
+
+	r, err := h.join(notebookId, c)
+	if err != nil {
+		logger.NewErrMessage("Failed to open collaboration room",
+			zap.Error(err),
+			zap.String("operation", "CollabServe"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+		websocket.JSON.Send(ws, map[string]interface{}{"type": "error", "message": "failed to open notebook"})
+		ws.Close()
+		return
+	}
+
+	go c.writeLoop()
+	c.enqueue(r.snapshot())
+	r.broadcastPresence()
+
+	logger.NewInfoMessage("Editor joined notebook",
+		zap.String("operation", "CollabServe"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("employee_id", caller.ID.String()),
+		zap.String("client", c.id),
+	)
+
+	for {
+		ws.SetReadDeadline(time.Now().Add(readTimeout))
+
+		var msg inbound
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			break
+		}
+
+		var handleErr error
+		switch {
+		case msg.Type == "ping":
+			c.enqueue(map[string]interface{}{"type": "pong", "ref": msg.Ref})
+		case msg.Type == "cursor":
+			r.broadcastCursor(c, msg)
+		case !c.canEdit:
+			handleErr = errViewOnly
+		case msg.Type == "op":
+			handleErr = r.applyText(c, msg)
+		default:
+			handleErr = r.applyBlock(c, msg)
+		}
+
+		if handleErr != nil {
+			reply := map[string]interface{}{"type": "error", "ref": msg.Ref, "message": handleErr.Error()}
+			if errors.Is(handleErr, errResync) {
+				reply["resync"] = true
+				c.enqueue(r.snapshot())
+			}
+			c.enqueue(reply)
+		}
+	}
+
+	c.shutdown()
+	h.leave(r, c)
+
+	logger.NewInfoMessage("Editor left notebook",
+		zap.String("operation", "CollabServe"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("employee_id", caller.ID.String()),
+		zap.String("client", c.id),
+	)
+}
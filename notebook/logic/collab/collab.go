@@ -0,0 +1,118 @@
+package collabLogic
+
+import (
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	flushInterval = 2 * time.Second  // как часто несохранённые тексты блоков пишутся в Mongo
+	historyLimit  = 1000             // сколько последних операций хранится для трансформации
+	sendBuffer    = 64               // очередь исходящих сообщений одного редактора
+	readTimeout   = 90 * time.Second // клиент обязан слать ping чаще
+	writeTimeout  = 10 * time.Second
+	maxPayload    = 1 << 20
+)
+
+// notebookStore - операции над журналом, через которые сохраняется совместное состояние
+type notebookStore interface {
+	GetNotebook(notebookId uuid.UUID, caller *employee.Employee) (*journal.Notebook, error)
+	InsertBlock(notebookId uuid.UUID, caller *employee.Employee, blockType string, body map[string]any, position int) (*journal.Block, error)
+	UpdateBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string, blockType string, body map[string]any) error
+	UpdateBlockAt(notebookId uuid.UUID, caller *employee.Employee, blockId string, blockType string, body map[string]any, expectedRevision int64) (int64, error)
+	MoveBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string, position int) error
+	DeleteBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string) error
+}
+
+// Hub держит комнаты совместного редактирования, по одной на открытый журнал
+type Hub struct {
+	store   notebookStore
+	mu      sync.Mutex
+	rooms   map[uuid.UUID]*room
+	closing map[uuid.UUID]*room
+	loading map[uuid.UUID]chan struct{} // журналы, которые сейчас читаются из Mongo для новой комнаты
+}
+
+func NewHub(store notebookStore) *Hub {
+	return &Hub{
+		store:   store,
+		rooms:   make(map[uuid.UUID]*room),
+		closing: make(map[uuid.UUID]*room),
+		loading: make(map[uuid.UUID]chan struct{}),
+	}
+}
+
+// join подключает редактора к комнате журнала, создавая её при первом подключении.
+// Если комната как раз закрывается, ждём окончательного сохранения, чтобы не загрузить устаревшее состояние.
+// Журнал читается без мьютекса хаба: остальные журналы открываются параллельно,
+// а редакторы того же журнала ждут окончания загрузки.
+func (h *Hub) join(notebookId uuid.UUID, c *client) (*room, error) {
+	for {
+		h.mu.Lock()
+		if closing, ok := h.closing[notebookId]; ok {
+			h.mu.Unlock()
+			<-closing.done
+			continue
+		}
+		if loading, ok := h.loading[notebookId]; ok {
+			h.mu.Unlock()
+			<-loading
+			continue
+		}
+
+		r, ok := h.rooms[notebookId]
+		if !ok {
+			loading := make(chan struct{})
+			h.loading[notebookId] = loading
+			h.mu.Unlock()
+
+			notebook, err := h.store.GetNotebook(notebookId, c.caller)
+
+			h.mu.Lock()
+			delete(h.loading, notebookId)
+			close(loading)
+			if err != nil {
+				h.mu.Unlock()
+				return nil, err
+			}
+			r = newRoom(h.store, notebook)
+			h.rooms[notebookId] = r
+		}
+
+		r.mu.Lock()
+		r.clients[c.id] = c
+		r.mu.Unlock()
+		h.mu.Unlock()
+
+		return r, nil
+	}
+}
+
+// leave отключает редактора; последний вышедший закрывает комнату с финальным сохранением
+func (h *Hub) leave(r *room, c *client) {
+	h.mu.Lock()
+	r.mu.Lock()
+	delete(r.clients, c.id)
+	empty := len(r.clients) == 0
+	r.mu.Unlock()
+
+	if !empty {
+		h.mu.Unlock()
+		r.broadcastPresence()
+		return
+	}
+
+	delete(h.rooms, r.notebookId)
+	h.closing[r.notebookId] = r
+	h.mu.Unlock()
+
+	r.close()
+
+	h.mu.Lock()
+	delete(h.closing, r.notebookId)
+	h.mu.Unlock()
+}
@@ -0,0 +1,102 @@
+package collabLogic
+
+import (
+	"testing"
+)
+
+func TestTextOperations(t *testing.T) {
+	base := "hello world"
+
+	t.Run("Apply", func(t *testing.T) {
+		op := TextOp{{Retain: 6}, {Delete: 5}, {Insert: "лаборатория"}}
+		got, err := op.Apply(base)
+		if err != nil {
+			t.Fatalf("Apply failed: %v\n", err)
+		}
+
+		if got != "hello лаборатория" {
+			t.Errorf("Expected (hello лаборатория), got (%s)\n", got)
+		}
+	})
+
+	t.Run("ApplyOutOfRange", func(t *testing.T) {
+		op := TextOp{{Retain: 20}}
+		if _, err := op.Apply(base); err == nil {
+			t.Errorf("Expected error for retain beyond text length\n")
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		if err := (TextOp{{Retain: 1, Insert: "x"}}).Validate(); err == nil {
+			t.Errorf("Expected error for component with two actions\n")
+		}
+		if err := (TextOp{}).Validate(); err == nil {
+			t.Errorf("Expected error for empty operation\n")
+		}
+	})
+
+	t.Run("TransformConverges", func(t *testing.T) {
+		a := TextOp{{Retain: 5}, {Insert: ","}}
+		b := TextOp{{Retain: 6}, {Delete: 5}, {Insert: "there"}}
+
+		afterA, _ := a.Apply(base)
+		afterB, _ := b.Apply(base)
+
+		left, err := Transform(a, b).Apply(afterB)
+		if err != nil {
+			t.Fatalf("Apply transformed a failed: %v\n", err)
+		}
+		right, err := Transform(b, a).Apply(afterA)
+		if err != nil {
+			t.Fatalf("Apply transformed b failed: %v\n", err)
+		}
+
+		if left != right || left != "hello, there" {
+			t.Errorf("Expected both sides (hello, there), got (%s) and (%s)\n", left, right)
+		}
+	})
+
+	t.Run("TransformSamePositionInsert", func(t *testing.T) {
+		incoming := TextOp{{Retain: 5}, {Insert: "X"}}
+		applied := TextOp{{Retain: 5}, {Insert: "Y"}}
+
+		afterApplied, _ := applied.Apply(base)
+		got, err := Transform(incoming, applied).Apply(afterApplied)
+		if err != nil {
+			t.Fatalf("Apply failed: %v\n", err)
+		}
+
+		if got != "helloYX world" {
+			t.Errorf("Expected applied insert first (helloYX world), got (%s)\n", got)
+		}
+	})
+
+	t.Run("TransformOverlappingDelete", func(t *testing.T) {
+		incoming := TextOp{{Retain: 2}, {Delete: 6}}
+		applied := TextOp{{Retain: 4}, {Delete: 6}}
+
+		afterApplied, _ := applied.Apply(base)
+		got, err := Transform(incoming, applied).Apply(afterApplied)
+		if err != nil {
+			t.Fatalf("Apply failed: %v\n", err)
+		}
+
+		if got != "hed" {
+			t.Errorf("Expected union of deletions (hed), got (%s)\n", got)
+		}
+	})
+
+	t.Run("TransformIndex", func(t *testing.T) {
+		op := TextOp{{Retain: 2}, {Insert: "abc"}, {Retain: 3}, {Delete: 4}}
+
+		if got := TransformIndex(1, op); got != 1 {
+			t.Errorf("Expected cursor before insert to stay at 1, got %d\n", got)
+		}
+		if got := TransformIndex(4, op); got != 7 {
+			t.Errorf("Expected cursor after insert to move to 7, got %d\n", got)
+		}
+		if got := TransformIndex(7, op); got != 8 {
+			t.Errorf("Expected cursor inside deleted range to clamp to 8, got %d\n", got)
+		}
+	})
+}
@@ -0,0 +1,572 @@
+package collabLogic
+
+import (
+	"errors"
+	"fmt"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+var errResync = errors.New("reload the notebook")
+
+// appliedOp - запись журнала применённых операций комнаты.
+// reset помечает замену или удаление блока: текстовые операции, построенные раньше, не трансформируются.
+// reset без blockId - перезагрузка состояния комнаты из Mongo, она касается всех блоков.
+type appliedOp struct {
+	version int64
+	blockId string
+	field   string
+	op      TextOp
+	reset   bool
+}
+
+// pendingBlock - несохранённые текстовые правки блока
+type pendingBlock struct {
+	author *employee.Employee // последний автор правок
+	base   map[string]string  // поле -> текст, сохранённый в Mongo до первой несохранённой правки
+}
+
+type room struct {
+	store      notebookStore
+	notebookId uuid.UUID
+	revision   int64
+
+	mu      sync.Mutex
+	version int64
+	blocks  []journal.Block
+	history []appliedOp
+	clients map[string]*client
+	dirty   map[string]*pendingBlock // block id -> несохранённые правки
+
+	// Все записи в Mongo (сохранение текстов, структурные операции, перезагрузка) идут строго по очереди:
+	// старый снимок не перезапишет новый, а ревизия комнаты меняется только под этим мьютексом
+	writeMu sync.Mutex
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newRoom(store notebookStore, notebook *journal.Notebook) *room {
+	r := &room{
+		store:      store,
+		notebookId: uuid.MustParse(notebook.UuidID),
+		revision:   notebook.Revision,
+		blocks:     notebook.Blocks,
+		clients:    make(map[string]*client),
+		dirty:      make(map[string]*pendingBlock),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go r.flushLoop()
+	return r
+}
+
+func (r *room) blockIndex(blockId string) int {
+	for i := range r.blocks {
+		if r.blocks[i].Id == blockId {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyText трансформирует текстовую операцию клиента относительно операций,
+// применённых после base, применяет её к полю блока и рассылает всем редакторам
+func (r *room) applyText(c *client, msg inbound) error {
+	if err := msg.Ops.Validate(); err != nil {
+		return err
+	}
+	if msg.Field == "" {
+		return errors.New("field is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if msg.Base > r.version {
+		return fmt.Errorf("base version %d is ahead of room version %d", msg.Base, r.version)
+	}
+	if len(r.history) > 0 && msg.Base < r.history[0].version-1 {
+		return fmt.Errorf("base version %d is too old: %w", msg.Base, errResync)
+	}
+
+	op := msg.Ops
+	for _, h := range r.history {
+		if h.version <= msg.Base || (h.blockId != msg.BlockID && h.blockId != "") {
+			continue
+		}
+		if h.reset {
+			return errResync
+		}
+		if h.field == msg.Field {
+			op = Transform(op, h.op)
+		}
+	}
+
+	idx := r.blockIndex(msg.BlockID)
+	if idx < 0 {
+		return fmt.Errorf("block %s not found", msg.BlockID)
+	}
+	block := &r.blocks[idx]
+
+	current := ""
+	if value, ok := block.Body[msg.Field]; ok && value != nil {
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("field %s is not a text field", msg.Field)
+		}
+		current = text
+	}
+
+	updated, err := op.Apply(current)
+	if err != nil {
+		return err
+	}
+
+	// Тело после правки должно оставаться валидным для типа блока, иначе клиент перезагружает состояние
+	body := make(map[string]any, len(block.Body)+1)
+	for k, v := range block.Body {
+		body[k] = v
+	}
+	body[msg.Field] = updated
+	if err := blocksLogic.Validate(block.Type, body); err != nil {
+		return fmt.Errorf("%v: %w", err, errResync)
+	}
+	block.Body = body
+
+	r.version++
+	r.record(appliedOp{version: r.version, blockId: msg.BlockID, field: msg.Field, op: op})
+	pending, ok := r.dirty[msg.BlockID]
+	if !ok {
+		pending = &pendingBlock{base: make(map[string]string)}
+		r.dirty[msg.BlockID] = pending
+	}
+	if _, ok := pending.base[msg.Field]; !ok {
+		pending.base[msg.Field] = current
+	}
+	pending.author = c.caller
+
+	for _, other := range r.clients {
+		other.transformCursor(msg.BlockID, msg.Field, op)
+	}
+
+	r.broadcastLocked(map[string]interface{}{
+		"type":     "op",
+		"version":  r.version,
+		"ref":      msg.Ref,
+		"user_id":  c.caller.UserID,
+		"block_id": msg.BlockID,
+		"field":    msg.Field,
+		"ops":      op,
+	})
+
+	return nil
+}
+
+// applyBlock выполняет структурную операцию над блоком: изменение сразу сохраняется в Mongo,
+// затем применяется к состоянию комнаты и рассылается редакторам.
+// Запрос к Mongo идёт без мьютекса комнаты, чтобы текстовые операции других редакторов не ждали его.
+func (r *room) applyBlock(c *client, msg inbound) error {
+	switch msg.Type {
+	case "block_insert", "block_update", "block_move", "block_delete":
+	default:
+		return fmt.Errorf("unknown message type %q", msg.Type)
+	}
+
+	position := -1
+	if msg.Position != nil {
+		position = *msg.Position
+	}
+	if msg.Type == "block_update" && msg.Body == nil {
+		msg.Body = map[string]any{}
+	}
+
+	// Структуру блоков меняют только операции под writeMu, поэтому проверка остаётся верной до конца записи
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if msg.Type != "block_insert" {
+		r.mu.Lock()
+		idx := r.blockIndex(msg.BlockID)
+		r.mu.Unlock()
+		if idx < 0 {
+			return fmt.Errorf("block %s not found", msg.BlockID)
+		}
+	}
+
+	var inserted *journal.Block
+	var err error
+	switch msg.Type {
+	case "block_insert":
+		inserted, err = r.store.InsertBlock(r.notebookId, c.caller, msg.BlockType, msg.Body, position)
+	case "block_update":
+		err = r.store.UpdateBlock(r.notebookId, c.caller, msg.BlockID, msg.BlockType, msg.Body)
+	case "block_move":
+		err = r.store.MoveBlock(r.notebookId, c.caller, msg.BlockID, position)
+	case "block_delete":
+		err = r.store.DeleteBlock(r.notebookId, c.caller, msg.BlockID)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event := map[string]interface{}{
+		"type":    msg.Type,
+		"ref":     msg.Ref,
+		"user_id": c.caller.UserID,
+	}
+
+	switch msg.Type {
+	case "block_insert":
+		if position < 0 || position > len(r.blocks) {
+			position = len(r.blocks)
+		}
+		r.blocks = append(r.blocks[:position], append([]journal.Block{*inserted}, r.blocks[position:]...)...)
+		event["block"] = inserted
+		event["position"] = position
+
+	case "block_update":
+		idx := r.blockIndex(msg.BlockID)
+		r.blocks[idx].Type = msg.BlockType
+		r.blocks[idx].Body = msg.Body
+		delete(r.dirty, msg.BlockID)
+		event["block"] = r.blocks[idx]
+
+	case "block_move":
+		idx := r.blockIndex(msg.BlockID)
+		block := r.blocks[idx]
+		r.blocks = append(r.blocks[:idx], r.blocks[idx+1:]...)
+		if position < 0 || position > len(r.blocks) {
+			position = len(r.blocks)
+		}
+		r.blocks = append(r.blocks[:position], append([]journal.Block{block}, r.blocks[position:]...)...)
+		event["block_id"] = msg.BlockID
+		event["position"] = position
+
+	case "block_delete":
+		idx := r.blockIndex(msg.BlockID)
+		r.blocks = append(r.blocks[:idx], r.blocks[idx+1:]...)
+		delete(r.dirty, msg.BlockID)
+		event["block_id"] = msg.BlockID
+	}
+
+	// Каждая структурная операция добавляет журналу одну ревизию; если журнал менялся и в обход комнаты,
+	// следующее сохранение текстов получит конфликт и перезагрузит состояние
+	r.revision++
+	r.version++
+	if msg.Type == "block_update" || msg.Type == "block_delete" {
+		r.record(appliedOp{version: r.version, blockId: msg.BlockID, reset: true})
+	}
+	event["version"] = r.version
+	r.broadcastLocked(event)
+
+	return nil
+}
+
+func (r *room) record(op appliedOp) {
+	r.history = append(r.history, op)
+	if len(r.history) > historyLimit {
+		r.history = r.history[len(r.history)-historyLimit:]
+	}
+}
+
+// snapshot возвращает состояние комнаты для нового редактора
+func (r *room) snapshot() map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.snapshotLocked()
+}
+
+func (r *room) snapshotLocked() map[string]interface{} {
+	blocks := make([]journal.Block, len(r.blocks))
+	copy(blocks, r.blocks)
+
+	return map[string]interface{}{
+		"type":        "snapshot",
+		"version":     r.version,
+		"revision":    r.revision,
+		"notebook_id": r.notebookId,
+		"blocks":      blocks,
+		"editors":     r.editorsLocked(),
+	}
+}
+
+func (r *room) editorsLocked() []map[string]interface{} {
+	editors := make([]map[string]interface{}, 0, len(r.clients))
+	for _, c := range r.clients {
+		editors = append(editors, c.presence())
+	}
+	return editors
+}
+
+func (r *room) broadcastPresence() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.broadcastLocked(map[string]interface{}{
+		"type":    "presence",
+		"editors": r.editorsLocked(),
+	})
+}
+
+func (r *room) broadcastCursor(c *client, msg inbound) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c.cursor = &cursor{BlockID: msg.BlockID, Field: msg.Field, Offset: msg.Offset, Length: msg.Length}
+	r.broadcastLocked(map[string]interface{}{
+		"type":    "cursor",
+		"user_id": c.caller.UserID,
+		"client":  c.id,
+		"cursor":  c.cursor,
+	})
+}
+
+func (r *room) broadcastLocked(msg map[string]interface{}) {
+	for _, c := range r.clients {
+		c.enqueue(msg)
+	}
+}
+
+// flushLoop периодически сохраняет тексты блоков, изменённые операциями
+func (r *room) flushLoop() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.flush()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// flush сохраняет изменённые тексты блоков, только если журнал не менялся в обход комнаты.
+// Временные ошибки Mongo оставляют правки в очереди; остальные ошибки (подписанный или закрытый журнал,
+// нет доступа, невалидный блок) не исправятся повтором - такие правки отбрасываются.
+// После конфликта ревизий или отброшенных правок комната перезагружается из Mongo.
+func (r *room) flush() {
+	type pending struct {
+		*pendingBlock
+		block journal.Block
+	}
+
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	r.mu.Lock()
+	batch := make([]pending, 0, len(r.dirty))
+	for blockId, p := range r.dirty {
+		idx := r.blockIndex(blockId)
+		if idx < 0 {
+			continue
+		}
+		body := make(map[string]any, len(r.blocks[idx].Body))
+		for k, v := range r.blocks[idx].Body {
+			body[k] = v
+		}
+		block := r.blocks[idx]
+		block.Body = body
+		batch = append(batch, pending{pendingBlock: p, block: block})
+	}
+	r.dirty = make(map[string]*pendingBlock)
+	expected := r.revision
+	r.mu.Unlock()
+
+	var requeue []pending
+	failed := make(map[string]*employee.Employee) // block id -> автор отброшенных правок
+	conflict := false
+	for _, p := range batch {
+		if conflict {
+			requeue = append(requeue, p)
+			continue
+		}
+
+		current, err := r.store.UpdateBlockAt(r.notebookId, p.author, p.block.Id, p.block.Type, p.block.Body, expected)
+		var conflictErr *revision.ConflictError
+		switch {
+		case err == nil:
+			expected = current
+		case errors.As(err, &conflictErr):
+			logger.NewWarnMessage("Notebook changed outside the collaboration room",
+				zap.String("operation", "CollabFlush"),
+				zap.String("notebook_id", r.notebookId.String()),
+				zap.Int64("expected_revision", expected),
+				zap.Int64("current_revision", conflictErr.Current),
+			)
+			conflict = true
+			requeue = append(requeue, p)
+		case transient(err):
+			logger.NewWarnMessage("Failed to persist collaborative block, will retry",
+				zap.Error(err),
+				zap.String("operation", "CollabFlush"),
+				zap.String("notebook_id", r.notebookId.String()),
+				zap.String("block_id", p.block.Id),
+			)
+			requeue = append(requeue, p)
+		default:
+			logger.NewErrMessage("Failed to persist collaborative block, changes discarded",
+				zap.Error(err),
+				zap.String("operation", "CollabFlush"),
+				zap.String("notebook_id", r.notebookId.String()),
+				zap.String("block_id", p.block.Id),
+			)
+			failed[p.block.Id] = p.author
+		}
+	}
+
+	r.mu.Lock()
+	r.revision = expected
+	// Вернём правки в очередь; если блок успели изменить заново, сохранённым остаётся более ранний текст
+	for _, p := range requeue {
+		if newer, ok := r.dirty[p.block.Id]; ok {
+			for field, base := range p.base {
+				newer.base[field] = base
+			}
+			continue
+		}
+		r.dirty[p.block.Id] = p.pendingBlock
+	}
+	r.mu.Unlock()
+
+	if conflict || len(failed) > 0 {
+		r.reload(failed)
+	}
+}
+
+// reload заменяет состояние комнаты сохранённым в Mongo и рассылает редакторам новый снимок.
+// Несохранённые правки переносятся на блоки из Mongo, если их поля там не менялись; иначе правки теряются,
+// как и отброшенные при сохранении правки failed (block id -> автор). Вызывается под writeMu.
+func (r *room) reload(failed map[string]*employee.Employee) {
+	// Журнал читается от имени любого, кто его открывал: редактора в комнате или автора правок
+	r.mu.Lock()
+	readers := make([]*employee.Employee, 0, len(r.clients)+len(r.dirty)+len(failed))
+	for _, c := range r.clients {
+		readers = append(readers, c.caller)
+	}
+	for _, p := range r.dirty {
+		readers = append(readers, p.author)
+	}
+	r.mu.Unlock()
+	for _, author := range failed {
+		readers = append(readers, author)
+	}
+
+	var notebook *journal.Notebook
+	var err error
+	for _, reader := range readers {
+		if notebook, err = r.store.GetNotebook(r.notebookId, reader); err == nil {
+			break
+		}
+	}
+	if notebook == nil {
+		// Правки остались в очереди: следующее сохранение снова получит конфликт и повторит перезагрузку
+		logger.NewErrMessage("Failed to reload collaboration room",
+			zap.Error(err),
+			zap.String("operation", "CollabReload"),
+			zap.String("notebook_id", r.notebookId.String()),
+		)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	blocks := notebook.Blocks
+	stored := make(map[string]int, len(blocks))
+	for i := range blocks {
+		stored[blocks[i].Id] = i
+	}
+
+	lost := make([]string, 0, len(failed))
+	for blockId := range failed {
+		lost = append(lost, blockId)
+	}
+	for blockId, p := range r.dirty {
+		si, ok := stored[blockId]
+		ri := r.blockIndex(blockId)
+		if !ok || ri < 0 || blocks[si].Type != r.blocks[ri].Type || !unchanged(blocks[si].Body, p.base) {
+			delete(r.dirty, blockId)
+			lost = append(lost, blockId)
+			continue
+		}
+
+		body := make(map[string]any, len(blocks[si].Body)+len(p.base))
+		for k, v := range blocks[si].Body {
+			body[k] = v
+		}
+		for field := range p.base {
+			body[field] = r.blocks[ri].Body[field]
+		}
+		blocks[si].Body = body
+	}
+
+	r.blocks = blocks
+	r.revision = notebook.Revision
+	r.version++
+	r.record(appliedOp{version: r.version, reset: true})
+
+	snapshot := r.snapshotLocked()
+	snapshot["resync"] = true
+	r.broadcastLocked(snapshot)
+
+	if len(lost) > 0 {
+		r.broadcastLocked(map[string]interface{}{
+			"type":      "error",
+			"message":   "unsaved changes were discarded",
+			"block_ids": lost,
+			"resync":    true,
+		})
+	}
+
+	logger.NewInfoMessage("Collaboration room reloaded",
+		zap.String("operation", "CollabReload"),
+		zap.String("notebook_id", r.notebookId.String()),
+		zap.Int64("revision", r.revision),
+		zap.Strings("lost_blocks", lost),
+	)
+}
+
+// unchanged сообщает, что текстовые поля body равны сохранённым до правок значениям base
+func unchanged(body map[string]any, base map[string]string) bool {
+	for field, text := range base {
+		current, _ := body[field].(string)
+		if current != text {
+			return false
+		}
+	}
+	return true
+}
+
+// transient сообщает, что ошибка сохранения временная (сеть, таймаут, откат транзакции) и запись стоит повторить
+func transient(err error) bool {
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) {
+		return true
+	}
+	var labeled mongo.LabeledError
+	if errors.As(err, &labeled) {
+		return labeled.HasErrorLabel("TransientTransactionError") || labeled.HasErrorLabel("RetryableWriteError")
+	}
+	return false
+}
+
+// close останавливает периодическое сохранение и сохраняет оставшиеся правки
+func (r *room) close() {
+	close(r.stop)
+	r.flush()
+	close(r.done)
+}
@@ -0,0 +1,239 @@
+package collabLogic
+
+import (
+	"errors"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// fakeStore хранит журнал в памяти; UpdateBlockAt сначала возвращает ошибки из errs по очереди
+type fakeStore struct {
+	mu       sync.Mutex
+	notebook journal.Notebook
+	errs     []error
+	writes   int
+}
+
+func (s *fakeStore) GetNotebook(notebookId uuid.UUID, caller *employee.Employee) (*journal.Notebook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notebook := s.notebook
+	notebook.Blocks = make([]journal.Block, len(s.notebook.Blocks))
+	for i, b := range s.notebook.Blocks {
+		body := make(map[string]any, len(b.Body))
+		for k, v := range b.Body {
+			body[k] = v
+		}
+		b.Body = body
+		notebook.Blocks[i] = b
+	}
+	return &notebook, nil
+}
+
+func (s *fakeStore) InsertBlock(notebookId uuid.UUID, caller *employee.Employee, blockType string, body map[string]any, position int) (*journal.Block, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeStore) UpdateBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string, blockType string, body map[string]any) error {
+	_, err := s.update(blockId, blockType, body, nil)
+	return err
+}
+
+func (s *fakeStore) UpdateBlockAt(notebookId uuid.UUID, caller *employee.Employee, blockId string, blockType string, body map[string]any, expectedRevision int64) (int64, error) {
+	return s.update(blockId, blockType, body, &expectedRevision)
+}
+
+func (s *fakeStore) MoveBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string, position int) error {
+	return errors.New("not implemented")
+}
+
+func (s *fakeStore) DeleteBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string) error {
+	return errors.New("not implemented")
+}
+
+func (s *fakeStore) update(blockId, blockType string, body map[string]any, expected *int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return 0, err
+	}
+	if expected != nil && *expected != s.notebook.Revision {
+		return 0, &revision.ConflictError{Current: s.notebook.Revision}
+	}
+	for i := range s.notebook.Blocks {
+		if s.notebook.Blocks[i].Id == blockId {
+			s.notebook.Blocks[i].Type = blockType
+			s.notebook.Blocks[i].Body = body
+			s.notebook.Revision++
+			s.writes++
+			return s.notebook.Revision, nil
+		}
+	}
+	return 0, journal.ErrBlockNotFound
+}
+
+func (s *fakeStore) content(blockId string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, b := range s.notebook.Blocks {
+		if b.Id == blockId {
+			return b.Body["content"]
+		}
+	}
+	return nil
+}
+
+func newTestRoom(t *testing.T, store *fakeStore) (*room, *client) {
+	t.Helper()
+
+	store.notebook = journal.Notebook{
+		UuidID:   uuid.New().String(),
+		Revision: 3,
+		Blocks: []journal.Block{
+			{Id: "a", Type: "text", Body: map[string]any{"content": "hello"}},
+			{Id: "b", Type: "text", Body: map[string]any{"content": "world"}},
+		},
+	}
+	notebook, _ := store.GetNotebook(uuid.Nil, nil)
+	r := newRoom(store, notebook)
+	close(r.stop) // сохранения в тестах вызываются явно
+
+	return r, &client{id: "c1", caller: &employee.Employee{ID: uuid.New(), UserID: uuid.New()}, canEdit: true}
+}
+
+func edit(t *testing.T, r *room, c *client, blockId string, op TextOp) {
+	t.Helper()
+
+	r.mu.Lock()
+	base := r.version
+	r.mu.Unlock()
+	if err := r.applyText(c, inbound{Type: "op", BlockID: blockId, Field: "content", Base: base, Ops: op}); err != nil {
+		t.Fatalf("applyText failed: %v\n", err)
+	}
+}
+
+func TestRoomFlush(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	t.Run("Persists", func(t *testing.T) {
+		store := &fakeStore{}
+		r, c := newTestRoom(t, store)
+		edit(t, r, c, "a", TextOp{{Retain: 5}, {Insert: "!"}})
+
+		r.flush()
+
+		if got := store.content("a"); got != "hello!" {
+			t.Errorf("Expected (hello!) in store, got (%v)\n", got)
+		}
+		if r.revision != 4 || len(r.dirty) != 0 {
+			t.Errorf("Expected revision 4 and empty queue, got %d and %d\n", r.revision, len(r.dirty))
+		}
+	})
+
+	t.Run("TransientRetried", func(t *testing.T) {
+		store := &fakeStore{errs: []error{mongo.CommandError{Labels: []string{"NetworkError"}}}}
+		r, c := newTestRoom(t, store)
+		edit(t, r, c, "a", TextOp{{Retain: 5}, {Insert: "!"}})
+
+		r.flush()
+		if _, ok := r.dirty["a"]; !ok || store.writes != 0 {
+			t.Fatalf("Expected block to stay queued after a network error\n")
+		}
+
+		r.flush()
+		if got := store.content("a"); got != "hello!" {
+			t.Errorf("Expected retry to persist (hello!), got (%v)\n", got)
+		}
+	})
+
+	t.Run("PermanentDropped", func(t *testing.T) {
+		store := &fakeStore{errs: []error{journal.ErrSignedContent}}
+		r, c := newTestRoom(t, store)
+		edit(t, r, c, "a", TextOp{{Retain: 5}, {Insert: "!"}})
+
+		r.flush()
+
+		if len(r.dirty) != 0 {
+			t.Errorf("Expected failed block to be dropped from the queue\n")
+		}
+		if got := r.blocks[r.blockIndex("a")].Body["content"]; got != "hello" {
+			t.Errorf("Expected room to reload (hello), got (%v)\n", got)
+		}
+		if store.writes != 0 {
+			t.Errorf("Expected no writes, got %d\n", store.writes)
+		}
+	})
+
+	t.Run("ConflictRebased", func(t *testing.T) {
+		store := &fakeStore{}
+		r, c := newTestRoom(t, store)
+		edit(t, r, c, "a", TextOp{{Retain: 5}, {Insert: "!"}})
+
+		// Другой блок изменён через REST в обход комнаты
+		if err := store.UpdateBlock(uuid.Nil, nil, "b", "text", map[string]any{"content": "rest"}); err != nil {
+			t.Fatalf("UpdateBlock failed: %v\n", err)
+		}
+
+		r.flush()
+		if store.writes != 1 || r.revision != 4 {
+			t.Fatalf("Expected conflict to reload revision 4 without writing, got %d writes and revision %d\n", store.writes, r.revision)
+		}
+		if got := r.blocks[r.blockIndex("b")].Body["content"]; got != "rest" {
+			t.Errorf("Expected REST update to survive reload, got (%v)\n", got)
+		}
+
+		r.flush()
+		if got := store.content("a"); got != "hello!" {
+			t.Errorf("Expected rebased edit to persist (hello!), got (%v)\n", got)
+		}
+		if got := store.content("b"); got != "rest" {
+			t.Errorf("Expected REST update to stay in store, got (%v)\n", got)
+		}
+	})
+
+	t.Run("ConflictLost", func(t *testing.T) {
+		store := &fakeStore{}
+		r, c := newTestRoom(t, store)
+		edit(t, r, c, "a", TextOp{{Retain: 5}, {Insert: "!"}})
+
+		if err := store.UpdateBlock(uuid.Nil, nil, "a", "text", map[string]any{"content": "rest"}); err != nil {
+			t.Fatalf("UpdateBlock failed: %v\n", err)
+		}
+
+		r.flush()
+		r.flush()
+
+		if got := store.content("a"); got != "rest" {
+			t.Errorf("Expected REST update to win over a stale edit, got (%v)\n", got)
+		}
+		if len(r.dirty) != 0 {
+			t.Errorf("Expected stale edit to be discarded\n")
+		}
+	})
+
+	t.Run("ResetAfterReload", func(t *testing.T) {
+		store := &fakeStore{errs: []error{journal.ErrReadOnly}}
+		r, c := newTestRoom(t, store)
+		base := r.version
+		edit(t, r, c, "a", TextOp{{Retain: 5}, {Insert: "!"}})
+		r.flush()
+
+		err := r.applyText(c, inbound{Type: "op", BlockID: "b", Field: "content", Base: base, Ops: TextOp{{Insert: ">"}}})
+		if !errors.Is(err, errResync) {
+			t.Errorf("Expected operation built before the reload to require resync, got %v\n", err)
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package collabLogic
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Component - один шаг текстовой операции: ровно одно из полей Retain, Insert, Delete.
+// Длины считаются в рунах, хвост документа после последнего шага считается сохранённым.
+type Component struct {
+	Retain int    `json:"retain,omitempty"`
+	Insert string `json:"insert,omitempty"`
+	Delete int    `json:"delete,omitempty"`
+}
+
+type TextOp []Component
+
+func (c Component) length() int {
+	switch {
+	case c.Retain > 0:
+		return c.Retain
+	case c.Delete > 0:
+		return c.Delete
+	default:
+		return utf8.RuneCountInString(c.Insert)
+	}
+}
+
+// Validate проверяет, что каждый шаг операции задаёт ровно одно действие
+func (op TextOp) Validate() error {
+	if len(op) == 0 {
+		return errors.New("operation is empty")
+	}
+	for i, c := range op {
+		set := 0
+		if c.Retain > 0 {
+			set++
+		}
+		if c.Insert != "" {
+			set++
+		}
+		if c.Delete > 0 {
+			set++
+		}
+		if set != 1 || c.Retain < 0 || c.Delete < 0 {
+			return fmt.Errorf("component %d must have exactly one positive retain, insert or delete", i)
+		}
+	}
+	return nil
+}
+
+// Apply применяет операцию к тексту
+func (op TextOp) Apply(text string) (string, error) {
+	src := []rune(text)
+	out := make([]rune, 0, len(src))
+	pos := 0
+
+	for _, c := range op {
+		switch {
+		case c.Retain > 0:
+			if pos+c.Retain > len(src) {
+				return "", fmt.Errorf("retain %d exceeds text length %d", c.Retain, len(src)-pos)
+			}
+			out = append(out, src[pos:pos+c.Retain]...)
+			pos += c.Retain
+		case c.Delete > 0:
+			if pos+c.Delete > len(src) {
+				return "", fmt.Errorf("delete %d exceeds text length %d", c.Delete, len(src)-pos)
+			}
+			pos += c.Delete
+		default:
+			out = append(out, []rune(c.Insert)...)
+		}
+	}
+
+	out = append(out, src[pos:]...)
+	return string(out), nil
+}
+
+// Transform перестраивает операцию op так, чтобы её можно было применить после applied.
+// Обе операции построены от одного состояния текста; при вставке в одну позицию
+// первой остаётся уже применённая операция applied.
+func Transform(op, applied TextOp) TextOp {
+	var b opBuilder
+	ia := newOpIter(op)
+	ib := newOpIter(applied)
+
+	for {
+		if ib.peek().Insert != "" {
+			b.retain(ib.next(-1).length())
+			continue
+		}
+		if ia.done() {
+			break
+		}
+		if ia.peek().Insert != "" {
+			b.insert(ia.next(-1).Insert)
+			continue
+		}
+		if ib.done() {
+			b.push(ia.next(-1))
+			continue
+		}
+
+		n := min(ia.peek().length(), ib.peek().length())
+		ca, cb := ia.next(n), ib.next(n)
+		if ca.Retain > 0 && cb.Retain > 0 {
+			b.retain(n)
+		} else if ca.Delete > 0 && cb.Retain > 0 {
+			b.delete(n)
+		}
+		// Участок, удалённый applied, в op больше не существует
+	}
+
+	return b.op
+}
+
+// TransformIndex сдвигает позицию в тексте (например, курсор) через операцию
+func TransformIndex(index int, op TextOp) int {
+	pos, result := 0, index
+	for _, c := range op {
+		if pos > index {
+			break
+		}
+		switch {
+		case c.Retain > 0:
+			pos += c.Retain
+		case c.Delete > 0:
+			if pos < index {
+				result -= min(c.Delete, index-pos)
+			}
+			pos += c.Delete
+		default:
+			result += utf8.RuneCountInString(c.Insert)
+		}
+	}
+	return result
+}
+
+type opIter struct {
+	op     TextOp
+	i      int
+	offset int // уже поглощённая часть текущего шага
+}
+
+func newOpIter(op TextOp) *opIter { return &opIter{op: op} }
+
+func (it *opIter) done() bool { return it.i >= len(it.op) }
+
+// peek возвращает остаток текущего шага; за концом операции - пустой шаг
+func (it *opIter) peek() Component {
+	if it.done() {
+		return Component{}
+	}
+	c := it.op[it.i]
+	switch {
+	case c.Retain > 0:
+		return Component{Retain: c.Retain - it.offset}
+	case c.Delete > 0:
+		return Component{Delete: c.Delete - it.offset}
+	default:
+		return Component{Insert: string([]rune(c.Insert)[it.offset:])}
+	}
+}
+
+// next забирает n единиц текущего шага (n < 0 - весь остаток)
+func (it *opIter) next(n int) Component {
+	c := it.peek()
+	if n < 0 || n >= c.length() {
+		it.i++
+		it.offset = 0
+		return c
+	}
+	it.offset += n
+	switch {
+	case c.Retain > 0:
+		return Component{Retain: n}
+	case c.Delete > 0:
+		return Component{Delete: n}
+	default:
+		return Component{Insert: string([]rune(c.Insert)[:n])}
+	}
+}
+
+type opBuilder struct {
+	op TextOp
+}
+
+func (b *opBuilder) push(c Component) {
+	switch {
+	case c.Retain > 0:
+		b.retain(c.Retain)
+	case c.Delete > 0:
+		b.delete(c.Delete)
+	case c.Insert != "":
+		b.insert(c.Insert)
+	}
+}
+
+func (b *opBuilder) retain(n int) {
+	if n <= 0 {
+		return
+	}
+	if last := len(b.op) - 1; last >= 0 && b.op[last].Retain > 0 {
+		b.op[last].Retain += n
+		return
+	}
+	b.op = append(b.op, Component{Retain: n})
+}
+
+func (b *opBuilder) delete(n int) {
+	if n <= 0 {
+		return
+	}
+	if last := len(b.op) - 1; last >= 0 && b.op[last].Delete > 0 {
+		b.op[last].Delete += n
+		return
+	}
+	b.op = append(b.op, Component{Delete: n})
+}
+
+func (b *opBuilder) insert(s string) {
+	if s == "" {
+		return
+	}
+	if last := len(b.op) - 1; last >= 0 && b.op[last].Insert != "" {
+		b.op[last].Insert += s
+		return
+	}
+	b.op = append(b.op, Component{Insert: s})
+}
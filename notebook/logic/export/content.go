@@ -0,0 +1,214 @@
+package exportLogic
+
+import (
+	"encoding/json"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// str возвращает строковое поле тела блока
+func str(body map[string]any, key string) string {
+	switch v := body[key].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return scalar(v)
+	}
+}
+
+// scalar приводит значение ячейки или поля к строке
+func scalar(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		if val {
+			return "да"
+		}
+		return "нет"
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32)
+	case int, int32, int64:
+		return fmt.Sprintf("%d", val)
+	}
+	return fmt.Sprint(v)
+}
+
+func flag(body map[string]any, key string) bool {
+	b, _ := body[key].(bool)
+	return b
+}
+
+func integer(body map[string]any, key string) int {
+	switch v := body[key].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// list возвращает элементы массива независимо от того, как его декодировал драйвер
+func list(v any) []any {
+	switch val := v.(type) {
+	case []any:
+		return val
+	case primitive.A:
+		return []any(val)
+	case []string:
+		out := make([]any, len(val))
+		for i, s := range val {
+			out[i] = s
+		}
+		return out
+	}
+	return nil
+}
+
+// fields возвращает вложенный объект как map
+func fields(v any) map[string]any {
+	switch val := v.(type) {
+	case map[string]any:
+		return val
+	case primitive.M:
+		return map[string]any(val)
+	case primitive.D:
+		return val.Map()
+	}
+	return nil
+}
+
+// headingLevel ограничивает уровень заголовка диапазоном 1-6
+func headingLevel(body map[string]any) int {
+	level := integer(body, "level")
+	if level < 1 {
+		return 1
+	}
+	if level > 6 {
+		return 6
+	}
+	return level
+}
+
+// measurementText - «величина: значение ± погрешность единица»
+func measurementText(body map[string]any) string {
+	var b strings.Builder
+	b.WriteString(str(body, "value"))
+	if _, ok := body["uncertainty"]; ok {
+		b.WriteString(" ± " + str(body, "uncertainty"))
+	}
+	if unit := str(body, "unit"); unit != "" {
+		b.WriteString(" " + unit)
+	}
+
+	var extra []string
+	if method := str(body, "method"); method != "" {
+		extra = append(extra, "метод: "+method)
+	}
+	if instrument := str(body, "instrument"); instrument != "" {
+		extra = append(extra, "прибор: "+instrument)
+	}
+	if len(extra) > 0 {
+		b.WriteString(" (" + strings.Join(extra, ", ") + ")")
+	}
+	return b.String()
+}
+
+// attachmentText - имя вложения с размером
+func attachmentText(body map[string]any) string {
+	name := str(body, "file_name")
+	if name == "" {
+		name = str(body, "object_key")
+	}
+	if size := integer(body, "size"); size > 0 {
+		return fmt.Sprintf("%s (%s)", name, humanSize(size))
+	}
+	return name
+}
+
+func humanSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f МБ", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f КБ", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d Б", n)
+}
+
+// rawBody - тело блока неизвестного типа в виде JSON
+func rawBody(body map[string]any) string {
+	data, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return fmt.Sprint(body)
+	}
+	return string(data)
+}
+
+// threadComment - комментарий с глубиной вложенности в ветке
+type threadComment struct {
+	journal.Comment
+	Depth int
+}
+
+// commentThreads раскладывает плоский список комментариев блока в порядке веток
+func commentThreads(comments []journal.Comment) []threadComment {
+	children := map[string][]journal.Comment{}
+	known := map[string]bool{}
+	for _, c := range comments {
+		known[c.Id] = true
+	}
+	var roots []journal.Comment
+	for _, c := range comments {
+		if c.ParentId == "" || !known[c.ParentId] {
+			roots = append(roots, c)
+			continue
+		}
+		children[c.ParentId] = append(children[c.ParentId], c)
+	}
+
+	var out []threadComment
+	var walk func(c journal.Comment, depth int)
+	walk = func(c journal.Comment, depth int) {
+		out = append(out, threadComment{Comment: c, Depth: depth})
+		for _, child := range children[c.Id] {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return out
+}
+
+// commentText - текст комментария для экспорта
+func commentText(c journal.Comment) string {
+	if c.Deleted {
+		return "(комментарий удалён)"
+	}
+	return c.Comment
+}
+
+// commentMeta - автор, дата и статус комментария
+func commentMeta(c journal.Comment) string {
+	meta := c.EmployeeId + ", " + formatTime(c.CreatedAt)
+	if c.Resolved {
+		meta += ", решено"
+	}
+	return meta
+}
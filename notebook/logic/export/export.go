@@ -0,0 +1,137 @@
+package exportLogic
+
+import (
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Форматы экспорта журнала
+const (
+	FormatPDF      = "pdf"
+	FormatHTML     = "html"
+	FormatMarkdown = "md"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Статусы подписи журнала в шапке экспорта
+const (
+	SignatureUnsigned  = "не подписан"
+	SignatureSigned    = "подписан автором"
+	SignatureWitnessed = "подписан автором и заверен свидетелем"
+)
+
+// SignatureLabel возвращает статус подписи журнала (journal.SignatureStatus*) для шапки экспорта
+func SignatureLabel(status string) string {
+	switch status {
+	case journal.SignatureStatusSigned:
+		return SignatureSigned
+	case journal.SignatureStatusWitnessed:
+		return SignatureWitnessed
+	}
+	return SignatureUnsigned
+}
+
+// Image - содержимое изображения из файлового хранилища
+type Image struct {
+	ContentType string
+	Data        []byte
+}
+
+// Document - журнал вместе со сведениями для шапки экспорта
+type Document struct {
+	Notebook        *journal.Notebook
+	Author          string
+	Department      string
+	Revision        int64
+	SignatureStatus string
+	IncludeComments bool
+	Images          map[string]Image // изображения блоков по object_key
+	GeneratedAt     time.Time
+}
+
+// File - результат экспорта
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Supported сообщает, поддерживается ли формат экспорта
+func Supported(format string) bool {
+	switch strings.ToLower(format) {
+	case FormatPDF, FormatHTML, FormatMarkdown, "markdown":
+		return true
+	}
+	return false
+}
+
+// Render формирует файл журнала в заданном формате
+func Render(format string, doc *Document) (*File, error) {
+	if doc == nil || doc.Notebook == nil {
+		return nil, errors.New("notebook is required")
+	}
+	if doc.SignatureStatus == "" {
+		doc.SignatureStatus = SignatureUnsigned
+	}
+	if doc.GeneratedAt.IsZero() {
+		doc.GeneratedAt = time.Now()
+	}
+
+	name := fileName(doc.Notebook)
+	switch strings.ToLower(format) {
+	case FormatPDF:
+		data, err := PDF(doc)
+		if err != nil {
+			return nil, err
+		}
+		return &File{Name: name + ".pdf", ContentType: "application/pdf", Data: data}, nil
+	case FormatHTML:
+		return &File{Name: name + ".html", ContentType: "text/html; charset=utf-8", Data: HTML(doc)}, nil
+	case FormatMarkdown, "markdown":
+		return &File{Name: name + ".md", ContentType: "text/markdown; charset=utf-8", Data: Markdown(doc)}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^\p{L}\p{N}._-]+`)
+
+// fileName строит имя файла из названия журнала и номера ревизии
+func fileName(n *journal.Notebook) string {
+	base := strings.Trim(unsafeFileChars.ReplaceAllString(n.Metadata.Title, "_"), "_")
+	if base == "" {
+		base = "notebook"
+	}
+	if len([]rune(base)) > 80 {
+		base = string([]rune(base)[:80])
+	}
+	return fmt.Sprintf("%s_r%d", base, n.Revision)
+}
+
+// headerFields - строки шапки документа в порядке вывода
+func headerFields(doc *Document) [][2]string {
+	m := doc.Notebook.Metadata
+	fields := [][2]string{
+		{"Автор", doc.Author},
+		{"Отдел", doc.Department},
+		{"Ревизия", fmt.Sprintf("%d", doc.Revision)},
+		{"Подпись", doc.SignatureStatus},
+		{"Создан", formatTime(m.Created.Date)},
+		{"Изменён", formatTime(m.LastUpdate.Date)},
+	}
+	if len(m.Tags) > 0 {
+		fields = append(fields, [2]string{"Теги", strings.Join(m.Tags, ", ")})
+	}
+	return fields
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02.01.2006 15:04")
+}
@@ -0,0 +1,207 @@
+package exportLogic_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	exportLogic "labyrinth/notebook/logic/export"
+	"labyrinth/notebook/models/journal"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testDocument(t *testing.T) *exportLogic.Document {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	img.Set(1, 1, color.RGBA{R: 255, A: 255})
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, img); err != nil {
+		t.Fatalf("png.Encode failed: %v", err)
+	}
+
+	n := journal.NewNotebook("author", "company", "division", "notebook-id", "Синтез <образца> 12", "Протокол")
+	n.Revision = 7
+	n.Blocks = []journal.Block{
+		journal.NewBlock("heading", map[string]any{"text": "Методика", "level": int32(1)}),
+		journal.NewBlock("text", map[string]any{"content": "Навеска <b>0,5 г</b> (сухая)"}),
+		journal.NewBlock("checklist", map[string]any{"items": primitive.A{
+			primitive.M{"text": "Взвесить", "checked": true},
+			primitive.M{"text": "Растворить", "checked": false},
+		}}),
+		journal.NewBlock("table", map[string]any{
+			"caption": "Результаты",
+			"columns": primitive.A{"Образец", "Масса, г"},
+			"rows":    primitive.A{primitive.A{"S-1", 0.51}, primitive.A{"S-2", nil}},
+		}),
+		journal.NewBlock("code", map[string]any{"code": "print('ok')", "language": "python"}),
+		journal.NewBlock("formula", map[string]any{"latex": `E = mc^2`, "display": true}),
+		journal.NewBlock("image", map[string]any{"object_key": "img/1.png", "caption": "Спектр"}),
+		journal.NewBlock("image", map[string]any{"object_key": "img/missing.png"}),
+		journal.NewBlock("measurement", map[string]any{"quantity": "Температура", "value": 25.5, "uncertainty": 0.1, "unit": "°C"}),
+		journal.NewBlock("chemical_structure", map[string]any{"smiles": "CCO", "name": "Этанол"}),
+	}
+	root := journal.NewComment("employee-1", "", "Проверьте навеску")
+	reply := journal.NewComment("employee-2", root.Id, "Проверено")
+	n.Blocks[1].Comment = []journal.Comment{root, reply}
+
+	return &exportLogic.Document{
+		Notebook:        &n,
+		Author:          "Иванова Анна",
+		Department:      "Синтез",
+		Revision:        7,
+		IncludeComments: true,
+		Images: map[string]exportLogic.Image{
+			"img/1.png": {ContentType: "image/png", Data: pngData.Bytes()},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Run("Markdown", func(t *testing.T) {
+		file, err := exportLogic.Render("md", testDocument(t))
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		out := string(file.Data)
+
+		for _, want := range []string{
+			"# Синтез &lt;образца&gt; 12",
+			"| **Автор** | Иванова Анна |",
+			"| **Подпись** | не подписан |",
+			"## Методика",
+			"- [x] Взвесить",
+			"| S-1 | 0.51 |",
+			"```python\nprint('ok')\n```",
+			"$$\nE = mc^2\n$$",
+			"](data:image/png;base64,",
+			"](img/missing.png)",
+			"**Температура:** 25.5 ± 0.1 °C",
+			"> > **employee-2",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Expected markdown to contain %q", want)
+			}
+		}
+		if file.Name != "Синтез_образца_12_r7.md" {
+			t.Errorf("Unexpected file name %q", file.Name)
+		}
+	})
+
+	t.Run("HTMLEscapesContent", func(t *testing.T) {
+		file, err := exportLogic.Render("html", testDocument(t))
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		out := string(file.Data)
+
+		if strings.Contains(out, "<b>0,5") {
+			t.Errorf("Expected user markup to be escaped")
+		}
+		for _, want := range []string{"&lt;b&gt;0,5 г&lt;/b&gt;", "<img src=\"data:image/png;base64,", "изображение недоступно", "<td>S-1</td>"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Expected html to contain %q", want)
+			}
+		}
+	})
+
+	t.Run("HTMLWithoutComments", func(t *testing.T) {
+		doc := testDocument(t)
+		doc.IncludeComments = false
+		file, err := exportLogic.Render("html", doc)
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		if strings.Contains(string(file.Data), "Проверьте навеску") {
+			t.Errorf("Expected comments to be omitted")
+		}
+	})
+
+	t.Run("PDFStructure", func(t *testing.T) {
+		file, err := exportLogic.Render("pdf", testDocument(t))
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		if err := checkPDF(file.Data); err != nil {
+			t.Fatalf("Invalid PDF: %v", err)
+		}
+		if !bytes.Contains(file.Data, []byte("/Subtype /Image /Width 4 /Height 3")) {
+			t.Errorf("Expected embedded image")
+		}
+
+		text, err := pageText(file.Data)
+		if err != nil {
+			t.Fatalf("Failed to read page content: %v", err)
+		}
+		// «Методика» в кодировке документа: Windows-1251 с экранированием байтов
+		if !strings.Contains(text, `\314\345\362\356\344\350\352\340`) {
+			t.Errorf("Expected cyrillic heading in page content")
+		}
+		if !strings.Contains(text, "| S-1     | 0.51     |") {
+			t.Errorf("Expected table grid in page content")
+		}
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		_, err := exportLogic.Render("docx", testDocument(t))
+		if !errors.Is(err, exportLogic.ErrUnknownFormat) {
+			t.Errorf("Expected ErrUnknownFormat, got %v", err)
+		}
+	})
+}
+
+// checkPDF проверяет заголовок, трейлер и то, что каждая запись xref указывает на свой объект
+func checkPDF(data []byte) error {
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		return errors.New("missing header or trailer")
+	}
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		return errors.New("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		return fmt.Errorf("startxref %d does not point to xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	if len(entries) == 0 {
+		return errors.New("empty xref table")
+	}
+	for i, e := range entries {
+		offset, _ := strconv.Atoi(string(e[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(data[offset:], []byte(want)) {
+			return fmt.Errorf("xref entry %d points to %q", i+1, data[offset:offset+10])
+		}
+	}
+	return nil
+}
+
+// pageText распаковывает потоки страниц
+func pageText(data []byte) (string, error) {
+	var out strings.Builder
+	re := regexp.MustCompile(`(?s)<< /Filter /FlateDecode /Length (\d+) >>\nstream\n`)
+	for _, loc := range re.FindAllSubmatchIndex(data, -1) {
+		length, _ := strconv.Atoi(string(data[loc[2]:loc[3]]))
+		zr, err := zlib.NewReader(bytes.NewReader(data[loc[1] : loc[1]+length]))
+		if err != nil {
+			return "", err
+		}
+		content, err := io.ReadAll(zr)
+		if err != nil {
+			return "", err
+		}
+		out.Write(content)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,149 @@
+package exportLogic
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"labyrinth/notebook/models/journal"
+	"strings"
+)
+
+const htmlStyle = `body{font-family:-apple-system,"Segoe UI",Roboto,Arial,sans-serif;max-width:860px;margin:2em auto;padding:0 1em;color:#222;line-height:1.5}
+header{border-bottom:2px solid #444;margin-bottom:1.5em}
+table{border-collapse:collapse;margin:1em 0}
+td,th{border:1px solid #bbb;padding:.3em .6em;text-align:left;vertical-align:top}
+table.meta td,table.meta th{border:none;padding:.1em .8em .1em 0}
+pre{background:#f5f5f5;padding:.8em;overflow:auto}
+figure{margin:1em 0}figure img{max-width:100%}
+.formula{font-family:"Latin Modern Math","Cambria Math",serif}
+.comments{border-left:3px solid #ddd;margin:.5em 0 1.5em;padding-left:1em;font-size:.9em;color:#555}
+.comment .meta{font-weight:bold}
+.text{white-space:pre-wrap}
+@media print{body{margin:0;max-width:none}}`
+
+// HTML формирует самостоятельный HTML-документ; изображения встраиваются как data URI
+func HTML(doc *Document) []byte {
+	var b bytes.Buffer
+	n := doc.Notebook
+	e := html.EscapeString
+
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"ru\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n<header>\n", e(n.Metadata.Title), htmlStyle)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", e(n.Metadata.Title))
+	if n.Metadata.Description != "" {
+		fmt.Fprintf(&b, "<p>%s</p>\n", e(n.Metadata.Description))
+	}
+	b.WriteString("<table class=\"meta\">\n")
+	for _, f := range headerFields(doc) {
+		fmt.Fprintf(&b, "<tr><th>%s</th><td>%s</td></tr>\n", e(f[0]), e(f[1]))
+	}
+	b.WriteString("</table>\n</header>\n<main>\n")
+
+	for _, block := range n.Blocks {
+		fmt.Fprintf(&b, "<section class=\"block block-%s\" id=\"block-%s\">\n", e(block.Type), e(block.Id))
+		writeHTMLBlock(&b, doc, block)
+		if doc.IncludeComments && len(block.Comment) > 0 {
+			b.WriteString("<div class=\"comments\">\n")
+			for _, c := range commentThreads(block.Comment) {
+				fmt.Fprintf(&b, "<div class=\"comment\" style=\"margin-left:%dem\"><span class=\"meta\">%s:</span> %s</div>\n",
+					c.Depth*2, e(commentMeta(c.Comment)), e(commentText(c.Comment)))
+			}
+			b.WriteString("</div>\n")
+		}
+		b.WriteString("</section>\n")
+	}
+
+	fmt.Fprintf(&b, "</main>\n<footer><p><small>Экспортировано %s</small></p></footer>\n</body>\n</html>\n", e(formatTime(doc.GeneratedAt)))
+	return b.Bytes()
+}
+
+func writeHTMLBlock(b *bytes.Buffer, doc *Document, block journal.Block) {
+	e := html.EscapeString
+	body := block.Body
+	switch block.Type {
+	case blocksLogic.TypeHeading:
+		level := headingLevel(body) + 1
+		if level > 6 {
+			level = 6
+		}
+		fmt.Fprintf(b, "<h%d>%s</h%d>\n", level, e(str(body, "text")), level)
+	case blocksLogic.TypeText:
+		// Разметка пользователя не исполняется: экспорт должен быть безопасен при открытии
+		fmt.Fprintf(b, "<p class=\"text\">%s</p>\n", e(str(body, "content")))
+	case blocksLogic.TypeChecklist:
+		if title := str(body, "title"); title != "" {
+			fmt.Fprintf(b, "<p><strong>%s</strong></p>\n", e(title))
+		}
+		b.WriteString("<ul>\n")
+		for _, raw := range list(body["items"]) {
+			item := fields(raw)
+			mark := "&#9744;"
+			if flag(item, "checked") {
+				mark = "&#9745;"
+			}
+			fmt.Fprintf(b, "<li>%s %s</li>\n", mark, e(str(item, "text")))
+		}
+		b.WriteString("</ul>\n")
+	case blocksLogic.TypeTable:
+		b.WriteString("<table>\n")
+		if caption := str(body, "caption"); caption != "" {
+			fmt.Fprintf(b, "<caption>%s</caption>\n", e(caption))
+		}
+		columns := list(body["columns"])
+		b.WriteString("<thead><tr>")
+		for _, c := range columns {
+			fmt.Fprintf(b, "<th>%s</th>", e(scalar(c)))
+		}
+		b.WriteString("</tr></thead>\n<tbody>\n")
+		for _, row := range list(body["rows"]) {
+			b.WriteString("<tr>")
+			for _, cell := range list(row) {
+				fmt.Fprintf(b, "<td>%s</td>", e(scalar(cell)))
+			}
+			b.WriteString("</tr>\n")
+		}
+		b.WriteString("</tbody>\n</table>\n")
+	case blocksLogic.TypeCode:
+		class := ""
+		if lang := str(body, "language"); lang != "" {
+			class = fmt.Sprintf(" class=\"language-%s\"", e(lang))
+		}
+		fmt.Fprintf(b, "<pre><code%s>%s</code></pre>\n", class, e(str(body, "code")))
+	case blocksLogic.TypeFormula:
+		if flag(body, "display") {
+			fmt.Fprintf(b, "<div class=\"formula\">\\[%s\\]</div>\n", e(str(body, "latex")))
+		} else {
+			fmt.Fprintf(b, "<p class=\"formula\">\\(%s\\)</p>\n", e(str(body, "latex")))
+		}
+	case blocksLogic.TypeImage:
+		caption := str(body, "caption")
+		b.WriteString("<figure>\n")
+		if img, ok := doc.Images[str(body, "object_key")]; ok {
+			fmt.Fprintf(b, "<img src=\"data:%s;base64,%s\" alt=\"%s\">\n", e(img.ContentType), base64.StdEncoding.EncodeToString(img.Data), e(caption))
+		} else {
+			fmt.Fprintf(b, "<p>[изображение недоступно: %s]</p>\n", e(str(body, "object_key")))
+		}
+		if caption != "" {
+			fmt.Fprintf(b, "<figcaption>%s</figcaption>\n", e(caption))
+		}
+		b.WriteString("</figure>\n")
+	case blocksLogic.TypeAttachment:
+		fmt.Fprintf(b, "<p>Вложение: <code>%s</code></p>\n", e(attachmentText(body)))
+	case blocksLogic.TypeMeasurement:
+		fmt.Fprintf(b, "<p><strong>%s:</strong> %s</p>\n", e(str(body, "quantity")), e(measurementText(body)))
+	case blocksLogic.TypeChemical:
+		var parts []string
+		if name := str(body, "name"); name != "" {
+			parts = append(parts, "<strong>"+e(name)+"</strong>")
+		}
+		parts = append(parts, "<code>"+e(str(body, "smiles"))+"</code>")
+		if formula := str(body, "formula"); formula != "" {
+			parts = append(parts, "("+e(formula)+")")
+		}
+		fmt.Fprintf(b, "<p>%s</p>\n", strings.Join(parts, " "))
+	default:
+		fmt.Fprintf(b, "<pre>%s</pre>\n", e(rawBody(body)))
+	}
+}
@@ -0,0 +1,140 @@
+package exportLogic
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"labyrinth/notebook/models/journal"
+	"strings"
+)
+
+// Markdown формирует журнал в Markdown; изображения встраиваются как data URI
+func Markdown(doc *Document) []byte {
+	var b bytes.Buffer
+	n := doc.Notebook
+
+	fmt.Fprintf(&b, "# %s\n\n", mdInline(n.Metadata.Title))
+	if n.Metadata.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", n.Metadata.Description)
+	}
+	b.WriteString("| | |\n|---|---|\n")
+	for _, f := range headerFields(doc) {
+		fmt.Fprintf(&b, "| **%s** | %s |\n", f[0], mdCell(f[1]))
+	}
+	b.WriteString("\n---\n\n")
+
+	for _, block := range n.Blocks {
+		writeMarkdownBlock(&b, doc, block)
+		if doc.IncludeComments && len(block.Comment) > 0 {
+			for _, c := range commentThreads(block.Comment) {
+				fmt.Fprintf(&b, "%s> **%s:** %s\n", strings.Repeat("> ", c.Depth), mdInline(commentMeta(c.Comment)), mdInline(commentText(c.Comment)))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "---\n\n_Экспортировано %s_\n", formatTime(doc.GeneratedAt))
+	return b.Bytes()
+}
+
+func writeMarkdownBlock(b *bytes.Buffer, doc *Document, block journal.Block) {
+	body := block.Body
+	switch block.Type {
+	case blocksLogic.TypeHeading:
+		// заголовки блоков на уровень ниже заголовка журнала
+		level := headingLevel(body) + 1
+		if level > 6 {
+			level = 6
+		}
+		fmt.Fprintf(b, "%s %s\n\n", strings.Repeat("#", level), mdInline(str(body, "text")))
+	case blocksLogic.TypeText:
+		fmt.Fprintf(b, "%s\n\n", str(body, "content"))
+	case blocksLogic.TypeChecklist:
+		if title := str(body, "title"); title != "" {
+			fmt.Fprintf(b, "**%s**\n\n", mdInline(title))
+		}
+		for _, raw := range list(body["items"]) {
+			item := fields(raw)
+			mark := " "
+			if flag(item, "checked") {
+				mark = "x"
+			}
+			fmt.Fprintf(b, "- [%s] %s\n", mark, mdInline(str(item, "text")))
+		}
+		b.WriteString("\n")
+	case blocksLogic.TypeTable:
+		if caption := str(body, "caption"); caption != "" {
+			fmt.Fprintf(b, "_%s_\n\n", mdInline(caption))
+		}
+		columns := list(body["columns"])
+		b.WriteString("|")
+		for _, c := range columns {
+			b.WriteString(" " + mdCell(scalar(c)) + " |")
+		}
+		b.WriteString("\n|" + strings.Repeat("---|", len(columns)) + "\n")
+		for _, row := range list(body["rows"]) {
+			b.WriteString("|")
+			cells := list(row)
+			for i := range columns {
+				var cell string
+				if i < len(cells) {
+					cell = scalar(cells[i])
+				}
+				b.WriteString(" " + mdCell(cell) + " |")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	case blocksLogic.TypeCode:
+		code := str(body, "code")
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		fmt.Fprintf(b, "%s%s\n%s\n%s\n\n", fence, str(body, "language"), code, fence)
+	case blocksLogic.TypeFormula:
+		if flag(body, "display") {
+			fmt.Fprintf(b, "$$\n%s\n$$\n\n", str(body, "latex"))
+		} else {
+			fmt.Fprintf(b, "$%s$\n\n", str(body, "latex"))
+		}
+	case blocksLogic.TypeImage:
+		caption := str(body, "caption")
+		src := str(body, "object_key")
+		if img, ok := doc.Images[src]; ok {
+			src = "data:" + img.ContentType + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+		}
+		fmt.Fprintf(b, "![%s](%s)\n\n", mdInline(caption), src)
+		if caption != "" {
+			fmt.Fprintf(b, "_%s_\n\n", mdInline(caption))
+		}
+	case blocksLogic.TypeAttachment:
+		fmt.Fprintf(b, "Вложение: `%s`\n\n", attachmentText(body))
+	case blocksLogic.TypeMeasurement:
+		fmt.Fprintf(b, "**%s:** %s\n\n", mdInline(str(body, "quantity")), mdInline(measurementText(body)))
+	case blocksLogic.TypeChemical:
+		if name := str(body, "name"); name != "" {
+			fmt.Fprintf(b, "**%s** ", mdInline(name))
+		}
+		fmt.Fprintf(b, "`%s`", str(body, "smiles"))
+		if formula := str(body, "formula"); formula != "" {
+			fmt.Fprintf(b, " (%s)", mdInline(formula))
+		}
+		b.WriteString("\n\n")
+	default:
+		fmt.Fprintf(b, "```json\n%s\n```\n\n", rawBody(body))
+	}
+}
+
+var mdEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;")
+
+// mdInline экранирует разметку Markdown в строке
+func mdInline(s string) string {
+	return mdEscaper.Replace(strings.ReplaceAll(s, "\n", " "))
+}
+
+// mdCell экранирует значение ячейки таблицы
+func mdCell(s string) string {
+	return strings.ReplaceAll(mdInline(s), "|", `\|`)
+}
@@ -0,0 +1,168 @@
+package exportLogic
+
+import (
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"labyrinth/notebook/models/journal"
+	"strings"
+)
+
+var headingSizes = []float64{16, 14, 13, 12, 11, 11}
+
+// PDF формирует журнал в PDF без внешних зависимостей.
+// Используются стандартные шрифты PDF с кириллицей через собственную кодировку.
+func PDF(doc *Document) ([]byte, error) {
+	w := newPDFWriter()
+	n := doc.Notebook
+
+	w.text(n.Metadata.Title, fontBold, 18, 0)
+	if n.Metadata.Description != "" {
+		w.space(2)
+		w.text(n.Metadata.Description, fontRegular, 11, 0)
+	}
+	w.space(6)
+	for _, f := range headerFields(doc) {
+		w.text(f[0]+": "+f[1], fontRegular, 10, 0)
+	}
+	w.rule()
+
+	for _, block := range n.Blocks {
+		writePDFBlock(w, doc, block)
+		if doc.IncludeComments && len(block.Comment) > 0 {
+			w.space(2)
+			for _, c := range commentThreads(block.Comment) {
+				w.text(commentMeta(c.Comment)+": "+commentText(c.Comment), fontOblique, 9, 15+float64(c.Depth)*12)
+			}
+		}
+		w.space(8)
+	}
+
+	w.footer(n.Metadata.Title + ", ревизия " + scalar(doc.Revision))
+	return w.render(n.Metadata.Title)
+}
+
+func writePDFBlock(w *pdfWriter, doc *Document, block journal.Block) {
+	body := block.Body
+	switch block.Type {
+	case blocksLogic.TypeHeading:
+		w.space(4)
+		w.text(str(body, "text"), fontBold, headingSizes[headingLevel(body)-1], 0)
+	case blocksLogic.TypeText:
+		w.text(str(body, "content"), fontRegular, 11, 0)
+	case blocksLogic.TypeChecklist:
+		if title := str(body, "title"); title != "" {
+			w.text(title, fontBold, 11, 0)
+		}
+		for _, raw := range list(body["items"]) {
+			item := fields(raw)
+			mark := "[  ] "
+			if flag(item, "checked") {
+				mark = "[x] "
+			}
+			w.text(mark+str(item, "text"), fontRegular, 11, 10)
+		}
+	case blocksLogic.TypeTable:
+		if caption := str(body, "caption"); caption != "" {
+			w.text(caption, fontOblique, 10, 0)
+		}
+		writePDFTable(w, body)
+	case blocksLogic.TypeCode:
+		if lang := str(body, "language"); lang != "" {
+			w.text(lang, fontOblique, 8, 10)
+		}
+		w.text(str(body, "code"), fontMono, 9, 10)
+	case blocksLogic.TypeFormula:
+		indent := 10.0
+		if flag(body, "display") {
+			indent = 40
+		}
+		w.text(str(body, "latex"), fontMono, 10, indent)
+	case blocksLogic.TypeImage:
+		key := str(body, "object_key")
+		img, ok := doc.Images[key]
+		if !ok || w.image(img.Data, 0) != nil {
+			w.text("[изображение недоступно: "+key+"]", fontOblique, 10, 0)
+		}
+		if caption := str(body, "caption"); caption != "" {
+			w.space(2)
+			w.text(caption, fontOblique, 9, 0)
+		}
+	case blocksLogic.TypeAttachment:
+		w.text("Вложение: "+attachmentText(body), fontRegular, 10, 0)
+	case blocksLogic.TypeMeasurement:
+		w.text(str(body, "quantity")+": "+measurementText(body), fontRegular, 11, 0)
+	case blocksLogic.TypeChemical:
+		line := str(body, "smiles")
+		if name := str(body, "name"); name != "" {
+			line = name + ": " + line
+		}
+		if formula := str(body, "formula"); formula != "" {
+			line += " (" + formula + ")"
+		}
+		w.text(line, fontMono, 10, 0)
+	default:
+		w.text(rawBody(body), fontMono, 8, 0)
+	}
+}
+
+// writePDFTable выводит таблицу моноширинной сеткой; если сетка не помещается по ширине,
+// строки выводятся списком «столбец: значение», чтобы не обрезать данные
+func writePDFTable(w *pdfWriter, body map[string]any) {
+	const size = 9.0
+	columns := list(body["columns"])
+	rows := list(body["rows"])
+
+	header := make([]string, len(columns))
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		header[i] = scalar(c)
+		widths[i] = len([]rune(header[i]))
+	}
+	cells := make([][]string, len(rows))
+	for r, row := range rows {
+		values := list(row)
+		cells[r] = make([]string, len(columns))
+		for i := range columns {
+			if i < len(values) {
+				cells[r][i] = strings.ReplaceAll(scalar(values[i]), "\n", " ")
+			}
+			if l := len([]rune(cells[r][i])); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+
+	total := 1
+	for _, width := range widths {
+		total += width + 3
+	}
+	if total > int(contentWidth/(fontWidths[fontMono]*size)) {
+		for r := range cells {
+			for i := range columns {
+				w.text(header[i]+": "+cells[r][i], fontRegular, 10, 10)
+			}
+			w.space(4)
+		}
+		return
+	}
+
+	line := func(values []string) string {
+		var b strings.Builder
+		b.WriteString("|")
+		for i, v := range values {
+			b.WriteString(" " + v + strings.Repeat(" ", widths[i]-len([]rune(v))) + " |")
+		}
+		return b.String()
+	}
+	separator := "+"
+	for _, width := range widths {
+		separator += strings.Repeat("-", width+2) + "+"
+	}
+
+	w.text(separator, fontMono, size, 0)
+	w.text(line(header), fontMono, size, 0)
+	w.text(separator, fontMono, size, 0)
+	for _, row := range cells {
+		w.text(line(row), fontMono, size, 0)
+	}
+	w.text(separator, fontMono, size, 0)
+}
@@ -0,0 +1,378 @@
+package exportLogic
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+	"unicode/utf16"
+)
+
+// Размеры страницы A4 в пунктах
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	pageMargin   = 50.0
+	contentWidth = pageWidth - 2*pageMargin
+	maxImageSide = 400.0
+)
+
+// pdfFont - один из стандартных шрифтов PDF, которые не нужно встраивать
+type pdfFont int
+
+const (
+	fontRegular pdfFont = iota
+	fontBold
+	fontOblique
+	fontMono
+)
+
+var fontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Courier"}
+
+// Средняя ширина символа в долях кегля; для переноса строк точной метрики не требуется
+var fontWidths = []float64{0.52, 0.58, 0.52, 0.6}
+
+type pdfImage struct {
+	width, height int
+	data          []byte // RGB, сжатый zlib
+}
+
+// pdfWriter - минимальный генератор PDF: текст стандартными шрифтами, линии и растровые изображения
+type pdfWriter struct {
+	pages  []*bytes.Buffer
+	images []pdfImage
+	y      float64
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.newPage()
+	return w
+}
+
+func (w *pdfWriter) newPage() {
+	w.pages = append(w.pages, &bytes.Buffer{})
+	w.y = pageHeight - pageMargin
+}
+
+func (w *pdfWriter) page() *bytes.Buffer {
+	return w.pages[len(w.pages)-1]
+}
+
+// ensure начинает новую страницу, если на текущей не хватает места
+func (w *pdfWriter) ensure(height float64) {
+	if w.y-height < pageMargin {
+		w.newPage()
+	}
+}
+
+func (w *pdfWriter) space(height float64) {
+	w.y -= height
+	if w.y < pageMargin {
+		w.newPage()
+	}
+}
+
+// text выводит текст с переносом по словам; моноширинный текст переносится посимвольно с сохранением отступов
+func (w *pdfWriter) text(s string, font pdfFont, size, indent float64) {
+	lineHeight := size * 1.4
+	limit := int((contentWidth - indent) / (fontWidths[font] * size))
+	if limit < 1 {
+		limit = 1
+	}
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(s, "\r", ""), "\n") {
+		var lines []string
+		if font == fontMono {
+			lines = hardWrap(strings.ReplaceAll(paragraph, "\t", "    "), limit)
+		} else {
+			lines = wordWrap(paragraph, limit)
+		}
+		for _, line := range lines {
+			w.ensure(lineHeight)
+			w.y -= lineHeight
+			fmt.Fprintf(w.page(), "BT /F%d %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+				font+1, size, pageMargin+indent, w.y+lineHeight*0.25, pdfString(line))
+		}
+	}
+}
+
+// rule рисует горизонтальную линию во всю ширину текста
+func (w *pdfWriter) rule() {
+	w.space(6)
+	fmt.Fprintf(w.page(), "0.6 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", pageMargin, w.y, pageWidth-pageMargin, w.y)
+	w.space(8)
+}
+
+// image размещает изображение, уменьшая его до ширины текста и maxImageSide по высоте
+func (w *pdfWriter) image(data []byte, indent float64) error {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	rgb := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// прозрачные пиксели смешиваются с белым фоном страницы
+			white := 0xffff - a
+			rgb = append(rgb, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(rgb); err != nil {
+		return fmt.Errorf("failed to compress image: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress image: %w", err)
+	}
+
+	index := len(w.images)
+	w.images = append(w.images, pdfImage{width: bounds.Dx(), height: bounds.Dy(), data: compressed.Bytes()})
+
+	width, height := float64(bounds.Dx())*0.75, float64(bounds.Dy())*0.75
+	scale := 1.0
+	if maxWidth := contentWidth - indent; width > maxWidth {
+		scale = maxWidth / width
+	}
+	if height*scale > maxImageSide {
+		scale = maxImageSide / height
+	}
+	width, height = width*scale, height*scale
+
+	w.ensure(height)
+	w.y -= height
+	fmt.Fprintf(w.page(), "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", width, height, pageMargin+indent, w.y, index)
+	return nil
+}
+
+// footer выводит номера страниц
+func (w *pdfWriter) footer(label string) {
+	total := len(w.pages)
+	for i, p := range w.pages {
+		fmt.Fprintf(p, "BT /F%d 8 Tf %.2f %.2f Td (%s) Tj ET\n",
+			fontRegular+1, pageMargin, pageMargin/2, pdfString(fmt.Sprintf("%s - стр. %d из %d", label, i+1, total)))
+	}
+}
+
+// render собирает PDF: каталог, дерево страниц, общие ресурсы, шрифты, изображения и страницы
+func (w *pdfWriter) render(title string) ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	stream := func(dict string, data []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(offsets), dict, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	const (
+		catalogObj   = 1
+		pagesObj     = 2
+		resourcesObj = 3
+		encodingObj  = 4
+		infoObj      = 5
+		firstFontObj = 6
+	)
+	firstImageObj := firstFontObj + len(fontNames)
+	firstPageObj := firstImageObj + len(w.images)
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	obj(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+i*2)
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+
+	var fonts, xobjects strings.Builder
+	for i := range fontNames {
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, firstFontObj+i)
+	}
+	for i := range w.images {
+		fmt.Fprintf(&xobjects, "/Im%d %d 0 R ", i, firstImageObj+i)
+	}
+	obj(fmt.Sprintf("<< /Font << %s>> /XObject << %s>> >>", fonts.String(), xobjects.String()))
+
+	obj("<< /Type /Encoding /BaseEncoding /WinAnsiEncoding /Differences [" + cyrillicDifferences() + "] >>")
+	obj(fmt.Sprintf("<< /Title %s /Producer (labyrinth) >>", pdfTextString(title)))
+
+	for _, name := range fontNames {
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding %d 0 R >>", name, encodingObj))
+	}
+
+	for _, img := range w.images {
+		stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode",
+			img.width, img.height), img.data)
+	}
+
+	for i, p := range w.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources %d 0 R /Contents %d 0 R >>",
+			pagesObj, pageWidth, pageHeight, resourcesObj, firstPageObj+i*2+1))
+
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		if _, err := zw.Write(p.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page: %w", err)
+		}
+		stream("/Filter /FlateDecode", content.Bytes())
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, catalogObj, infoObj, xref)
+
+	return out.Bytes(), nil
+}
+
+// cyrillicDifferences переназначает коды 0xC0-0xFF, 0xA8 и 0xB8 на кириллицу как в Windows-1251
+func cyrillicDifferences() string {
+	var b strings.Builder
+	b.WriteString("168 /afii10023 184 /afii10071 192")
+	for i := 0; i < 32; i++ {
+		glyph := 10017 + i
+		if i >= 6 {
+			glyph++ // afii10023 - буква Ё, она стоит вне алфавитного ряда
+		}
+		fmt.Fprintf(&b, " /afii%d", glyph)
+	}
+	for i := 0; i < 32; i++ {
+		glyph := 10065 + i
+		if i >= 6 {
+			glyph++
+		}
+		fmt.Fprintf(&b, " /afii%d", glyph)
+	}
+	return b.String()
+}
+
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‰': 0x89, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encodeRune переводит символ в однобайтовую кодировку шрифтов документа
+func encodeRune(r rune) (byte, bool) {
+	switch {
+	case r == '\t':
+		return ' ', true
+	case r >= 0x20 && r < 0x7f:
+		return byte(r), true
+	case r >= 'А' && r <= 'я':
+		return byte(0xC0 + r - 'А'), true
+	case r == 'Ё':
+		return 0xA8, true
+	case r == 'ё':
+		return 0xB8, true
+	case r >= 0xA0 && r < 0xC0 && r != 0xA8 && r != 0xB8:
+		return byte(r), true
+	}
+	b, ok := winAnsiExtra[r]
+	return b, ok
+}
+
+// pdfString кодирует и экранирует строку для оператора Tj
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		c, ok := encodeRune(r)
+		if !ok {
+			if r < 0x20 {
+				continue
+			}
+			c = '?'
+		}
+		switch {
+		case c == '\\' || c == '(' || c == ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c >= 0x80:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// pdfTextString - строка метаданных в UTF-16BE
+func pdfTextString(s string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// wordWrap разбивает абзац на строки не длиннее limit символов
+func wordWrap(s string, limit int) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var line []rune
+	for _, word := range words {
+		runes := []rune(word)
+		for len(runes) > limit {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = nil
+			}
+			lines = append(lines, string(runes[:limit]))
+			runes = runes[limit:]
+		}
+		switch {
+		case len(line) == 0:
+			line = runes
+		case len(line)+1+len(runes) <= limit:
+			line = append(append(line, ' '), runes...)
+		default:
+			lines = append(lines, string(line))
+			line = runes
+		}
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// hardWrap режет строку на куски по limit символов, не трогая пробелы
+func hardWrap(s string, limit int) []string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	for len(runes) > limit {
+		lines = append(lines, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	return append(lines, string(runes))
+}
@@ -0,0 +1,64 @@
+package folderLogic
+
+import (
+	"context"
+	"fmt"
+	"labyrinth/config"
+	"labyrinth/database/minio"
+	"labyrinth/logger"
+
+	minioClient "github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+)
+
+// copyAttachments копирует вложения журналов в хранилище по соответствию старых ключей новым.
+// При ошибке уже скопированные объекты удаляются; при успехе возвращаются новые ключи для отката.
+func copyAttachments(ctx context.Context, operation string, keys map[string]string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	client, err := minio.NewConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to file storage: %w", err)
+	}
+	storage := minio.NewMinioDB(client)
+
+	copied := make([]string, 0, len(keys))
+	for src, dst := range keys {
+		if err := storage.File.CopyFile(ctx, config.Conf.Minio.Bucket, src, dst); err != nil {
+			removeAttachments(ctx, operation, copied)
+			return nil, fmt.Errorf("failed to copy attachment %s: %w", src, err)
+		}
+		copied = append(copied, dst)
+	}
+
+	return copied, nil
+}
+
+// removeAttachments удаляет скопированные вложения, если копию не удалось сохранить
+func removeAttachments(ctx context.Context, operation string, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	client, err := minio.NewConnection()
+	if err != nil {
+		logger.NewWarnMessage("MinIO connection failed",
+			zap.Error(err),
+			zap.String("operation", operation),
+		)
+		return
+	}
+	storage := minio.NewMinioDB(client)
+
+	for _, key := range keys {
+		if err := storage.File.DeleteFile(ctx, config.Conf.Minio.Bucket, key, minioClient.RemoveObjectOptions{}); err != nil {
+			logger.NewWarnMessage("Failed to remove copied attachment",
+				zap.Error(err),
+				zap.String("operation", operation),
+				zap.String("object_key", key),
+			)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package folderLogic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// copySuffix добавляется к названию дубликата, оставленного рядом с оригиналом
+const copySuffix = " (копия)"
+
+// cloneNotebook собирает копию журнала с новым ID в отделе divisionId.
+// Копируются метаданные и блоки; комментарии, подписи и статус не переносятся - копия начинается черновиком.
+// Вложения блоков получают новые ключи в хранилище; возвращается соответствие старых ключей новым.
+func cloneNotebook(src *journal.Notebook, newId, employeeId, divisionId, title string) (journal.Notebook, map[string]string) {
+	if strings.TrimSpace(title) == "" {
+		title = src.Metadata.Title
+	}
+
+	nb := journal.NewNotebook(employeeId, src.Metadata.CompanyID, divisionId, newId, title, src.Metadata.Description)
+	if len(src.Metadata.Tags) > 0 {
+		nb.Metadata.Tags = slices.Clone(src.Metadata.Tags)
+	}
+
+	keys := make(map[string]string)
+	for _, b := range src.Blocks {
+		body := maps.Clone(b.Body)
+		if key, _ := body["object_key"].(string); key != "" {
+			newKey, ok := keys[key]
+			if !ok {
+				newKey = fmt.Sprintf("notebooks/%s/%s%s", newId, uuid.New(), path.Ext(key))
+				keys[key] = newKey
+			}
+			body["object_key"] = newKey
+		}
+		nb.Blocks = append(nb.Blocks, journal.NewBlock(b.Type, body))
+	}
+
+	return nb, keys
+}
+
+// clonePermission копирует правила доступа src для нового ресурса; src может быть nil.
+// Копирующий сотрудник всегда получает полный доступ к копии.
+func clonePermission(src *permission.Permission, employeeId, newId, resourceType string, resourceId primitive.ObjectID) permission.Permission {
+	perm := permission.NewPermission(employeeId, newId, newId, resourceType, resourceId)
+	if src == nil {
+		return perm
+	}
+
+	perm.Rules = permission.PermissionRules{
+		AccessAllowed: slices.Clone(src.Rules.AccessAllowed),
+		CommentOnly:   slices.Clone(src.Rules.CommentOnly),
+		ReadOnly:      slices.Clone(src.Rules.ReadOnly),
+		AccessLevel:   src.Rules.AccessLevel,
+	}
+	perm.Inherit = src.Inherit
+	if perm.Rules.AccessAllowed == nil {
+		perm.Rules.AccessAllowed = []string{}
+	}
+	if perm.Rules.CommentOnly == nil {
+		perm.Rules.CommentOnly = []string{}
+	}
+	if perm.Rules.ReadOnly == nil {
+		perm.Rules.ReadOnly = []string{}
+	}
+	if !slices.Contains(perm.Rules.AccessAllowed, employeeId) {
+		perm.Rules.AccessAllowed = append(perm.Rules.AccessAllowed, employeeId)
+	}
+
+	return perm
+}
+
+// duplicateTitle возвращает название дубликата, если новое не задано
+func duplicateTitle(title, original string) string {
+	if strings.TrimSpace(title) != "" {
+		return strings.TrimSpace(title)
+	}
+	return original + copySuffix
+}
+
+// notebookCopy - копия журнала с правами доступа, готовая к сохранению
+type notebookCopy struct {
+	id         uuid.UUID
+	notebook   journal.Notebook
+	permission permission.Permission
+}
+
+// prepareNotebookCopy резервирует ID и собирает копию журнала src с его правами доступа.
+// employeeId - сотрудник, автор копии: права проверяются и выдаются по нему.
+// Копировать можно только журнал, который сотрудник может читать; ключи вложений копии добавляются в keys.
+func prepareNotebookCopy(
+	ctx context.Context,
+	tx *sql.Tx,
+	md *m.MongoDB,
+	session *mongo.Session,
+	src *journal.Notebook,
+	employeeId, divisionId, title string,
+	keys map[string]string,
+) (*notebookCopy, error) {
+	chain, err := permissionLogic.LoadChain(ctx, md, session, src.UuidID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notebook permission: %w", err)
+	}
+	perm := &chain[0]
+	if permissionLogic.EffectiveAccess(chain, employeeId) < permission.AccessRead {
+		return nil, permission.ErrForbidden
+	}
+
+	newId, err := postgres.NewPostgresDB().UuidValidation.CheckAndReserveUUID(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("uuid generation failed: %w", err)
+	}
+
+	nb, attachments := cloneNotebook(src, newId.String(), employeeId, divisionId, title)
+	maps.Copy(keys, attachments)
+
+	return &notebookCopy{
+		id:         newId,
+		notebook:   nb,
+		permission: clonePermission(perm, employeeId, nb.UuidID, "file", nb.ID),
+	}, nil
+}
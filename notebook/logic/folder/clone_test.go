@@ -0,0 +1,102 @@
+package folderLogic
+
+import (
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCloneNotebook(t *testing.T) {
+	src := journal.NewNotebook("author", "company", "division", "source", "Синтез", "Описание")
+	src.Metadata.Tags = []string{"synthesis"}
+	src.Blocks = []journal.Block{
+		journal.NewBlock("text", map[string]any{"text": "Навеска"}),
+		journal.NewBlock("image", map[string]any{"object_key": "notebooks/source/a.png", "width": 300}),
+		journal.NewBlock("image", map[string]any{"object_key": "notebooks/source/a.png"}),
+	}
+	src.Blocks[0].Comment = []journal.Comment{journal.NewComment("author", "", "Проверьте")}
+	src.Signatures = []journal.Signature{{Id: "signature"}}
+	src.Lifecycle.Status = journal.StatusApproved
+
+	t.Run("CopiesContentWithoutHistory", func(t *testing.T) {
+		nb, _ := cloneNotebook(&src, "copy", "employee", "other", "")
+		if nb.UuidID != "copy" || nb.Metadata.DivisionID != "other" || nb.Metadata.CompanyID != "company" {
+			t.Errorf("Expected copy in division other of company, got %+v\n", nb.Metadata)
+		}
+		if nb.Metadata.Title != "Синтез" || nb.Metadata.Created.Author != "employee" {
+			t.Errorf("Expected original title and new author, got %+v\n", nb.Metadata)
+		}
+		if !slices.Equal(nb.Metadata.Tags, src.Metadata.Tags) {
+			t.Errorf("Expected tags %v, got %v\n", src.Metadata.Tags, nb.Metadata.Tags)
+		}
+		if len(nb.Blocks) != 3 || nb.Blocks[0].Id == src.Blocks[0].Id || len(nb.Blocks[0].Comment) != 0 {
+			t.Errorf("Expected blocks with new IDs and no comments, got %+v\n", nb.Blocks)
+		}
+		if len(nb.Signatures) != 0 || nb.Lifecycle.Status != journal.StatusDraft {
+			t.Errorf("Expected unsigned draft, got %+v %+v\n", nb.Signatures, nb.Lifecycle)
+		}
+	})
+
+	t.Run("RemapsAttachments", func(t *testing.T) {
+		nb, keys := cloneNotebook(&src, "copy", "employee", "division", "")
+		if len(keys) != 1 {
+			t.Fatalf("Expected one attachment to copy, got %v\n", keys)
+		}
+		newKey := keys["notebooks/source/a.png"]
+		if !strings.HasPrefix(newKey, "notebooks/copy/") || !strings.HasSuffix(newKey, ".png") {
+			t.Errorf("Expected key under the copy, got %s\n", newKey)
+		}
+		if nb.Blocks[1].Body["object_key"] != newKey || nb.Blocks[2].Body["object_key"] != newKey {
+			t.Errorf("Expected both image blocks to point at %s\n", newKey)
+		}
+		if src.Blocks[1].Body["object_key"] != "notebooks/source/a.png" {
+			t.Errorf("Expected source block to stay unchanged, got %v\n", src.Blocks[1].Body)
+		}
+	})
+}
+
+func TestClonePermission(t *testing.T) {
+	src := permission.NewPermission("owner", "source", "source", "file", primitive.NewObjectID())
+	src.Rules.ReadOnly = []string{"reader"}
+	src.Rules.AccessLevel = "restricted"
+	src.Inherit = false
+
+	t.Run("CopiesRules", func(t *testing.T) {
+		perm := clonePermission(&src, "employee", "copy", "file", primitive.NewObjectID())
+		if perm.UuidId != "copy" || perm.ResourceUuid != "copy" {
+			t.Errorf("Expected permission for copy, got %+v\n", perm)
+		}
+		if !perm.Rules.CanEdit("owner") || !perm.Rules.CanEdit("employee") || !perm.Rules.CanRead("reader") {
+			t.Errorf("Expected copied rules plus the copier, got %+v\n", perm.Rules)
+		}
+		if perm.Rules.AccessLevel != "restricted" {
+			t.Errorf("Expected access level restricted, got %s\n", perm.Rules.AccessLevel)
+		}
+		if perm.Inherit {
+			t.Errorf("Expected the copy to keep the inheritance override\n")
+		}
+		if len(src.Rules.AccessAllowed) != 1 {
+			t.Errorf("Expected source rules to stay unchanged, got %v\n", src.Rules.AccessAllowed)
+		}
+	})
+
+	t.Run("MissingSource", func(t *testing.T) {
+		perm := clonePermission(nil, "employee", "copy", "folder", primitive.NewObjectID())
+		if !perm.Rules.CanEdit("employee") || len(perm.Rules.AccessAllowed) != 1 || !perm.Inherit {
+			t.Errorf("Expected default rules, got %+v\n", perm)
+		}
+	})
+}
+
+func TestDuplicateTitle(t *testing.T) {
+	if got := duplicateTitle("", "Синтез"); got != "Синтез (копия)" {
+		t.Errorf("Expected suffixed title, got %s\n", got)
+	}
+	if got := duplicateTitle(" Новый ", "Синтез"); got != "Новый" {
+		t.Errorf("Expected explicit title, got %s\n", got)
+	}
+}
@@ -0,0 +1,256 @@
+package folderLogic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// CopyFolder копирует папку со всеми вложенными папками и журналами, их правами доступа и вложениями
+// в папку parentId или в корень отдела (parentId == divisionId) и возвращает ID копии.
+// Пустой parentId оставляет дубликат рядом с оригиналом; копирование в саму себя или во вложенную папку отклоняется.
+func (f FolderMongoLogic) CopyFolder(folderId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID, title string) (uuid.UUID, error) {
+	// 1. Validate input parameters
+	if folderId == uuid.Nil || caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("folder, employee, company and division IDs cannot be nil")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "CopyFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout; a subtree with attachments takes longer than a single write
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	// 4. Begin transaction for UUID reservation
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "CopyFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "CopyFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "CopyFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Resolve the source and the target; without a target the copy stays next to the original
+	source, err := md.Folder.GetFolderByFolderId(ctx, &session, folderId.String())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if source.Metadata.CompanyID != companyId.String() {
+		return uuid.Nil, fmt.Errorf("folder does not belong to the company: %w", permission.ErrForbidden)
+	}
+
+	target, targetDivision := parentId.String(), divisionId.String()
+	if parentId == uuid.Nil {
+		target, targetDivision = source.ParentId, source.Metadata.DivisionID
+		title = duplicateTitle(title, source.Metadata.Title)
+	}
+
+	parent, newDivision, err := destination(ctx, md, &session, companyId.String(), targetDivision, target, caller)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if parent != nil {
+		within, err := isWithin(ctx, md, &session, parent.UuidID, source.UuidID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to check target folder: %w", err)
+		}
+		if within {
+			return uuid.Nil, directory.ErrMoveIntoDescendant
+		}
+	}
+
+	// 7. Build copies of the whole subtree; parents come before their children
+	dirs, err := subtree(ctx, md, &session, source)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	rootParent, err := uuid.Parse(target)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid target %q: %w", target, err)
+	}
+	copyDivision, err := uuid.Parse(newDivision)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid target division %q: %w", newDivision, err)
+	}
+
+	newIds := make(map[string]uuid.UUID, len(dirs))
+	position := make(map[string]int, len(dirs)) // индекс копии в folders по ID исходной папки
+	folders := make([]directory.Directory, 0, len(dirs))
+	folderPerms := make([]permission.Permission, 0, len(dirs))
+	var notebooks []*notebookCopy
+	keys := make(map[string]string)
+
+	for i, dir := range dirs {
+		chain, err := permissionLogic.LoadChain(ctx, md, &session, dir.UuidID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to read folder permission: %w", err)
+		}
+		perm := &chain[0]
+		if permissionLogic.EffectiveAccess(chain, caller.ID.String()) < permission.AccessRead {
+			return uuid.Nil, permission.ErrForbidden
+		}
+
+		newId, err := postgres.NewPostgresDB().UuidValidation.CheckAndReserveUUID(ctx, tx)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("uuid generation failed: %w", err)
+		}
+		newIds[dir.UuidID] = newId
+
+		newParent, dirTitle := rootParent, dir.Metadata.Title
+		if i == 0 {
+			if strings.TrimSpace(title) != "" {
+				dirTitle = strings.TrimSpace(title)
+			}
+		} else {
+			if _, ok := position[dir.ParentId]; !ok {
+				return uuid.Nil, fmt.Errorf("folder %s is linked from a folder other than its parent", dir.UuidID)
+			}
+			newParent = newIds[dir.ParentId]
+		}
+
+		copyDir := directory.NewDirectory(caller.ID, companyId, copyDivision, newId, newParent, dir.Version, false, dirTitle, dir.Metadata.Description)
+		if len(dir.Metadata.Tags) > 0 {
+			copyDir.Metadata.Tags = slices.Clone(dir.Metadata.Tags)
+		}
+
+		for _, file := range dir.Files {
+			if len(folders)+len(notebooks) >= directory.MaxTreeNodes {
+				return uuid.Nil, directory.ErrTreeTooLarge
+			}
+			notebook, err := md.Notebook.GetNotebookById(ctx, &session, file.FileUUID)
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("failed to read notebook %s: %w", file.FileUUID, err)
+			}
+			cp, err := prepareNotebookCopy(ctx, tx, md, &session, notebook, caller.ID.String(), newDivision, "", keys)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			notebooks = append(notebooks, cp)
+			copyDir.Files = append(copyDir.Files, directory.NewFile(cp.id, cp.notebook.Metadata.Title, cp.notebook.Metadata.Description))
+		}
+
+		if i > 0 {
+			parentCopy := &folders[position[dir.ParentId]]
+			parentCopy.Folders = append(parentCopy.Folders, directory.NewFolder(newId, dirTitle, dir.Metadata.Description))
+		}
+
+		position[dir.UuidID] = len(folders)
+		folders = append(folders, copyDir)
+		folderPerms = append(folderPerms, clonePermission(perm, caller.ID.String(), newId.String(), "folder", copyDir.ID))
+	}
+
+	// 8. Copy attachments before the transaction: it may be retried, storage writes may not
+	copied, err := copyAttachments(ctx, "CopyFolder", keys)
+	if err != nil {
+		logger.NewErrMessage("Failed to copy attachments",
+			zap.Error(err),
+			zap.String("operation", "CopyFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return uuid.Nil, err
+	}
+
+	// 9. Save folders, notebooks, their history and permissions and link the copy into the target in one transaction
+	copyId := newIds[source.UuidID]
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		for i := range folders {
+			if err := md.Folder.CreateFolder(sc, &session, &folders[i]); err != nil {
+				return nil, fmt.Errorf("failed to create folder copy: %w", err)
+			}
+			if err := md.Permission.CreatePermission(sc, &session, &folderPerms[i]); err != nil {
+				return nil, fmt.Errorf("failed to create folder permission: %w", err)
+			}
+		}
+		for _, cp := range notebooks {
+			if err := saveNotebookCopy(sc, md, &session, &cp.notebook, &cp.permission, caller.ID.String()); err != nil {
+				return nil, err
+			}
+		}
+		if parent != nil {
+			ref := directory.NewFolder(copyId, folders[0].Metadata.Title, folders[0].Metadata.Description)
+			if _, err := md.Folder.AddSubfolder(sc, &session, parent.UuidID, ref); err != nil {
+				return nil, fmt.Errorf("failed to link folder copy to parent: %w", err)
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to save folder copy",
+			zap.Error(err),
+			zap.String("operation", "CopyFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		removeAttachments(ctx, "CopyFolder", copied)
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "CopyFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	logger.NewInfoMessage("Folder copied successfully",
+		zap.String("operation", "CopyFolder"),
+		zap.String("folder_id", folderId.String()),
+		zap.String("copy_id", copyId.String()),
+		zap.Int("folders", len(folders)),
+		zap.Int("notebooks", len(notebooks)),
+		zap.Int("attachments", len(copied)),
+	)
+
+	return copyId, nil
+}
@@ -0,0 +1,164 @@
+package folderLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// CopyNotebook копирует журнал с правами доступа и вложениями в папку parentId или в корень отдела
+// (parentId == divisionId) и возвращает ID копии. Пустой parentId оставляет дубликат рядом с оригиналом.
+func (f FolderMongoLogic) CopyNotebook(notebookId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID, title string) (uuid.UUID, error) {
+	// 1. Validate input parameters
+	if notebookId == uuid.Nil || caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("notebook, employee, company and division IDs cannot be nil")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "CopyNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout; attachments are copied in storage
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// 4. Begin transaction for UUID reservation
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "CopyNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "CopyNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "CopyNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Resolve the source and the target; without a target the copy stays next to the original
+	notebook, err := md.Notebook.GetNotebookById(ctx, &session, notebookId.String())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if notebook.Metadata.CompanyID != companyId.String() {
+		return uuid.Nil, fmt.Errorf("notebook does not belong to the company: %w", permission.ErrForbidden)
+	}
+
+	target, targetDivision := parentId.String(), divisionId.String()
+	if parentId == uuid.Nil {
+		source, err := md.Folder.GetFolderByFileId(ctx, &session, notebook.UuidID)
+		switch {
+		case errors.Is(err, directory.ErrNotFound):
+			target, targetDivision = notebook.Metadata.DivisionID, notebook.Metadata.DivisionID
+		case err != nil:
+			return uuid.Nil, err
+		default:
+			target = source.UuidID
+		}
+		title = duplicateTitle(title, notebook.Metadata.Title)
+	}
+
+	parent, newDivision, err := destination(ctx, md, &session, companyId.String(), targetDivision, target, caller)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	keys := make(map[string]string)
+	cp, err := prepareNotebookCopy(ctx, tx, md, &session, notebook, caller.ID.String(), newDivision, title, keys)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// 7. Copy attachments before the transaction: it may be retried, storage writes may not
+	copied, err := copyAttachments(ctx, "CopyNotebook", keys)
+	if err != nil {
+		logger.NewErrMessage("Failed to copy attachments",
+			zap.Error(err),
+			zap.String("operation", "CopyNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return uuid.Nil, err
+	}
+
+	// 8. Save the copy, its history and permission and link it into the folder in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if err := saveNotebookCopy(sc, md, &session, &cp.notebook, &cp.permission, caller.ID.String()); err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			ref := directory.NewFile(cp.id, cp.notebook.Metadata.Title, cp.notebook.Metadata.Description)
+			if _, err := md.Folder.AddFile(sc, &session, parent.UuidID, ref); err != nil {
+				return nil, fmt.Errorf("failed to add notebook to folder: %w", err)
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to save notebook copy",
+			zap.Error(err),
+			zap.String("operation", "CopyNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		removeAttachments(ctx, "CopyNotebook", copied)
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "CopyNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return uuid.Nil, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	logger.NewInfoMessage("Notebook copied successfully",
+		zap.String("operation", "CopyNotebook"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("copy_id", cp.id.String()),
+		zap.Int("attachments", len(copied)),
+	)
+
+	return cp.id, nil
+}
@@ -3,10 +3,13 @@ package folderLogic
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	m "labyrinth/database/mongo"
 	"labyrinth/database/postgres"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
 	"labyrinth/notebook/models/directory"
 	"labyrinth/notebook/models/permission"
 	"strings"
@@ -16,19 +19,25 @@ import (
 	"go.uber.org/zap"
 )
 
-func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parentId uuid.UUID, isPrimary bool, title, description string) error {
+// CreateFolder создает папку и возвращает ее ID. parentId - родительская папка или подразделение (компания, отдел);
+// в родительскую папку добавляется ссылка на новую. Сотрудник caller становится автором папки
+// и получает на нее полный доступ.
+func (f FolderMongoLogic) CreateFolder(caller *employee.Employee, companyId, divisionId, parentId uuid.UUID, isPrimary bool, title, description string) (uuid.UUID, error) {
 	// 1. Validate input parameters
-	if employeeId == uuid.Nil {
-		return fmt.Errorf("employeeId cannot be nil")
+	if caller == nil {
+		return uuid.Nil, fmt.Errorf("employeeId cannot be nil")
 	}
 	if companyId == uuid.Nil {
-		return fmt.Errorf("companyId cannot be nil")
+		return uuid.Nil, fmt.Errorf("companyId cannot be nil")
 	}
 	if divisionId == uuid.Nil {
-		return fmt.Errorf("divisionId cannot be nil")
+		return uuid.Nil, fmt.Errorf("divisionId cannot be nil")
 	}
 	if strings.TrimSpace(title) == "" {
-		return fmt.Errorf("title cannot be empty")
+		return uuid.Nil, directory.ErrInvalidTitle
+	}
+	if err := permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+		return uuid.Nil, err
 	}
 
 	// 2. Initialize PostgreSQL connection
@@ -37,9 +46,9 @@ func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parent
 		logger.NewErrMessage("Database connection failed",
 			zap.Error(err),
 			zap.String("operation", "CreateFolder"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
-		return fmt.Errorf("database connection failed: %w", err)
+		return uuid.Nil, fmt.Errorf("database connection failed: %w", err)
 	}
 	defer db.Close()
 
@@ -53,9 +62,9 @@ func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parent
 		logger.NewErrMessage("Transaction begin failed",
 			zap.Error(err),
 			zap.String("operation", "CreateFolder"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
-		return fmt.Errorf("transaction begin failed: %w", err)
+		return uuid.Nil, fmt.Errorf("transaction begin failed: %w", err)
 	}
 
 	// 5. Transaction rollback handler
@@ -77,7 +86,7 @@ func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parent
 			zap.Error(err),
 			zap.String("operation", "CreateFolder"),
 		)
-		return fmt.Errorf("mongodb initialization failed: %w", err)
+		return uuid.Nil, fmt.Errorf("mongodb initialization failed: %w", err)
 	}
 
 	// 7. Generate and validate UUID
@@ -87,7 +96,7 @@ func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parent
 			zap.Error(err),
 			zap.String("operation", "CreateFolder"),
 		)
-		return fmt.Errorf("uuid generation failed: %w", err)
+		return uuid.Nil, fmt.Errorf("uuid generation failed: %w", err)
 	}
 
 	// 8. Start MongoDB session
@@ -97,12 +106,41 @@ func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parent
 			zap.Error(err),
 			zap.String("operation", "CreateFolder"),
 		)
-		return fmt.Errorf("mongodb session start failed: %w", err)
+		return uuid.Nil, fmt.Errorf("mongodb session start failed: %w", err)
 	}
 	defer session.EndSession(ctx)
 
-	// 9. Create new folder
-	newFolder := directory.NewDirectory(employeeId, companyId, divisionId, generatedId, parentId, "1.0.0", isPrimary, title, description)
+	// 9. A department division must be an active department of the company
+	if err = requireDivision(ctx, tx, companyId, divisionId); err != nil {
+		if !errors.Is(err, directory.ErrNoDivision) {
+			logger.NewErrMessage("Failed to fetch department",
+				zap.Error(err),
+				zap.String("operation", "CreateFolder"),
+				zap.String("department_id", divisionId.String()),
+			)
+		}
+		return uuid.Nil, err
+	}
+
+	// 10. A parent folder must belong to the same company and department
+	var parentIsFolder bool
+	parentIsFolder, err = md.Folder.ExistsFolder(ctx, &session, parentId.String())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to check parent folder: %w", err)
+	}
+	if parentIsFolder {
+		var parent *directory.Directory
+		if parent, err = md.Folder.GetFolderByFolderId(ctx, &session, parentId.String()); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to get parent folder: %w", err)
+		}
+		if parent.Metadata.CompanyID != companyId.String() || parent.Metadata.DivisionID != divisionId.String() {
+			err = directory.ErrInvalidParent
+			return uuid.Nil, err
+		}
+	}
+
+	// 11. Create new folder
+	newFolder := directory.NewDirectory(caller.ID, companyId, divisionId, generatedId, parentId, "1.0.0", isPrimary, title, description)
 	err = md.Folder.CreateFolder(ctx, &session, &newFolder)
 	if err != nil {
 		logger.NewErrMessage("Folder creation failed",
@@ -110,10 +148,10 @@ func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parent
 			zap.String("operation", "CreateFolder"),
 			zap.String("folder_id", generatedId.String()),
 		)
-		return fmt.Errorf("folder creation failed: %w", err)
+		return uuid.Nil, fmt.Errorf("folder creation failed: %w", err)
 	}
 
-	newPermission := permission.NewPermission(employeeId.String(), generatedId.String(), generatedId.String(), "folder", newFolder.ID)
+	newPermission := permission.NewPermission(caller.ID.String(), generatedId.String(), generatedId.String(), "folder", newFolder.ID)
 	err = md.Permission.CreatePermission(ctx, &session, &newPermission)
 	if err != nil {
 		logger.NewErrMessage("Permission creation failed",
@@ -121,17 +159,30 @@ func (f FolderMongoLogic) CreateFolder(employeeId, companyId, divisionId, parent
 			zap.String("operation", "CreateFolder"),
 			zap.String("folder_id", generatedId.String()),
 		)
-		return fmt.Errorf("permission creation failed: %w", err)
+		return uuid.Nil, fmt.Errorf("permission creation failed: %w", err)
+	}
+
+	// 12. Link the folder into its parent folder
+	if parentIsFolder {
+		if _, err = md.Folder.AddSubfolder(ctx, &session, parentId.String(), directory.NewFolder(generatedId, title, description)); err != nil {
+			logger.NewErrMessage("Failed to link folder to parent",
+				zap.Error(err),
+				zap.String("operation", "CreateFolder"),
+				zap.String("folder_id", generatedId.String()),
+				zap.String("parent_id", parentId.String()),
+			)
+			return uuid.Nil, fmt.Errorf("failed to link folder to parent: %w", err)
+		}
 	}
 
-	// 10. Commit transaction if everything succeeded
+	// 13. Commit transaction if everything succeeded
 	if err = tx.Commit(); err != nil {
 		logger.NewErrMessage("Transaction commit failed",
 			zap.Error(err),
 			zap.String("operation", "CreateFolder"),
 		)
-		return fmt.Errorf("transaction commit failed: %w", err)
+		return uuid.Nil, fmt.Errorf("transaction commit failed: %w", err)
 	}
 
-	return nil
+	return generatedId, nil
 }
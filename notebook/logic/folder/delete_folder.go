@@ -3,8 +3,15 @@ package folderLogic
 import (
 	"context"
 	"fmt"
+	"labyrinth/config"
 	m "labyrinth/database/mongo"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	signatureLogic "labyrinth/notebook/logic/signature"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/trash"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,14 +19,17 @@ import (
 	"go.uber.org/zap"
 )
 
-func (f FolderMongoLogic) DeleteFolder(folderId uuid.UUID) error {
+// DeleteFolder убирает папку со всем содержимым в корзину отдела.
+// Документы поддерева переносятся в корзину в одной транзакции, права доступа сохраняются
+// до восстановления или окончательного удаления. Папку с утвержденными или подписанными журналами удалить нельзя.
+func (f FolderMongoLogic) DeleteFolder(folderId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error {
 	// 1. Validate input
-	if folderId == uuid.Nil {
-		return fmt.Errorf("folderId cannot be nil")
+	if folderId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		return fmt.Errorf("folder, employee and company IDs cannot be nil")
 	}
 
 	// 2. Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	// 3. Initialize MongoDB
@@ -45,9 +55,71 @@ func (f FolderMongoLogic) DeleteFolder(folderId uuid.UUID) error {
 	}
 	defer session.EndSession(ctx)
 
-	// 5. Execute recursive deletion
-	if err := cleanDir(ctx, &session, md, folderId.String()); err != nil {
-		logger.NewErrMessage("Failed to delete folder",
+	// 5. Move the subtree into the trash and unlink it from the parent in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		folder, err := md.Folder.GetFolderByFolderId(sc, &session, folderId.String())
+		if err != nil {
+			return nil, err
+		}
+		if folder.Metadata.CompanyID != companyId.String() {
+			return nil, fmt.Errorf("folder does not belong to the company: %w", permission.ErrForbidden)
+		}
+		if folder.IsPrimary {
+			return nil, directory.ErrPrimaryFolder
+		}
+		if err := requireAccess(sc, md, &session, companyId.String(), folder.UuidID, caller, permission.AccessEdit); err != nil {
+			return nil, err
+		}
+
+		dirs, err := subtree(sc, md, &session, folder)
+		if err != nil {
+			return nil, err
+		}
+		folders := make([]directory.Directory, 0, len(dirs))
+		var notebooks []journal.Notebook
+		for _, dir := range dirs {
+			folders = append(folders, *dir)
+			for _, file := range dir.Files {
+				notebook, err := md.Notebook.GetNotebookById(sc, &session, file.FileUUID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get notebook %s: %w", file.FileUUID, err)
+				}
+				if notebook.Lifecycle.ReadOnly {
+					return nil, fmt.Errorf("%w: notebook %s is %s", journal.ErrReadOnly, notebook.UuidID, notebook.Lifecycle.Status)
+				}
+				if err := signatureLogic.VerifyDeletable(notebook); err != nil {
+					return nil, fmt.Errorf("notebook %s: %w", notebook.UuidID, err)
+				}
+				notebooks = append(notebooks, *notebook)
+			}
+		}
+
+		item := trash.NewItem(trash.KindFolder, folder.UuidID, folder.Metadata.CompanyID, folder.Metadata.DivisionID,
+			folder.Metadata.Title, folder.ParentId, caller.ID.String(), config.Conf.Trash.Retention)
+		item.Folders, item.Notebooks = len(folders), len(notebooks)
+		if err := md.Trash.CreateItem(sc, &session, &item, folders, notebooks); err != nil {
+			return nil, err
+		}
+
+		for _, notebook := range notebooks {
+			if err := md.Notebook.DeleteNotebook(sc, &session, notebook.UuidID); err != nil {
+				return nil, fmt.Errorf("failed to delete notebook %s: %w", notebook.UuidID, err)
+			}
+		}
+		for _, dir := range folders {
+			if err := md.Folder.DeleteFolder(sc, &session, dir.UuidID); err != nil {
+				return nil, fmt.Errorf("failed to delete directory %s: %w", dir.UuidID, err)
+			}
+		}
+
+		// Departments keep no links, so there is nothing to unlink for a top-level folder
+		if folder.ParentId == folder.Metadata.DivisionID {
+			return nil, nil
+		}
+		return nil, md.Folder.RemoveSubfolder(sc, &session, folder.ParentId, folder.UuidID)
+	})
+	if err != nil {
+		logger.NewWarnMessage("Failed to delete folder",
 			zap.Error(err),
 			zap.String("operation", "DeleteFolder"),
 			zap.String("folder_id", folderId.String()),
@@ -55,42 +127,10 @@ func (f FolderMongoLogic) DeleteFolder(folderId uuid.UUID) error {
 		return fmt.Errorf("failed to delete folder: %w", err)
 	}
 
-	logger.NewInfoMessage("Folder deleted successfully",
+	logger.NewInfoMessage("Folder moved to trash",
 		zap.String("folder_id", folderId.String()),
 		zap.String("operation", "DeleteFolder"),
 	)
 
 	return nil
 }
-
-func cleanDir(ctx context.Context, session *mongo.Session, md *m.MongoDB, dirId string) error {
-	dir, err := md.Folder.GetFolderByFolderId(ctx, session, dirId)
-	if err != nil {
-		return fmt.Errorf("failed to get directory %s: %w", dirId, err)
-	}
-
-	for _, folder := range dir.Folders {
-		if err := cleanDir(ctx, session, md, folder.FolderUUID); err != nil {
-			return fmt.Errorf("failed to clean subdirectory %s: %w", folder.FolderUUID, err)
-		}
-	}
-
-	for _, file := range dir.Files {
-		if err := md.Notebook.DeleteNotebook(ctx, session, file.FileUUID); err != nil {
-			return fmt.Errorf("failed to delete notebook %s: %w", file.FileUUID, err)
-		}
-		if err := md.Permission.DeletePermission(ctx, session, file.FileUUID); err != nil {
-			return fmt.Errorf("failed to delete notebook permission %s: %w", file.FileUUID, err)
-		}
-	}
-
-	if err := md.Folder.DeleteFolder(ctx, session, dirId); err != nil {
-		return fmt.Errorf("failed to delete directory %s: %w", dirId, err)
-	}
-
-	if err := md.Permission.DeletePermission(ctx, session, dir.UuidID); err != nil {
-		return fmt.Errorf("failed to delete directory permission %s: %w", dirId, err)
-	}
-
-	return nil
-}
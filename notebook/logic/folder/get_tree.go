@@ -0,0 +1,274 @@
+package folderLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GetTree возвращает папку с вложенными папками и журналами до глубины depth:
+// при depth = 1 раскрываются только непосредственные дочерние папки.
+// Сотрудник должен иметь право читать папку; вложенные папки и журналы без такого права не показываются.
+func (f FolderMongoLogic) GetTree(folderId uuid.UUID, caller *employee.Employee, depth int) (*directory.Node, error) {
+	// 1. Validate input
+	if folderId == uuid.Nil || caller == nil {
+		return nil, errors.New("folderId and employeeId cannot be nil")
+	}
+	depth = clampDepth(depth)
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "GetTree"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "GetTree"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Load the folder and check that the employee may read it
+	dir, err := md.Folder.GetFolderByFolderId(ctx, &session, folderId.String())
+	if err != nil {
+		return nil, err
+	}
+	v, access, err := readerOf(ctx, md, &session, dir, caller)
+	if err != nil {
+		logger.NewWarnMessage("Folder access denied",
+			zap.Error(err),
+			zap.String("operation", "GetTree"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Build the tree of what the employee may read: the folder access passes down to inheriting children
+	perms, err := permissionsOf(ctx, md, &session, notebookIds(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	budget := directory.MaxTreeNodes
+	root := v.newNode(dir, perms, access)
+	if root.Folders, err = childNodes(ctx, md, &session, dir.UuidID, access, v, depth, &budget); err != nil {
+		logger.NewErrMessage("Failed to build folder tree",
+			zap.Error(err),
+			zap.String("operation", "GetTree"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, err
+	}
+
+	return &root, nil
+}
+
+// GetDepartmentTree возвращает верхние папки отдела с вложенными папками и журналами до глубины depth.
+// Видны только папки и журналы, которые сотрудник компании может читать.
+func (f FolderMongoLogic) GetDepartmentTree(departmentId uuid.UUID, caller *employee.Employee, companyId uuid.UUID, depth int) ([]directory.Node, error) {
+	// 1. Validate input
+	if departmentId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		return nil, errors.New("departmentId, employeeId and companyId cannot be nil")
+	}
+	depth = clampDepth(depth)
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentTree"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentTree"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Check that the employee belongs to the company
+	if err := permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+		logger.NewWarnMessage("Department tree access denied",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentTree"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Build the tree: top-level folders of a department have the department as parent and inherit nothing
+	budget := directory.MaxTreeNodes
+	nodes, err := childNodes(ctx, md, &session, departmentId.String(), permission.AccessNone, viewer{employeeId: caller.ID.String(), companyId: companyId.String()}, depth, &budget)
+	if err != nil {
+		logger.NewErrMessage("Failed to build department tree",
+			zap.Error(err),
+			zap.String("operation", "GetDepartmentTree"),
+			zap.String("department_id", departmentId.String()),
+		)
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// clampDepth приводит запрошенную глубину к допустимому диапазону
+func clampDepth(depth int) int {
+	if depth <= 0 {
+		return directory.DefaultTreeDepth
+	}
+	return min(depth, directory.MaxTreeDepth)
+}
+
+// viewer - сотрудник, для которого строится дерево: в него попадают только папки его компании
+// и только те папки и журналы, которые он может читать. employeeId - ID сотрудника, а не пользователя
+type viewer struct {
+	employeeId string
+	companyId  string
+}
+
+// readerOf проверяет, что сотрудник компании папки может ее читать.
+// Возвращает сотрудника как viewer и его право на папку
+func readerOf(ctx context.Context, md *m.MongoDB, session *mongo.Session, dir *directory.Directory, caller *employee.Employee) (viewer, permission.Access, error) {
+	access, err := permissionLogic.Authorize(ctx, md, session, dir.Metadata.CompanyID, dir.UuidID, caller, permission.AccessRead)
+	if err != nil {
+		return viewer{}, permission.AccessNone, err
+	}
+	return viewer{employeeId: caller.ID.String(), companyId: dir.Metadata.CompanyID}, access, nil
+}
+
+// permissionsOf читает разрешения ресурсов ids одним запросом; у отсутствующих в ответе ресурсов нет прав
+func permissionsOf(ctx context.Context, md *m.MongoDB, session *mongo.Session, ids []string) (map[string]permission.Permission, error) {
+	list, err := md.Permission.GetPermissionsByUuidIds(ctx, session, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read permissions: %w", err)
+	}
+	perms := make(map[string]permission.Permission, len(list))
+	for _, perm := range list {
+		perms[perm.UuidId] = perm
+	}
+	return perms, nil
+}
+
+// access возвращает право сотрудника на ресурс id, лежащий в папке, на которую у него право parent
+func (v viewer) access(perms map[string]permission.Permission, id string, parent permission.Access) permission.Access {
+	return permissionLogic.Inherited(perms[id], v.employeeId, parent)
+}
+
+// notebookIds возвращает ID журналов папки
+func notebookIds(dir *directory.Directory) []string {
+	ids := make([]string, 0, len(dir.Files))
+	for _, file := range dir.Files {
+		ids = append(ids, file.FileUUID)
+	}
+	return ids
+}
+
+// newNode строит узел папки, на которую у сотрудника право access; в него попадают только журналы, которые он может читать
+func (v viewer) newNode(dir *directory.Directory, perms map[string]permission.Permission, access permission.Access) directory.Node {
+	notebooks := make([]directory.File, 0, len(dir.Files))
+	for _, file := range dir.Files {
+		if v.access(perms, file.FileUUID, access) >= permission.AccessRead {
+			notebooks = append(notebooks, file)
+		}
+	}
+	return directory.Node{
+		FolderID:    dir.UuidID,
+		Title:       dir.Metadata.Title,
+		Description: dir.Metadata.Description,
+		Revision:    dir.Revision,
+		Tags:        dir.Metadata.Tags,
+		Notebooks:   notebooks,
+		Folders:     []directory.Node{},
+	}
+}
+
+// childNodes раскрывает дочерние папки parentId на depth уровней; parentAccess - право сотрудника на parentId.
+// budget ограничивает общее число узлов, чтобы глубокое дерево не читалось целиком.
+func childNodes(ctx context.Context, md *m.MongoDB, session *mongo.Session, parentId string, parentAccess permission.Access, v viewer, depth int, budget *int) ([]directory.Node, error) {
+	children, err := md.Folder.GetFoldersByParentId(ctx, session, parentId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subfolders of %s: %w", parentId, err)
+	}
+
+	*budget -= len(children)
+	if *budget < 0 {
+		return nil, directory.ErrTreeTooLarge
+	}
+
+	// Права на дочерние папки и их журналы читаются одним запросом на уровень
+	var ids []string
+	for _, child := range children {
+		ids = append(ids, child.UuidID)
+		ids = append(ids, notebookIds(child)...)
+	}
+	perms, err := permissionsOf(ctx, md, session, ids)
+	if err != nil {
+		return nil, err
+	}
+	children = slices.DeleteFunc(children, func(child *directory.Directory) bool {
+		return child.Metadata.CompanyID != v.companyId || v.access(perms, child.UuidID, parentAccess) < permission.AccessRead
+	})
+
+	slices.SortFunc(children, func(a, b *directory.Directory) int {
+		return strings.Compare(strings.ToLower(a.Metadata.Title), strings.ToLower(b.Metadata.Title))
+	})
+
+	nodes := make([]directory.Node, 0, len(children))
+	for _, child := range children {
+		access := v.access(perms, child.UuidID, parentAccess)
+		node := v.newNode(child, perms, access)
+		if depth > 1 {
+			if node.Folders, err = childNodes(ctx, md, session, child.UuidID, access, v, depth-1, budget); err != nil {
+				return nil, err
+			}
+		} else {
+			more, err := md.Folder.GetFoldersByParentId(ctx, session, child.UuidID, options.Find().SetLimit(1).SetProjection(map[string]int{"uuid_id": 1}))
+			if err != nil {
+				return nil, fmt.Errorf("failed to check subfolders of %s: %w", child.UuidID, err)
+			}
+			node.HasMore = len(more) > 0
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
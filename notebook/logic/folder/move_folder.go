@@ -0,0 +1,140 @@
+package folderLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// MoveFolder переносит папку со всем содержимым в папку parentId или в корень отдела (parentId == divisionId).
+// Ссылки в родительских папках, ParentId и отдел поддерева меняются в одной транзакции;
+// перенос папки в саму себя или во вложенную папку отклоняется.
+func (f FolderMongoLogic) MoveFolder(folderId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID) error {
+	// 1. Validate input parameters
+	if folderId == uuid.Nil || caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil || parentId == uuid.Nil {
+		return fmt.Errorf("folder, employee, company, division and parent IDs cannot be nil")
+	}
+	if folderId == parentId {
+		return directory.ErrMoveIntoDescendant
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MoveFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MoveFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Relink the folder and move its subtree to the target department in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		folder, err := md.Folder.GetFolderByFolderId(sc, &session, folderId.String())
+		if err != nil {
+			return nil, err
+		}
+		if folder.Metadata.CompanyID != companyId.String() {
+			return nil, fmt.Errorf("folder does not belong to the company: %w", permission.ErrForbidden)
+		}
+		if folder.IsPrimary {
+			return nil, directory.ErrPrimaryFolder
+		}
+		if err := requireAccess(sc, md, &session, companyId.String(), folder.UuidID, caller, permission.AccessEdit); err != nil {
+			return nil, err
+		}
+
+		parent, newDivision, err := destination(sc, md, &session, companyId.String(), divisionId.String(), parentId.String(), caller)
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			within, err := isWithin(sc, md, &session, parent.UuidID, folder.UuidID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check target folder: %w", err)
+			}
+			if within {
+				return nil, directory.ErrMoveIntoDescendant
+			}
+		}
+		if folder.ParentId == parentId.String() && folder.Metadata.DivisionID == newDivision {
+			return nil, nil
+		}
+
+		if err := md.Folder.RemoveSubfolder(sc, &session, folder.ParentId, folder.UuidID); err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			ref := directory.NewFolder(folderId, folder.Metadata.Title, folder.Metadata.Description)
+			if _, err := md.Folder.AddSubfolder(sc, &session, parent.UuidID, ref); err != nil {
+				return nil, err
+			}
+		}
+		if err := md.Folder.MoveFolder(sc, &session, folder.UuidID, parentId.String(), newDivision); err != nil {
+			return nil, err
+		}
+
+		if folder.Metadata.DivisionID == newDivision {
+			return nil, nil
+		}
+		dirs, err := subtree(sc, md, &session, folder)
+		if err != nil {
+			return nil, err
+		}
+		var folderIds, notebookIds []string
+		for i, dir := range dirs {
+			if i > 0 {
+				folderIds = append(folderIds, dir.UuidID)
+			}
+			for _, file := range dir.Files {
+				notebookIds = append(notebookIds, file.FileUUID)
+			}
+		}
+		if err := md.Folder.SetDivision(sc, &session, folderIds, newDivision); err != nil {
+			return nil, err
+		}
+		return nil, md.Notebook.SetDivision(sc, &session, notebookIds, newDivision)
+	})
+	if err != nil {
+		logger.NewWarnMessage("Failed to move folder",
+			zap.Error(err),
+			zap.String("operation", "MoveFolder"),
+			zap.String("folder_id", folderId.String()),
+			zap.String("parent_id", parentId.String()),
+		)
+		return fmt.Errorf("failed to move folder: %w", err)
+	}
+
+	logger.NewInfoMessage("Folder moved successfully",
+		zap.String("operation", "MoveFolder"),
+		zap.String("folder_id", folderId.String()),
+		zap.String("parent_id", parentId.String()),
+	)
+
+	return nil
+}
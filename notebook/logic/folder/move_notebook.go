@@ -0,0 +1,113 @@
+package folderLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// MoveNotebook переносит журнал в папку parentId или в корень отдела (parentId == divisionId).
+// Ссылки в Files старой и новой папки и отдел журнала меняются в одной транзакции.
+func (f FolderMongoLogic) MoveNotebook(notebookId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID) error {
+	// 1. Validate input parameters
+	if notebookId == uuid.Nil || caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil || parentId == uuid.Nil {
+		return fmt.Errorf("notebook, employee, company, division and parent IDs cannot be nil")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MoveNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MoveNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Relink the notebook between folders in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		notebook, err := md.Notebook.GetNotebookById(sc, &session, notebookId.String())
+		if err != nil {
+			return nil, err
+		}
+		if notebook.Metadata.CompanyID != companyId.String() {
+			return nil, fmt.Errorf("notebook does not belong to the company: %w", permission.ErrForbidden)
+		}
+		if err := requireAccess(sc, md, &session, companyId.String(), notebook.UuidID, caller, permission.AccessEdit); err != nil {
+			return nil, err
+		}
+
+		parent, newDivision, err := destination(sc, md, &session, companyId.String(), divisionId.String(), parentId.String(), caller)
+		if err != nil {
+			return nil, err
+		}
+
+		// Журнал, созданный вне папки, ни в одной папке не числится
+		source, err := md.Folder.GetFolderByFileId(sc, &session, notebook.UuidID)
+		if err != nil && !errors.Is(err, directory.ErrNotFound) {
+			return nil, err
+		}
+		if source != nil && parent != nil && source.UuidID == parent.UuidID {
+			return nil, nil
+		}
+
+		if source != nil {
+			if err := md.Folder.RemoveFile(sc, &session, source.UuidID, notebook.UuidID); err != nil {
+				return nil, err
+			}
+		}
+		if parent != nil {
+			ref := directory.NewFile(notebookId, notebook.Metadata.Title, notebook.Metadata.Description)
+			if _, err := md.Folder.AddFile(sc, &session, parent.UuidID, ref); err != nil {
+				return nil, err
+			}
+		}
+		if notebook.Metadata.DivisionID != newDivision {
+			return nil, md.Notebook.SetDivision(sc, &session, []string{notebook.UuidID}, newDivision)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logger.NewWarnMessage("Failed to move notebook",
+			zap.Error(err),
+			zap.String("operation", "MoveNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("parent_id", parentId.String()),
+		)
+		return fmt.Errorf("failed to move notebook: %w", err)
+	}
+
+	logger.NewInfoMessage("Notebook moved successfully",
+		zap.String("operation", "MoveNotebook"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("parent_id", parentId.String()),
+	)
+
+	return nil
+}
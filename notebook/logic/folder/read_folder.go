@@ -0,0 +1,85 @@
+package folderLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ReadFolder возвращает папку, если сотрудник может ее читать. В списках вложенных папок
+// и журналов остаются только те, которые он тоже может читать.
+// В отличие от GetFolder, которым пользуются компании и отделы, применяет правила доступа папки.
+func (f FolderMongoLogic) ReadFolder(folderId uuid.UUID, caller *employee.Employee) (*directory.Directory, error) {
+	// 1. Validate input
+	if folderId == uuid.Nil || caller == nil {
+		return nil, fmt.Errorf("folderId and employeeId cannot be nil")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ReadFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ReadFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Load the folder and check that the employee may read it
+	dir, err := md.Folder.GetFolderByFolderId(ctx, &session, folderId.String())
+	if err != nil {
+		return nil, err
+	}
+	v, access, err := readerOf(ctx, md, &session, dir, caller)
+	if err != nil {
+		logger.NewWarnMessage("Folder access denied",
+			zap.Error(err),
+			zap.String("operation", "ReadFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Hide subfolders and notebooks the employee may not read; inheriting ones get the folder access
+	ids := notebookIds(dir)
+	for _, sub := range dir.Folders {
+		ids = append(ids, sub.FolderUUID)
+	}
+	perms, err := permissionsOf(ctx, md, &session, ids)
+	if err != nil {
+		return nil, err
+	}
+	dir.Folders = slices.DeleteFunc(dir.Folders, func(sub directory.Folder) bool {
+		return v.access(perms, sub.FolderUUID, access) < permission.AccessRead
+	})
+	dir.Files = slices.DeleteFunc(dir.Files, func(file directory.File) bool {
+		return v.access(perms, file.FileUUID, access) < permission.AccessRead
+	})
+
+	return dir, nil
+}
@@ -0,0 +1,87 @@
+package folderLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// destination находит новое место для папки или журнала: папку parentId или корень отдела divisionId,
+// который должен быть активным отделом компании (иначе directory.ErrNoDivision).
+// Возвращает папку (nil для корня отдела) и отдел, в который попадает объект.
+// Вызывающий должен быть сотрудником компании и иметь право записи в папку назначения.
+func destination(ctx context.Context, md *m.MongoDB, session *mongo.Session, companyId, divisionId, parentId string, caller *employee.Employee) (*directory.Directory, string, error) {
+	if err := permissionLogic.RequireMember(caller, companyId); err != nil {
+		return nil, "", err
+	}
+	if parentId == divisionId {
+		if err := checkDivision(ctx, companyId, divisionId); err != nil {
+			return nil, "", err
+		}
+		return nil, divisionId, nil
+	}
+
+	parent, err := md.Folder.GetFolderByFolderId(ctx, session, parentId)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get target folder: %w", err)
+	}
+	if parent.Metadata.CompanyID != companyId {
+		return nil, "", directory.ErrOtherCompany
+	}
+	if _, err := permissionLogic.Authorize(ctx, md, session, companyId, parent.UuidID, caller, permission.AccessEdit); err != nil {
+		return nil, "", err
+	}
+
+	return parent, parent.Metadata.DivisionID, nil
+}
+
+// requireAccess проверяет, что сотрудник компании companyId имеет право need на папку или журнал
+func requireAccess(ctx context.Context, md *m.MongoDB, session *mongo.Session, companyId, resourceId string, caller *employee.Employee, need permission.Access) error {
+	_, err := permissionLogic.Authorize(ctx, md, session, companyId, resourceId, caller, need)
+	return err
+}
+
+// isWithin сообщает, лежит ли папка folderId внутри ancestorId или совпадает с ней.
+// Поднимается по ParentId до корня отдела: там родитель уже не папка.
+func isWithin(ctx context.Context, md *m.MongoDB, session *mongo.Session, folderId, ancestorId string) (bool, error) {
+	current := folderId
+	for i := 0; i < directory.MaxTreeNodes; i++ {
+		if current == ancestorId {
+			return true, nil
+		}
+		dir, err := md.Folder.GetFolderByFolderId(ctx, session, current)
+		if errors.Is(err, directory.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		current = dir.ParentId
+	}
+	return false, directory.ErrTreeTooLarge
+}
+
+// subtree возвращает папку root и все вложенные в нее папки, родители раньше детей
+func subtree(ctx context.Context, md *m.MongoDB, session *mongo.Session, root *directory.Directory) ([]*directory.Directory, error) {
+	dirs := []*directory.Directory{root}
+	for i := 0; i < len(dirs); i++ {
+		for _, ref := range dirs[i].Folders {
+			if len(dirs) >= directory.MaxTreeNodes {
+				return nil, directory.ErrTreeTooLarge
+			}
+			child, err := md.Folder.GetFolderByFolderId(ctx, session, ref.FolderUUID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get subfolder %s: %w", ref.FolderUUID, err)
+			}
+			dirs = append(dirs, child)
+		}
+	}
+	return dirs, nil
+}
@@ -0,0 +1,86 @@
+package folderLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RenameFolder меняет название и описание папки при совпадении ревизии и возвращает новую ревизию
+func (f FolderMongoLogic) RenameFolder(folderId uuid.UUID, caller *employee.Employee, title, description string, expectedRevision int64) (int64, error) {
+	// 1. Validate input parameters
+	if folderId == uuid.Nil || caller == nil {
+		return 0, fmt.Errorf("folderId and employeeId cannot be nil")
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return 0, directory.ErrInvalidTitle
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "RenameFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "RenameFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Load the folder and check that the employee may edit it
+	dir, err := md.Folder.GetFolderByFolderId(ctx, &session, folderId.String())
+	if err != nil {
+		return 0, err
+	}
+	if err := requireAccess(ctx, md, &session, dir.Metadata.CompanyID, dir.UuidID, caller, permission.AccessEdit); err != nil {
+		return 0, err
+	}
+
+	// 5. Update title and description; the revision check rejects concurrent edits
+	dir.Metadata.Title = title
+	dir.Metadata.Description = strings.TrimSpace(description)
+	dir.Metadata.LastUpdate = directory.NewTimestamp(time.Now(), caller.ID.String())
+
+	newRevision, err := md.Folder.UpdateFolder(ctx, &session, folderId.String(), dir, expectedRevision)
+	if err != nil {
+		logger.NewErrMessage("Failed to rename folder",
+			zap.Error(err),
+			zap.String("operation", "RenameFolder"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return 0, fmt.Errorf("failed to rename folder: %w", err)
+	}
+
+	logger.NewInfoMessage("Folder renamed successfully",
+		zap.String("operation", "RenameFolder"),
+		zap.String("folder_id", folderId.String()),
+		zap.Int64("revision", newRevision),
+	)
+
+	return newRevision, nil
+}
@@ -0,0 +1,57 @@
+package folderLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/notebook/models/directory"
+
+	"github.com/google/uuid"
+)
+
+// requireDivision проверяет, что divisionId - сама компания или ее активный отдел; иначе directory.ErrNoDivision
+func requireDivision(ctx context.Context, tx *sql.Tx, companyId, divisionId uuid.UUID) error {
+	if divisionId == companyId {
+		return nil
+	}
+
+	division, err := postgres.NewPostgresDB().Department.GetDepartmentById(ctx, tx, divisionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return directory.ErrNoDivision
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch department: %w", err)
+	}
+	if division.CompanyID != companyId || !division.IsActive {
+		return directory.ErrNoDivision
+	}
+	return nil
+}
+
+// checkDivision - requireDivision для вызывающих без транзакции PostgreSQL
+func checkDivision(ctx context.Context, companyId, divisionId string) error {
+	company, err := uuid.Parse(companyId)
+	if err != nil {
+		return fmt.Errorf("invalid company ID %q: %w", companyId, err)
+	}
+	division, err := uuid.Parse(divisionId)
+	if err != nil {
+		return directory.ErrNoDivision
+	}
+
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	return requireDivision(ctx, tx, company, division)
+}
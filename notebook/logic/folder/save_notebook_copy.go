@@ -0,0 +1,48 @@
+package folderLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	chainLogic "labyrinth/notebook/logic/chain"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// saveNotebookCopy сохраняет копию журнала, ее первую ревизию и права доступа в транзакции вызывающего.
+// История копии начинается заново: первая ревизия открывает новую цепочку хешей.
+func saveNotebookCopy(
+	ctx context.Context,
+	md *m.MongoDB,
+	session *mongo.Session,
+	nb *journal.Notebook,
+	perm *permission.Permission,
+	author string,
+) error {
+	if err := md.Notebook.CreateNotebook(ctx, session, nb); err != nil {
+		return fmt.Errorf("failed to create notebook copy: %w", err)
+	}
+
+	current, err := md.Notebook.GetNotebookById(ctx, session, nb.UuidID)
+	if err != nil {
+		return fmt.Errorf("failed to read notebook copy: %w", err)
+	}
+
+	changes := revision.DiffBlocks(nil, current.Blocks)
+	rev := revision.NewRevision(*current, revision.ActionCopy, author, revision.ChangedBlockIDs(changes))
+	if err := chainLogic.Seal(&rev, nil); err != nil {
+		return fmt.Errorf("failed to seal revision: %w", err)
+	}
+	if err := md.Revision.CreateRevision(ctx, session, &rev); err != nil {
+		return fmt.Errorf("failed to store revision: %w", err)
+	}
+
+	if err := md.Permission.CreatePermission(ctx, session, perm); err != nil {
+		return fmt.Errorf("failed to create permission: %w", err)
+	}
+
+	return nil
+}
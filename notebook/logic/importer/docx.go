@@ -0,0 +1,394 @@
+package importLogic
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// maxDocxPart - предел размера одной части архива DOCX после распаковки
+const maxDocxPart = 64 << 20
+
+var headingStyle = regexp.MustCompile(`^heading ([1-6])$`)
+
+// xmlNode - элемент XML с дочерними узлами и собственным текстом
+type xmlNode struct {
+	Name     string
+	Attr     map[string]string
+	Children []*xmlNode
+	Text     string
+}
+
+// docx - открытый документ Word
+type docx struct {
+	files  map[string]*zip.File
+	styles map[string]string
+	rels   map[string]string
+	b      *builder
+}
+
+// parseDocx разбирает word/document.xml: абзацы, заголовки, списки, таблицы и рисунки
+func parseDocx(b *builder, data []byte) error {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	d := &docx{files: map[string]*zip.File{}, styles: map[string]string{}, rels: map[string]string{}, b: b}
+	for _, f := range archive.File {
+		d.files[f.Name] = f
+	}
+
+	// 1. The document body is mandatory
+	document, err := d.node("word/document.xml")
+	if err != nil || document == nil {
+		return fmt.Errorf("%w: word/document.xml is missing or malformed", ErrInvalidFile)
+	}
+
+	// 2. Style names ("heading 1") are stable across locales, unlike style ids
+	if styles, err := d.node("word/styles.xml"); err == nil && styles != nil {
+		for _, style := range styles.all("style") {
+			if name := style.child("name"); name != nil {
+				d.styles[style.Attr["styleId"]] = strings.ToLower(name.Attr["val"])
+			}
+		}
+	}
+	if rels, err := d.node("word/_rels/document.xml.rels"); err == nil && rels != nil {
+		for _, rel := range rels.all("Relationship") {
+			if rel.Attr["TargetMode"] == "External" {
+				continue
+			}
+			d.rels[rel.Attr["Id"]] = path.Clean(path.Join("word", rel.Attr["Target"]))
+		}
+	}
+	if core, err := d.node("docProps/core.xml"); err == nil && core != nil {
+		if title := core.child("title"); title != nil {
+			b.res.Title = strings.TrimSpace(title.text())
+		}
+	}
+
+	body := document.child("body")
+	if body == nil {
+		return fmt.Errorf("%w: document has no body", ErrInvalidFile)
+	}
+
+	// 3. Walk top-level content, merging runs of list and code paragraphs
+	var list, code []string
+	flush := func(loc string) {
+		if len(list) > 0 {
+			b.text(strings.Join(list, "\n"), "markdown", loc)
+			list = nil
+		}
+		if len(code) > 0 {
+			b.code(strings.Join(code, "\n"), "", loc)
+			code = nil
+		}
+	}
+
+	for n, el := range d.content(body) {
+		loc := fmt.Sprintf("element %d", n+1)
+		switch el.Name {
+		case "tbl":
+			flush(loc)
+			d.table(el, loc)
+
+		case "p":
+			style := d.styles[el.path("pPr", "pStyle").attr("val")]
+			text := d.paragraph(el, loc)
+
+			switch {
+			case el.path("pPr", "numPr") != nil:
+				if len(code) > 0 {
+					flush(loc)
+				}
+				list = append(list, "- "+strings.TrimSpace(text))
+			case strings.Contains(style, "code") || strings.Contains(style, "preformatted"):
+				if len(list) > 0 {
+					flush(loc)
+				}
+				code = append(code, text)
+			default:
+				flush(loc)
+				if m := headingStyle.FindStringSubmatch(style); m != nil {
+					b.heading(text, int(m[1][0]-'0'), loc)
+				} else if style == "title" {
+					if b.res.Title == "" {
+						b.res.Title = strings.TrimSpace(text)
+					} else {
+						b.heading(text, 1, loc)
+					}
+				} else {
+					b.text(text, "plain", loc)
+				}
+			}
+
+		case "sectPr", "bookmarkStart", "bookmarkEnd", "proofErr":
+
+		default:
+			b.issue(loc, el.Name, "unsupported document element was skipped")
+		}
+	}
+	flush("end of document")
+	return nil
+}
+
+// content раскрывает элементы управления содержимым (w:sdt) в их содержимое
+func (d *docx) content(parent *xmlNode) []*xmlNode {
+	var out []*xmlNode
+	for _, el := range parent.Children {
+		if el.Name == "sdt" {
+			if inner := el.child("sdtContent"); inner != nil {
+				out = append(out, d.content(inner)...)
+			}
+			continue
+		}
+		out = append(out, el)
+	}
+	return out
+}
+
+// paragraph собирает текст абзаца; рисунки добавляются отдельными блоками после него
+func (d *docx) paragraph(p *xmlNode, loc string) string {
+	var sb strings.Builder
+	var images []string
+
+	var walk func(n *xmlNode)
+	walk = func(n *xmlNode) {
+		switch n.Name {
+		case "t":
+			sb.WriteString(n.Text)
+			return
+		case "tab":
+			sb.WriteByte('\t')
+			return
+		case "br", "cr":
+			sb.WriteByte('\n')
+			return
+		case "delText", "instrText", "pPr", "rPr":
+			return
+		case "blip":
+			if id := n.Attr["embed"]; id != "" {
+				images = append(images, id)
+			}
+			return
+		case "oMath", "oMathPara":
+			d.b.issue(loc, "equation", "Office Math equations are imported as plain text")
+			sb.WriteString(n.text())
+			return
+		case "object", "pict":
+			d.b.issue(loc, "embedded object", "embedded OLE objects and legacy drawings were skipped")
+			return
+		case "txbxContent":
+			d.b.issue(loc, "text box", "text boxes were skipped")
+			return
+		case "footnoteReference", "endnoteReference":
+			d.b.issue(loc, "footnote", "footnotes and endnotes were skipped")
+			return
+		}
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	walk(p)
+
+	text := sb.String()
+	if len(images) == 0 {
+		return text
+	}
+
+	// Images are separate blocks, so the surrounding text is emitted first
+	d.b.text(text, "plain", loc)
+	for _, id := range images {
+		target, ok := d.rels[id]
+		if !ok {
+			d.b.issue(loc, "image", "linked (not embedded) image was skipped")
+			continue
+		}
+		data, err := d.read(target)
+		if err != nil {
+			d.b.issue(loc, "image", fmt.Sprintf("image %q could not be read", target))
+			continue
+		}
+		d.b.image(path.Base(target), data, "", loc)
+	}
+	return ""
+}
+
+// table переносит таблицу Word; первая строка считается заголовком
+func (d *docx) table(tbl *xmlNode, loc string) {
+	var grid [][]string
+	merged := false
+
+	for _, tr := range tbl.all("tr") {
+		var row []string
+		for _, tc := range tr.all("tc") {
+			if tc.path("tcPr", "gridSpan") != nil || tc.path("tcPr", "vMerge") != nil {
+				merged = true
+			}
+			var parts []string
+			for _, el := range tc.Children {
+				switch el.Name {
+				case "p":
+					parts = append(parts, d.paragraph(el, loc))
+				case "tbl":
+					d.b.issue(loc, "nested table", "tables inside table cells were skipped")
+				}
+			}
+			row = append(row, strings.TrimSpace(strings.Join(parts, "\n")))
+		}
+		grid = append(grid, row)
+	}
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		d.b.issue(loc, "table", "empty table was skipped")
+		return
+	}
+	if merged {
+		d.b.issue(loc, "table", "merged cells were split; their text is kept in the first cell")
+	}
+
+	columns := make([]any, len(grid[0]))
+	for k, c := range grid[0] {
+		columns[k] = c
+	}
+	rows := []any{}
+	for _, cells := range grid[1:] {
+		row := make([]any, len(columns))
+		for k := range row {
+			row[k] = ""
+			if k < len(cells) {
+				row[k] = cells[k]
+			}
+		}
+		rows = append(rows, row)
+	}
+	d.b.add(blocksLogic.TypeTable, map[string]any{"columns": columns, "rows": rows}, "", loc)
+}
+
+// node читает и разбирает XML-часть архива; отсутствующая часть - не ошибка
+func (d *docx) node(name string) (*xmlNode, error) {
+	if _, ok := d.files[name]; !ok {
+		return nil, nil
+	}
+	data, err := d.read(name)
+	if err != nil {
+		return nil, err
+	}
+	return parseXML(data)
+}
+
+// read распаковывает часть архива с ограничением размера
+func (d *docx) read(name string) ([]byte, error) {
+	f, ok := d.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, maxDocxPart+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDocxPart {
+		return nil, fmt.Errorf("%s is too large", name)
+	}
+	return data, nil
+}
+
+// parseXML строит дерево элементов; пространства имен отбрасываются
+func parseXML(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &xmlNode{Name: t.Name.Local, Attr: map[string]string{}}
+			for _, a := range t.Attr {
+				n.Attr[a.Name.Local] = a.Value
+			}
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			stack[len(stack)-1].Text += string(t)
+		}
+	}
+	if len(root.Children) == 0 {
+		return nil, fmt.Errorf("empty XML document")
+	}
+	return root.Children[0], nil
+}
+
+func (n *xmlNode) child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *xmlNode) path(names ...string) *xmlNode {
+	for _, name := range names {
+		n = n.child(name)
+	}
+	return n
+}
+
+func (n *xmlNode) attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	return n.Attr[name]
+}
+
+// all возвращает всех потомков с заданным именем, не заходя во вложенные совпадения
+func (n *xmlNode) all(name string) []*xmlNode {
+	var out []*xmlNode
+	for _, c := range n.Children {
+		if c.Name == name {
+			out = append(out, c)
+			continue
+		}
+		out = append(out, c.all(name)...)
+	}
+	return out
+}
+
+// text возвращает весь текст элемента и его потомков
+func (n *xmlNode) text() string {
+	var sb strings.Builder
+	var walk func(*xmlNode)
+	walk = func(n *xmlNode) {
+		sb.WriteString(n.Text)
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
@@ -0,0 +1,177 @@
+package importLogic
+
+import (
+	"errors"
+	"fmt"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"labyrinth/notebook/models/journal"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Поддерживаемые форматы импорта
+const (
+	FormatMarkdown = "markdown"
+	FormatDocx     = "docx"
+	FormatJupyter  = "ipynb"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported import format")
+	ErrInvalidFile       = errors.New("invalid import file")
+)
+
+// Asset - изображение из импортируемого файла, которое нужно загрузить в хранилище.
+// Key временный: блоки ссылаются на него в object_key до загрузки.
+type Asset struct {
+	Key         string
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// Issue - конструкция исходного файла, которую не удалось перенести без потерь
+type Issue struct {
+	Location  string `json:"location"`
+	Construct string `json:"construct"`
+	Message   string `json:"message"`
+}
+
+// Result - журнал, собранный из файла, до сохранения
+type Result struct {
+	Format string
+	Title  string
+	Blocks []journal.Block
+	Assets []Asset
+	Issues []Issue
+}
+
+// Report - итог импорта для клиента
+type Report struct {
+	NotebookID string  `json:"notebook_id"`
+	Title      string  `json:"title"`
+	Format     string  `json:"format"`
+	Blocks     int     `json:"blocks"`
+	Images     int     `json:"images"`
+	Issues     []Issue `json:"issues"`
+}
+
+// DetectFormat определяет формат по расширению файла
+func DetectFormat(fileName string) (string, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case ".docx":
+		return FormatDocx, nil
+	case ".ipynb":
+		return FormatJupyter, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, path.Ext(fileName))
+}
+
+// Parse превращает файл в блоки журнала. Заголовок берется из метаданных файла
+// или первого заголовка первого уровня, иначе - из имени файла.
+func Parse(fileName string, data []byte) (*Result, error) {
+	format, err := DetectFormat(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &builder{res: &Result{Format: format, Blocks: []journal.Block{}, Assets: []Asset{}, Issues: []Issue{}}}
+	switch format {
+	case FormatMarkdown:
+		parseMarkdown(b, string(data), nil, "")
+	case FormatDocx:
+		err = parseDocx(b, data)
+	case FormatJupyter:
+		err = parseNotebook(b, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b.promoteTitle()
+	if strings.TrimSpace(b.res.Title) == "" {
+		b.res.Title = strings.TrimSuffix(path.Base(fileName), path.Ext(fileName))
+	}
+	return b.res, nil
+}
+
+// builder накапливает блоки, изображения и замечания
+type builder struct {
+	res *Result
+}
+
+// add добавляет блок; если тело не проходит схему типа, блок сохраняется как текст с замечанием
+func (b *builder) add(blockType string, body map[string]any, fallback, location string) {
+	if err := blocksLogic.Validate(blockType, body); err != nil {
+		b.issue(location, blockType, "converted to text: "+err.Error())
+		blockType, body = blocksLogic.TypeText, map[string]any{"content": fallback, "format": "plain"}
+	}
+	b.res.Blocks = append(b.res.Blocks, journal.NewBlock(blockType, body))
+}
+
+func (b *builder) text(content, format, location string) {
+	if strings.TrimSpace(content) == "" {
+		return
+	}
+	b.add(blocksLogic.TypeText, map[string]any{"content": content, "format": format}, content, location)
+}
+
+func (b *builder) heading(text string, level int, location string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	b.add(blocksLogic.TypeHeading, map[string]any{"text": text, "level": level}, text, location)
+}
+
+func (b *builder) code(code, language, location string) {
+	body := map[string]any{"code": code}
+	if language != "" {
+		body["language"] = language
+	}
+	b.add(blocksLogic.TypeCode, body, code, location)
+}
+
+// image сохраняет изображение как вложение и добавляет блок, ссылающийся на него
+func (b *builder) image(fileName string, data []byte, caption, location string) {
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		b.issue(location, "image", fmt.Sprintf("unsupported image format of %q was skipped", fileName))
+		return
+	}
+
+	key := fmt.Sprintf("asset-%d%s", len(b.res.Assets)+1, path.Ext(fileName))
+	b.res.Assets = append(b.res.Assets, Asset{Key: key, FileName: fileName, ContentType: contentType, Data: data})
+
+	body := map[string]any{"object_key": key, "content_type": contentType}
+	if fileName != "" {
+		body["file_name"] = fileName
+	}
+	if caption != "" {
+		body["caption"] = caption
+	}
+	b.add(blocksLogic.TypeImage, body, caption, location)
+}
+
+func (b *builder) issue(location, construct, message string) {
+	b.res.Issues = append(b.res.Issues, Issue{Location: location, Construct: construct, Message: message})
+}
+
+// promoteTitle делает первый заголовок первого уровня названием журнала, если название не задано
+func (b *builder) promoteTitle() {
+	if b.res.Title != "" || len(b.res.Blocks) == 0 {
+		return
+	}
+	first := b.res.Blocks[0]
+	if first.Type != blocksLogic.TypeHeading {
+		return
+	}
+	if level, _ := first.Body["level"].(int); level != 1 {
+		return
+	}
+	b.res.Title, _ = first.Body["text"].(string)
+	b.res.Blocks = b.res.Blocks[1:]
+}
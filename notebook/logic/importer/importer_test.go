@@ -0,0 +1,249 @@
+package importLogic_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	importLogic "labyrinth/notebook/logic/importer"
+	"strings"
+	"testing"
+)
+
+func pngBytes(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("png.Encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func blockTypes(res *importLogic.Result) string {
+	types := make([]string, len(res.Blocks))
+	for i, b := range res.Blocks {
+		types[i] = b.Type
+	}
+	return strings.Join(types, ",")
+}
+
+func hasIssue(res *importLogic.Result, construct string) bool {
+	for _, issue := range res.Issues {
+		if issue.Construct == construct {
+			return true
+		}
+	}
+	return false
+}
+
+func docxFile(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("zip.Create failed: %v", err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("zip write failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("zip.Close failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		if _, err := importLogic.Parse("protocol.odt", []byte("x")); !errors.Is(err, importLogic.ErrUnsupportedFormat) {
+			t.Fatalf("Expected ErrUnsupportedFormat, got %v", err)
+		}
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		src := strings.Join([]string{
+			"# Синтез образца",
+			"",
+			"Навеска **0,5 г**,",
+			"растворить в воде.",
+			"",
+			"## Реактивы",
+			"",
+			"| Вещество | Масса \\| г |",
+			"|---|---:|",
+			"| NaCl | 0.5 |",
+			"| KCl |",
+			"",
+			"```python",
+			"print('ok')",
+			"```",
+			"",
+			"$$",
+			"E = mc^2",
+			"$$",
+			"",
+			"- [x] Взвесить",
+			"- [ ] Растворить",
+			"",
+			"![Спектр](data:image/png;base64," + base64.StdEncoding.EncodeToString(pngBytes(t)) + ")",
+			"![Схема](scheme.png)",
+			"",
+			"---",
+			"",
+			"<div>raw</div>",
+		}, "\n")
+
+		res, err := importLogic.Parse("protocol.md", []byte(src))
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if res.Title != "Синтез образца" {
+			t.Errorf("Expected title from the first heading, got %q", res.Title)
+		}
+		if got, want := blockTypes(res), "text,heading,table,code,formula,checklist,image,text,text"; got != want {
+			t.Fatalf("Expected blocks %s, got %s", want, got)
+		}
+
+		table := res.Blocks[2].Body
+		if cols := table["columns"].([]any); cols[1] != "Масса | г" {
+			t.Errorf("Expected escaped pipe in column, got %v", cols[1])
+		}
+		if rows := table["rows"].([]any); len(rows[1].([]any)) != 2 {
+			t.Errorf("Expected short row to be padded")
+		}
+		if res.Blocks[3].Body["language"] != "python" {
+			t.Errorf("Expected code language python")
+		}
+		if len(res.Assets) != 1 || res.Blocks[6].Body["object_key"] != res.Assets[0].Key || res.Assets[0].ContentType != "image/png" {
+			t.Errorf("Expected image block to reference the uploaded asset")
+		}
+		for _, construct := range []string{"table", "image", "thematic break", "html"} {
+			if !hasIssue(res, construct) {
+				t.Errorf("Expected issue for %s", construct)
+			}
+		}
+	})
+
+	t.Run("MarkdownTitleFallsBackToFileName", func(t *testing.T) {
+		res, err := importLogic.Parse("notes/Опыт 3.markdown", []byte("Текст\n\nВторой\n===\n"))
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if res.Title != "Опыт 3" {
+			t.Errorf("Expected title from the file name, got %q", res.Title)
+		}
+		if got := blockTypes(res); got != "text,heading" {
+			t.Errorf("Expected setext heading, got %s", got)
+		}
+	})
+
+	t.Run("Docx", func(t *testing.T) {
+		const w = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:m="http://schemas.openxmlformats.org/officeDocument/2006/math"`
+		data := docxFile(t, map[string]string{
+			"word/document.xml": `<?xml version="1.0"?><w:document ` + w + `><w:body>
+				<w:p><w:pPr><w:pStyle w:val="1"/></w:pPr><w:r><w:t>Методика</w:t></w:r></w:p>
+				<w:p><w:r><w:t xml:space="preserve">Первый </w:t></w:r><w:r><w:t>абзац</w:t></w:r></w:p>
+				<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/></w:numPr></w:pPr><w:r><w:t>пункт 1</w:t></w:r></w:p>
+				<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/></w:numPr></w:pPr><w:r><w:t>пункт 2</w:t></w:r></w:p>
+				<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Образец</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Масса</w:t></w:r></w:p></w:tc></w:tr>
+					<w:tr><w:tc><w:p><w:r><w:t>S-1</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>0,5</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+				<w:p><w:r><w:drawing><a:blip r:embed="rId5"/></w:drawing></w:r></w:p>
+				<w:p><w:pPr><w:pStyle w:val="Code"/></w:pPr><w:r><w:t>x = 1</w:t></w:r></w:p>
+				<w:p><m:oMath><m:r><m:t>a+b</m:t></m:r></m:oMath></w:p>
+				<w:sectPr/>
+			</w:body></w:document>`,
+			"word/styles.xml": `<?xml version="1.0"?><w:styles ` + w + `>
+				<w:style w:styleId="1"><w:name w:val="heading 1"/></w:style>
+				<w:style w:styleId="Code"><w:name w:val="HTML Code"/></w:style>
+			</w:styles>`,
+			"word/_rels/document.xml.rels": `<?xml version="1.0"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+				<Relationship Id="rId5" Target="media/image1.png"/>
+			</Relationships>`,
+			"word/media/image1.png": string(pngBytes(t)),
+			"docProps/core.xml":     `<?xml version="1.0"?><cp:coreProperties xmlns:cp="x" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Протокол</dc:title></cp:coreProperties>`,
+		})
+
+		res, err := importLogic.Parse("protocol.docx", data)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if res.Title != "Протокол" {
+			t.Errorf("Expected title from document properties, got %q", res.Title)
+		}
+		if got, want := blockTypes(res), "heading,text,text,table,image,code,text"; got != want {
+			t.Fatalf("Expected blocks %s, got %s", want, got)
+		}
+		if res.Blocks[2].Body["content"] != "- пункт 1\n- пункт 2" {
+			t.Errorf("Expected list items to be merged, got %q", res.Blocks[2].Body["content"])
+		}
+		if rows := res.Blocks[3].Body["rows"].([]any); rows[0].([]any)[1] != "0,5" {
+			t.Errorf("Unexpected table rows %v", rows)
+		}
+		if len(res.Assets) != 1 || res.Assets[0].FileName != "image1.png" {
+			t.Errorf("Expected embedded image asset, got %+v", res.Assets)
+		}
+		if !hasIssue(res, "equation") {
+			t.Errorf("Expected issue for equation")
+		}
+	})
+
+	t.Run("DocxInvalid", func(t *testing.T) {
+		if _, err := importLogic.Parse("broken.docx", []byte("not a zip")); !errors.Is(err, importLogic.ErrInvalidFile) {
+			t.Fatalf("Expected ErrInvalidFile, got %v", err)
+		}
+	})
+
+	t.Run("Jupyter", func(t *testing.T) {
+		img := base64.StdEncoding.EncodeToString(pngBytes(t))
+		src := `{
+			"nbformat": 4,
+			"metadata": {"language_info": {"name": "python"}},
+			"cells": [
+				{"cell_type": "markdown", "source": ["# Анализ\n", "\n", "![plot](attachment:plot.png)"],
+				 "attachments": {"plot.png": {"image/png": "` + img + `"}}},
+				{"cell_type": "code", "source": "print(1)\n1/0", "outputs": [
+					{"output_type": "stream", "name": "stdout", "text": ["1\n"]},
+					{"output_type": "display_data", "data": {"image/png": "` + img + `", "text/plain": "<Figure>"}},
+					{"output_type": "execute_result", "data": {"text/html": "<b>x</b>", "text/plain": "x"}},
+					{"output_type": "error", "ename": "ZeroDivisionError", "evalue": "division by zero", "traceback": ["\u001b[0;31mZeroDivisionError\u001b[0m"]}
+				]},
+				{"cell_type": "raw", "source": "raw text"}
+			]
+		}`
+
+		res, err := importLogic.Parse("analysis.ipynb", []byte(src))
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if res.Title != "Анализ" {
+			t.Errorf("Expected title from the first heading, got %q", res.Title)
+		}
+		if got, want := blockTypes(res), "image,code,code,image,code,code,text"; got != want {
+			t.Fatalf("Expected blocks %s, got %s", want, got)
+		}
+		if res.Blocks[1].Body["language"] != "python" {
+			t.Errorf("Expected kernel language on code cells")
+		}
+		if res.Blocks[5].Body["code"] != "ZeroDivisionError" {
+			t.Errorf("Expected ANSI codes to be stripped, got %q", res.Blocks[5].Body["code"])
+		}
+		if len(res.Assets) != 2 {
+			t.Errorf("Expected 2 image assets, got %d", len(res.Assets))
+		}
+		if !hasIssue(res, "text/html") {
+			t.Errorf("Expected issue for HTML output")
+		}
+	})
+
+	t.Run("JupyterOldFormat", func(t *testing.T) {
+		if _, err := importLogic.Parse("old.ipynb", []byte(`{"nbformat": 3, "worksheets": []}`)); !errors.Is(err, importLogic.ErrInvalidFile) {
+			t.Fatalf("Expected ErrInvalidFile, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package importLogic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+type jupyterNotebook struct {
+	NBFormat int `json:"nbformat"`
+	Metadata struct {
+		Title      string `json:"title"`
+		KernelSpec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+	Cells []jupyterCell `json:"cells"`
+}
+
+type jupyterCell struct {
+	CellType    string                                `json:"cell_type"`
+	Source      json.RawMessage                       `json:"source"`
+	Outputs     []jupyterOutput                       `json:"outputs"`
+	Attachments map[string]map[string]json.RawMessage `json:"attachments"`
+}
+
+type jupyterOutput struct {
+	OutputType string                     `json:"output_type"`
+	Name       string                     `json:"name"`
+	Text       json.RawMessage            `json:"text"`
+	Data       map[string]json.RawMessage `json:"data"`
+	EName      string                     `json:"ename"`
+	EValue     string                     `json:"evalue"`
+	Traceback  []string                   `json:"traceback"`
+}
+
+// parseNotebook переносит ячейки Jupyter (nbformat 4): Markdown, код и его вывод
+func parseNotebook(b *builder, data []byte) error {
+	var nb jupyterNotebook
+	if err := json.Unmarshal(data, &nb); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if nb.NBFormat < 4 {
+		return fmt.Errorf("%w: nbformat %d is not supported, convert the notebook to version 4", ErrInvalidFile, nb.NBFormat)
+	}
+
+	b.res.Title = strings.TrimSpace(nb.Metadata.Title)
+	language := nb.Metadata.LanguageInfo.Name
+	if language == "" {
+		language = nb.Metadata.KernelSpec.Language
+	}
+
+	for n, cell := range nb.Cells {
+		loc := fmt.Sprintf("cell %d", n+1)
+		source := multiline(cell.Source)
+
+		switch cell.CellType {
+		case "markdown":
+			attachments := map[string][]byte{}
+			for name, bundle := range cell.Attachments {
+				for mime, raw := range bundle {
+					if strings.HasPrefix(mime, "image/") && mime != "image/svg+xml" {
+						if decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(multiline(raw), "\n", "")); err == nil {
+							attachments[name] = decoded
+						}
+					}
+				}
+			}
+			parseMarkdown(b, source, attachments, loc)
+
+		case "code":
+			if strings.TrimSpace(source) != "" {
+				b.code(source, language, loc)
+			}
+			for _, out := range cell.Outputs {
+				notebookOutput(b, out, loc+" output")
+			}
+
+		case "raw":
+			b.text(source, "plain", loc)
+
+		default:
+			b.issue(loc, cell.CellType, "unknown cell type was skipped")
+		}
+	}
+	return nil
+}
+
+// notebookOutput переносит результат выполнения ячейки, выбирая самое богатое поддерживаемое представление
+func notebookOutput(b *builder, out jupyterOutput, loc string) {
+	switch out.OutputType {
+	case "stream":
+		if text := multiline(out.Text); strings.TrimSpace(text) != "" {
+			b.code(text, "text", loc)
+		}
+
+	case "error":
+		trace := ansiEscape.ReplaceAllString(strings.Join(out.Traceback, "\n"), "")
+		if trace == "" {
+			trace = out.EName + ": " + out.EValue
+		}
+		b.code(trace, "text", loc)
+
+	case "execute_result", "display_data":
+		for _, mime := range []string{"image/png", "image/jpeg", "image/gif"} {
+			if raw, ok := out.Data[mime]; ok {
+				data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(multiline(raw), "\n", ""))
+				if err != nil {
+					b.issue(loc, mime, "image output could not be decoded")
+					break
+				}
+				b.image("output"+extension(mime), data, "", loc)
+				return
+			}
+		}
+		if raw, ok := out.Data["text/latex"]; ok {
+			latex := strings.Trim(strings.TrimSpace(multiline(raw)), "$")
+			if latex != "" {
+				b.add(blocksLogic.TypeFormula, map[string]any{"latex": latex, "display": true}, latex, loc)
+				return
+			}
+		}
+		if raw, ok := out.Data["text/markdown"]; ok {
+			parseMarkdown(b, multiline(raw), nil, loc)
+			return
+		}
+		if raw, ok := out.Data["text/plain"]; ok {
+			if _, html := out.Data["text/html"]; html {
+				b.issue(loc, "text/html", "HTML output was replaced by its plain text representation")
+			}
+			b.code(multiline(raw), "text", loc)
+			return
+		}
+		mimes := make([]string, 0, len(out.Data))
+		for mime := range out.Data {
+			mimes = append(mimes, mime)
+		}
+		sort.Strings(mimes)
+		for _, mime := range mimes {
+			b.issue(loc, mime, "output type is not supported and was skipped")
+		}
+
+	default:
+		b.issue(loc, out.OutputType, "unknown output type was skipped")
+	}
+}
+
+// multiline читает строку Jupyter, записанную строкой или массивом строк
+func multiline(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var parts []string
+	if err := json.Unmarshal(raw, &parts); err == nil {
+		return strings.Join(parts, "")
+	}
+	return ""
+}
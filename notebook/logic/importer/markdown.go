@@ -0,0 +1,292 @@
+package importLogic
+
+import (
+	"encoding/base64"
+	"fmt"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"regexp"
+	"strings"
+)
+
+var (
+	atxHeading    = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	fenceOpen     = regexp.MustCompile("^(`{3,}|~{3,})\\s*([^`\\s]*)")
+	tableDivider  = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	imageLine     = regexp.MustCompile(`^!\[([^\]]*)\]\(\s*(\S+?)(?:\s+"[^"]*")?\s*\)$`)
+	inlineImage   = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	taskItem      = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	thematicBreak = regexp.MustCompile(`^(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	htmlBlock     = regexp.MustCompile(`^</?[A-Za-z][A-Za-z0-9-]*[\s/>]|^<!--`)
+	setextLine    = regexp.MustCompile(`^(=+|-+)\s*$`)
+)
+
+// parseMarkdown разбирает Markdown построчно. attachments - вложения ячейки Jupyter,
+// на которые ссылаются как attachment:имя; prefix добавляется к номерам строк в замечаниях.
+func parseMarkdown(b *builder, src string, attachments map[string][]byte, prefix string) {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	// 1. Front matter is metadata, not content
+	i := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" && prefix == "" {
+		for j := 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "---" {
+				for _, meta := range lines[1:j] {
+					if key, value, ok := strings.Cut(meta, ":"); ok && strings.TrimSpace(key) == "title" {
+						b.res.Title = strings.Trim(strings.TrimSpace(value), `"'`)
+					}
+				}
+				b.issue(location(prefix, 1), "front matter", "only the title field is imported")
+				i = j + 1
+				break
+			}
+		}
+	}
+
+	var para []string
+	paraStart := 0
+	flush := func() {
+		if len(para) == 0 {
+			return
+		}
+		content := strings.Join(para, "\n")
+		if inlineImage.MatchString(content) {
+			b.issue(location(prefix, paraStart), "inline image", "images inside a paragraph are kept as Markdown links")
+		}
+		b.text(content, "markdown", location(prefix, paraStart))
+		para = nil
+	}
+
+	// 2. Walk the lines, turning each recognised construct into a block
+	for i < len(lines) {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		loc := location(prefix, i+1)
+
+		switch {
+		case trimmed == "":
+			flush()
+			i++
+
+		case len(para) > 0 && setextLine.MatchString(trimmed) && !strings.HasPrefix(line, "    "):
+			level := 1
+			if trimmed[0] == '-' {
+				level = 2
+			}
+			heading := strings.Join(para, " ")
+			para = nil
+			b.heading(heading, level, location(prefix, paraStart))
+			i++
+
+		case fenceOpen.MatchString(trimmed):
+			flush()
+			m := fenceOpen.FindStringSubmatch(trimmed)
+			fence := m[1]
+			var code []string
+			j := i + 1
+			for ; j < len(lines); j++ {
+				if closing := strings.TrimSpace(lines[j]); strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+					break
+				}
+				code = append(code, lines[j])
+			}
+			if j == len(lines) {
+				b.issue(loc, "code fence", "unterminated code fence was closed at the end of the document")
+			}
+			b.code(strings.Join(code, "\n"), m[2], loc)
+			i = j + 1
+
+		case atxHeading.MatchString(trimmed):
+			flush()
+			m := atxHeading.FindStringSubmatch(trimmed)
+			b.heading(m[2], len(m[1]), loc)
+			i++
+
+		case strings.HasPrefix(trimmed, "$$"):
+			flush()
+			latex, next := displayMath(lines, i)
+			if strings.TrimSpace(latex) == "" {
+				b.issue(loc, "formula", "empty formula was skipped")
+			} else {
+				b.add(blocksLogic.TypeFormula, map[string]any{"latex": strings.TrimSpace(latex), "display": true}, latex, loc)
+			}
+			i = next
+
+		case strings.Contains(trimmed, "|") && i+1 < len(lines) && tableDivider.MatchString(strings.TrimSpace(lines[i+1])) && strings.Contains(lines[i+1], "-"):
+			flush()
+			i = markdownTable(b, lines, i, prefix)
+
+		case imageLine.MatchString(trimmed):
+			flush()
+			m := imageLine.FindStringSubmatch(trimmed)
+			markdownImage(b, m[1], m[2], trimmed, attachments, loc)
+			i++
+
+		case taskItem.MatchString(line) && len(para) == 0:
+			var items []any
+			for ; i < len(lines) && taskItem.MatchString(lines[i]); i++ {
+				m := taskItem.FindStringSubmatch(lines[i])
+				items = append(items, map[string]any{"text": strings.TrimSpace(m[2]), "checked": m[1] != " "})
+			}
+			b.add(blocksLogic.TypeChecklist, map[string]any{"items": items}, "", loc)
+
+		case len(para) == 0 && thematicBreak.MatchString(trimmed):
+			b.issue(loc, "thematic break", "horizontal rules are not supported and were dropped")
+			i++
+
+		case len(para) == 0 && htmlBlock.MatchString(trimmed):
+			var raw []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				raw = append(raw, lines[i])
+			}
+			b.issue(loc, "html", "raw HTML is kept as plain text")
+			b.text(strings.Join(raw, "\n"), "plain", loc)
+
+		case len(para) == 0 && (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")):
+			var code []string
+			for ; i < len(lines); i++ {
+				l := lines[i]
+				if strings.TrimSpace(l) != "" && !strings.HasPrefix(l, "    ") && !strings.HasPrefix(l, "\t") {
+					break
+				}
+				code = append(code, strings.TrimPrefix(strings.TrimPrefix(l, "\t"), "    "))
+			}
+			b.code(strings.TrimRight(strings.Join(code, "\n"), "\n"), "", loc)
+
+		default:
+			if len(para) == 0 {
+				paraStart = i + 1
+			}
+			para = append(para, line)
+			i++
+		}
+	}
+	flush()
+}
+
+// displayMath собирает формулу между $$ и возвращает индекс следующей строки
+func displayMath(lines []string, i int) (string, int) {
+	first := strings.TrimPrefix(strings.TrimSpace(lines[i]), "$$")
+	if strings.HasSuffix(first, "$$") {
+		return strings.TrimSuffix(first, "$$"), i + 1
+	}
+
+	body := []string{first}
+	for j := i + 1; j < len(lines); j++ {
+		if l := strings.TrimSpace(lines[j]); strings.HasSuffix(l, "$$") {
+			body = append(body, strings.TrimSuffix(l, "$$"))
+			return strings.Join(body, "\n"), j + 1
+		}
+		body = append(body, lines[j])
+	}
+	return strings.Join(body, "\n"), len(lines)
+}
+
+// markdownTable разбирает таблицу GFM, начинающуюся со строки i
+func markdownTable(b *builder, lines []string, i int, prefix string) int {
+	loc := location(prefix, i+1)
+	header := tableCells(lines[i])
+
+	columns := make([]any, len(header))
+	for k, c := range header {
+		columns[k] = c
+	}
+
+	rows := []any{}
+	ragged := false
+	j := i + 2
+	for ; j < len(lines) && strings.Contains(lines[j], "|") && strings.TrimSpace(lines[j]) != ""; j++ {
+		cells := tableCells(lines[j])
+		if len(cells) != len(header) {
+			ragged = true
+		}
+		row := make([]any, len(header))
+		for k := range row {
+			row[k] = ""
+			if k < len(cells) {
+				row[k] = cells[k]
+			}
+		}
+		rows = append(rows, row)
+	}
+	if ragged {
+		b.issue(loc, "table", "rows with a different number of cells were padded or truncated to the header")
+	}
+
+	b.add(blocksLogic.TypeTable, map[string]any{"columns": columns, "rows": rows}, strings.Join(lines[i:j], "\n"), loc)
+	return j
+}
+
+// tableCells делит строку таблицы на ячейки с учетом экранированных \|
+func tableCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for k := 0; k < len(line); k++ {
+		switch {
+		case line[k] == '\\' && k+1 < len(line) && line[k+1] == '|':
+			cell.WriteByte('|')
+			k++
+		case line[k] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[k])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// markdownImage переносит изображение из data: URI или вложения Jupyter.
+// Ссылки на внешние файлы и адреса не загружаются.
+func markdownImage(b *builder, alt, src, raw string, attachments map[string][]byte, loc string) {
+	switch {
+	case strings.HasPrefix(src, "attachment:"):
+		name := strings.TrimPrefix(src, "attachment:")
+		if data, ok := attachments[name]; ok {
+			b.image(name, data, alt, loc)
+			return
+		}
+
+	case strings.HasPrefix(src, "data:"):
+		meta, payload, ok := strings.Cut(strings.TrimPrefix(src, "data:"), ",")
+		if ok && strings.HasSuffix(meta, ";base64") {
+			if data, err := base64.StdEncoding.DecodeString(payload); err == nil {
+				b.image("image"+extension(strings.TrimSuffix(meta, ";base64")), data, alt, loc)
+				return
+			}
+		}
+	}
+
+	b.issue(loc, "image", fmt.Sprintf("image %q is referenced by path or URL and was kept as a link", src))
+	b.text(raw, "markdown", loc)
+}
+
+// location формирует ссылку на место в исходном файле
+func location(prefix string, line int) string {
+	if prefix == "" {
+		return fmt.Sprintf("line %d", line)
+	}
+	return fmt.Sprintf("%s, line %d", prefix, line)
+}
+
+// extension подбирает расширение файла по MIME-типу изображения
+func extension(contentType string) string {
+	switch contentType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "image/bmp":
+		return ".bmp"
+	}
+	return ""
+}
@@ -1,37 +1,125 @@
 package notebookLogic
 
 import (
+	"context"
+	"labyrinth/models/employee"
+	chainLogic "labyrinth/notebook/logic/chain"
+	exportLogic "labyrinth/notebook/logic/export"
 	folderLogic "labyrinth/notebook/logic/folder"
+	importLogic "labyrinth/notebook/logic/importer"
 	notebookLogic "labyrinth/notebook/logic/notebook"
 	permissionLogic "labyrinth/notebook/logic/permission"
+	searchLogic "labyrinth/notebook/logic/search"
+	tagLogic "labyrinth/notebook/logic/tag"
+	templateLogic "labyrinth/notebook/logic/template"
+	trashLogic "labyrinth/notebook/logic/trash"
+	workflowLogic "labyrinth/notebook/logic/workflow"
 	"labyrinth/notebook/models/directory"
 	"labyrinth/notebook/models/journal"
 	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/search"
+	"labyrinth/notebook/models/tag"
+	"labyrinth/notebook/models/template"
+	"labyrinth/notebook/models/trash"
+	"labyrinth/notebook/models/workflow"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type notebookInterface interface {
-	NewNotebook(employeeId, companyId, divisionId uuid.UUID, title, description string) error
-	GetNotebook(notebookId uuid.UUID) (*journal.Notebook, error)
-	UpdateNotebook(notebookId uuid.UUID, updatedNotebook *journal.Notebook) error
-	DeleteNotebook(notebookId uuid.UUID) error
+	NewNotebook(caller *employee.Employee, companyId, divisionId uuid.UUID, title, description string) error
+	NewNotebookFromTemplate(caller *employee.Employee, companyId, divisionId, templateId uuid.UUID, title string, values map[string]string) (uuid.UUID, error)
+	GetNotebook(notebookId uuid.UUID, caller *employee.Employee) (*journal.Notebook, error)
+	UpdateNotebook(notebookId uuid.UUID, caller *employee.Employee, updatedNotebook *journal.Notebook, expectedRevision int64) (int64, error)
+	DeleteNotebook(notebookId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error
+	InsertBlock(notebookId uuid.UUID, caller *employee.Employee, blockType string, body map[string]any, position int) (*journal.Block, error)
+	UpdateBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string, blockType string, body map[string]any) error
+	UpdateBlockAt(notebookId uuid.UUID, caller *employee.Employee, blockId string, blockType string, body map[string]any, expectedRevision int64) (int64, error)
+	MoveBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string, position int) error
+	DeleteBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string) error
+	ListRevisions(notebookId uuid.UUID, caller *employee.Employee, limit, offset int) (*[]revision.Revision, int64, error)
+	GetRevision(notebookId uuid.UUID, caller *employee.Employee, number int64) (*revision.Revision, error)
+	DiffRevisions(notebookId uuid.UUID, caller *employee.Employee, from, to int64) (*[]revision.BlockChange, error)
+	RestoreRevision(notebookId uuid.UUID, caller *employee.Employee, number, expectedRevision int64) (int64, error)
+	VerifyChain(notebookId uuid.UUID) (*chainLogic.Report, error)
+	AddComment(notebookId uuid.UUID, caller *employee.Employee, blockId, parentId, text string) (*journal.Comment, error)
+	UpdateComment(notebookId uuid.UUID, caller *employee.Employee, blockId, commentId, text string) (*journal.Comment, error)
+	DeleteComment(notebookId uuid.UUID, caller *employee.Employee, blockId, commentId string) error
+	ResolveComment(notebookId uuid.UUID, caller *employee.Employee, blockId, commentId string, resolved bool) (*journal.Comment, error)
+	ExportNotebook(notebookId uuid.UUID, caller *employee.Employee, format string, includeComments bool) (*exportLogic.File, error)
+	ImportNotebook(caller *employee.Employee, companyId, divisionId, folderId uuid.UUID, fileName string, data []byte) (*importLogic.Report, error)
+	SignNotebook(notebookId uuid.UUID, caller *employee.Employee, password, meaning string, blockIds []string) (*journal.Signature, error)
+	WitnessSignature(notebookId uuid.UUID, caller *employee.Employee, signatureId, password, meaning string) (*journal.Signature, error)
+	ChangeStatus(notebookId uuid.UUID, caller *employee.Employee, action string, reviewerId uuid.UUID, note string) (*journal.Lifecycle, error)
+	MigrateBlockIds(dryRun bool) (int64, error)
+	MigrateComments(dryRun bool) (*journal.CommentMigration, error)
+	MigrateAuthors(dryRun bool) (*journal.AuthorMigration, error)
 }
 
 type directoryInterface interface {
-	CreateFolder(employeeId, companyId, divisionId, parentId uuid.UUID, isPrimary bool, title, description string) error
+	CreateFolder(caller *employee.Employee, companyId, divisionId, parentId uuid.UUID, isPrimary bool, title, description string) (uuid.UUID, error)
 	GetFolder(folderId uuid.UUID) (*directory.Directory, error)
-	UpdateFolder(folderId uuid.UUID, dir *directory.Directory) error
-	DeleteFolder(folderId uuid.UUID) error
+	ReadFolder(folderId uuid.UUID, caller *employee.Employee) (*directory.Directory, error)
+	DeleteFolder(folderId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error
+	RenameFolder(folderId uuid.UUID, caller *employee.Employee, title, description string, expectedRevision int64) (int64, error)
+	GetTree(folderId uuid.UUID, caller *employee.Employee, depth int) (*directory.Node, error)
+	GetDepartmentTree(departmentId uuid.UUID, caller *employee.Employee, companyId uuid.UUID, depth int) ([]directory.Node, error)
+	MoveFolder(folderId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID) error
+	CopyFolder(folderId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID, title string) (uuid.UUID, error)
+	MoveNotebook(notebookId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID) error
+	CopyNotebook(notebookId uuid.UUID, caller *employee.Employee, companyId, divisionId, parentId uuid.UUID, title string) (uuid.UUID, error)
 }
 type permissionInterface interface {
-	GetPermission(objectId uuid.UUID) (*permission.Permission, error)
-	UpdatePermission(objectId uuid.UUID, updatedPerm *permission.Permission) error
+	GetPermission(objectId uuid.UUID, caller *employee.Employee) (*permission.Permission, error)
+	UpdatePermission(objectId uuid.UUID, caller *employee.Employee, updatedPerm *permission.Permission, expectedRevision int64) (int64, error)
+	GetEffectivePermission(objectId uuid.UUID, caller *employee.Employee) (*permission.Effective, error)
+	CheckAccess(resourceId uuid.UUID, caller *employee.Employee, need permission.Access) error
+	MigrateToEmployees(dryRun bool) (*permission.Migration, error)
+}
+type templateInterface interface {
+	SaveTemplate(notebookId uuid.UUID, caller *employee.Employee, companyId, divisionId uuid.UUID, companyWide bool, title, description string, tags []string) (*template.Template, error)
+	ListTemplates(companyId, divisionId uuid.UUID) (*[]template.Template, error)
+	GetTemplate(templateId, companyId, divisionId uuid.UUID) (*template.Template, error)
+	UpdateTemplate(templateId uuid.UUID, caller *employee.Employee, companyId, divisionId uuid.UUID, updated *template.Template, expectedRevision int64) (int64, error)
+	DeleteTemplate(templateId uuid.UUID, caller *employee.Employee, companyId, divisionId uuid.UUID) error
+}
+type searchInterface interface {
+	Search(caller *employee.Employee, companyId uuid.UUID, query search.Query) (*search.Result, error)
+}
+type tagInterface interface {
+	CreateTag(caller *employee.Employee, companyId uuid.UUID, name, color string) (*tag.Tag, error)
+	ListTags(caller *employee.Employee, companyId uuid.UUID) ([]tag.Usage, error)
+	SuggestTags(companyId uuid.UUID, prefix string, limit int) ([]tag.Tag, error)
+	UpdateTag(tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID, name, color string) (*tag.Tag, error)
+	MergeTags(sourceId, targetId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) (*tag.Tag, error)
+	DeleteTag(tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error
+	TagResource(kind string, resourceId, tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error
+	UntagResource(kind string, resourceId, tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error
+	ListTaggedNotebooks(tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID, limit, offset int) ([]tag.TaggedNotebook, int64, error)
+}
+type workflowInterface interface {
+	GetWorkflow(companyId uuid.UUID) (*workflow.Workflow, error)
+	UpdateWorkflow(caller *employee.Employee, companyId uuid.UUID, transitions []workflow.Transition, editable []string) (*workflow.Workflow, error)
+	ListReviews(caller *employee.Employee, companyId uuid.UUID) ([]workflow.ReviewItem, error)
+}
+type trashInterface interface {
+	ListTrash(caller *employee.Employee, companyId, divisionId uuid.UUID, limit, offset int) ([]trash.Item, int64, error)
+	RestoreItem(itemId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) (*trash.Item, error)
+	PurgeItem(itemId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error
+	PurgeExpired() (int, error)
+	RunTrashPurger(ctx context.Context, interval time.Duration)
 }
 type FileSystem struct {
 	Folder     directoryInterface
 	File       notebookInterface
 	Permission permissionInterface
+	Template   templateInterface
+	Search     searchInterface
+	Tag        tagInterface
+	Workflow   workflowInterface
+	Trash      trashInterface
 }
 
 func NewFileSystem() *FileSystem {
@@ -39,5 +127,10 @@ func NewFileSystem() *FileSystem {
 		Folder:     folderLogic.NewFolderMongoLogic(),
 		File:       notebookLogic.NewNotebookMongoLogic(),
 		Permission: permissionLogic.NewPermissionMongoLogic(),
+		Template:   templateLogic.NewTemplateMongoLogic(),
+		Search:     searchLogic.NewSearchMongoLogic(),
+		Tag:        tagLogic.NewTagMongoLogic(),
+		Workflow:   workflowLogic.NewWorkflowMongoLogic(),
+		Trash:      trashLogic.NewTrashMongoLogic(),
 	}
 }
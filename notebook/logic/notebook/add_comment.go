@@ -0,0 +1,119 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// AddComment добавляет комментарий к блоку; с parentId комментарий становится ответом в ветке
+func (n NotebookMongoLogic) AddComment(
+	notebookId uuid.UUID, caller *employee.Employee,
+	blockId, parentId, text string,
+) (*journal.Comment, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "AddComment"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+	if strings.TrimSpace(blockId) == "" {
+		logger.NewWarnMessage("Empty block ID provided",
+			zap.String("operation", "AddComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, errors.New("block ID cannot be empty")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > journal.MaxCommentLength {
+		logger.NewWarnMessage("Invalid comment text",
+			zap.String("operation", "AddComment"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Int("length", utf8.RuneCountInString(text)),
+		)
+		return nil, journal.ErrInvalidComment
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "AddComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "AddComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check permission, add comment and record revision in one transaction
+	comment := journal.NewComment(caller.ID.String(), parentId, text)
+	result, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		block, _, err := loadCommentBlock(sc, md, &session, notebookId.String(), caller, blockId)
+		if err != nil {
+			return nil, err
+		}
+		if parentId != "" {
+			parent, err := block.FindComment(parentId)
+			if err != nil {
+				return nil, err
+			}
+			if parent.Deleted {
+				return nil, journal.ErrCommentNotFound
+			}
+		}
+
+		if err := md.Notebook.AddComment(sc, &session, notebookId.String(), blockId, &comment, journal.NewDateTimeAuthor(caller.ID.String())); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionAddComment, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to add comment",
+			zap.Error(err),
+			zap.String("operation", "AddComment"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("block_id", blockId),
+		)
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	notifyMentions(result, caller.UserID, blockId, comment.Id, text)
+
+	logger.NewInfoMessage("Comment added successfully",
+		zap.String("operation", "AddComment"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("block_id", blockId),
+		zap.String("comment_id", comment.Id),
+	)
+
+	return &comment, nil
+}
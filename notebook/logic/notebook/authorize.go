@@ -0,0 +1,27 @@
+package notebookLogic
+
+import (
+	"context"
+	m "labyrinth/database/mongo"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// authorize загружает журнал и проверяет, что сотрудник компании журнала имеет на него право need.
+// Возвращает журнал и действующее право сотрудника с учетом унаследованного от папок.
+func authorize(ctx context.Context, md *m.MongoDB, session *mongo.Session, notebookId string, caller *employee.Employee, need permission.Access) (*journal.Notebook, permission.Access, error) {
+	notebook, err := md.Notebook.GetNotebookById(ctx, session, notebookId)
+	if err != nil {
+		return nil, permission.AccessNone, err
+	}
+
+	access, err := permissionLogic.Authorize(ctx, md, session, notebook.Metadata.CompanyID, notebookId, caller, need)
+	if err != nil {
+		return nil, permission.AccessNone, err
+	}
+	return notebook, access, nil
+}
@@ -0,0 +1,202 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	workflowLogic "labyrinth/notebook/logic/workflow"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/workflow"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ChangeStatus выполняет переход журнала по процессу согласования компании.
+// Отправить на проверку может сотрудник с полным доступом, назначив рецензентом сотрудника отдела журнала по его ID;
+// решение по журналу на проверке принимает только назначенный рецензент. Рецензент без доступа
+// к журналу получает право комментирования.
+func (n NotebookMongoLogic) ChangeStatus(notebookId uuid.UUID, caller *employee.Employee, action string, reviewerId uuid.UUID, note string) (*journal.Lifecycle, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil || caller == nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "ChangeStatus"),
+		)
+		return nil, errors.New("notebook and employee IDs cannot be empty")
+	}
+	action = strings.TrimSpace(action)
+	if action == "" {
+		return nil, fmt.Errorf("%w: action is required", workflow.ErrInvalidTransition)
+	}
+	note = strings.TrimSpace(note)
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "ChangeStatus"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "ChangeStatus"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 4. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ChangeStatus"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ChangeStatus"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Apply the transition, store the new status and record revision in one transaction
+	var next journal.Lifecycle
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		notebook, err := md.Notebook.GetNotebookById(sc, &session, notebookId.String())
+		if err != nil {
+			return nil, err
+		}
+		if err := permissionLogic.RequireMember(caller, notebook.Metadata.CompanyID); err != nil {
+			return nil, err
+		}
+		companyId, err := uuid.Parse(notebook.Metadata.CompanyID)
+		if err != nil {
+			return nil, fmt.Errorf("notebook has invalid company ID: %w", err)
+		}
+		departmentId, err := uuid.Parse(notebook.Metadata.DivisionID)
+		if err != nil {
+			return nil, fmt.Errorf("notebook has invalid department ID: %w", err)
+		}
+
+		chain, err := permissionLogic.LoadChain(sc, md, &session, notebookId.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notebook permission: %w", err)
+		}
+		perm := &chain[0]
+
+		pg := postgres.NewPostgresDB()
+		company, err := pg.Company.GetCompanyByID(ctx, tx, companyId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch company: %w", err)
+		}
+
+		w, err := workflowLogic.Load(sc, md, &session, companyId.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow: %w", err)
+		}
+
+		actor := workflowLogic.Actor{ID: caller.ID.String(), Owner: company.OwnerID == caller.UserID}
+		if actor.Level, err = departmentLevel(ctx, tx, departmentId, caller.ID); err != nil {
+			return nil, err
+		}
+
+		// вне проверки статус меняет тот, кто может изменять журнал; на проверке - рецензент
+		if notebook.Lifecycle.CurrentStatus() != journal.StatusInReview && !actor.Owner {
+			if permissionLogic.EffectiveAccess(chain, caller.ID.String()) < permission.AccessEdit {
+				return nil, permission.ErrForbidden
+			}
+		}
+
+		// рецензент - ID активного сотрудника компании журнала
+		var member *employee.Employee
+		reviewer := ""
+		if reviewerId != uuid.Nil {
+			member, err = pg.Employee.GetEmployeeById(ctx, tx, reviewerId)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, workflow.ErrInvalidReviewer
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch reviewer: %w", err)
+			}
+			if !member.IsActive || member.CompanyID != companyId {
+				return nil, workflow.ErrInvalidReviewer
+			}
+			reviewer = member.ID.String()
+		}
+
+		next, err = workflowLogic.Apply(*w, notebook.Lifecycle, action, actor, notebook.Metadata.Created.Author, reviewer, note, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		if next.Status == journal.StatusInReview {
+			level, err := departmentLevel(ctx, tx, departmentId, member.ID)
+			if err != nil {
+				return nil, err
+			}
+			if level == 0 || !workflowLogic.CanReview(*w, level) {
+				return nil, workflow.ErrInvalidReviewer
+			}
+
+			if permissionLogic.EffectiveAccess(chain, member.ID.String()) < permission.AccessComment {
+				perm.Rules.CommentOnly = append(perm.Rules.CommentOnly, member.ID.String())
+				if _, err := md.Permission.UpdatePermission(sc, &session, notebookId.String(), perm, perm.Revision); err != nil {
+					return nil, fmt.Errorf("failed to grant reviewer access: %w", err)
+				}
+			}
+		}
+
+		if err := md.Notebook.SetLifecycle(sc, &session, notebookId.String(), &next, notebook.Revision); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionStatusChange, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to change notebook status",
+			zap.Error(err),
+			zap.String("operation", "ChangeStatus"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("action", action),
+		)
+		return nil, fmt.Errorf("failed to change notebook status: %w", err)
+	}
+
+	logger.NewInfoMessage("Notebook status changed successfully",
+		zap.String("operation", "ChangeStatus"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("action", action),
+		zap.String("status", next.Status),
+		zap.String("reviewer", next.Reviewer),
+	)
+
+	return &next, nil
+}
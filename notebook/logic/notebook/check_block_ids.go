@@ -0,0 +1,35 @@
+package notebookLogic
+
+import (
+	"fmt"
+	"labyrinth/notebook/models/journal"
+
+	"github.com/google/uuid"
+)
+
+// checkBlockIds проверяет ID блоков, присланных клиентом вместо сохраненных stored:
+// ID не повторяются и принадлежат существующим блокам журнала. Блоки без ID получают серверный ID.
+func checkBlockIds(blocks []journal.Block, stored []journal.Block) error {
+	known := make(map[string]bool, len(stored))
+	for _, b := range stored {
+		known[b.Id] = true
+	}
+
+	seen := make(map[string]bool, len(blocks))
+	for i := range blocks {
+		id := blocks[i].Id
+		if id == "" {
+			blocks[i].Id = uuid.New().String()
+			continue
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: block %d repeats id %s", journal.ErrInvalidBlockId, i, id)
+		}
+		if !known[id] {
+			return fmt.Errorf("%w: block %d has unknown id %s", journal.ErrInvalidBlockId, i, id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package notebookLogic
+
+import (
+	"errors"
+	"labyrinth/notebook/models/journal"
+	"testing"
+)
+
+func TestCheckBlockIds(t *testing.T) {
+	stored := []journal.Block{{Id: "a"}, {Id: "b"}}
+
+	t.Run("KnownAndNew", func(t *testing.T) {
+		blocks := []journal.Block{{Id: "b"}, {Id: ""}, {Id: "a"}}
+		if err := checkBlockIds(blocks, stored); err != nil {
+			t.Fatalf("checkBlockIds failed: %v\n", err)
+		}
+		if blocks[1].Id == "" || blocks[1].Id == "a" || blocks[1].Id == "b" {
+			t.Errorf("Expected a new server ID, got (%s)\n", blocks[1].Id)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		blocks := []journal.Block{{Id: "a"}, {Id: "a"}}
+		if err := checkBlockIds(blocks, stored); !errors.Is(err, journal.ErrInvalidBlockId) {
+			t.Errorf("Expected ErrInvalidBlockId for a repeated ID, got %v\n", err)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		blocks := []journal.Block{{Id: "a"}, {Id: "client-made"}}
+		if err := checkBlockIds(blocks, stored); !errors.Is(err, journal.ErrInvalidBlockId) {
+			t.Errorf("Expected ErrInvalidBlockId for an unknown ID, got %v\n", err)
+		}
+	})
+}
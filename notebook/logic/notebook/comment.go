@@ -0,0 +1,34 @@
+package notebookLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// loadCommentBlock проверяет право сотрудника комментировать журнал и возвращает блок вместе с действующим правом
+func loadCommentBlock(
+	ctx context.Context,
+	md *m.MongoDB,
+	session *mongo.Session,
+	notebookId string,
+	caller *employee.Employee,
+	blockId string,
+) (*journal.Block, permission.Access, error) {
+	notebook, access, err := authorize(ctx, md, session, notebookId, caller, permission.AccessComment)
+	if err != nil {
+		return nil, permission.AccessNone, fmt.Errorf("failed to authorize comment: %w", err)
+	}
+
+	block, err := notebook.FindBlock(blockId)
+	if err != nil {
+		return nil, permission.AccessNone, err
+	}
+
+	return block, access, nil
+}
@@ -0,0 +1,34 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/notebook/models/journal"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// confirmPassword повторно проверяет пароль пользователя, под которым работает сотрудник, перед подписью.
+// Неверный пароль и отсутствие пользователя одинаково дают journal.ErrInvalidPassword.
+func confirmPassword(ctx context.Context, tx *sql.Tx, userId uuid.UUID, password string) error {
+	if password == "" {
+		return journal.ErrInvalidPassword
+	}
+
+	user, err := postgres.NewPostgresDB().User.GetUserByID(ctx, tx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return journal.ErrInvalidPassword
+		}
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return journal.ErrInvalidPassword
+	}
+	return nil
+}
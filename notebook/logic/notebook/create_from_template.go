@@ -0,0 +1,219 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/template"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// NewNotebookFromTemplate создает журнал отдела из шаблона и возвращает его ID.
+// Переменные date, time и author заполняет сервер, остальные (например, sample_id) передает клиент.
+func (n NotebookMongoLogic) NewNotebookFromTemplate(
+	caller *employee.Employee,
+	companyId, divisionId, templateId uuid.UUID,
+	title string,
+	values map[string]string,
+) (uuid.UUID, error) {
+	// 1. Validate input parameters
+	if caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil || templateId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "NewNotebookFromTemplate"),
+		)
+		return uuid.Nil, errors.New("employee, company, division and template IDs cannot be empty")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return uuid.Nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return uuid.Nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Check that the caller works in the company; permission lists hold employee IDs
+	if err := permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+		logger.NewWarnMessage("Notebook creation denied",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return uuid.Nil, err
+	}
+
+	// 6. Generate notebook UUID and resolve author name
+	ps := postgres.NewPostgresDB()
+	generatedId, err := ps.UuidValidation.CheckAndReserveUUID(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("UUID generation failed",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return uuid.Nil, fmt.Errorf("uuid generation failed: %w", err)
+	}
+
+	author := employeeName(ctx, tx, caller.ID.String())
+
+	// 7. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 8. Start MongoDB session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return uuid.Nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 9. Load template available to the department
+	tpl, err := md.Template.GetTemplateByUuidId(ctx, &session, templateId.String())
+	if err != nil {
+		logger.NewWarnMessage("Failed to get template",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("template_id", templateId.String()),
+		)
+		return uuid.Nil, err
+	}
+	if !tpl.AvailableIn(companyId.String(), divisionId.String()) {
+		return uuid.Nil, template.ErrNotFound
+	}
+
+	// 10. Fill placeholders; server-side variables cannot be overridden by the client
+	filled := make(map[string]string, len(values)+3)
+	for name, value := range values {
+		filled[name] = value
+	}
+	now := time.Now()
+	filled[template.VarDate] = now.Format("2006-01-02")
+	filled[template.VarTime] = now.Format("15:04")
+	filled[template.VarAuthor] = author
+
+	notebookTitle, blocks, err := tpl.Instantiate(title, filled)
+	if err != nil {
+		logger.NewWarnMessage("Template instantiation failed",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("template_id", templateId.String()),
+		)
+		return uuid.Nil, err
+	}
+	for _, b := range blocks {
+		if err := blocksLogic.Validate(b.Type, b.Body); err != nil {
+			logger.NewWarnMessage("Filled template block failed schema validation",
+				zap.Error(err),
+				zap.String("operation", "NewNotebookFromTemplate"),
+				zap.String("template_id", templateId.String()),
+				zap.String("block_type", b.Type),
+			)
+			return uuid.Nil, err
+		}
+	}
+
+	newNotebook := journal.NewNotebook(
+		caller.ID.String(),
+		companyId.String(),
+		divisionId.String(),
+		generatedId.String(),
+		notebookTitle,
+		template.Fill(tpl.Description, filled),
+	)
+	newNotebook.Metadata.Tags = append([]string{}, tpl.Tags...)
+	newNotebook.Blocks = blocks
+
+	// 11. Create notebook, initial revision and permission in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if err := md.Notebook.CreateNotebook(sc, &session, &newNotebook); err != nil {
+			return nil, fmt.Errorf("failed to create notebook: %w", err)
+		}
+		if _, err := recordRevision(sc, md, &session, newNotebook.UuidID, caller.ID.String(), revision.ActionCreate, nil); err != nil {
+			return nil, fmt.Errorf("failed to record initial revision: %w", err)
+		}
+		newPerm := permission.NewPermission(
+			caller.ID.String(),
+			generatedId.String(),
+			generatedId.String(),
+			"file",
+			newNotebook.ID,
+		)
+		if err := md.Permission.CreatePermission(sc, &session, &newPerm); err != nil {
+			return nil, fmt.Errorf("failed to create permission: %w", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to create notebook from template",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("template_id", templateId.String()),
+			zap.String("notebook_id", generatedId.String()),
+		)
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "NewNotebookFromTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return uuid.Nil, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	logger.NewInfoMessage("Notebook created from template",
+		zap.String("operation", "NewNotebookFromTemplate"),
+		zap.String("employee_id", caller.ID.String()),
+		zap.String("template_id", templateId.String()),
+		zap.String("notebook_id", generatedId.String()),
+	)
+
+	return generatedId, nil
+}
@@ -8,8 +8,11 @@ import (
 	"labyrinth/database/mongo"
 	"labyrinth/database/postgres"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
 	"labyrinth/notebook/models/journal"
 	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
 	"strings"
 	"time"
 
@@ -17,9 +20,9 @@ import (
 	"go.uber.org/zap"
 )
 
-func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.UUID, title, description string) error {
+func (n NotebookMongoLogic) NewNotebook(caller *employee.Employee, companyId, divisionId uuid.UUID, title, description string) error {
 	// 1. Validate input parameters
-	if employeeId == uuid.Nil {
+	if caller == nil {
 		logger.NewErrMessage("Empty employee ID provided",
 			zap.String("operation", "NewNotebook"),
 		)
@@ -29,7 +32,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 	if companyId == uuid.Nil {
 		logger.NewErrMessage("Empty company ID provided",
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return errors.New("company ID cannot be empty")
 	}
@@ -37,7 +40,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 	if divisionId == uuid.Nil {
 		logger.NewErrMessage("Empty division ID provided",
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return errors.New("division ID cannot be empty")
 	}
@@ -45,7 +48,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 	if strings.TrimSpace(title) == "" {
 		logger.NewErrMessage("Empty notebook title provided",
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return errors.New("notebook title cannot be empty")
 	}
@@ -56,7 +59,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 		logger.NewErrMessage("Database connection failed",
 			zap.Error(err),
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return fmt.Errorf("database connection failed: %w", err)
 	}
@@ -72,7 +75,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 		logger.NewErrMessage("Transaction begin failed",
 			zap.Error(err),
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return fmt.Errorf("transaction begin failed: %w", err)
 	}
@@ -84,7 +87,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 				logger.NewErrMessage("Transaction rollback failed",
 					zap.Error(rbErr),
 					zap.String("operation", "NewNotebook"),
-					zap.String("employee_id", employeeId.String()),
+					zap.String("employee_id", caller.ID.String()),
 				)
 			}
 			return
@@ -93,48 +96,58 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 			logger.NewErrMessage("Transaction commit failed",
 				zap.Error(err),
 				zap.String("operation", "NewNotebook"),
-				zap.String("employee_id", employeeId.String()),
+				zap.String("employee_id", caller.ID.String()),
 			)
 		}
 	}()
 
-	// 6. Generate and validate UUID
+	// 6. Check that the caller works in the company; permission lists hold employee IDs
+	if err = permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+		logger.NewWarnMessage("Notebook creation denied",
+			zap.Error(err),
+			zap.String("operation", "NewNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return err
+	}
+
+	// 7. Generate and validate UUID
 	generatedId, err := postgres.NewPostgresDB().UuidValidation.CheckAndReserveUUID(ctx, tx)
 	if err != nil {
 		logger.NewErrMessage("UUID generation failed",
 			zap.Error(err),
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return fmt.Errorf("uuid generation failed: %w", err)
 	}
 
-	// 7. Initialize MongoDB
+	// 8. Initialize MongoDB
 	md, err := mongo.NewMongoDB()
 	if err != nil {
 		logger.NewErrMessage("MongoDB initialization failed",
 			zap.Error(err),
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return fmt.Errorf("failed to initialize MongoDB: %w", err)
 	}
 
-	// 8. Start MongoDB session
+	// 9. Start MongoDB session
 	session, err := md.Client.StartSession()
 	if err != nil {
 		logger.NewErrMessage("MongoDB session start failed",
 			zap.Error(err),
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 		)
 		return fmt.Errorf("failed to start MongoDB session: %w", err)
 	}
 	defer session.EndSession(ctx)
 
-	// 9. Create new notebook
+	// 10. Create new notebook
 	newNotebook := journal.NewNotebook(
-		employeeId.String(),
+		caller.ID.String(),
 		companyId.String(),
 		divisionId.String(),
 		generatedId.String(),
@@ -147,15 +160,26 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 		logger.NewErrMessage("Failed to create notebook",
 			zap.Error(err),
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 			zap.String("notebook_id", generatedId.String()),
 		)
 		return fmt.Errorf("failed to create notebook: %w", err)
 	}
 
-	// 10. Create permission for the notebook
+	// 11. Record initial revision
+	if _, err := recordRevision(ctx, md, &session, newNotebook.UuidID, caller.ID.String(), revision.ActionCreate, nil); err != nil {
+		logger.NewErrMessage("Failed to record initial revision",
+			zap.Error(err),
+			zap.String("operation", "NewNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+			zap.String("notebook_id", generatedId.String()),
+		)
+		return fmt.Errorf("failed to record initial revision: %w", err)
+	}
+
+	// 12. Create permission for the notebook
 	newPerm := permission.NewPermission(
-		employeeId.String(),
+		caller.ID.String(),
 		generatedId.String(),
 		generatedId.String(),
 		"file",
@@ -167,7 +191,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 		logger.NewErrMessage("Failed to create permission",
 			zap.Error(err),
 			zap.String("operation", "NewNotebook"),
-			zap.String("employee_id", employeeId.String()),
+			zap.String("employee_id", caller.ID.String()),
 			zap.String("notebook_id", generatedId.String()),
 		)
 		return fmt.Errorf("failed to create permission: %w", err)
@@ -175,7 +199,7 @@ func (n NotebookMongoLogic) NewNotebook(employeeId, companyId, divisionId uuid.U
 
 	logger.NewInfoMessage("Notebook created successfully",
 		zap.String("operation", "NewNotebook"),
-		zap.String("employee_id", employeeId.String()),
+		zap.String("employee_id", caller.ID.String()),
 		zap.String("notebook_id", generatedId.String()),
 		zap.String("company_id", companyId.String()),
 		zap.String("division_id", divisionId.String()),
@@ -0,0 +1,116 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// DeleteComment удаляет комментарий. Удалить может автор или сотрудник с полным доступом;
+// комментарий с ответами остаётся в ветке без текста, чтобы ответы не потеряли контекст.
+func (n NotebookMongoLogic) DeleteComment(notebookId uuid.UUID, caller *employee.Employee, blockId, commentId string) error {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "DeleteComment"),
+		)
+		return errors.New("notebook ID cannot be empty")
+	}
+	if strings.TrimSpace(blockId) == "" || strings.TrimSpace(commentId) == "" {
+		logger.NewWarnMessage("Empty block or comment ID provided",
+			zap.String("operation", "DeleteComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return errors.New("block ID and comment ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check permission, delete comment and record revision in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		block, access, err := loadCommentBlock(sc, md, &session, notebookId.String(), caller, blockId)
+		if err != nil {
+			return nil, err
+		}
+		comment, err := block.FindComment(commentId)
+		if err != nil {
+			return nil, err
+		}
+		if comment.Deleted {
+			return nil, journal.ErrCommentNotFound
+		}
+		if comment.EmployeeId != caller.ID.String() && access < permission.AccessEdit {
+			return nil, permission.ErrForbidden
+		}
+
+		lastUpdate := journal.NewDateTimeAuthor(caller.ID.String())
+		if block.HasReplies(commentId) {
+			removed := *comment
+			removed.Comment = ""
+			removed.Deleted = true
+			removed.UpdatedAt = time.Now()
+			err = md.Notebook.UpdateComment(sc, &session, notebookId.String(), blockId, &removed, lastUpdate)
+		} else {
+			err = md.Notebook.DeleteComment(sc, &session, notebookId.String(), blockId, commentId, lastUpdate)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionDeleteComment, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to delete comment",
+			zap.Error(err),
+			zap.String("operation", "DeleteComment"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("comment_id", commentId),
+		)
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	logger.NewInfoMessage("Comment deleted successfully",
+		zap.String("operation", "DeleteComment"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("comment_id", commentId),
+	)
+
+	return nil
+}
@@ -2,19 +2,31 @@ package notebookLogic
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"labyrinth/config"
 	m "labyrinth/database/mongo"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	signatureLogic "labyrinth/notebook/logic/signature"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/trash"
 	"time"
 
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
-func (n NotebookMongoLogic) DeleteNotebook(notebookId uuid.UUID) error {
-	// 1. Validate notebookId
-	if notebookId == uuid.Nil {
-		return fmt.Errorf("notebookId cannot be nil")
+// DeleteNotebook убирает журнал в корзину отдела. Права доступа и ревизии сохраняются
+// до восстановления или окончательного удаления; утвержденные, архивные и подписанные журналы удалить нельзя.
+func (n NotebookMongoLogic) DeleteNotebook(notebookId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error {
+	// 1. Validate input
+	if notebookId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		return fmt.Errorf("notebook, employee and company IDs cannot be nil")
 	}
 
 	// 2. Create context with timeout
@@ -46,10 +58,55 @@ func (n NotebookMongoLogic) DeleteNotebook(notebookId uuid.UUID) error {
 	}
 	defer session.EndSession(ctx)
 
-	// 5. Delete notebook
-	err = md.Notebook.DeleteNotebook(ctx, &session, notebookId.String())
+	// 5. Move the notebook into the trash and unlink it from its folder in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		notebook, err := md.Notebook.GetNotebookById(sc, &session, notebookId.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notebook: %w", err)
+		}
+		if notebook.Metadata.CompanyID != companyId.String() {
+			return nil, fmt.Errorf("notebook does not belong to the company: %w", permission.ErrForbidden)
+		}
+
+		if _, err := permissionLogic.Authorize(sc, md, &session, notebook.Metadata.CompanyID, notebookId.String(), caller, permission.AccessEdit); err != nil {
+			return nil, err
+		}
+
+		// Approved, archived and signed notebooks stay as a record
+		if notebook.Lifecycle.ReadOnly {
+			return nil, fmt.Errorf("%w: %s", journal.ErrReadOnly, notebook.Lifecycle.Status)
+		}
+		if err := signatureLogic.VerifyDeletable(notebook); err != nil {
+			return nil, err
+		}
+
+		// A notebook created outside any folder goes back to the department root
+		parentId := notebook.Metadata.DivisionID
+		folder, err := md.Folder.GetFolderByFileId(sc, &session, notebook.UuidID)
+		if err != nil && !errors.Is(err, directory.ErrNotFound) {
+			return nil, err
+		}
+		if folder != nil {
+			parentId = folder.UuidID
+		}
+
+		item := trash.NewItem(trash.KindNotebook, notebook.UuidID, notebook.Metadata.CompanyID, notebook.Metadata.DivisionID,
+			notebook.Metadata.Title, parentId, caller.ID.String(), config.Conf.Trash.Retention)
+		item.Notebooks = 1
+		if err := md.Trash.CreateItem(sc, &session, &item, nil, []journal.Notebook{*notebook}); err != nil {
+			return nil, err
+		}
+
+		if err := md.Notebook.DeleteNotebook(sc, &session, notebook.UuidID); err != nil {
+			return nil, fmt.Errorf("failed to delete notebook: %w", err)
+		}
+		if folder != nil {
+			return nil, md.Folder.RemoveFile(sc, &session, folder.UuidID, notebook.UuidID)
+		}
+		return nil, nil
+	})
 	if err != nil {
-		logger.NewErrMessage(
+		logger.NewWarnMessage(
 			"Failed to delete notebook",
 			zap.Error(err),
 			zap.String("operation", "DeleteNotebook"),
@@ -58,17 +115,10 @@ func (n NotebookMongoLogic) DeleteNotebook(notebookId uuid.UUID) error {
 		return fmt.Errorf("failed to delete notebook: %w", err)
 	}
 
-	// 6. Delete related permissions
-	err = md.Permission.DeletePermission(ctx, &session, notebookId.String())
-	if err != nil {
-		logger.NewErrMessage(
-			"Failed to delete notebook permissions",
-			zap.Error(err),
-			zap.String("operation", "DeleteNotebook"),
-			zap.String("notebook_id", notebookId.String()),
-		)
-		return fmt.Errorf("failed to delete notebook permissions: %w", err)
-	}
+	logger.NewInfoMessage("Notebook moved to trash",
+		zap.String("operation", "DeleteNotebook"),
+		zap.String("notebook_id", notebookId.String()),
+	)
 
 	return nil
 }
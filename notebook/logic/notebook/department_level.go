@@ -0,0 +1,35 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"labyrinth/database/postgres"
+
+	"github.com/google/uuid"
+)
+
+// departmentLevel возвращает уровень должности сотрудника в отделе (1 - самая старшая).
+// 0 означает, что сотрудник не работает в отделе. Активность сотрудника в компании проверяет вызывающий.
+func departmentLevel(ctx context.Context, tx *sql.Tx, departmentId, employeeId uuid.UUID) (int, error) {
+	pg := postgres.NewPostgresDB()
+
+	active, err := pg.DepartmentEmployee.ExistsEmployeeDepartment(ctx, tx, employeeId, departmentId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check department membership: %w", err)
+	}
+	if !active {
+		return 0, nil
+	}
+
+	link, err := pg.DepartmentEmployee.GetEmployeeDepartmentByEmployeeId(ctx, tx, employeeId, departmentId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get department employee: %w", err)
+	}
+
+	position, err := pg.DepartmentEmployeePosition.GetDepartmentPositionById(ctx, tx, link.PositionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get department position: %w", err)
+	}
+	return position.Level, nil
+}
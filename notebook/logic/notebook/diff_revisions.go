@@ -0,0 +1,90 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DiffRevisions возвращает поблочную разницу между ревизиями from и to
+func (n NotebookMongoLogic) DiffRevisions(notebookId uuid.UUID, caller *employee.Employee, from, to int64) (*[]revision.BlockChange, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "DiffRevisions"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+	if from < 0 || to < 0 {
+		return nil, errors.New("revision numbers cannot be negative")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "DiffRevisions"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "DiffRevisions"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may read the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessRead); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", "DiffRevisions"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, err
+	}
+
+	// 6. Fetch both snapshots
+	fromRev, err := md.Revision.GetRevision(ctx, &session, notebookId.String(), from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %d: %w", from, err)
+	}
+	toRev, err := md.Revision.GetRevision(ctx, &session, notebookId.String(), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %d: %w", to, err)
+	}
+
+	// 7. Compare blocks
+	changes := revision.DiffBlocks(fromRev.Snapshot.Blocks, toRev.Snapshot.Blocks)
+
+	logger.NewInfoMessage("Revisions compared successfully",
+		zap.String("operation", "DiffRevisions"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.Int64("from", from),
+		zap.Int64("to", to),
+		zap.Int("changes", len(changes)),
+	)
+
+	return &changes, nil
+}
@@ -0,0 +1,33 @@
+package notebookLogic
+
+import "labyrinth/notebook/models/journal"
+
+// employeeAuthors переводит авторов журнала на ID сотрудников по словарю employees (ID пользователя -> ID сотрудника):
+// создание и последнее изменение, комментарии, подписи и согласование. ID, которых нет в словаре, не меняются.
+// changed = false, если журнал уже хранит ID сотрудников.
+func employeeAuthors(n *journal.Notebook, employees map[string]string) (changed bool) {
+	replace := func(id *string) {
+		if employeeId, ok := employees[*id]; ok {
+			*id = employeeId
+			changed = true
+		}
+	}
+
+	replace(&n.Metadata.Created.Author)
+	replace(&n.Metadata.LastUpdate.Author)
+	for i := range n.Blocks {
+		for j := range n.Blocks[i].Comment {
+			replace(&n.Blocks[i].Comment[j].EmployeeId)
+			replace(&n.Blocks[i].Comment[j].ResolvedBy)
+		}
+	}
+	for i := range n.Signatures {
+		replace(&n.Signatures[i].SignerID)
+	}
+	replace(&n.Lifecycle.Reviewer)
+	for i := range n.Lifecycle.History {
+		replace(&n.Lifecycle.History[i].By)
+		replace(&n.Lifecycle.History[i].Reviewer)
+	}
+	return changed
+}
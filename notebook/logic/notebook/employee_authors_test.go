@@ -0,0 +1,58 @@
+package notebookLogic
+
+import (
+	"labyrinth/notebook/models/journal"
+	"testing"
+)
+
+func TestEmployeeAuthors(t *testing.T) {
+	employees := map[string]string{"user-author": "empl-author", "user-reviewer": "empl-reviewer"}
+
+	t.Run("UserIdsReplaced", func(t *testing.T) {
+		n := journal.Notebook{
+			Metadata: journal.Metadata{
+				Created:    journal.DateTimeAuthor{Author: "user-author"},
+				LastUpdate: journal.DateTimeAuthor{Author: "user-reviewer"},
+			},
+			Blocks: []journal.Block{{Id: "a", Comment: []journal.Comment{
+				{Id: "c1", EmployeeId: "user-reviewer", ResolvedBy: "user-author"},
+				{Id: "c2", EmployeeId: "unknown"},
+			}}},
+			Signatures: []journal.Signature{{Id: "s1", SignerID: "user-author"}},
+			Lifecycle: journal.Lifecycle{
+				Reviewer: "user-reviewer",
+				History:  []journal.StatusChange{{By: "user-author", Reviewer: "user-reviewer"}},
+			},
+		}
+
+		if !employeeAuthors(&n, employees) {
+			t.Fatalf("Expected notebook authors to change\n")
+		}
+		if n.Metadata.Created.Author != "empl-author" || n.Metadata.LastUpdate.Author != "empl-reviewer" {
+			t.Errorf("Unexpected metadata authors: %q, %q\n", n.Metadata.Created.Author, n.Metadata.LastUpdate.Author)
+		}
+		comments := n.Blocks[0].Comment
+		if comments[0].EmployeeId != "empl-reviewer" || comments[0].ResolvedBy != "empl-author" {
+			t.Errorf("Unexpected comment authors: %+v\n", comments[0])
+		}
+		if comments[1].EmployeeId != "unknown" {
+			t.Errorf("Expected unknown ID to stay, got %q\n", comments[1].EmployeeId)
+		}
+		if n.Signatures[0].SignerID != "empl-author" {
+			t.Errorf("Expected signer empl-author, got %q\n", n.Signatures[0].SignerID)
+		}
+		if n.Lifecycle.Reviewer != "empl-reviewer" || n.Lifecycle.History[0].By != "empl-author" || n.Lifecycle.History[0].Reviewer != "empl-reviewer" {
+			t.Errorf("Unexpected lifecycle authors: %+v\n", n.Lifecycle)
+		}
+	})
+
+	t.Run("EmployeeIdsUnchanged", func(t *testing.T) {
+		n := journal.Notebook{
+			Metadata: journal.Metadata{Created: journal.DateTimeAuthor{Author: "empl-author"}},
+			Blocks:   []journal.Block{{Id: "a", Comment: []journal.Comment{{Id: "c1", EmployeeId: "empl-reviewer"}}}},
+		}
+		if employeeAuthors(&n, employees) {
+			t.Errorf("Expected notebook with employee IDs to stay unchanged\n")
+		}
+	})
+}
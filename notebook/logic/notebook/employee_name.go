@@ -0,0 +1,36 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"labyrinth/database/postgres"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// employeeName возвращает отображаемое имя сотрудника для документов;
+// если профиль не найден или не заполнен - email или сам идентификатор.
+// Журналы, созданные до перехода на ID сотрудников, хранят ID пользователя: он ищется напрямую.
+func employeeName(ctx context.Context, tx *sql.Tx, employeeId string) string {
+	id, err := uuid.Parse(employeeId)
+	if err != nil {
+		return employeeId
+	}
+
+	pg := postgres.NewPostgresDB()
+	if empl, err := pg.Employee.GetEmployeeById(ctx, tx, id); err == nil {
+		id = empl.UserID
+	}
+	u, err := pg.User.GetUserByID(ctx, tx, id)
+	if err != nil {
+		return employeeId
+	}
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	}
+	if u.Email != "" {
+		return u.Email
+	}
+	return employeeId
+}
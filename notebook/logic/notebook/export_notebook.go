@@ -0,0 +1,211 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"labyrinth/config"
+	"labyrinth/database/minio"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	exportLogic "labyrinth/notebook/logic/export"
+	"labyrinth/notebook/models/permission"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	minioClient "github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+)
+
+// maxExportImageSize - изображения крупнее не встраиваются в экспорт
+const maxExportImageSize = 20 << 20
+
+// ExportNotebook формирует файл журнала (pdf, html или md) с шапкой: автор, отдел, ревизия, статус подписи
+func (n NotebookMongoLogic) ExportNotebook(notebookId uuid.UUID, caller *employee.Employee, format string, includeComments bool) (*exportLogic.File, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "ExportNotebook"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+	if !exportLogic.Supported(format) {
+		return nil, fmt.Errorf("%w: %q", exportLogic.ErrUnknownFormat, format)
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ExportNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ExportNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Load notebook and check that the employee may read it
+	notebook, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessRead)
+	if err != nil {
+		logger.NewWarnMessage("Failed to get notebook",
+			zap.Error(err),
+			zap.String("operation", "ExportNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to get notebook: %w", err)
+	}
+
+	doc := &exportLogic.Document{
+		Notebook:        notebook,
+		Author:          notebook.Metadata.Created.Author,
+		Department:      notebook.Metadata.DivisionID,
+		Revision:        notebook.Revision,
+		IncludeComments: includeComments,
+		Images:          map[string]exportLogic.Image{},
+	}
+
+	// 6. Resolve author and department names for the header
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "ExportNotebook"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "ExportNotebook"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	doc.Author = employeeName(ctx, tx, notebook.Metadata.Created.Author)
+	doc.SignatureStatus = exportLogic.SignatureLabel(notebook.SignatureStatus())
+	if len(notebook.Signatures) > 0 {
+		signers := make([]string, 0, len(notebook.Signatures))
+		for _, s := range notebook.Signatures {
+			signers = append(signers, fmt.Sprintf("%s - %s, %s", employeeName(ctx, tx, s.SignerID), s.Meaning, s.SignedAt.Format("02.01.2006 15:04")))
+		}
+		doc.SignatureStatus += " (" + strings.Join(signers, "; ") + ")"
+	}
+	if divisionId, err := uuid.Parse(notebook.Metadata.DivisionID); err == nil {
+		if dep, err := postgres.NewPostgresDB().Department.GetDepartmentById(ctx, tx, divisionId); err == nil {
+			doc.Department = dep.Name
+		}
+	}
+
+	// 7. Fetch embedded images from MinIO; unavailable images are marked in the document
+	var keys []string
+	for _, b := range notebook.Blocks {
+		if b.Type != blocksLogic.TypeImage {
+			continue
+		}
+		if key, _ := b.Body["object_key"].(string); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 0 {
+		client, err := minio.NewConnection()
+		if err != nil {
+			logger.NewWarnMessage("MinIO connection failed, images are skipped",
+				zap.Error(err),
+				zap.String("operation", "ExportNotebook"),
+			)
+		} else {
+			storage := minio.NewMinioDB(client)
+			for _, key := range keys {
+				if _, done := doc.Images[key]; done {
+					continue
+				}
+				img, err := downloadImage(ctx, storage, key)
+				if err != nil {
+					logger.NewWarnMessage("Failed to fetch image for export",
+						zap.Error(err),
+						zap.String("operation", "ExportNotebook"),
+						zap.String("object_key", key),
+					)
+					continue
+				}
+				doc.Images[key] = *img
+			}
+		}
+	}
+
+	// 8. Render
+	file, err := exportLogic.Render(format, doc)
+	if err != nil {
+		logger.NewErrMessage("Failed to render notebook",
+			zap.Error(err),
+			zap.String("operation", "ExportNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("format", format),
+		)
+		return nil, fmt.Errorf("failed to render notebook: %w", err)
+	}
+
+	logger.NewInfoMessage("Notebook exported",
+		zap.String("operation", "ExportNotebook"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("employee_id", caller.ID.String()),
+		zap.String("format", format),
+		zap.Int("size", len(file.Data)),
+	)
+
+	return file, nil
+}
+
+// downloadImage читает изображение блока из бакета журналов
+func downloadImage(ctx context.Context, storage *minio.MinioDB, key string) (*exportLogic.Image, error) {
+	obj, err := storage.File.DownloadFile(ctx, config.Conf.Minio.Bucket, key, minioClient.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	info, err := obj.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size > maxExportImageSize {
+		return nil, fmt.Errorf("image is too large: %d bytes", info.Size)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(obj, maxExportImageSize))
+	if err != nil {
+		return nil, err
+	}
+	contentType := info.ContentType
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(data)
+	}
+	return &exportLogic.Image{ContentType: contentType, Data: data}, nil
+}
@@ -0,0 +1,35 @@
+package notebookLogic
+
+import (
+	"labyrinth/notebook/models/journal"
+
+	"github.com/google/uuid"
+)
+
+// flattenComments переводит комментарии блока из прежнего формата в ветки по ParentId:
+// вложенные ответы становятся комментариями с ParentId родителя, комментарии без ID получают новый.
+// Ответы идут сразу за родителем. changed = false, если комментарии уже в новом формате.
+func flattenComments(legacy []journal.LegacyComment) (comments []journal.Comment, changed bool) {
+	comments = make([]journal.Comment, 0, len(legacy))
+	var walk func(level []journal.LegacyComment, parentId string)
+	walk = func(level []journal.LegacyComment, parentId string) {
+		for _, l := range level {
+			c := l.Comment
+			if c.Id == "" {
+				c.Id = uuid.New().String()
+				changed = true
+			}
+			if parentId != "" {
+				c.ParentId = parentId
+			}
+			comments = append(comments, c)
+
+			if len(l.Replies) > 0 {
+				changed = true
+				walk(l.Replies, c.Id)
+			}
+		}
+	}
+	walk(legacy, "")
+	return comments, changed
+}
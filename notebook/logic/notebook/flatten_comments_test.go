@@ -0,0 +1,60 @@
+package notebookLogic
+
+import (
+	"labyrinth/notebook/models/journal"
+	"testing"
+)
+
+func TestFlattenComments(t *testing.T) {
+	t.Run("NestedRepliesBecomeThreads", func(t *testing.T) {
+		legacy := []journal.LegacyComment{
+			{
+				Comment: journal.Comment{EmployeeId: "author", Comment: "Проверьте навеску"},
+				Replies: []journal.LegacyComment{
+					{
+						Comment: journal.Comment{EmployeeId: "reviewer", Comment: "Проверено"},
+						Replies: []journal.LegacyComment{{Comment: journal.Comment{EmployeeId: "author", Comment: "Спасибо"}}},
+					},
+				},
+			},
+			{Comment: journal.Comment{EmployeeId: "witness", Comment: "Вторая ветка"}},
+		}
+
+		comments, changed := flattenComments(legacy)
+		if !changed {
+			t.Errorf("Expected legacy comments to change\n")
+		}
+		if len(comments) != 4 {
+			t.Fatalf("Expected 4 comments, got %d\n", len(comments))
+		}
+		for i, c := range comments {
+			if c.Id == "" {
+				t.Errorf("Expected comment %d to get an ID\n", i)
+			}
+		}
+		if comments[0].ParentId != "" || comments[3].ParentId != "" {
+			t.Errorf("Expected thread starts without parent, got %q and %q\n", comments[0].ParentId, comments[3].ParentId)
+		}
+		if comments[1].ParentId != comments[0].Id || comments[2].ParentId != comments[1].Id {
+			t.Errorf("Expected replies to point at their parents, got %+v\n", comments)
+		}
+		if comments[2].Comment != "Спасибо" {
+			t.Errorf("Expected reply text to stay, got %q\n", comments[2].Comment)
+		}
+	})
+
+	t.Run("ThreadedCommentsUnchanged", func(t *testing.T) {
+		legacy := []journal.LegacyComment{
+			{Comment: journal.Comment{Id: "c1", EmployeeId: "author", Comment: "Начало"}},
+			{Comment: journal.Comment{Id: "c2", ParentId: "c1", EmployeeId: "reviewer", Comment: "Ответ", Resolved: true}},
+		}
+
+		comments, changed := flattenComments(legacy)
+		if changed {
+			t.Errorf("Expected threaded comments to stay as is\n")
+		}
+		if len(comments) != 2 || comments[1].ParentId != "c1" || !comments[1].Resolved {
+			t.Errorf("Expected comments to be kept, got %+v\n", comments)
+		}
+	})
+}
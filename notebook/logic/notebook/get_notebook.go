@@ -6,14 +6,18 @@ import (
 	"fmt"
 	m "labyrinth/database/mongo"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
 	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
 	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-func (n NotebookMongoLogic) GetNotebook(notebookId uuid.UUID) (*journal.Notebook, error) {
+// GetNotebook возвращает журнал, если сотрудник имеет право его читать
+func (n NotebookMongoLogic) GetNotebook(notebookId uuid.UUID, caller *employee.Employee) (*journal.Notebook, error) {
+	// 1. Validate input
 	if notebookId == uuid.Nil {
 		logger.NewErrMessage("Empty notebook ID provided",
 			zap.String("operation", "GetNotebook"),
@@ -47,14 +51,15 @@ func (n NotebookMongoLogic) GetNotebook(notebookId uuid.UUID) (*journal.Notebook
 	}
 	defer session.EndSession(ctx)
 
-	fetchedNotebook, err := md.Notebook.GetNotebookById(ctx, &session, notebookId.String())
+	// 5. Load notebook and check that the employee may read it
+	fetchedNotebook, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessRead)
 	if err != nil {
-		logger.NewErrMessage("Failed to get notebook",
+		logger.NewWarnMessage("Failed to get notebook",
 			zap.Error(err),
 			zap.String("operation", "GetNotebook"),
 			zap.String("notebook_id", notebookId.String()),
 		)
-		return nil, fmt.Errorf("failed to create notebook: %w", err)
+		return nil, fmt.Errorf("failed to get notebook: %w", err)
 	}
 
 	logger.NewInfoMessage("Notebook get successfully",
@@ -0,0 +1,87 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetRevision возвращает ревизию журнала вместе со снимком его содержимого на тот момент
+func (n NotebookMongoLogic) GetRevision(notebookId uuid.UUID, caller *employee.Employee, number int64) (*revision.Revision, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "GetRevision"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+	if number < 0 {
+		return nil, errors.New("revision number cannot be negative")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "GetRevision"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "GetRevision"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may read the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessRead); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", "GetRevision"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, err
+	}
+
+	// 6. Fetch revision snapshot
+	rev, err := md.Revision.GetRevision(ctx, &session, notebookId.String(), number)
+	if err != nil {
+		logger.NewWarnMessage("Failed to get revision",
+			zap.Error(err),
+			zap.String("operation", "GetRevision"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Int64("revision", number),
+		)
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	logger.NewInfoMessage("Revision retrieved successfully",
+		zap.String("operation", "GetRevision"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.Int64("revision", number),
+	)
+
+	return rev, nil
+}
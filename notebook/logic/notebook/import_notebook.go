@@ -0,0 +1,267 @@
+package notebookLogic
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/config"
+	"labyrinth/database/minio"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	importLogic "labyrinth/notebook/logic/importer"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	minioClient "github.com/minio/minio-go/v7"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ImportNotebook создает журнал из файла Markdown, DOCX или Jupyter и кладет его в папку отдела.
+// Изображения загружаются в MinIO; конструкции, перенесенные с потерями, перечислены в отчете.
+func (n NotebookMongoLogic) ImportNotebook(
+	caller *employee.Employee,
+	companyId, divisionId, folderId uuid.UUID,
+	fileName string,
+	data []byte,
+) (*importLogic.Report, error) {
+	// 1. Validate input parameters
+	if caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil || folderId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "ImportNotebook"),
+		)
+		return nil, errors.New("employee, company, division and folder IDs cannot be empty")
+	}
+
+	// 2. Convert the file before touching any storage
+	res, err := importLogic.Parse(fileName, data)
+	if err != nil {
+		logger.NewWarnMessage("Failed to parse imported file",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("file_name", fileName),
+		)
+		return nil, err
+	}
+
+	// 3. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 4. Create context with timeout; image uploads need more time than plain writes
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// 5. Begin transaction and reserve notebook UUID
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	generatedId, err := postgres.NewPostgresDB().UuidValidation.CheckAndReserveUUID(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("UUID generation failed",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, fmt.Errorf("uuid generation failed: %w", err)
+	}
+
+	// 6. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 7. The target folder must belong to the same department and be writable by the employee
+	folder, err := md.Folder.GetFolderByFolderId(ctx, &session, folderId.String())
+	if err != nil {
+		logger.NewWarnMessage("Failed to get target folder",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, err
+	}
+	if folder.Metadata.CompanyID != companyId.String() || folder.Metadata.DivisionID != divisionId.String() {
+		return nil, directory.ErrNotFound
+	}
+	if _, err := permissionLogic.Authorize(ctx, md, &session, folder.Metadata.CompanyID, folderId.String(), caller, permission.AccessEdit); err != nil {
+		logger.NewWarnMessage("Import into folder denied",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("folder_id", folderId.String()),
+		)
+		return nil, err
+	}
+
+	// 8. Upload images and point image blocks at their final object keys
+	var uploaded []string
+	var storage *minio.MinioDB
+	if len(res.Assets) > 0 {
+		client, err := minio.NewConnection()
+		if err != nil {
+			logger.NewErrMessage("MinIO connection failed",
+				zap.Error(err),
+				zap.String("operation", "ImportNotebook"),
+			)
+			return nil, fmt.Errorf("failed to connect to file storage: %w", err)
+		}
+		storage = minio.NewMinioDB(client)
+
+		keys := make(map[string]string, len(res.Assets))
+		for _, asset := range res.Assets {
+			key := fmt.Sprintf("notebooks/%s/%s%s", generatedId, uuid.New(), path.Ext(asset.FileName))
+			err := storage.File.UploadFile(ctx, config.Conf.Minio.Bucket, key,
+				bytes.NewReader(asset.Data), int64(len(asset.Data)),
+				minioClient.PutObjectOptions{ContentType: asset.ContentType},
+			)
+			if err != nil {
+				logger.NewErrMessage("Failed to upload imported image",
+					zap.Error(err),
+					zap.String("operation", "ImportNotebook"),
+					zap.String("file_name", asset.FileName),
+				)
+				removeUploaded(ctx, storage, uploaded)
+				return nil, fmt.Errorf("failed to upload image: %w", err)
+			}
+			uploaded = append(uploaded, key)
+			keys[asset.Key] = key
+		}
+
+		for _, b := range res.Blocks {
+			if b.Type != blocksLogic.TypeImage {
+				continue
+			}
+			temporary, _ := b.Body["object_key"].(string)
+			if key, ok := keys[temporary]; ok {
+				b.Body["object_key"] = key
+			}
+		}
+	}
+
+	newNotebook := journal.NewNotebook(
+		caller.ID.String(),
+		companyId.String(),
+		divisionId.String(),
+		generatedId.String(),
+		res.Title,
+		"",
+	)
+	newNotebook.Blocks = res.Blocks
+
+	// 9. Create notebook, revision and permission and link it into the folder in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if err := md.Notebook.CreateNotebook(sc, &session, &newNotebook); err != nil {
+			return nil, fmt.Errorf("failed to create notebook: %w", err)
+		}
+		if _, err := recordRevision(sc, md, &session, newNotebook.UuidID, caller.ID.String(), revision.ActionImport, nil); err != nil {
+			return nil, fmt.Errorf("failed to record initial revision: %w", err)
+		}
+		newPerm := permission.NewPermission(
+			caller.ID.String(),
+			generatedId.String(),
+			generatedId.String(),
+			"file",
+			newNotebook.ID,
+		)
+		if err := md.Permission.CreatePermission(sc, &session, &newPerm); err != nil {
+			return nil, fmt.Errorf("failed to create permission: %w", err)
+		}
+		if _, err := md.Folder.AddFile(sc, &session, folderId.String(), directory.NewFile(generatedId, res.Title, "")); err != nil {
+			return nil, fmt.Errorf("failed to add notebook to folder: %w", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to save imported notebook",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("notebook_id", generatedId.String()),
+		)
+		removeUploaded(ctx, storage, uploaded)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "ImportNotebook"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	logger.NewInfoMessage("Notebook imported",
+		zap.String("operation", "ImportNotebook"),
+		zap.String("employee_id", caller.ID.String()),
+		zap.String("notebook_id", generatedId.String()),
+		zap.String("format", res.Format),
+		zap.Int("blocks", len(res.Blocks)),
+		zap.Int("issues", len(res.Issues)),
+	)
+
+	return &importLogic.Report{
+		NotebookID: generatedId.String(),
+		Title:      res.Title,
+		Format:     res.Format,
+		Blocks:     len(res.Blocks),
+		Images:     len(uploaded),
+		Issues:     res.Issues,
+	}, nil
+}
+
+// removeUploaded удаляет изображения, загруженные для импорта, который не удалось сохранить
+func removeUploaded(ctx context.Context, storage *minio.MinioDB, keys []string) {
+	for _, key := range keys {
+		if err := storage.File.DeleteFile(ctx, config.Conf.Minio.Bucket, key, minioClient.RemoveObjectOptions{}); err != nil {
+			logger.NewWarnMessage("Failed to remove uploaded image",
+				zap.Error(err),
+				zap.String("operation", "ImportNotebook"),
+				zap.String("object_key", key),
+			)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	notificationlogic "labyrinth/logic/notificationLogic"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// InsertBlock добавляет блок с серверным ID на позицию position (position < 0 - в конец)
+func (n NotebookMongoLogic) InsertBlock(
+	notebookId uuid.UUID, caller *employee.Employee,
+	blockType string,
+	body map[string]any,
+	position int,
+) (*journal.Block, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "InsertBlock"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+	if strings.TrimSpace(blockType) == "" {
+		logger.NewWarnMessage("Empty block type provided",
+			zap.String("operation", "InsertBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, errors.New("block type cannot be empty")
+	}
+	if body == nil {
+		body = map[string]any{}
+	}
+	if err := blocksLogic.Validate(blockType, body); err != nil {
+		logger.NewWarnMessage("Block body failed schema validation",
+			zap.Error(err),
+			zap.String("operation", "InsertBlock"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("block_type", blockType),
+		)
+		return nil, err
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "InsertBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "InsertBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may edit the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessEdit); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", "InsertBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, err
+	}
+
+	// 6. Insert block with server-assigned ID and record revision in one transaction
+	block := journal.NewBlock(blockType, body)
+	result, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if err := md.Notebook.InsertBlock(sc, &session, notebookId.String(), &block, position, journal.NewDateTimeAuthor(caller.ID.String())); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionInsertBlock, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to insert block",
+			zap.Error(err),
+			zap.String("operation", "InsertBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to insert block: %w", err)
+	}
+
+	notifyMentions(result, caller.UserID, block.Id, "", notificationlogic.ExtractText(body))
+
+	logger.NewInfoMessage("Block inserted successfully",
+		zap.String("operation", "InsertBlock"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("block_id", block.Id),
+	)
+
+	return &block, nil
+}
@@ -0,0 +1,98 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRevisionsLimit = 50
+	maxRevisionsLimit     = 200
+)
+
+// ListRevisions возвращает историю изменений журнала от новых ревизий к старым
+func (n NotebookMongoLogic) ListRevisions(notebookId uuid.UUID, caller *employee.Employee, limit, offset int) (*[]revision.Revision, int64, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "ListRevisions"),
+		)
+		return nil, 0, errors.New("notebook ID cannot be empty")
+	}
+	if limit <= 0 {
+		limit = defaultRevisionsLimit
+	}
+	if limit > maxRevisionsLimit {
+		limit = maxRevisionsLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ListRevisions"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ListRevisions"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may read the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessRead); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", "ListRevisions"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, 0, err
+	}
+
+	// 6. Fetch page of history
+	revisions, total, err := md.Revision.GetRevisions(ctx, &session, notebookId.String(), int64(limit), int64(offset))
+	if err != nil {
+		logger.NewErrMessage("Failed to list revisions",
+			zap.Error(err),
+			zap.String("operation", "ListRevisions"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, 0, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	logger.NewInfoMessage("Revisions listed successfully",
+		zap.String("operation", "ListRevisions"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.Int("count", len(revisions)),
+		zap.Int64("total", total),
+	)
+
+	return &revisions, total, nil
+}
@@ -0,0 +1,55 @@
+package notebookLogic
+
+import (
+	"labyrinth/logger"
+	notificationlogic "labyrinth/logic/notificationLogic"
+	"labyrinth/models/notification"
+	"labyrinth/notebook/models/revision"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// notifyMentions в фоне уведомляет сотрудников, упомянутых в тексте блока или комментария.
+// result - снимок, записанный recordRevision; ошибки доставки не отменяют изменение журнала.
+func notifyMentions(result any, actorId uuid.UUID, blockId, commentId, text string) {
+	if len(notificationlogic.ParseMentions(text)) == 0 {
+		return
+	}
+
+	rev, ok := result.(*revision.Revision)
+	if !ok || rev == nil || rev.Snapshot == nil {
+		return
+	}
+
+	companyId, err := uuid.Parse(rev.Snapshot.Metadata.CompanyID)
+	if err != nil {
+		logger.NewWarnMessage("Notebook has no valid company, mentions skipped",
+			zap.Error(err),
+			zap.String("operation", "NotifyMentions"),
+			zap.String("notebook_id", rev.NotebookID),
+		)
+		return
+	}
+
+	event := notification.MentionEvent{
+		CompanyID:     companyId,
+		ActorID:       actorId,
+		NotebookID:    rev.NotebookID,
+		NotebookTitle: rev.Snapshot.Metadata.Title,
+		BlockID:       blockId,
+		CommentID:     commentId,
+		Text:          text,
+	}
+
+	go func() {
+		if _, err := notificationlogic.NewNotificationLogic().NotifyMentions(event); err != nil {
+			logger.NewWarnMessage("Failed to deliver mention notifications",
+				zap.Error(err),
+				zap.String("operation", "NotifyMentions"),
+				zap.String("notebook_id", event.NotebookID),
+				zap.String("block_id", blockId),
+			)
+		}
+	}()
+}
@@ -0,0 +1,204 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// MigrateAuthors переводит авторов с ID пользователей на ID сотрудников: раньше журналы, комментарии, подписи,
+// согласование, папки, шаблоны, теги и корзина хранили ID пользователя. ID пользователя заменяется на ID его
+// сотрудника в компании объекта, уволенные сотрудники тоже учитываются. Каждый переведенный журнал получает
+// ревизию migrate_authors, прежние ревизии не меняются. Повторный запуск безопасен.
+// При dryRun только возвращаются журналы, которые были бы переведены.
+func (n NotebookMongoLogic) MigrateAuthors(dryRun bool) (*journal.AuthorMigration, error) {
+	// 1. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateAuthors"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 2. Create context with timeout; the migration walks every notebook
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateAuthors"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 3. Map user IDs to employee IDs company by company
+	all, err := postgres.NewPostgresDB().Employee.GetAllEmployees(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("Failed to read employees",
+			zap.Error(err),
+			zap.String("operation", "MigrateAuthors"),
+		)
+		return nil, fmt.Errorf("failed to read employees: %w", err)
+	}
+	companies := make(map[string]map[string]string)
+	for _, empl := range *all {
+		companyId := empl.CompanyID.String()
+		if companies[companyId] == nil {
+			companies[companyId] = make(map[string]string)
+		}
+		companies[companyId][empl.UserID.String()] = empl.ID.String()
+	}
+
+	// 4. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateAuthors"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateAuthors"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Migrate notebooks with a revision each, then replace authors in the other collections
+	report := &journal.AuthorMigration{Updated: []string{}, Skipped: []string{}}
+	companyIds := make([]string, 0, len(companies))
+	for companyId := range companies {
+		companyIds = append(companyIds, companyId)
+	}
+	slices.Sort(companyIds)
+
+	for _, companyId := range companyIds {
+		employees := companies[companyId]
+
+		notebookIds, err := md.Notebook.GetNotebookIds(ctx, &session, companyId)
+		if err != nil {
+			logger.NewErrMessage("Failed to list notebooks",
+				zap.Error(err),
+				zap.String("operation", "MigrateAuthors"),
+				zap.String("company_id", companyId),
+			)
+			return nil, err
+		}
+
+		for _, notebookId := range notebookIds {
+			report.Scanned++
+			if err := migrateNotebookAuthors(ctx, md, &session, notebookId, employees, dryRun); err != nil {
+				if errors.Is(err, errAuthorsUnchanged) {
+					continue
+				}
+				var conflict *revision.ConflictError
+				if errors.As(err, &conflict) {
+					logger.NewWarnMessage("Notebook changed during author migration",
+						zap.String("operation", "MigrateAuthors"),
+						zap.String("notebook_id", notebookId),
+					)
+				} else {
+					logger.NewErrMessage("Failed to migrate notebook authors",
+						zap.Error(err),
+						zap.String("operation", "MigrateAuthors"),
+						zap.String("notebook_id", notebookId),
+					)
+				}
+				report.Skipped = append(report.Skipped, notebookId)
+				continue
+			}
+			report.Updated = append(report.Updated, notebookId)
+		}
+
+		if dryRun {
+			continue
+		}
+		for userId, employeeId := range employees {
+			for _, replace := range []func(context.Context, *mongo.Session, string, string, string) (int64, error){
+				md.Folder.ReplaceAuthor,
+				md.Template.ReplaceAuthor,
+				md.Tag.ReplaceAuthor,
+				md.Workflow.ReplaceAuthor,
+				md.Trash.ReplaceAuthor,
+			} {
+				replaced, err := replace(ctx, &session, companyId, userId, employeeId)
+				if err != nil {
+					logger.NewErrMessage("Failed to replace author",
+						zap.Error(err),
+						zap.String("operation", "MigrateAuthors"),
+						zap.String("company_id", companyId),
+						zap.String("employee_id", employeeId),
+					)
+					return nil, err
+				}
+				report.Replaced += replaced
+			}
+		}
+	}
+
+	logger.NewInfoMessage("Authors migrated",
+		zap.String("operation", "MigrateAuthors"),
+		zap.Int("scanned", report.Scanned),
+		zap.Int("notebooks", len(report.Updated)),
+		zap.Int("skipped", len(report.Skipped)),
+		zap.Int64("replaced", report.Replaced),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	return report, nil
+}
+
+// errAuthorsUnchanged - журнал уже хранит ID сотрудников
+var errAuthorsUnchanged = errors.New("notebook authors are already employee IDs")
+
+// migrateNotebookAuthors переводит авторов журнала и записывает ревизию в одной транзакции.
+// Журнал, изменившийся после чтения, дает *revision.ConflictError.
+func migrateNotebookAuthors(
+	ctx context.Context,
+	md *m.MongoDB,
+	session *mongo.Session,
+	notebookId string,
+	employees map[string]string,
+	dryRun bool,
+) error {
+	notebook, err := md.Notebook.GetNotebookById(ctx, session, notebookId)
+	if err != nil {
+		return fmt.Errorf("failed to read notebook: %w", err)
+	}
+	if !employeeAuthors(notebook, employees) {
+		return errAuthorsUnchanged
+	}
+	if dryRun {
+		return nil
+	}
+
+	_, err = (*session).WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if err := md.Notebook.SetAuthors(sc, session, notebook, notebook.Revision); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, session, notebookId, "", revision.ActionMigrateAuthors, nil)
+	})
+	return err
+}
@@ -0,0 +1,56 @@
+package notebookLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MigrateBlockIds переводит числовые ID блоков, сохраненные до перехода на строковые ID, в строки.
+// Возвращает число затронутых журналов. Повторный запуск безопасен: строковые ID не меняются.
+// При dryRun журналы только подсчитываются.
+func (n NotebookMongoLogic) MigrateBlockIds(dryRun bool) (int64, error) {
+	// 1. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateBlockIds"),
+		)
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateBlockIds"),
+		)
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 2. Convert block ids in a single server-side update
+	affected, err := md.Notebook.ConvertBlockIds(ctx, &session, dryRun)
+	if err != nil {
+		logger.NewErrMessage("Failed to convert block ids",
+			zap.Error(err),
+			zap.String("operation", "MigrateBlockIds"),
+		)
+		return 0, err
+	}
+
+	logger.NewInfoMessage("Block ids migrated",
+		zap.Int64("notebooks", affected),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	return affected, nil
+}
@@ -0,0 +1,98 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MigrateComments переносит комментарии, сохраненные до веток по ParentId: вложенные ответы sub_comments
+// становятся отдельными комментариями ветки, комментарии без ID получают ID.
+// Повторный запуск безопасен: перенесенные комментарии не меняются. При dryRun изменения только возвращаются.
+func (n NotebookMongoLogic) MigrateComments(dryRun bool) (*journal.CommentMigration, error) {
+	// 1. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateComments"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateComments"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 2. Read notebooks with legacy comments
+	notebooks, err := md.Notebook.GetLegacyComments(ctx, &session)
+	if err != nil {
+		logger.NewErrMessage("Failed to read legacy comments",
+			zap.Error(err),
+			zap.String("operation", "MigrateComments"),
+		)
+		return nil, err
+	}
+
+	// 3. Flatten the comments of every block; a notebook changed meanwhile is left for the next run
+	report := &journal.CommentMigration{Scanned: len(notebooks), Updated: []string{}, Skipped: []string{}}
+	for _, notebook := range notebooks {
+		comments := make(map[int][]journal.Comment)
+		for position, block := range notebook.Blocks {
+			if flat, changed := flattenComments(block.Comments); changed {
+				comments[position] = flat
+			}
+		}
+		if len(comments) == 0 {
+			continue
+		}
+		if dryRun {
+			report.Updated = append(report.Updated, notebook.UuidID)
+			continue
+		}
+
+		err := md.Notebook.SetBlockComments(ctx, &session, notebook.UuidID, comments, notebook.Revision)
+		var conflict *revision.ConflictError
+		if errors.As(err, &conflict) {
+			logger.NewWarnMessage("Notebook changed during comment migration",
+				zap.String("operation", "MigrateComments"),
+				zap.String("notebook_id", notebook.UuidID),
+			)
+			report.Skipped = append(report.Skipped, notebook.UuidID)
+			continue
+		}
+		if err != nil {
+			logger.NewErrMessage("Failed to migrate notebook comments",
+				zap.Error(err),
+				zap.String("operation", "MigrateComments"),
+				zap.String("notebook_id", notebook.UuidID),
+			)
+			return nil, err
+		}
+		report.Updated = append(report.Updated, notebook.UuidID)
+	}
+
+	logger.NewInfoMessage("Comments migrated",
+		zap.Int("notebooks", len(report.Updated)),
+		zap.Int("skipped", len(report.Skipped)),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	return report, nil
+}
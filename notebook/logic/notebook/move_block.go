@@ -0,0 +1,99 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+func (n NotebookMongoLogic) MoveBlock(notebookId uuid.UUID, caller *employee.Employee, blockId string, position int) error {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "MoveBlock"),
+		)
+		return errors.New("notebook ID cannot be empty")
+	}
+	if strings.TrimSpace(blockId) == "" {
+		logger.NewWarnMessage("Empty block ID provided",
+			zap.String("operation", "MoveBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return errors.New("block ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MoveBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MoveBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may edit the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessEdit); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", "MoveBlock"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return err
+	}
+
+	// 6. Move block inside transaction (pull + push must be atomic) and record revision
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if err := md.Notebook.MoveBlock(sc, &session, notebookId.String(), blockId, position, journal.NewDateTimeAuthor(caller.ID.String())); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionMoveBlock, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to move block",
+			zap.Error(err),
+			zap.String("operation", "MoveBlock"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("block_id", blockId),
+		)
+		return fmt.Errorf("failed to move block: %w", err)
+	}
+
+	logger.NewInfoMessage("Block moved successfully",
+		zap.String("operation", "MoveBlock"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("block_id", blockId),
+		zap.Int("position", position),
+	)
+
+	return nil
+}
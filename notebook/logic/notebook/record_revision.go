@@ -0,0 +1,61 @@
+package notebookLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	chainLogic "labyrinth/notebook/logic/chain"
+	signatureLogic "labyrinth/notebook/logic/signature"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// recordRevision сохраняет снимок журнала после изменения.
+// Вызывается в той же транзакции, что и само изменение, чтобы история не расходилась с документом.
+// Если изменение затронуло подписанное содержимое, возвращает journal.ErrSignedContent и транзакция откатывается;
+// так же откатывается изменение содержимого журнала в статусе только для чтения (journal.ErrReadOnly).
+func recordRevision(
+	ctx context.Context,
+	md *m.MongoDB,
+	session *mongo.Session,
+	notebookId, author, action string,
+	restoredFrom *int64,
+) (*revision.Revision, error) {
+	current, err := md.Notebook.GetNotebookById(ctx, session, notebookId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notebook snapshot: %w", err)
+	}
+
+	if err := signatureLogic.Verify(current); err != nil {
+		return nil, err
+	}
+
+	if current.Lifecycle.ReadOnly && revision.ChangesContent(action) {
+		return nil, fmt.Errorf("%w: %s", journal.ErrReadOnly, current.Lifecycle.Status)
+	}
+
+	previous, err := md.Revision.GetLatestRevision(ctx, session, notebookId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous revision: %w", err)
+	}
+
+	var previousBlocks []journal.Block
+	if previous != nil && previous.Snapshot != nil {
+		previousBlocks = previous.Snapshot.Blocks
+	}
+
+	changes := revision.DiffBlocks(previousBlocks, current.Blocks)
+	rev := revision.NewRevision(*current, action, author, revision.ChangedBlockIDs(changes))
+	rev.RestoredFrom = restoredFrom
+	if err := chainLogic.Seal(&rev, previous); err != nil {
+		return nil, fmt.Errorf("failed to seal revision: %w", err)
+	}
+
+	if err := md.Revision.CreateRevision(ctx, session, &rev); err != nil {
+		return nil, fmt.Errorf("failed to store revision: %w", err)
+	}
+
+	return &rev, nil
+}
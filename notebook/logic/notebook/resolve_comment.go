@@ -0,0 +1,117 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ResolveComment отмечает ветку комментариев решённой или открывает её снова
+func (n NotebookMongoLogic) ResolveComment(
+	notebookId uuid.UUID, caller *employee.Employee,
+	blockId, commentId string,
+	resolved bool,
+) (*journal.Comment, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "ResolveComment"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+	if strings.TrimSpace(blockId) == "" || strings.TrimSpace(commentId) == "" {
+		logger.NewWarnMessage("Empty block or comment ID provided",
+			zap.String("operation", "ResolveComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, errors.New("block ID and comment ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ResolveComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ResolveComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check permission, change thread state and record revision in one transaction
+	var updated journal.Comment
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		block, _, err := loadCommentBlock(sc, md, &session, notebookId.String(), caller, blockId)
+		if err != nil {
+			return nil, err
+		}
+		comment, err := block.FindComment(commentId)
+		if err != nil {
+			return nil, err
+		}
+		if comment.ParentId != "" {
+			return nil, journal.ErrCommentIsReply
+		}
+
+		updated = *comment
+		updated.Resolved = resolved
+		if resolved {
+			now := time.Now()
+			updated.ResolvedBy = caller.ID.String()
+			updated.ResolvedAt = &now
+		} else {
+			updated.ResolvedBy = ""
+			updated.ResolvedAt = nil
+		}
+
+		if err := md.Notebook.UpdateComment(sc, &session, notebookId.String(), blockId, &updated, journal.NewDateTimeAuthor(caller.ID.String())); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionResolveComment, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to resolve comment",
+			zap.Error(err),
+			zap.String("operation", "ResolveComment"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("comment_id", commentId),
+		)
+		return nil, fmt.Errorf("failed to resolve comment: %w", err)
+	}
+
+	logger.NewInfoMessage("Comment thread state changed",
+		zap.String("operation", "ResolveComment"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("comment_id", commentId),
+		zap.Bool("resolved", resolved),
+	)
+
+	return &updated, nil
+}
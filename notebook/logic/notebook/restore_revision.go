@@ -0,0 +1,107 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RestoreRevision записывает содержимое старой ревизии как новую ревизию журнала.
+// История не переписывается: восстановление - обычное изменение с пометкой restored_from.
+func (n NotebookMongoLogic) RestoreRevision(notebookId uuid.UUID, caller *employee.Employee, number, expectedRevision int64) (int64, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "RestoreRevision"),
+		)
+		return 0, errors.New("notebook ID cannot be empty")
+	}
+	if number < 0 {
+		return 0, errors.New("revision number cannot be negative")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "RestoreRevision"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "RestoreRevision"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may edit the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessEdit); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", "RestoreRevision"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, err
+	}
+
+	// 6. Write old snapshot as a new revision
+	var newRevision int64
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		old, err := md.Revision.GetRevision(sc, &session, notebookId.String(), number)
+		if err != nil {
+			return nil, err
+		}
+
+		restored := *old.Snapshot
+		restored.Metadata.LastUpdate = journal.NewDateTimeAuthor(caller.ID.String())
+
+		newRevision, err = md.Notebook.UpdateNotebook(sc, &session, notebookId.String(), &restored, expectedRevision)
+		if err != nil {
+			return nil, err
+		}
+
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionRestore, &number)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to restore revision",
+			zap.Error(err),
+			zap.String("operation", "RestoreRevision"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Int64("revision", number),
+		)
+		return 0, fmt.Errorf("failed to restore revision: %w", err)
+	}
+
+	logger.NewInfoMessage("Revision restored successfully",
+		zap.String("operation", "RestoreRevision"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.Int64("restored_from", number),
+		zap.Int64("revision", newRevision),
+	)
+
+	return newRevision, nil
+}
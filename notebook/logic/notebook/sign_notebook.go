@@ -0,0 +1,139 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	signatureLogic "labyrinth/notebook/logic/signature"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// SignNotebook подписывает журнал (при пустом blockIds) или перечисленные блоки от имени автора.
+// Подпись требует повторного ввода пароля и полного доступа к журналу; после нее подписанное содержимое нельзя изменить.
+func (n NotebookMongoLogic) SignNotebook(notebookId uuid.UUID, caller *employee.Employee, password, meaning string, blockIds []string) (*journal.Signature, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil || caller == nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "SignNotebook"),
+		)
+		return nil, errors.New("notebook and employee IDs cannot be empty")
+	}
+	meaning = strings.TrimSpace(meaning)
+	if meaning == "" || utf8.RuneCountInString(meaning) > journal.MaxMeaningLength {
+		return nil, journal.ErrInvalidMeaning
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "SignNotebook"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Re-authenticate the signer
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "SignNotebook"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := confirmPassword(ctx, tx, caller.UserID, password); err != nil {
+		logger.NewWarnMessage("Signature password confirmation failed",
+			zap.Error(err),
+			zap.String("operation", "SignNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "SignNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "SignNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Hash the signed content, store the signature and record revision in one transaction
+	var signature journal.Signature
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		notebook, _, err := authorize(sc, md, &session, notebookId.String(), caller, permission.AccessEdit)
+		if err != nil {
+			return nil, err
+		}
+
+		ids, err := signatureLogic.BlockIDs(notebook, blockIds)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := signatureLogic.ContentHash(notebook, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		signature = journal.NewSignature(journal.SignatureAuthor, caller.ID.String(), meaning, hash, "", ids, notebook.Revision)
+		if err := md.Notebook.AddSignature(sc, &session, notebookId.String(), &signature); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionSign, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to sign notebook",
+			zap.Error(err),
+			zap.String("operation", "SignNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to sign notebook: %w", err)
+	}
+
+	logger.NewInfoMessage("Notebook signed successfully",
+		zap.String("operation", "SignNotebook"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("signature_id", signature.Id),
+		zap.String("hash", signature.Hash),
+		zap.Int("blocks", len(signature.BlockIDs)),
+	)
+
+	return &signature, nil
+}
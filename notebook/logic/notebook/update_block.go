@@ -0,0 +1,161 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	notificationlogic "labyrinth/logic/notificationLogic"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+func (n NotebookMongoLogic) UpdateBlock(
+	notebookId uuid.UUID, caller *employee.Employee,
+	blockId string,
+	blockType string,
+	body map[string]any,
+) error {
+	_, err := updateBlock("UpdateBlock", notebookId, caller, blockId, blockType, body, nil)
+	return err
+}
+
+// UpdateBlockAt - UpdateBlock, который сохраняет блок, только если ревизия журнала равна expectedRevision.
+// Возвращает новую ревизию; если журнал изменился - *revision.ConflictError.
+func (n NotebookMongoLogic) UpdateBlockAt(
+	notebookId uuid.UUID, caller *employee.Employee,
+	blockId string,
+	blockType string,
+	body map[string]any,
+	expectedRevision int64,
+) (int64, error) {
+	return updateBlock("UpdateBlockAt", notebookId, caller, blockId, blockType, body, &expectedRevision)
+}
+
+// updateBlock заменяет тип и содержимое блока; при непустом expectedRevision - только на этой ревизии журнала.
+// Возвращает новую ревизию журнала.
+func updateBlock(
+	operation string,
+	notebookId uuid.UUID, caller *employee.Employee,
+	blockId string,
+	blockType string,
+	body map[string]any,
+	expectedRevision *int64,
+) (int64, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", operation),
+		)
+		return 0, errors.New("notebook ID cannot be empty")
+	}
+	if strings.TrimSpace(blockId) == "" {
+		logger.NewWarnMessage("Empty block ID provided",
+			zap.String("operation", operation),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, errors.New("block ID cannot be empty")
+	}
+	if strings.TrimSpace(blockType) == "" {
+		logger.NewWarnMessage("Empty block type provided",
+			zap.String("operation", operation),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, errors.New("block type cannot be empty")
+	}
+	if body == nil {
+		body = map[string]any{}
+	}
+	if err := blocksLogic.Validate(blockType, body); err != nil {
+		logger.NewWarnMessage("Block body failed schema validation",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("block_type", blockType),
+		)
+		return 0, err
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may edit the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessEdit); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, err
+	}
+
+	// 6. Update single block and record revision in one transaction
+	var newRevision int64
+	result, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		lastUpdate := journal.NewDateTimeAuthor(caller.ID.String())
+		if expectedRevision == nil {
+			if err := md.Notebook.UpdateBlock(sc, &session, notebookId.String(), blockId, blockType, body, lastUpdate); err != nil {
+				return nil, err
+			}
+		} else {
+			var err error
+			if newRevision, err = md.Notebook.UpdateBlockAt(sc, &session, notebookId.String(), blockId, blockType, body, lastUpdate, *expectedRevision); err != nil {
+				return nil, err
+			}
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionUpdateBlock, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to update block",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("block_id", blockId),
+		)
+		return 0, fmt.Errorf("failed to update block: %w", err)
+	}
+
+	notifyMentions(result, caller.UserID, blockId, "", notificationlogic.ExtractText(body))
+
+	logger.NewInfoMessage("Block updated successfully",
+		zap.String("operation", operation),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("block_id", blockId),
+	)
+
+	return newRevision, nil
+}
@@ -0,0 +1,124 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// UpdateComment изменяет текст комментария; править можно только свой комментарий
+func (n NotebookMongoLogic) UpdateComment(
+	notebookId uuid.UUID, caller *employee.Employee,
+	blockId, commentId, text string,
+) (*journal.Comment, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "UpdateComment"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+	if strings.TrimSpace(blockId) == "" || strings.TrimSpace(commentId) == "" {
+		logger.NewWarnMessage("Empty block or comment ID provided",
+			zap.String("operation", "UpdateComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, errors.New("block ID and comment ID cannot be empty")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > journal.MaxCommentLength {
+		logger.NewWarnMessage("Invalid comment text",
+			zap.String("operation", "UpdateComment"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Int("length", utf8.RuneCountInString(text)),
+		)
+		return nil, journal.ErrInvalidComment
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateComment"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check permission and authorship, update comment and record revision in one transaction
+	var updated journal.Comment
+	result, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		block, _, err := loadCommentBlock(sc, md, &session, notebookId.String(), caller, blockId)
+		if err != nil {
+			return nil, err
+		}
+		comment, err := block.FindComment(commentId)
+		if err != nil {
+			return nil, err
+		}
+		if comment.Deleted {
+			return nil, journal.ErrCommentNotFound
+		}
+		if comment.EmployeeId != caller.ID.String() {
+			return nil, permission.ErrForbidden
+		}
+
+		updated = *comment
+		updated.Comment = text
+		updated.UpdatedAt = time.Now()
+
+		if err := md.Notebook.UpdateComment(sc, &session, notebookId.String(), blockId, &updated, journal.NewDateTimeAuthor(caller.ID.String())); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionUpdateComment, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to update comment",
+			zap.Error(err),
+			zap.String("operation", "UpdateComment"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("comment_id", commentId),
+		)
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	notifyMentions(result, caller.UserID, blockId, commentId, text)
+
+	logger.NewInfoMessage("Comment updated successfully",
+		zap.String("operation", "UpdateComment"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("comment_id", commentId),
+	)
+
+	return &updated, nil
+}
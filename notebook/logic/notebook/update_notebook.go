@@ -6,25 +6,44 @@ import (
 	"fmt"
 	m "labyrinth/database/mongo"
 	"labyrinth/logger"
+	notificationlogic "labyrinth/logic/notificationLogic"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
 	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
 	"time"
 
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
-func (n NotebookMongoLogic) UpdateNotebook(notebookId uuid.UUID, updatedNotebook *journal.Notebook) error {
+func (n NotebookMongoLogic) UpdateNotebook(notebookId uuid.UUID, caller *employee.Employee, updatedNotebook *journal.Notebook, expectedRevision int64) (int64, error) {
 	if notebookId == uuid.Nil {
 		logger.NewErrMessage("Empty notebook ID provided",
 			zap.String("operation", "UpdateNotebook"),
 		)
-		return errors.New("notebook ID cannot be empty")
+		return 0, errors.New("notebook ID cannot be empty")
 	}
 	if updatedNotebook == nil {
 		logger.NewErrMessage("Empty notebook provided",
 			zap.String("operation", "UpdateNotebook"),
 		)
-		return errors.New("notebook cannot be empty")
+		return 0, errors.New("notebook cannot be empty")
+	}
+
+	// Тело каждого блока проверяется по схеме типа; ID блоков проверяются в транзакции по сохраненному журналу
+	for i := range updatedNotebook.Blocks {
+		if err := blocksLogic.Validate(updatedNotebook.Blocks[i].Type, updatedNotebook.Blocks[i].Body); err != nil {
+			logger.NewWarnMessage("Block body failed schema validation",
+				zap.Error(err),
+				zap.String("operation", "UpdateNotebook"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Int("block_index", i),
+			)
+			return 0, fmt.Errorf("block %d: %w", i, err)
+		}
 	}
 
 	// 2. Create context with timeout
@@ -39,7 +58,7 @@ func (n NotebookMongoLogic) UpdateNotebook(notebookId uuid.UUID, updatedNotebook
 			zap.String("operation", "GetNotebook"),
 			zap.String("notebook_id", notebookId.String()),
 		)
-		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
 	}
 
 	// 4. Start session
@@ -50,24 +69,61 @@ func (n NotebookMongoLogic) UpdateNotebook(notebookId uuid.UUID, updatedNotebook
 			zap.String("operation", "GetNotebook"),
 			zap.String("notebook_id", notebookId.String()),
 		)
-		return fmt.Errorf("failed to start MongoDB session: %w", err)
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
 	}
 	defer session.EndSession(ctx)
 
-	err = md.Notebook.UpdateNotebook(ctx, &session, notebookId.String(), updatedNotebook)
+	// 5. Check that the employee may edit the notebook
+	if _, _, err := authorize(ctx, md, &session, notebookId.String(), caller, permission.AccessEdit); err != nil {
+		logger.NewWarnMessage("Notebook access denied",
+			zap.Error(err),
+			zap.String("operation", "UpdateNotebook"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return 0, err
+	}
+
+	// 6. Conditional update and revision record in one transaction
+	updatedNotebook.Metadata.LastUpdate = journal.NewDateTimeAuthor(caller.ID.String())
+	var newRevision int64
+	result, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		// Клиент может оставить ID существующих блоков, но не придумать свои и не повторить их:
+		// иначе ревизии, комментарии и совместное редактирование перепутают блоки
+		current, err := md.Notebook.GetNotebookById(sc, &session, notebookId.String())
+		if err != nil {
+			return nil, err
+		}
+		if current.Revision != expectedRevision {
+			return nil, &revision.ConflictError{Current: current.Revision}
+		}
+		if err := checkBlockIds(updatedNotebook.Blocks, current.Blocks); err != nil {
+			return nil, err
+		}
+
+		newRevision, err = md.Notebook.UpdateNotebook(sc, &session, notebookId.String(), updatedNotebook, expectedRevision)
+		if err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionUpdate, nil)
+	})
 	if err != nil {
 		logger.NewErrMessage("update notebook failed",
 			zap.Error(err),
 			zap.String("operation", "UpdateNotebook"),
 			zap.String("notebook_id", notebookId.String()),
 		)
-		return fmt.Errorf("failed to update notebook: %w", err)
+		return 0, fmt.Errorf("failed to update notebook: %w", err)
+	}
+
+	for _, block := range updatedNotebook.Blocks {
+		notifyMentions(result, caller.UserID, block.Id, "", notificationlogic.ExtractText(block.Body))
 	}
 
-	logger.NewInfoMessage("Notebook created successfully",
-		zap.String("operation", "GetNotebook"),
+	logger.NewInfoMessage("Notebook updated successfully",
+		zap.String("operation", "UpdateNotebook"),
 		zap.String("notebook_id", notebookId.String()),
+		zap.Int64("revision", newRevision),
 	)
 
-	return nil
+	return newRevision, nil
 }
@@ -0,0 +1,101 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	chainLogic "labyrinth/notebook/logic/chain"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// VerifyChain пересчитывает цепочку хешей ревизий журнала и сверяет ее с текущим журналом
+func (n NotebookMongoLogic) VerifyChain(notebookId uuid.UUID) (*chainLogic.Report, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil {
+		logger.NewErrMessage("Empty notebook ID provided",
+			zap.String("operation", "VerifyChain"),
+		)
+		return nil, errors.New("notebook ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "VerifyChain"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "VerifyChain"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Recompute the chain
+	report, err := verifyNotebookChain(ctx, md, &session, notebookId.String())
+	if err != nil {
+		logger.NewErrMessage("Failed to verify revision chain",
+			zap.Error(err),
+			zap.String("operation", "VerifyChain"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, err
+	}
+
+	logChainReport("VerifyChain", report)
+	return report, nil
+}
+
+// verifyNotebookChain читает журнал и всю его историю и проверяет цепочку
+func verifyNotebookChain(ctx context.Context, md *m.MongoDB, session *mongo.Session, notebookId string) (*chainLogic.Report, error) {
+	notebook, err := md.Notebook.GetNotebookById(ctx, session, notebookId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notebook: %w", err)
+	}
+
+	revisions, err := md.Revision.GetRevisionChain(ctx, session, notebookId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision chain: %w", err)
+	}
+
+	report := chainLogic.Verify(notebookId, notebook, revisions)
+	return &report, nil
+}
+
+// logChainReport пишет в журнал аудита результат проверки; нарушения цепочки - предупреждение
+func logChainReport(operation string, report *chainLogic.Report) {
+	if report.Valid {
+		logger.NewInfoMessage("Revision chain is intact",
+			zap.String("operation", operation),
+			zap.String("notebook_id", report.NotebookID),
+			zap.Int("revisions", report.Revisions),
+			zap.String("head", report.Head),
+		)
+		return
+	}
+	logger.NewWarnMessage("Revision chain is broken",
+		zap.String("operation", operation),
+		zap.String("notebook_id", report.NotebookID),
+		zap.Any("breaks", report.Breaks),
+	)
+}
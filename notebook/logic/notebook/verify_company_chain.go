@@ -0,0 +1,81 @@
+package notebookLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	chainLogic "labyrinth/notebook/logic/chain"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// VerifyCompanyChain проверяет цепочки хешей всех журналов компании.
+// Каждый журнал проверяется со своим таймаутом, поэтому большая компания не упирается в общий лимит.
+func (n NotebookMongoLogic) VerifyCompanyChain(companyId uuid.UUID) ([]chainLogic.Report, error) {
+	// 1. Validate input
+	if companyId == uuid.Nil {
+		logger.NewErrMessage("Empty company ID provided",
+			zap.String("operation", "VerifyCompanyChain"),
+		)
+		return nil, errors.New("company ID cannot be empty")
+	}
+
+	// 2. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "VerifyCompanyChain"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "VerifyCompanyChain"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(context.Background())
+
+	// 3. List company notebooks
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ids, err := md.Notebook.GetNotebookIds(ctx, &session, companyId.String())
+	cancel()
+	if err != nil {
+		logger.NewErrMessage("Failed to list company notebooks",
+			zap.Error(err),
+			zap.String("operation", "VerifyCompanyChain"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, err
+	}
+
+	// 4. Verify each notebook
+	reports := make([]chainLogic.Report, 0, len(ids))
+	for _, id := range ids {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		report, err := verifyNotebookChain(ctx, md, &session, id)
+		cancel()
+		if err != nil {
+			logger.NewErrMessage("Failed to verify revision chain",
+				zap.Error(err),
+				zap.String("operation", "VerifyCompanyChain"),
+				zap.String("notebook_id", id),
+			)
+			return nil, fmt.Errorf("notebook %s: %w", id, err)
+		}
+		logChainReport("VerifyCompanyChain", report)
+		reports = append(reports, *report)
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,157 @@
+package notebookLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	signatureLogic "labyrinth/notebook/logic/signature"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// WitnessSignature заверяет подпись автора подписью свидетеля. Свидетель повторно вводит пароль,
+// должен иметь доступ к журналу и не может заверить собственную подпись.
+func (n NotebookMongoLogic) WitnessSignature(notebookId uuid.UUID, caller *employee.Employee, signatureId, password, meaning string) (*journal.Signature, error) {
+	// 1. Validate input
+	if notebookId == uuid.Nil || caller == nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "WitnessSignature"),
+		)
+		return nil, errors.New("notebook and employee IDs cannot be empty")
+	}
+	if strings.TrimSpace(signatureId) == "" {
+		return nil, journal.ErrSignatureNotFound
+	}
+	meaning = strings.TrimSpace(meaning)
+	if meaning == "" || utf8.RuneCountInString(meaning) > journal.MaxMeaningLength {
+		return nil, journal.ErrInvalidMeaning
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "WitnessSignature"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Re-authenticate the witness
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "WitnessSignature"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := confirmPassword(ctx, tx, caller.UserID, password); err != nil {
+		logger.NewWarnMessage("Signature password confirmation failed",
+			zap.Error(err),
+			zap.String("operation", "WitnessSignature"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "WitnessSignature"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "WitnessSignature"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Countersign the author signature and record revision in one transaction
+	var signature journal.Signature
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		notebook, err := md.Notebook.GetNotebookById(sc, &session, notebookId.String())
+		if err != nil {
+			return nil, err
+		}
+
+		author, err := notebook.FindSignature(signatureId)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case author.Kind != journal.SignatureAuthor:
+			return nil, journal.ErrNotAuthorSignature
+		case notebook.WitnessOf(author.Id) != nil:
+			return nil, journal.ErrAlreadyWitnessed
+		case author.SignerID == caller.ID.String():
+			return nil, journal.ErrSelfWitness
+		}
+
+		if _, err := permissionLogic.Authorize(sc, md, &session, notebook.Metadata.CompanyID, notebookId.String(), caller, permission.AccessRead); err != nil {
+			return nil, err
+		}
+
+		// Свидетель заверяет то же содержимое, что подписал автор
+		hash, err := signatureLogic.ContentHash(notebook, author.BlockIDs)
+		if err != nil || hash != author.Hash {
+			return nil, fmt.Errorf("%w: signature %s", journal.ErrSignedContent, author.Id)
+		}
+
+		signature = journal.NewSignature(journal.SignatureWitness, caller.ID.String(), meaning, hash, author.Id, author.BlockIDs, notebook.Revision)
+		if err := md.Notebook.AddSignature(sc, &session, notebookId.String(), &signature); err != nil {
+			return nil, err
+		}
+		return recordRevision(sc, md, &session, notebookId.String(), caller.ID.String(), revision.ActionWitness, nil)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to witness signature",
+			zap.Error(err),
+			zap.String("operation", "WitnessSignature"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("signature_id", signatureId),
+		)
+		return nil, fmt.Errorf("failed to witness signature: %w", err)
+	}
+
+	logger.NewInfoMessage("Signature witnessed successfully",
+		zap.String("operation", "WitnessSignature"),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("signature_id", signatureId),
+		zap.String("witness_signature_id", signature.Id),
+	)
+
+	return &signature, nil
+}
@@ -0,0 +1,78 @@
+package permissionLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RequireMember проверяет, что вызывающий - активный сотрудник компании companyId.
+// Сотрудника загружает и проверяет EmployeeMiddleware, здесь сверяется только компания:
+// доступ к журналам и папкам есть только у сотрудников компании, которой они принадлежат.
+func RequireMember(caller *employee.Employee, companyId string) error {
+	if caller == nil || !caller.IsActive || caller.CompanyID.String() != companyId {
+		return fmt.Errorf("caller is not an active employee of the company: %w", permission.ErrForbidden)
+	}
+	return nil
+}
+
+// Authorize - единая проверка доступа к журналу или папке: вызывающий должен быть активным сотрудником
+// компании ресурса, а правила доступа ресурса вместе с унаследованными от папок - давать его ID сотрудника право need.
+// Возвращает действующее право; при отказе - ошибку с permission.ErrForbidden.
+func Authorize(ctx context.Context, md *m.MongoDB, session *mongo.Session, companyId, resourceId string, caller *employee.Employee, need permission.Access) (permission.Access, error) {
+	if err := RequireMember(caller, companyId); err != nil {
+		return permission.AccessNone, err
+	}
+	chain, err := LoadChain(ctx, md, session, resourceId)
+	if err != nil {
+		return permission.AccessNone, err
+	}
+	return require(chain, caller, need)
+}
+
+// AuthorizeResource - Authorize для ресурса, компания которого заранее неизвестна:
+// она берется из папки или журнала, к которому относится разрешение. Возвращает собственное разрешение ресурса.
+func AuthorizeResource(ctx context.Context, md *m.MongoDB, session *mongo.Session, resourceId string, caller *employee.Employee, need permission.Access) (*permission.Permission, error) {
+	chain, err := LoadChain(ctx, md, session, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	perm := &chain[0]
+
+	var companyId string
+	if perm.ResourceType == permission.ResourceFolder {
+		folder, err := md.Folder.GetFolderByFolderId(ctx, session, resourceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get folder: %w", err)
+		}
+		companyId = folder.Metadata.CompanyID
+	} else {
+		notebook, err := md.Notebook.GetNotebookById(ctx, session, resourceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notebook: %w", err)
+		}
+		companyId = notebook.Metadata.CompanyID
+	}
+
+	if err := RequireMember(caller, companyId); err != nil {
+		return nil, err
+	}
+	if _, err := require(chain, caller, need); err != nil {
+		return nil, err
+	}
+	return perm, nil
+}
+
+// require сравнивает действующее право сотрудника по цепочке разрешений с требуемым:
+// в списках разрешений хранятся ID сотрудников, а не пользователей
+func require(chain []permission.Permission, caller *employee.Employee, need permission.Access) (permission.Access, error) {
+	access := EffectiveAccess(chain, caller.ID.String())
+	if access < need {
+		return permission.AccessNone, fmt.Errorf("%s access required: %w", need, permission.ErrForbidden)
+	}
+	return access, nil
+}
@@ -0,0 +1,70 @@
+package permissionLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// LoadChain возвращает разрешение ресурса и разрешения папок, правила которых он наследует, от ближайшей к верхней.
+// Журнал наследует правила папки, в которой лежит, папка - родительской папки.
+// Цепочка заканчивается на разрешении, которое не наследует правила (Inherit = false), или на верхней папке отдела.
+func LoadChain(ctx context.Context, md *m.MongoDB, session *mongo.Session, resourceId string) ([]permission.Permission, error) {
+	perm, err := md.Permission.GetPermissionByUuidId(ctx, session, resourceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read permission: %w", err)
+	}
+	chain := []permission.Permission{*perm}
+	if !perm.Inherit {
+		return chain, nil
+	}
+
+	// Ресурс в корзине не лежит ни в одной папке: для него действуют только собственные правила
+	var parentId string
+	if perm.ResourceType == permission.ResourceFolder {
+		folder, err := md.Folder.GetFolderByFolderId(ctx, session, resourceId)
+		if errors.Is(err, directory.ErrNotFound) {
+			return chain, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get folder: %w", err)
+		}
+		parentId = folder.ParentId
+	} else {
+		folder, err := md.Folder.GetFolderByFileId(ctx, session, resourceId)
+		if errors.Is(err, directory.ErrNotFound) {
+			return chain, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		parentId = folder.UuidID
+	}
+
+	// У верхней папки отдела родитель - отдел, а не папка: там подъем заканчивается
+	for i := 0; i < directory.MaxTreeNodes; i++ {
+		folder, err := md.Folder.GetFolderByFolderId(ctx, session, parentId)
+		if errors.Is(err, directory.ErrNotFound) {
+			return chain, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent folder %s: %w", parentId, err)
+		}
+
+		perm, err := md.Permission.GetPermissionByUuidId(ctx, session, folder.UuidID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read permission of folder %s: %w", folder.UuidID, err)
+		}
+		chain = append(chain, *perm)
+		if !perm.Inherit {
+			return chain, nil
+		}
+		parentId = folder.ParentId
+	}
+	return nil, directory.ErrTreeTooLarge
+}
@@ -0,0 +1,67 @@
+package permissionLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CheckAccess проверяет, что сотрудник имеет право need на журнал или папку
+func (p PermissionMongoLogic) CheckAccess(resourceId uuid.UUID, caller *employee.Employee, need permission.Access) error {
+	// 1. Validate input
+	if resourceId == uuid.Nil || caller == nil {
+		logger.NewErrMessage("Invalid resource or employee ID",
+			zap.String("operation", "CheckAccess"),
+		)
+		return errors.New("resource and employee IDs cannot be nil")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := mongo.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.String("operation", "CheckAccess"),
+			zap.String("resource_id", resourceId.String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.String("operation", "CheckAccess"),
+			zap.String("resource_id", resourceId.String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Apply the resource access rules
+	if _, err := AuthorizeResource(ctx, md, &session, resourceId.String(), caller, need); err != nil {
+		logger.NewWarnMessage("Access denied",
+			zap.String("operation", "CheckAccess"),
+			zap.String("resource_id", resourceId.String()),
+			zap.String("employee_id", caller.ID.String()),
+			zap.String("access", need.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package permissionLogic
+
+import "labyrinth/notebook/models/permission"
+
+// EffectiveAccess возвращает право сотрудника по цепочке разрешений из LoadChain:
+// наибольшее из прав, которые дают правила самого ресурса и папок, от которых он наследует
+func EffectiveAccess(chain []permission.Permission, employeeId string) permission.Access {
+	access := permission.AccessNone
+	for _, perm := range chain {
+		access = max(access, perm.Rules.AccessFor(employeeId))
+	}
+	return access
+}
+
+// Inherited возвращает право сотрудника на ресурс с разрешением perm, лежащий в папке, на которую у него право parent.
+// Так права раскрываются сверху вниз по дереву без чтения цепочки каждого ресурса.
+func Inherited(perm permission.Permission, employeeId string, parent permission.Access) permission.Access {
+	access := perm.Rules.AccessFor(employeeId)
+	if perm.Inherit {
+		access = max(access, parent)
+	}
+	return access
+}
+
+// Explain раскладывает цепочку разрешений на действующие права сотрудников с указанием их источника
+func Explain(chain []permission.Permission) permission.Effective {
+	effective := permission.Effective{
+		Sources: make([]permission.Source, 0, len(chain)),
+		Grants:  []permission.Grant{},
+	}
+	if len(chain) == 0 {
+		return effective
+	}
+	effective.ResourceID = chain[0].UuidId
+
+	// index - позиция права сотрудника в Grants: сотрудники перечисляются в порядке появления в цепочке,
+	// а более далекий источник заменяет ближний, только если дает больше
+	index := map[string]int{}
+	granted := map[string]permission.Access{}
+	for i, perm := range chain {
+		effective.Sources = append(effective.Sources, permission.Source{
+			ResourceID:   perm.UuidId,
+			ResourceType: perm.ResourceType,
+			AccessLevel:  perm.Rules.AccessLevel,
+			Inherit:      perm.Inherit,
+		})
+		if effective.PublicFrom == "" && perm.Rules.AccessLevel == permission.LevelPublic {
+			effective.PublicFrom = perm.UuidId
+		}
+
+		for _, list := range [][]string{perm.Rules.AccessAllowed, perm.Rules.CommentOnly, perm.Rules.ReadOnly} {
+			for _, employeeId := range list {
+				access := perm.Rules.AccessFor(employeeId)
+				if access == permission.AccessNone {
+					continue
+				}
+				grant := permission.Grant{
+					EmployeeID: employeeId,
+					Access:     access.String(),
+					SourceID:   perm.UuidId,
+					SourceType: perm.ResourceType,
+					Inherited:  i > 0,
+				}
+
+				j, ok := index[employeeId]
+				switch {
+				case !ok:
+					index[employeeId] = len(effective.Grants)
+					effective.Grants = append(effective.Grants, grant)
+				case access > granted[employeeId]:
+					effective.Grants[j] = grant
+				default:
+					continue
+				}
+				granted[employeeId] = access
+			}
+		}
+	}
+	return effective
+}
@@ -0,0 +1,84 @@
+package permissionLogic
+
+import (
+	"labyrinth/notebook/models/permission"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEffective(t *testing.T) {
+	notebook := permission.NewPermission("author", "notebook", "notebook", permission.ResourceNotebook, primitive.NewObjectID())
+	notebook.Rules.ReadOnly = []string{"reviewer"}
+
+	folder := permission.NewPermission("head", "folder", "folder", permission.ResourceFolder, primitive.NewObjectID())
+	folder.Rules.AccessAllowed = append(folder.Rules.AccessAllowed, "reviewer")
+	folder.Rules.CommentOnly = []string{"author"}
+
+	root := permission.NewPermission("head", "root", "root", permission.ResourceFolder, primitive.NewObjectID())
+	root.Rules.AccessLevel = permission.LevelPublic
+	root.Inherit = false
+
+	chain := []permission.Permission{notebook, folder, root}
+
+	t.Run("EffectiveAccess", func(t *testing.T) {
+		cases := map[string]permission.Access{
+			"author":   permission.AccessEdit,
+			"reviewer": permission.AccessEdit,
+			"head":     permission.AccessEdit,
+			"stranger": permission.AccessRead,
+		}
+		for employeeId, want := range cases {
+			if got := EffectiveAccess(chain, employeeId); got != want {
+				t.Errorf("Expected %s for %s, got %s\n", want, employeeId, got)
+			}
+		}
+		if got := EffectiveAccess(chain[:1], "head"); got != permission.AccessNone {
+			t.Errorf("Expected no access without inheritance, got %s\n", got)
+		}
+	})
+
+	t.Run("Inherited", func(t *testing.T) {
+		if got := Inherited(notebook, "head", permission.AccessComment); got != permission.AccessComment {
+			t.Errorf("Expected folder access to pass down, got %s\n", got)
+		}
+		if got := Inherited(notebook, "author", permission.AccessRead); got != permission.AccessEdit {
+			t.Errorf("Expected own rules to win, got %s\n", got)
+		}
+		override := notebook
+		override.Inherit = false
+		if got := Inherited(override, "head", permission.AccessEdit); got != permission.AccessNone {
+			t.Errorf("Expected override to cut inheritance, got %s\n", got)
+		}
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		effective := Explain(chain)
+		if effective.ResourceID != "notebook" || len(effective.Sources) != 3 || effective.PublicFrom != "root" {
+			t.Fatalf("Unexpected sources %+v\n", effective)
+		}
+
+		want := []permission.Grant{
+			{EmployeeID: "author", Access: "edit", SourceID: "notebook", SourceType: permission.ResourceNotebook},
+			{EmployeeID: "reviewer", Access: "edit", SourceID: "folder", SourceType: permission.ResourceFolder, Inherited: true},
+			{EmployeeID: "head", Access: "edit", SourceID: "folder", SourceType: permission.ResourceFolder, Inherited: true},
+		}
+		if len(effective.Grants) != len(want) {
+			t.Fatalf("Expected %d grants, got %+v\n", len(want), effective.Grants)
+		}
+		for i := range want {
+			if effective.Grants[i] != want[i] {
+				t.Errorf("Expected grant %+v, got %+v\n", want[i], effective.Grants[i])
+			}
+		}
+	})
+
+	t.Run("ExplainPrivate", func(t *testing.T) {
+		private := notebook
+		private.Rules.AccessLevel = permission.LevelPrivate
+		effective := Explain([]permission.Permission{private})
+		if len(effective.Grants) != 1 || effective.Grants[0].EmployeeID != "author" {
+			t.Errorf("Expected read-only list to be ignored on a private notebook, got %+v\n", effective.Grants)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package permissionLogic
+
+import "labyrinth/notebook/models/permission"
+
+// EmployeeRules заменяет ID в списках правил на ID сотрудников. resolve возвращает ID сотрудника
+// для ID пользователя или самого сотрудника; ID, которые resolve не знает, остаются в списке и возвращаются отдельно.
+// Повторы, появившиеся после замены, убираются; порядок списков сохраняется.
+func EmployeeRules(rules permission.PermissionRules, resolve func(id string) (string, bool)) (permission.PermissionRules, []string) {
+	var unresolved []string
+	convert := func(list []string) []string {
+		result := make([]string, 0, len(list))
+		seen := make(map[string]bool, len(list))
+		for _, id := range list {
+			employeeId, ok := resolve(id)
+			if !ok {
+				employeeId = id
+				unresolved = append(unresolved, id)
+			}
+			if !seen[employeeId] {
+				seen[employeeId] = true
+				result = append(result, employeeId)
+			}
+		}
+		return result
+	}
+
+	return permission.PermissionRules{
+		AccessAllowed: convert(rules.AccessAllowed),
+		CommentOnly:   convert(rules.CommentOnly),
+		ReadOnly:      convert(rules.ReadOnly),
+		AccessLevel:   rules.AccessLevel,
+	}, unresolved
+}
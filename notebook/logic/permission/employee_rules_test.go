@@ -0,0 +1,47 @@
+package permissionLogic
+
+import (
+	"labyrinth/notebook/models/permission"
+	"slices"
+	"testing"
+)
+
+func TestEmployeeRules(t *testing.T) {
+	known := map[string]string{
+		"user-1":     "employee-1",
+		"user-2":     "employee-2",
+		"employee-1": "employee-1",
+	}
+	resolve := func(id string) (string, bool) {
+		employeeId, ok := known[id]
+		return employeeId, ok
+	}
+
+	t.Run("Convert", func(t *testing.T) {
+		rules := permission.PermissionRules{
+			AccessAllowed: []string{"employee-1", "user-1", "user-2"},
+			CommentOnly:   []string{"stranger"},
+			ReadOnly:      []string{},
+			AccessLevel:   permission.LevelRestricted,
+		}
+
+		got, unresolved := EmployeeRules(rules, resolve)
+		if !slices.Equal(got.AccessAllowed, []string{"employee-1", "employee-2"}) {
+			t.Errorf("Expected user IDs replaced without duplicates, got %v\n", got.AccessAllowed)
+		}
+		if !slices.Equal(got.CommentOnly, []string{"stranger"}) || !slices.Equal(unresolved, []string{"stranger"}) {
+			t.Errorf("Expected unknown ID to stay and be reported, got %v and %v\n", got.CommentOnly, unresolved)
+		}
+		if got.ReadOnly == nil || got.AccessLevel != permission.LevelRestricted {
+			t.Errorf("Expected empty list and access level to be kept, got %+v\n", got)
+		}
+	})
+
+	t.Run("AlreadyMigrated", func(t *testing.T) {
+		rules := permission.NewPermissionRules("employee-1")
+		got, unresolved := EmployeeRules(rules, resolve)
+		if !slices.Equal(got.AccessAllowed, rules.AccessAllowed) || len(unresolved) != 0 {
+			t.Errorf("Expected migrated rules to stay unchanged, got %+v and %v\n", got, unresolved)
+		}
+	})
+}
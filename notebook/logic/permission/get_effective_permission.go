@@ -0,0 +1,84 @@
+package permissionLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetEffectivePermission возвращает действующие права на журнал или папку с учетом унаследованных от папок
+// и источник каждого права, если сотрудник может читать ресурс
+func (p PermissionMongoLogic) GetEffectivePermission(objectId uuid.UUID, caller *employee.Employee) (*permission.Effective, error) {
+	// 1. Validate input
+	if objectId == uuid.Nil || caller == nil {
+		logger.NewErrMessage("Invalid resource or employee ID",
+			zap.String("operation", "GetEffectivePermission"),
+		)
+		return nil, errors.New("resource and employee IDs cannot be nil")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := mongo.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.String("operation", "GetEffectivePermission"),
+			zap.String("permission_id", objectId.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.String("operation", "GetEffectivePermission"),
+			zap.String("permission_id", objectId.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Check that the employee may read the resource
+	if _, err := AuthorizeResource(ctx, md, &session, objectId.String(), caller, permission.AccessRead); err != nil {
+		logger.NewWarnMessage("Effective permission access denied",
+			zap.String("operation", "GetEffectivePermission"),
+			zap.String("permission_id", objectId.String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	// 6. Load the inheritance chain and explain it
+	chain, err := LoadChain(ctx, md, &session, objectId.String())
+	if err != nil {
+		logger.NewErrMessage("Failed to load permission chain",
+			zap.String("operation", "GetEffectivePermission"),
+			zap.String("permission_id", objectId.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("database operation failed: %w", err)
+	}
+	effective := Explain(chain)
+
+	logger.NewInfoMessage("Successfully resolved effective permission",
+		zap.String("operation", "GetEffectivePermission"),
+		zap.String("permission_id", objectId.String()),
+		zap.Int("sources", len(effective.Sources)),
+	)
+
+	return &effective, nil
+}
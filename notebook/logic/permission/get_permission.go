@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"labyrinth/database/mongo"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
 	"labyrinth/notebook/models/permission"
 	"time"
 
@@ -13,7 +14,8 @@ import (
 	"go.uber.org/zap"
 )
 
-func (p PermissionMongoLogic) GetPermission(objectId uuid.UUID) (*permission.Permission, error) {
+// GetPermission возвращает правила доступа журнала или папки, если сотрудник может их читать
+func (p PermissionMongoLogic) GetPermission(objectId uuid.UUID, caller *employee.Employee) (*permission.Permission, error) {
 	// 1. Validate input
 	if objectId == uuid.Nil {
 		logger.NewErrMessage("Invalid permission ID",
@@ -50,10 +52,10 @@ func (p PermissionMongoLogic) GetPermission(objectId uuid.UUID) (*permission.Per
 	}
 	defer session.EndSession(ctx)
 
-	// 5. Get permission from MongoDB
-	fetchedPermission, err := md.Permission.GetPermissionByUuidId(ctx, &session, objectId.String())
+	// 5. Get permission from MongoDB and check that the employee may read the resource
+	fetchedPermission, err := AuthorizeResource(ctx, md, &session, objectId.String(), caller, permission.AccessRead)
 	if err != nil {
-		logger.NewErrMessage("Failed to get permission",
+		logger.NewWarnMessage("Failed to get permission",
 			zap.String("operation", "GetPermission"),
 			zap.String("permission_id", objectId.String()),
 			zap.Error(err),
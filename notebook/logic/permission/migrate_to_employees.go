@@ -0,0 +1,205 @@
+package permissionLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/trash"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// migrationPage - сколько разрешений читается за один запрос
+const migrationPage = 500
+
+// MigrateToEmployees переводит списки всех разрешений на ID сотрудников: раньше в них попадали и ID пользователей.
+// ID пользователя заменяется на ID его сотрудника в компании ресурса, ресурсы в корзине тоже переводятся.
+// Повторный запуск безопасен: уже переведенные списки не меняются. При dryRun изменения только возвращаются.
+func (p PermissionMongoLogic) MigrateToEmployees(dryRun bool) (*permission.Migration, error) {
+	// 1. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateToEmployees"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 2. Create context with timeout; the migration walks every permission
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateToEmployees"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateToEmployees"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MigrateToEmployees"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Walk all permissions page by page and rewrite their lists
+	report := &permission.Migration{Updated: []string{}, Skipped: []string{}, Unresolved: []string{}}
+	employees := newEmployeeResolver(ctx, tx)
+	after := ""
+	for {
+		page, err := md.Permission.ListPermissions(ctx, &session, after, migrationPage)
+		if err != nil {
+			logger.NewErrMessage("Failed to list permissions",
+				zap.Error(err),
+				zap.String("operation", "MigrateToEmployees"),
+			)
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		after = page[len(page)-1].UuidId
+
+		for i := range page {
+			perm := &page[i]
+			report.Scanned++
+
+			companyId, err := resourceCompany(ctx, md, &session, perm)
+			if err != nil {
+				logger.NewWarnMessage("Permission resource company not found",
+					zap.Error(err),
+					zap.String("operation", "MigrateToEmployees"),
+					zap.String("permission_id", perm.UuidId),
+				)
+				report.Skipped = append(report.Skipped, perm.UuidId)
+				continue
+			}
+
+			rules, unresolved := EmployeeRules(perm.Rules, func(id string) (string, bool) {
+				return employees.resolve(companyId, id)
+			})
+			for _, id := range unresolved {
+				report.Unresolved = append(report.Unresolved, perm.UuidId+": "+id)
+			}
+			if sameRules(perm.Rules, rules) {
+				continue
+			}
+
+			if !dryRun {
+				perm.Rules = rules
+				if _, err := md.Permission.UpdatePermission(ctx, &session, perm.UuidId, perm, perm.Revision); err != nil {
+					logger.NewWarnMessage("Failed to update permission",
+						zap.Error(err),
+						zap.String("operation", "MigrateToEmployees"),
+						zap.String("permission_id", perm.UuidId),
+					)
+					report.Skipped = append(report.Skipped, perm.UuidId)
+					continue
+				}
+			}
+			report.Updated = append(report.Updated, perm.UuidId)
+		}
+	}
+
+	logger.NewInfoMessage("Permission migration finished",
+		zap.String("operation", "MigrateToEmployees"),
+		zap.Int("scanned", report.Scanned),
+		zap.Int("updated", len(report.Updated)),
+		zap.Int("skipped", len(report.Skipped)),
+		zap.Int("unresolved", len(report.Unresolved)),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	return report, nil
+}
+
+// resourceCompany возвращает компанию папки или журнала, к которому относится разрешение, в том числе лежащих в корзине
+func resourceCompany(ctx context.Context, md *m.MongoDB, session *mongo.Session, perm *permission.Permission) (string, error) {
+	companyId, err := md.Trash.GetTrashedCompany(ctx, session, perm.ResourceUuid)
+	if !errors.Is(err, trash.ErrNotFound) {
+		return companyId, err
+	}
+
+	if perm.ResourceType == permission.ResourceFolder {
+		folder, err := md.Folder.GetFolderByFolderId(ctx, session, perm.ResourceUuid)
+		if err != nil {
+			return "", err
+		}
+		return folder.Metadata.CompanyID, nil
+	}
+	notebook, err := md.Notebook.GetNotebookById(ctx, session, perm.ResourceUuid)
+	if err != nil {
+		return "", err
+	}
+	return notebook.Metadata.CompanyID, nil
+}
+
+// sameRules сообщает, совпадают ли списки правил
+func sameRules(a, b permission.PermissionRules) bool {
+	return slices.Equal(a.AccessAllowed, b.AccessAllowed) &&
+		slices.Equal(a.CommentOnly, b.CommentOnly) &&
+		slices.Equal(a.ReadOnly, b.ReadOnly)
+}
+
+// employeeResolver находит ID сотрудника для ID из списков разрешений и запоминает ответы по компаниям
+type employeeResolver struct {
+	ctx   context.Context
+	tx    *sql.Tx
+	known map[string]string // "компания/ID" -> ID сотрудника; пустая строка - ID не найден
+}
+
+func newEmployeeResolver(ctx context.Context, tx *sql.Tx) *employeeResolver {
+	return &employeeResolver{ctx: ctx, tx: tx, known: make(map[string]string)}
+}
+
+// resolve возвращает ID сотрудника компании companyId для ID пользователя или самого сотрудника.
+// Уволенные сотрудники тоже находятся: при восстановлении в компании права должны к ним вернуться.
+func (r *employeeResolver) resolve(companyId, id string) (string, bool) {
+	key := companyId + "/" + id
+	if employeeId, ok := r.known[key]; ok {
+		return employeeId, employeeId != ""
+	}
+
+	employeeId := ""
+	company, companyErr := uuid.Parse(companyId)
+	parsed, idErr := uuid.Parse(id)
+	if companyErr == nil && idErr == nil {
+		pg := postgres.NewPostgresDB()
+		if empl, err := pg.Employee.GetEmployeeByUserId(r.ctx, r.tx, parsed, company); err == nil {
+			employeeId = empl.ID.String()
+		} else if exists, err := pg.Employee.ExistsEmployee(r.ctx, r.tx, parsed); err == nil && exists {
+			employeeId = id
+		}
+	}
+
+	r.known[key] = employeeId
+	return employeeId, employeeId != ""
+}
@@ -0,0 +1,29 @@
+package permissionLogic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"labyrinth/database/postgres"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+
+	"github.com/google/uuid"
+)
+
+// RequireCompanyAdmin проверяет, что общими для компании объектами (шаблонами, тегами) управляет владелец компании.
+// Владелец компании - пользователь, поэтому с ним сравнивается пользователь сотрудника.
+// what попадает в текст ошибки: "only company administrators can manage <what>".
+func RequireCompanyAdmin(ctx context.Context, tx *sql.Tx, companyId uuid.UUID, caller *employee.Employee, what string) error {
+	if err := RequireMember(caller, companyId.String()); err != nil {
+		return err
+	}
+	company, err := postgres.NewPostgresDB().Company.GetCompanyByID(ctx, tx, companyId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch company: %w", err)
+	}
+	if company.OwnerID != caller.UserID {
+		return fmt.Errorf("only company administrators can manage %s: %w", what, permission.ErrForbidden)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"labyrinth/database/mongo"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
 	"labyrinth/notebook/models/permission"
 	"time"
 
@@ -13,14 +14,15 @@ import (
 	"go.uber.org/zap"
 )
 
-func (p PermissionMongoLogic) UpdatePermission(objectId uuid.UUID, updatedPerm *permission.Permission) error {
+// UpdatePermission заменяет правила доступа журнала или папки; менять их может только сотрудник с правом изменения
+func (p PermissionMongoLogic) UpdatePermission(objectId uuid.UUID, caller *employee.Employee, updatedPerm *permission.Permission, expectedRevision int64) (int64, error) {
 	// 1. Validate input parameters
 	if objectId == uuid.Nil {
 		logger.NewErrMessage("Invalid permission ID",
 			zap.String("operation", "UpdatePermission"),
 			zap.Error(errors.New("permission ID cannot be nil")),
 		)
-		return errors.New("invalid permission ID: cannot be nil")
+		return 0, errors.New("invalid permission ID: cannot be nil")
 	}
 
 	if updatedPerm == nil {
@@ -28,7 +30,17 @@ func (p PermissionMongoLogic) UpdatePermission(objectId uuid.UUID, updatedPerm *
 			zap.String("operation", "UpdatePermission"),
 			zap.String("permission_id", objectId.String()),
 		)
-		return errors.New("permission data cannot be nil")
+		return 0, errors.New("permission data cannot be nil")
+	}
+
+	// 2. Validate access rules
+	if err := updatedPerm.Rules.Validate(); err != nil {
+		logger.NewWarnMessage("Invalid access rules",
+			zap.String("operation", "UpdatePermission"),
+			zap.String("permission_id", objectId.String()),
+			zap.Error(err),
+		)
+		return 0, err
 	}
 
 	// 3. Create context with timeout
@@ -43,7 +55,7 @@ func (p PermissionMongoLogic) UpdatePermission(objectId uuid.UUID, updatedPerm *
 			zap.String("permission_id", objectId.String()),
 			zap.Error(err),
 		)
-		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
 	}
 
 	// 5. Start session
@@ -54,12 +66,22 @@ func (p PermissionMongoLogic) UpdatePermission(objectId uuid.UUID, updatedPerm *
 			zap.String("permission_id", objectId.String()),
 			zap.Error(err),
 		)
-		return fmt.Errorf("failed to start MongoDB session: %w", err)
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
 	}
 	defer session.EndSession(ctx)
 
-	// 6. Execute update operation
-	err = md.Permission.UpdatePermission(ctx, &session, objectId.String(), updatedPerm)
+	// 6. Check that the employee may edit the resource
+	if _, err := AuthorizeResource(ctx, md, &session, objectId.String(), caller, permission.AccessEdit); err != nil {
+		logger.NewWarnMessage("Permission update denied",
+			zap.String("operation", "UpdatePermission"),
+			zap.String("permission_id", objectId.String()),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	// 7. Execute update operation
+	newRevision, err := md.Permission.UpdatePermission(ctx, &session, objectId.String(), updatedPerm, expectedRevision)
 	if err != nil {
 		logger.NewErrMessage("Failed to update permission",
 			zap.String("operation", "UpdatePermission"),
@@ -67,13 +89,14 @@ func (p PermissionMongoLogic) UpdatePermission(objectId uuid.UUID, updatedPerm *
 			zap.Error(err),
 		)
 
-		return fmt.Errorf("database operation failed: %w", err)
+		return 0, fmt.Errorf("database operation failed: %w", err)
 	}
 
 	logger.NewInfoMessage("Successfully updated permission",
 		zap.String("operation", "UpdatePermission"),
 		zap.String("permission_id", objectId.String()),
+		zap.Int64("revision", newRevision),
 	)
 
-	return nil
+	return newRevision, nil
 }
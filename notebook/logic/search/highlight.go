@@ -0,0 +1,150 @@
+package searchLogic
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// contextBefore и contextAfter - сколько символов текста показывать вокруг первого совпадения
+	contextBefore = 60
+	contextAfter  = 100
+	// stemCut - сколько последних символов слова отбрасывается при сравнении,
+	// чтобы подсвечивать словоформы, которые находит стеммер Mongo ("синтез" -> "синтеза")
+	stemCut     = 2
+	minStemBase = 4
+)
+
+type span struct{ start, end int }
+
+// Terms разбирает поисковую строку Mongo: фразы в кавычках остаются целыми,
+// исключенные слова (-слово) не подсвечиваются
+func Terms(query string) []string {
+	var terms []string
+	for i, part := range strings.Split(query, `"`) {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		if i%2 == 1 {
+			terms = append(terms, part)
+			continue
+		}
+		for _, word := range strings.FieldsFunc(part, func(r rune) bool { return !isWordRune(r) && r != '-' }) {
+			if strings.HasPrefix(word, "-") {
+				continue
+			}
+			if word = strings.Trim(word, "-"); word != "" {
+				terms = append(terms, word)
+			}
+		}
+	}
+	return terms
+}
+
+// Highlight возвращает экранированный фрагмент текста вокруг первого совпадения,
+// в котором совпавшие слова обрамлены <mark></mark>. ok=false, если совпадений нет.
+func Highlight(text string, terms []string) (fragment string, ok bool) {
+	original := []rune(text)
+	lower := make([]rune, len(original))
+	for i, r := range original {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// 1. Find word-aligned matches, left to right, without overlaps
+	var spans []span
+	for i := 0; i < len(lower); i++ {
+		if i > 0 && isWordRune(lower[i-1]) {
+			continue
+		}
+		for _, term := range terms {
+			stem := []rune(term)
+			phrase := strings.ContainsRune(term, ' ')
+			if !phrase && len(stem)-stemCut >= minStemBase {
+				stem = stem[:len(stem)-stemCut]
+			}
+			if !hasPrefix(lower[i:], stem) {
+				continue
+			}
+			end := i + len(stem)
+			for end < len(lower) && isWordRune(lower[end]) {
+				end++
+			}
+			spans = append(spans, span{i, end})
+			i = end - 1
+			break
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	// 2. Cut a window around the first match and mark every match inside it
+	from := max(0, spans[0].start-contextBefore)
+	for from > 0 && from < spans[0].start && isWordRune(original[from-1]) {
+		from++
+	}
+	to := min(len(original), spans[0].end+contextAfter)
+	for to < len(original) && to > spans[0].end && isWordRune(original[to]) {
+		to--
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString("…")
+	}
+	pos := from
+	for _, s := range spans {
+		if s.start < from || s.end > to {
+			continue
+		}
+		sb.WriteString(escape(original[pos:s.start]))
+		sb.WriteString("<mark>")
+		sb.WriteString(escape(original[s.start:s.end]))
+		sb.WriteString("</mark>")
+		pos = s.end
+	}
+	sb.WriteString(escape(original[pos:to]))
+	if to < len(original) {
+		sb.WriteString("…")
+	}
+	return sb.String(), true
+}
+
+func hasPrefix(text, prefix []rune) bool {
+	if len(prefix) == 0 || len(text) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if text[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// escape экранирует текст для HTML и схлопывает переводы строк и повторные пробелы
+func escape(text []rune) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		sb.WriteRune(r)
+	}
+	if space {
+		sb.WriteByte(' ')
+	}
+	return html.EscapeString(sb.String())
+}
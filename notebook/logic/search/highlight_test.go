@@ -0,0 +1,58 @@
+package searchLogic_test
+
+import (
+	searchLogic "labyrinth/notebook/logic/search"
+	"strings"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	got := searchLogic.Terms(`Синтез "уксусный ангидрид" -кислота pH-метр`)
+	want := []string{"синтез", "уксусный ангидрид", "ph-метр"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected terms %v, got %v", want, got)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	t.Run("MarksWordForms", func(t *testing.T) {
+		fragment, ok := searchLogic.Highlight("Протокол синтеза аспирина. Синтез завершен", searchLogic.Terms("синтез"))
+		if !ok {
+			t.Fatalf("Expected a match")
+		}
+		want := "Протокол <mark>синтеза</mark> аспирина. <mark>Синтез</mark> завершен"
+		if fragment != want {
+			t.Errorf("Expected %q, got %q", want, fragment)
+		}
+	})
+
+	t.Run("RequiresWordStart", func(t *testing.T) {
+		if _, ok := searchLogic.Highlight("биосинтез", searchLogic.Terms("синтез")); ok {
+			t.Errorf("Expected no match inside a word")
+		}
+	})
+
+	t.Run("EscapesAndTrims", func(t *testing.T) {
+		text := strings.Repeat("слово ", 30) + "<b>ангидрид</b>\n\nи " + strings.Repeat("хвост ", 40)
+		fragment, ok := searchLogic.Highlight(text, []string{"ангидрид"})
+		if !ok {
+			t.Fatalf("Expected a match")
+		}
+		if !strings.HasPrefix(fragment, "…") || !strings.HasSuffix(fragment, "…") {
+			t.Errorf("Expected fragment to be clipped on both sides, got %q", fragment)
+		}
+		if !strings.Contains(fragment, "&lt;b&gt;<mark>ангидрид</mark>&lt;/b&gt; и ") {
+			t.Errorf("Expected escaped markup around the match, got %q", fragment)
+		}
+		if strings.Contains(fragment, "<b>") || strings.Contains(fragment, "\n") {
+			t.Errorf("Expected no raw markup or newlines, got %q", fragment)
+		}
+	})
+
+	t.Run("Phrase", func(t *testing.T) {
+		fragment, ok := searchLogic.Highlight("Добавлен уксусный ангидрид", searchLogic.Terms(`"уксусный ангидрид"`))
+		if !ok || fragment != "Добавлен <mark>уксусный ангидрид</mark>" {
+			t.Errorf("Unexpected phrase highlight %q", fragment)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package searchLogic
+
+import "sync/atomic"
+
+type SearchMongoLogic struct{}
+
+func NewSearchMongoLogic() SearchMongoLogic { return SearchMongoLogic{} }
+
+// indexesReady - текстовые индексы создаются один раз за время жизни процесса
+var indexesReady atomic.Bool
+
+// maxHighlights - сколько фрагментов с совпадениями возвращать на один результат
+const maxHighlights = 3
@@ -0,0 +1,160 @@
+package searchLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/search"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Search ищет журналы и папки компании, доступные сотруднику, и подсвечивает совпадения.
+// Без query.Kind результаты обеих коллекций сливаются по релевантности.
+func (s SearchMongoLogic) Search(caller *employee.Employee, companyId uuid.UUID, query search.Query) (*search.Result, error) {
+	// 1. Validate and normalize the query
+	if caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "Search"),
+		)
+		return nil, errors.New("employee and company IDs cannot be empty")
+	}
+
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" {
+		return nil, search.ErrEmptyQuery
+	}
+	query.CompanyID = companyId.String()
+	if query.Limit <= 0 {
+		query.Limit = search.DefaultLimit
+	}
+	query.Limit = min(query.Limit, search.MaxLimit)
+	query.Offset = min(max(query.Offset, 0), search.MaxOffset)
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Check that the employee belongs to the company
+	if err := permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+		logger.NewWarnMessage("Company access denied",
+			zap.Error(err),
+			zap.String("operation", "Search"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, err
+	}
+	query.EmployeeID = caller.ID.String()
+
+	// 4. Initialize MongoDB and make sure text indexes exist
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "Search"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	if !indexesReady.Load() {
+		if err := md.Search.EnsureIndexes(ctx); err != nil {
+			logger.NewErrMessage("Failed to create text indexes",
+				zap.Error(err),
+				zap.String("operation", "Search"),
+			)
+			return nil, err
+		}
+		indexesReady.Store(true)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "Search"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Query both collections for the top offset+limit each, then merge and cut the page
+	window := query
+	window.Offset, window.Limit = 0, query.Offset+query.Limit
+	if query.Kind != "" {
+		window = query
+	}
+
+	var docs []search.Document
+	var total int64
+	if query.Kind == "" || query.Kind == search.KindNotebook {
+		found, count, err := md.Search.SearchNotebooks(ctx, &session, window)
+		if err != nil {
+			logger.NewErrMessage("Notebook search failed",
+				zap.Error(err),
+				zap.String("operation", "Search"),
+			)
+			return nil, err
+		}
+		docs, total = append(docs, found...), total+count
+	}
+	if query.Kind == "" || query.Kind == search.KindFolder {
+		found, count, err := md.Search.SearchFolders(ctx, &session, window)
+		if err != nil {
+			logger.NewErrMessage("Folder search failed",
+				zap.Error(err),
+				zap.String("operation", "Search"),
+			)
+			return nil, err
+		}
+		docs, total = append(docs, found...), total+count
+	}
+
+	if query.Kind == "" {
+		sort.SliceStable(docs, func(i, j int) bool { return docs[i].Score > docs[j].Score })
+		docs = docs[min(query.Offset, len(docs)):]
+		docs = docs[:min(query.Limit, len(docs))]
+	}
+
+	// 6. Highlight matches
+	terms := Terms(query.Text)
+	result := &search.Result{Hits: make([]search.Hit, 0, len(docs)), Total: total}
+	for _, doc := range docs {
+		hit := search.Hit{
+			Kind:       doc.Kind,
+			ID:         doc.UuidID,
+			DivisionID: doc.DivisionID,
+			Title:      doc.Title,
+			Author:     doc.Author,
+			Tags:       doc.Tags,
+			Created:    doc.Created,
+			Score:      doc.Score,
+			Highlights: []search.Highlight{},
+		}
+		for _, field := range doc.Fields {
+			if len(hit.Highlights) == maxHighlights {
+				break
+			}
+			if fragment, ok := Highlight(field.Text, terms); ok {
+				hit.Highlights = append(hit.Highlights, search.Highlight{Field: field.Name, ID: field.ID, Fragment: fragment})
+			}
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+
+	logger.NewInfoMessage("Search completed",
+		zap.String("operation", "Search"),
+		zap.String("employee_id", caller.ID.String()),
+		zap.Int64("total", total),
+		zap.Int("returned", len(result.Hits)),
+	)
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package signatureLogic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+)
+
+// signedBlock - часть блока, которая входит в подпись. Комментарии не подписываются:
+// обсуждение подписанной записи остается открытым.
+type signedBlock struct {
+	Id   string         `json:"id"`
+	Type string         `json:"type"`
+	Body map[string]any `json:"body"`
+}
+
+// signedContent - канонический вид подписанного содержимого. Для подписи всего журнала
+// в него входят название, описание и блоки по порядку, для диапазона - только блоки.
+type signedContent struct {
+	Title       string        `json:"title,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Blocks      []signedBlock `json:"blocks"`
+}
+
+// BlockIDs проверяет, что блоки есть в журнале, и убирает повторы, сохраняя порядок
+func BlockIDs(n *journal.Notebook, blockIds []string) ([]string, error) {
+	seen := make(map[string]bool, len(blockIds))
+	ids := make([]string, 0, len(blockIds))
+	for _, id := range blockIds {
+		if seen[id] {
+			continue
+		}
+		if _, err := n.FindBlock(id); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, id)
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ContentHash возвращает SHA-256 подписываемого содержимого: всего журнала при пустом blockIds
+// или перечисленных блоков в заданном порядке
+func ContentHash(n *journal.Notebook, blockIds []string) (string, error) {
+	var content signedContent
+	if len(blockIds) == 0 {
+		content.Title = n.Metadata.Title
+		content.Description = n.Metadata.Description
+		content.Blocks = make([]signedBlock, 0, len(n.Blocks))
+		for _, b := range n.Blocks {
+			content.Blocks = append(content.Blocks, signedBlock{Id: b.Id, Type: b.Type, Body: b.Body})
+		}
+	} else {
+		content.Blocks = make([]signedBlock, 0, len(blockIds))
+		for _, id := range blockIds {
+			b, err := n.FindBlock(id)
+			if err != nil {
+				return "", fmt.Errorf("%w: %s", err, id)
+			}
+			content.Blocks = append(content.Blocks, signedBlock{Id: b.Id, Type: b.Type, Body: b.Body})
+		}
+	}
+
+	// json.Marshal сортирует ключи map, поэтому одно и то же содержимое дает один и тот же хеш
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode signed content: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify проверяет, что содержимое под каждой подписью журнала не изменилось.
+// Возвращает journal.ErrSignedContent с ID первой нарушенной подписи.
+func Verify(n *journal.Notebook) error {
+	for _, s := range n.Signatures {
+		hash, err := ContentHash(n, s.BlockIDs)
+		if err != nil || hash != s.Hash {
+			return fmt.Errorf("%w: signature %s", journal.ErrSignedContent, s.Id)
+		}
+	}
+	return nil
+}
+
+// VerifyDeletable запрещает удалять подписанный журнал: удаление затрагивает все подписанное содержимое,
+// поэтому проверка строже Verify. Возвращает journal.ErrSignedContent с ID первой подписи.
+func VerifyDeletable(n *journal.Notebook) error {
+	if len(n.Signatures) > 0 {
+		return fmt.Errorf("%w: signature %s", journal.ErrSignedContent, n.Signatures[0].Id)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package signatureLogic
+
+import (
+	"errors"
+	"labyrinth/notebook/models/journal"
+	"testing"
+)
+
+func testNotebook() *journal.Notebook {
+	n := journal.NewNotebook("employee", "company", "division", "notebook", "Синтез аспирина", "Методика")
+	n.Blocks = []journal.Block{
+		{Id: "b1", Type: "text", Body: map[string]any{"content": "Навеска 1.25 г", "format": "markdown"}},
+		{Id: "b2", Type: "measurement", Body: map[string]any{"quantity": "Масса", "value": 12.5, "unit": "mg"}},
+		{Id: "b3", Type: "text", Body: map[string]any{"content": "Выход 87%"}},
+	}
+	return &n
+}
+
+func sign(t *testing.T, n *journal.Notebook, blockIds []string) {
+	t.Helper()
+	hash, err := ContentHash(n, blockIds)
+	if err != nil {
+		t.Fatalf("Failed to hash content: %v\n", err)
+	}
+	n.Signatures = append(n.Signatures, journal.NewSignature(journal.SignatureAuthor, "employee", "Автор записи", hash, "", blockIds, n.Revision))
+}
+
+func TestSignature(t *testing.T) {
+	t.Run("HashIsStable", func(t *testing.T) {
+		first, err := ContentHash(testNotebook(), nil)
+		if err != nil {
+			t.Fatalf("Failed to hash content: %v\n", err)
+		}
+		second, _ := ContentHash(testNotebook(), nil)
+		if first != second || len(first) != 64 {
+			t.Errorf("Expected the same SHA-256 hex for equal content, got %q and %q\n", first, second)
+		}
+	})
+
+	t.Run("CommentsAreNotSigned", func(t *testing.T) {
+		n := testNotebook()
+		sign(t, n, nil)
+		n.Blocks[0].Comment = append(n.Blocks[0].Comment, journal.NewComment("witness", "", "Проверьте навеску"))
+		if err := Verify(n); err != nil {
+			t.Errorf("Expected comments to be allowed on signed content, got %v\n", err)
+		}
+	})
+
+	t.Run("WholeNotebookIsLocked", func(t *testing.T) {
+		cases := map[string]func(n *journal.Notebook){
+			"EditBlock":   func(n *journal.Notebook) { n.Blocks[2].Body["content"] = "Выход 97%" },
+			"InsertBlock": func(n *journal.Notebook) { n.Blocks = append(n.Blocks, journal.NewBlock("text", nil)) },
+			"MoveBlock":   func(n *journal.Notebook) { n.Blocks[0], n.Blocks[1] = n.Blocks[1], n.Blocks[0] },
+			"Rename":      func(n *journal.Notebook) { n.Metadata.Title = "Синтез парацетамола" },
+		}
+		for name, change := range cases {
+			n := testNotebook()
+			sign(t, n, nil)
+			change(n)
+			if err := Verify(n); !errors.Is(err, journal.ErrSignedContent) {
+				t.Errorf("%s: expected ErrSignedContent, got %v\n", name, err)
+			}
+		}
+	})
+
+	t.Run("RangeLocksOnlySignedBlocks", func(t *testing.T) {
+		n := testNotebook()
+		sign(t, n, []string{"b1", "b2"})
+
+		n.Blocks[2].Body["content"] = "Выход 97%"
+		n.Blocks = append([]journal.Block{journal.NewBlock("heading", map[string]any{"text": "Итоги"})}, n.Blocks...)
+		n.Metadata.Title = "Синтез аспирина, серия 2"
+		if err := Verify(n); err != nil {
+			t.Fatalf("Expected unsigned changes to be allowed, got %v\n", err)
+		}
+
+		n.Blocks = append(n.Blocks[:1], n.Blocks[2:]...) // удален b1
+		if err := Verify(n); !errors.Is(err, journal.ErrSignedContent) {
+			t.Errorf("Expected deleting a signed block to fail, got %v\n", err)
+		}
+	})
+
+	t.Run("BlockIDs", func(t *testing.T) {
+		n := testNotebook()
+		ids, err := BlockIDs(n, []string{"b2", "b1", "b2"})
+		if err != nil || len(ids) != 2 || ids[0] != "b2" || ids[1] != "b1" {
+			t.Errorf("Expected [b2 b1], got %v (%v)\n", ids, err)
+		}
+		if _, err := BlockIDs(n, []string{"missing"}); !errors.Is(err, journal.ErrBlockNotFound) {
+			t.Errorf("Expected ErrBlockNotFound, got %v\n", err)
+		}
+	})
+
+	t.Run("SignedNotebookIsNotDeletable", func(t *testing.T) {
+		n := testNotebook()
+		if err := VerifyDeletable(n); err != nil {
+			t.Errorf("Expected unsigned notebook to be deletable, got %v\n", err)
+		}
+		sign(t, n, []string{n.Blocks[0].Id})
+		if err := VerifyDeletable(n); !errors.Is(err, journal.ErrSignedContent) {
+			t.Errorf("Expected ErrSignedContent, got %v\n", err)
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		n := testNotebook()
+		if s := n.SignatureStatus(); s != journal.SignatureStatusUnsigned {
+			t.Errorf("Expected unsigned, got %s\n", s)
+		}
+		sign(t, n, nil)
+		if s := n.SignatureStatus(); s != journal.SignatureStatusSigned {
+			t.Errorf("Expected signed, got %s\n", s)
+		}
+		author := n.Signatures[0]
+		n.Signatures = append(n.Signatures, journal.NewSignature(journal.SignatureWitness, "witness", "Свидетель", author.Hash, author.Id, author.BlockIDs, n.Revision))
+		if s := n.SignatureStatus(); s != journal.SignatureStatusWitnessed {
+			t.Errorf("Expected witnessed, got %s\n", s)
+		}
+	})
+}
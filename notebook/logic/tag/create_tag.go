@@ -0,0 +1,116 @@
+package tagLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/tag"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// CreateTag добавляет тег в словарь компании. Создавать теги может любой сотрудник компании.
+func (t TagMongoLogic) CreateTag(caller *employee.Employee, companyId uuid.UUID, name, color string) (*tag.Tag, error) {
+	// 1. Validate input
+	if caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "CreateTag"),
+		)
+		return nil, errors.New("employee and company IDs cannot be empty")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "CreateTag"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Reserve tag UUID
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "CreateTag"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	generatedId, err := postgres.NewPostgresDB().UuidValidation.CheckAndReserveUUID(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("UUID generation failed",
+			zap.Error(err),
+			zap.String("operation", "CreateTag"),
+		)
+		return nil, fmt.Errorf("uuid generation failed: %w", err)
+	}
+
+	newTag, err := tag.NewTag(caller.ID.String(), companyId.String(), generatedId.String(), name, color)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "CreateTag"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "CreateTag"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Save tag
+	if err := md.Tag.CreateTag(ctx, &session, &newTag); err != nil {
+		if !errors.Is(err, tag.ErrDuplicate) {
+			logger.NewErrMessage("Failed to create tag",
+				zap.Error(err),
+				zap.String("operation", "CreateTag"),
+				zap.String("company_id", companyId.String()),
+			)
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "CreateTag"),
+		)
+		return nil, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	logger.NewInfoMessage("Tag created successfully",
+		zap.String("operation", "CreateTag"),
+		zap.String("company_id", companyId.String()),
+		zap.String("tag_id", newTag.UuidID),
+	)
+
+	return &newTag, nil
+}
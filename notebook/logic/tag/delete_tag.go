@@ -0,0 +1,112 @@
+package tagLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// DeleteTag удаляет тег из словаря и снимает его со всех журналов, папок и шаблонов компании
+func (t TagMongoLogic) DeleteTag(tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error {
+	// 1. Validate input
+	if tagId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "DeleteTag"),
+		)
+		return errors.New("tag, employee and company IDs cannot be empty")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTag"),
+		)
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 4. Only company administrators delete tags
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTag"),
+		)
+		return fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "tags"); err != nil {
+		logger.NewWarnMessage("Tag management denied",
+			zap.Error(err),
+			zap.String("operation", "DeleteTag"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTag"),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTag"),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	current, err := companyTag(ctx, md, &session, tagId.String(), companyId.String())
+	if err != nil {
+		return err
+	}
+
+	// 6. Untag objects and delete the tag in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if _, err := md.Tag.RemoveTagUsage(sc, &session, companyId.String(), current.Name); err != nil {
+			return nil, err
+		}
+		return nil, md.Tag.DeleteTag(sc, &session, current.UuidID)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to delete tag",
+			zap.Error(err),
+			zap.String("operation", "DeleteTag"),
+			zap.String("tag_id", tagId.String()),
+		)
+		return err
+	}
+
+	logger.NewInfoMessage("Tag deleted successfully",
+		zap.String("operation", "DeleteTag"),
+		zap.String("tag_id", tagId.String()),
+	)
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package tagLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/tag"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListTaggedNotebooks возвращает страницу доступных сотруднику журналов с тегом и их общее число
+func (t TagMongoLogic) ListTaggedNotebooks(tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID, limit, offset int) ([]tag.TaggedNotebook, int64, error) {
+	// 1. Validate input
+	if tagId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "ListTaggedNotebooks"),
+		)
+		return nil, 0, errors.New("tag, employee and company IDs cannot be empty")
+	}
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	limit = min(limit, MaxPageLimit)
+	offset = max(offset, 0)
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ListTaggedNotebooks"),
+		)
+		return nil, 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ListTaggedNotebooks"),
+		)
+		return nil, 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Check that the employee belongs to the company
+	if err := permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+		logger.NewWarnMessage("Company access denied",
+			zap.Error(err),
+			zap.String("operation", "ListTaggedNotebooks"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, 0, err
+	}
+
+	// 5. Resolve the tag and load the page
+	tg, err := companyTag(ctx, md, &session, tagId.String(), companyId.String())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notebooks, total, err := md.Tag.GetTaggedNotebooks(ctx, &session, companyId.String(), caller.ID.String(), tg.Name, int64(limit), int64(offset))
+	if err != nil {
+		logger.NewErrMessage("Failed to get tagged notebooks",
+			zap.Error(err),
+			zap.String("operation", "ListTaggedNotebooks"),
+			zap.String("tag_id", tagId.String()),
+		)
+		return nil, 0, err
+	}
+
+	return notebooks, total, nil
+}
@@ -0,0 +1,89 @@
+package tagLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/tag"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListTags возвращает словарь тегов компании с числом доступных сотруднику журналов по каждому тегу
+func (t TagMongoLogic) ListTags(caller *employee.Employee, companyId uuid.UUID) ([]tag.Usage, error) {
+	// 1. Validate input
+	if caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "ListTags"),
+		)
+		return nil, errors.New("employee and company IDs cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ListTags"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ListTags"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Check that the employee belongs to the company
+	if err := permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+		logger.NewWarnMessage("Company access denied",
+			zap.Error(err),
+			zap.String("operation", "ListTags"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Load vocabulary and counts
+	tags, err := md.Tag.GetTags(ctx, &session, companyId.String(), "", 0)
+	if err != nil {
+		logger.NewErrMessage("Failed to get tags",
+			zap.Error(err),
+			zap.String("operation", "ListTags"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, err
+	}
+
+	counts, err := md.Tag.CountTags(ctx, &session, companyId.String(), caller.ID.String())
+	if err != nil {
+		logger.NewErrMessage("Failed to count tags",
+			zap.Error(err),
+			zap.String("operation", "ListTags"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, err
+	}
+
+	usages := make([]tag.Usage, 0, len(tags))
+	for _, tg := range tags {
+		usages = append(usages, tag.Usage{Tag: tg, Notebooks: counts[tg.Name]})
+	}
+
+	return usages, nil
+}
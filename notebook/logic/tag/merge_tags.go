@@ -0,0 +1,128 @@
+package tagLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/tag"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// MergeTags сливает тег sourceId в targetId: объекты с исходным тегом получают целевой,
+// исходный тег удаляется из словаря. Возвращает целевой тег.
+func (t TagMongoLogic) MergeTags(sourceId, targetId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) (*tag.Tag, error) {
+	// 1. Validate input
+	if sourceId == uuid.Nil || targetId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "MergeTags"),
+		)
+		return nil, errors.New("tag, employee and company IDs cannot be empty")
+	}
+	if sourceId == targetId {
+		return nil, tag.ErrSelfMerge
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "MergeTags"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 4. Only company administrators merge tags
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "MergeTags"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "tags"); err != nil {
+		logger.NewWarnMessage("Tag management denied",
+			zap.Error(err),
+			zap.String("operation", "MergeTags"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "MergeTags"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "MergeTags"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Both tags must belong to the company
+	source, err := companyTag(ctx, md, &session, sourceId.String(), companyId.String())
+	if err != nil {
+		return nil, err
+	}
+	target, err := companyTag(ctx, md, &session, targetId.String(), companyId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Retag objects and drop the source tag in one transaction
+	var merged int64
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		n, err := md.Tag.ReplaceTagUsage(sc, &session, companyId.String(), source.Name, target.Name)
+		if err != nil {
+			return nil, err
+		}
+		merged = n
+		return nil, md.Tag.DeleteTag(sc, &session, source.UuidID)
+	})
+	if err != nil {
+		logger.NewErrMessage("Failed to merge tags",
+			zap.Error(err),
+			zap.String("operation", "MergeTags"),
+			zap.String("source_id", sourceId.String()),
+			zap.String("target_id", targetId.String()),
+		)
+		return nil, err
+	}
+
+	logger.NewInfoMessage("Tags merged successfully",
+		zap.String("operation", "MergeTags"),
+		zap.String("source_id", sourceId.String()),
+		zap.String("target_id", targetId.String()),
+		zap.Int64("retagged_objects", merged),
+	)
+
+	return target, nil
+}
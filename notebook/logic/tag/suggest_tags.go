@@ -0,0 +1,69 @@
+package tagLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/notebook/models/tag"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SuggestTags дополняет начало имени тега по словарю компании
+func (t TagMongoLogic) SuggestTags(companyId uuid.UUID, prefix string, limit int) ([]tag.Tag, error) {
+	// 1. Validate input
+	if companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "SuggestTags"),
+		)
+		return nil, errors.New("company ID cannot be empty")
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	if limit <= 0 {
+		limit = DefaultSuggestLimit
+	}
+	limit = min(limit, MaxSuggestLimit)
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "SuggestTags"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "SuggestTags"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Prefix lookup
+	tags, err := md.Tag.GetTags(ctx, &session, companyId.String(), prefix, int64(limit))
+	if err != nil {
+		logger.NewErrMessage("Failed to suggest tags",
+			zap.Error(err),
+			zap.String("operation", "SuggestTags"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, err
+	}
+
+	return tags, nil
+}
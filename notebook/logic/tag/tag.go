@@ -0,0 +1,68 @@
+package tagLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/tag"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Размеры выдачи автодополнения
+const (
+	DefaultSuggestLimit = 10
+	MaxSuggestLimit     = 50
+)
+
+// Размеры страницы журналов с тегом
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+type TagMongoLogic struct{}
+
+func NewTagMongoLogic() TagMongoLogic { return TagMongoLogic{} }
+
+// companyTag возвращает тег, только если он из словаря этой компании
+func companyTag(ctx context.Context, md *m.MongoDB, session *mongo.Session, tagId, companyId string) (*tag.Tag, error) {
+	t, err := md.Tag.GetTagByUuidId(ctx, session, tagId)
+	if err != nil {
+		return nil, err
+	}
+	if t.CompanyID != companyId {
+		return nil, tag.ErrNotFound
+	}
+	return t, nil
+}
+
+// requireEditable проверяет, что журнал или папка принадлежит компании и ее сотрудник может их изменять
+func requireEditable(ctx context.Context, md *m.MongoDB, session *mongo.Session, kind, resourceId, companyId string, caller *employee.Employee) error {
+	var owner string
+	switch kind {
+	case tag.ResourceNotebook:
+		notebook, err := md.Notebook.GetNotebookById(ctx, session, resourceId)
+		if err != nil {
+			return fmt.Errorf("failed to read notebook: %w", err)
+		}
+		owner = notebook.Metadata.CompanyID
+	case tag.ResourceFolder:
+		folder, err := md.Folder.GetFolderByFolderId(ctx, session, resourceId)
+		if err != nil {
+			return fmt.Errorf("failed to read folder: %w", err)
+		}
+		owner = folder.Metadata.CompanyID
+	default:
+		return fmt.Errorf("unknown tagged resource %q", kind)
+	}
+	if owner != companyId {
+		return fmt.Errorf("%s does not belong to the company: %w", kind, permission.ErrForbidden)
+	}
+
+	_, err := permissionLogic.Authorize(ctx, md, session, companyId, resourceId, caller, permission.AccessEdit)
+	return err
+}
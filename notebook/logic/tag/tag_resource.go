@@ -0,0 +1,103 @@
+package tagLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TagResource отмечает журнал или папку (kind) тегом из словаря компании.
+// Требуется право на изменение объекта.
+func (t TagMongoLogic) TagResource(kind string, resourceId, tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error {
+	return changeResourceTag("TagResource", kind, resourceId, tagId, caller, companyId, true)
+}
+
+// UntagResource снимает тег с журнала или папки (kind)
+func (t TagMongoLogic) UntagResource(kind string, resourceId, tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error {
+	return changeResourceTag("UntagResource", kind, resourceId, tagId, caller, companyId, false)
+}
+
+func changeResourceTag(operation, kind string, resourceId, tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID, add bool) error {
+	// 1. Validate input
+	if resourceId == uuid.Nil || tagId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", operation),
+		)
+		return errors.New("resource, tag, employee and company IDs cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", operation),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", operation),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Check the tag and the access to the resource
+	tg, err := companyTag(ctx, md, &session, tagId.String(), companyId.String())
+	if err != nil {
+		return err
+	}
+
+	if err := requireEditable(ctx, md, &session, kind, resourceId.String(), companyId.String(), caller); err != nil {
+		if errors.Is(err, permission.ErrForbidden) {
+			logger.NewWarnMessage("Tagging denied",
+				zap.String("operation", operation),
+				zap.String("resource", kind),
+				zap.String("resource_id", resourceId.String()),
+				zap.String("employee_id", caller.ID.String()),
+			)
+		}
+		return err
+	}
+
+	// 5. Change the resource tags
+	if add {
+		err = md.Tag.TagResource(ctx, &session, kind, resourceId.String(), tg.Name)
+	} else {
+		err = md.Tag.UntagResource(ctx, &session, kind, resourceId.String(), tg.Name)
+	}
+	if err != nil {
+		logger.NewErrMessage("Failed to change resource tags",
+			zap.Error(err),
+			zap.String("operation", operation),
+			zap.String("resource", kind),
+			zap.String("resource_id", resourceId.String()),
+		)
+		return err
+	}
+
+	logger.NewInfoMessage("Resource tags changed",
+		zap.String("operation", operation),
+		zap.String("resource", kind),
+		zap.String("resource_id", resourceId.String()),
+		zap.String("tag", tg.Name),
+	)
+
+	return nil
+}
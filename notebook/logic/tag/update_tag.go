@@ -0,0 +1,140 @@
+package tagLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/tag"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// UpdateTag переименовывает тег и меняет его цвет; пустое значение оставляет поле без изменений.
+// Новое имя переписывается во всех журналах, папках и шаблонах компании в той же транзакции.
+func (t TagMongoLogic) UpdateTag(tagId uuid.UUID, caller *employee.Employee, companyId uuid.UUID, name, color string) (*tag.Tag, error) {
+	// 1. Validate input
+	if tagId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "UpdateTag"),
+		)
+		return nil, errors.New("tag, employee and company IDs cannot be empty")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTag"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 4. Only company administrators rename tags
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTag"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "tags"); err != nil {
+		logger.NewWarnMessage("Tag management denied",
+			zap.Error(err),
+			zap.String("operation", "UpdateTag"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTag"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTag"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Apply changes to the tag
+	current, err := companyTag(ctx, md, &session, tagId.String(), companyId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *current
+	if name != "" {
+		if updated.Name, err = tag.NormalizeName(name); err != nil {
+			return nil, err
+		}
+		updated.Key = tag.Key(updated.Name)
+	}
+	if color != "" {
+		if updated.Color, err = tag.NormalizeColor(color); err != nil {
+			return nil, err
+		}
+	}
+	updated.LastUpdate = time.Now()
+
+	// 7. Save the tag and rename it everywhere in one transaction
+	var renamed int64
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if err := md.Tag.UpdateTag(sc, &session, &updated); err != nil {
+			return nil, err
+		}
+		if updated.Name != current.Name {
+			n, err := md.Tag.ReplaceTagUsage(sc, &session, companyId.String(), current.Name, updated.Name)
+			if err != nil {
+				return nil, err
+			}
+			renamed = n
+		}
+		return nil, nil
+	})
+	if err != nil {
+		if !errors.Is(err, tag.ErrDuplicate) {
+			logger.NewErrMessage("Failed to update tag",
+				zap.Error(err),
+				zap.String("operation", "UpdateTag"),
+				zap.String("tag_id", tagId.String()),
+			)
+		}
+		return nil, err
+	}
+
+	logger.NewInfoMessage("Tag updated successfully",
+		zap.String("operation", "UpdateTag"),
+		zap.String("tag_id", tagId.String()),
+		zap.Int64("renamed_objects", renamed),
+	)
+
+	return &updated, nil
+}
@@ -0,0 +1,111 @@
+package templateLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/template"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DeleteTemplate удаляет шаблон; журналы, уже созданные из него, не затрагиваются
+func (t TemplateMongoLogic) DeleteTemplate(templateId uuid.UUID, caller *employee.Employee, companyId, divisionId uuid.UUID) error {
+	// 1. Validate input
+	if templateId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "DeleteTemplate"),
+		)
+		return errors.New("template, employee and company IDs cannot be empty")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTemplate"),
+		)
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Only company administrators manage templates
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTemplate"),
+		)
+		return fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "templates"); err != nil {
+		logger.NewWarnMessage("Template management denied",
+			zap.Error(err),
+			zap.String("operation", "DeleteTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+			zap.String("template_id", templateId.String()),
+		)
+		return err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := mongo.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTemplate"),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 6. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "DeleteTemplate"),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 7. Template must be visible to the department before deletion
+	current, err := md.Template.GetTemplateByUuidId(ctx, &session, templateId.String())
+	if err != nil {
+		return err
+	}
+	if !current.AvailableIn(companyId.String(), divisionId.String()) {
+		return template.ErrNotFound
+	}
+
+	if err := md.Template.DeleteTemplate(ctx, &session, templateId.String()); err != nil {
+		logger.NewErrMessage("Failed to delete template",
+			zap.Error(err),
+			zap.String("operation", "DeleteTemplate"),
+			zap.String("template_id", templateId.String()),
+		)
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	logger.NewInfoMessage("Template deleted successfully",
+		zap.String("operation", "DeleteTemplate"),
+		zap.String("template_id", templateId.String()),
+	)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package templateLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/notebook/models/template"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetTemplate возвращает шаблон, если он доступен отделу; иначе template.ErrNotFound
+func (t TemplateMongoLogic) GetTemplate(templateId, companyId, divisionId uuid.UUID) (*template.Template, error) {
+	// 1. Validate input
+	if templateId == uuid.Nil {
+		logger.NewErrMessage("Empty template ID provided",
+			zap.String("operation", "GetTemplate"),
+		)
+		return nil, errors.New("template ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := mongo.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "GetTemplate"),
+			zap.String("template_id", templateId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "GetTemplate"),
+			zap.String("template_id", templateId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Fetch template and check it is visible to the department
+	tpl, err := md.Template.GetTemplateByUuidId(ctx, &session, templateId.String())
+	if err != nil {
+		logger.NewWarnMessage("Failed to get template",
+			zap.Error(err),
+			zap.String("operation", "GetTemplate"),
+			zap.String("template_id", templateId.String()),
+		)
+		return nil, err
+	}
+	if !tpl.AvailableIn(companyId.String(), divisionId.String()) {
+		return nil, template.ErrNotFound
+	}
+
+	return tpl, nil
+}
@@ -0,0 +1,64 @@
+package templateLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/notebook/models/template"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListTemplates возвращает шаблоны отдела и общие шаблоны компании
+func (t TemplateMongoLogic) ListTemplates(companyId, divisionId uuid.UUID) (*[]template.Template, error) {
+	// 1. Validate input
+	if companyId == uuid.Nil || divisionId == uuid.Nil {
+		logger.NewErrMessage("Empty company or division ID provided",
+			zap.String("operation", "ListTemplates"),
+		)
+		return nil, errors.New("company and division IDs cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := mongo.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ListTemplates"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 4. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ListTemplates"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Fetch templates
+	templates, err := md.Template.GetTemplates(ctx, &session, companyId.String(), divisionId.String())
+	if err != nil {
+		logger.NewErrMessage("Failed to list templates",
+			zap.Error(err),
+			zap.String("operation", "ListTemplates"),
+			zap.String("company_id", companyId.String()),
+			zap.String("division_id", divisionId.String()),
+		)
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	return &templates, nil
+}
@@ -0,0 +1,158 @@
+package templateLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/template"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SaveTemplate сохраняет журнал отдела как шаблон. При companyWide шаблон доступен всем отделам компании.
+func (t TemplateMongoLogic) SaveTemplate(
+	notebookId uuid.UUID, caller *employee.Employee, companyId, divisionId uuid.UUID,
+	companyWide bool,
+	title, description string,
+	tags []string,
+) (*template.Template, error) {
+	// 1. Validate input parameters
+	if notebookId == uuid.Nil || caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "SaveTemplate"),
+		)
+		return nil, errors.New("notebook, employee, company and division IDs cannot be empty")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Begin transaction
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 5. Only company administrators manage templates
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "templates"); err != nil {
+		logger.NewWarnMessage("Template management denied",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, err
+	}
+
+	// 6. Reserve template UUID
+	generatedId, err := postgres.NewPostgresDB().UuidValidation.CheckAndReserveUUID(ctx, tx)
+	if err != nil {
+		logger.NewErrMessage("UUID generation failed",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+		)
+		return nil, fmt.Errorf("uuid generation failed: %w", err)
+	}
+
+	// 7. Initialize MongoDB
+	md, err := mongo.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 8. Start MongoDB session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 9. Load source notebook; it must belong to the company and department
+	source, err := md.Notebook.GetNotebookById(ctx, &session, notebookId.String())
+	if err != nil {
+		logger.NewErrMessage("Failed to get source notebook",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, fmt.Errorf("failed to get notebook: %w", err)
+	}
+	if source.Metadata.CompanyID != companyId.String() || source.Metadata.DivisionID != divisionId.String() {
+		logger.NewWarnMessage("Source notebook belongs to another department",
+			zap.String("operation", "SaveTemplate"),
+			zap.String("notebook_id", notebookId.String()),
+		)
+		return nil, errors.New("notebook not found")
+	}
+
+	// 10. Build and store the template
+	if strings.TrimSpace(title) == "" {
+		title = source.Metadata.Title
+	}
+	division := divisionId.String()
+	if companyWide {
+		division = ""
+	}
+
+	tpl := template.NewTemplate(caller.ID.String(), companyId.String(), division, generatedId.String(), source, title, description, tags)
+	if err := md.Template.CreateTemplate(ctx, &session, &tpl); err != nil {
+		logger.NewErrMessage("Failed to create template",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+			zap.String("template_id", tpl.UuidID),
+		)
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.NewErrMessage("Transaction commit failed",
+			zap.Error(err),
+			zap.String("operation", "SaveTemplate"),
+		)
+		return nil, fmt.Errorf("transaction commit failed: %w", err)
+	}
+
+	logger.NewInfoMessage("Template saved successfully",
+		zap.String("operation", "SaveTemplate"),
+		zap.String("template_id", tpl.UuidID),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("company_id", companyId.String()),
+		zap.String("division_id", division),
+	)
+
+	return &tpl, nil
+}
@@ -0,0 +1,5 @@
+package templateLogic
+
+type TemplateMongoLogic struct{}
+
+func NewTemplateMongoLogic() TemplateMongoLogic { return TemplateMongoLogic{} }
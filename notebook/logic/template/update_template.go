@@ -0,0 +1,151 @@
+package templateLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/template"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UpdateTemplate меняет название, описание, теги и блоки шаблона при совпадении ревизии.
+// Если updated.Blocks == nil, блоки шаблона не меняются.
+func (t TemplateMongoLogic) UpdateTemplate(
+	templateId uuid.UUID, caller *employee.Employee, companyId, divisionId uuid.UUID,
+	updated *template.Template,
+	expectedRevision int64,
+) (int64, error) {
+	// 1. Validate input
+	if templateId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "UpdateTemplate"),
+		)
+		return 0, errors.New("template, employee and company IDs cannot be empty")
+	}
+	if updated == nil || strings.TrimSpace(updated.Title) == "" {
+		return 0, errors.New("template title cannot be empty")
+	}
+	for _, b := range updated.Blocks {
+		// Тело блока может содержать переменные, поэтому схема проверяется при создании журнала
+		if _, ok := blocksLogic.Lookup(b.Type); !ok {
+			return 0, fmt.Errorf("%w: %q", blocksLogic.ErrUnknownType, b.Type)
+		}
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTemplate"),
+		)
+		return 0, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Only company administrators manage templates
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTemplate"),
+		)
+		return 0, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "templates"); err != nil {
+		logger.NewWarnMessage("Template management denied",
+			zap.Error(err),
+			zap.String("operation", "UpdateTemplate"),
+			zap.String("employee_id", caller.ID.String()),
+			zap.String("template_id", templateId.String()),
+		)
+		return 0, err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := mongo.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTemplate"),
+		)
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	// 6. Start session
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateTemplate"),
+		)
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 7. Merge changes into the current template
+	current, err := md.Template.GetTemplateByUuidId(ctx, &session, templateId.String())
+	if err != nil {
+		return 0, err
+	}
+	if !current.AvailableIn(companyId.String(), divisionId.String()) {
+		return 0, template.ErrNotFound
+	}
+
+	current.Title = updated.Title
+	current.Description = updated.Description
+	if updated.Tags != nil {
+		current.Tags = updated.Tags
+	}
+	if updated.Blocks != nil {
+		blocks := template.CleanBlocks(updated.Blocks)
+		for i := range blocks {
+			if blocks[i].Id == "" {
+				blocks[i].Id = uuid.New().String()
+			}
+			if blocks[i].Body == nil {
+				blocks[i].Body = map[string]any{}
+			}
+		}
+		current.Blocks = blocks
+	}
+	current.Variables = template.Variables(current.Title, current.Blocks)
+	current.LastUpdate = journal.NewDateTimeAuthor(caller.ID.String())
+
+	// 8. Conditional update
+	newRevision, err := md.Template.UpdateTemplate(ctx, &session, templateId.String(), current, expectedRevision)
+	if err != nil {
+		logger.NewErrMessage("Failed to update template",
+			zap.Error(err),
+			zap.String("operation", "UpdateTemplate"),
+			zap.String("template_id", templateId.String()),
+		)
+		return 0, err
+	}
+
+	logger.NewInfoMessage("Template updated successfully",
+		zap.String("operation", "UpdateTemplate"),
+		zap.String("template_id", templateId.String()),
+		zap.Int64("revision", newRevision),
+	)
+
+	return newRevision, nil
+}
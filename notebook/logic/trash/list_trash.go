@@ -0,0 +1,91 @@
+package trashLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/trash"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListTrash возвращает страницу корзины отдела: кто и когда удалил объект и когда он будет удален окончательно.
+// Администратор компании видит все элементы, остальные - только объекты, которые могли читать.
+func (l TrashMongoLogic) ListTrash(caller *employee.Employee, companyId, divisionId uuid.UUID, limit, offset int) ([]trash.Item, int64, error) {
+	// 1. Validate input
+	if caller == nil || companyId == uuid.Nil || divisionId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "ListTrash"),
+		)
+		return nil, 0, errors.New("employee, company and division IDs cannot be empty")
+	}
+	if limit <= 0 {
+		limit = trash.DefaultLimit
+	}
+	limit = min(limit, trash.MaxLimit)
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Administrators see the whole department trash, other employees only what they can read
+	admin, err := isCompanyAdmin(ctx, companyId, caller)
+	if err != nil {
+		logger.NewErrMessage("Failed to check company administrator",
+			zap.Error(err),
+			zap.String("operation", "ListTrash"),
+		)
+		return nil, 0, err
+	}
+	reader := ""
+	if !admin {
+		if err := permissionLogic.RequireMember(caller, companyId.String()); err != nil {
+			logger.NewWarnMessage("Company access denied",
+				zap.Error(err),
+				zap.String("operation", "ListTrash"),
+				zap.String("company_id", companyId.String()),
+			)
+			return nil, 0, err
+		}
+		reader = caller.ID.String()
+	}
+
+	// 4. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ListTrash"),
+		)
+		return nil, 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ListTrash"),
+		)
+		return nil, 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Fetch the page
+	items, total, err := md.Trash.ListItems(ctx, &session, companyId.String(), divisionId.String(), reader, limit, offset)
+	if err != nil {
+		logger.NewErrMessage("Failed to list trash",
+			zap.Error(err),
+			zap.String("operation", "ListTrash"),
+			zap.String("division_id", divisionId.String()),
+		)
+		return nil, 0, fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	return items, total, nil
+}
@@ -0,0 +1,85 @@
+package trashLogic
+
+import (
+	"context"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// PurgeExpired безвозвратно удаляет элементы корзины, срок хранения которых истек, и возвращает их число.
+// Каждый элемент удаляется в своей транзакции: ошибка на одном не задерживает остальные.
+func (l TrashMongoLogic) PurgeExpired() (int, error) {
+	// 1. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// 2. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 3. Take a batch of expired items; the rest wait for the next run
+	items, err := md.Trash.ListExpired(ctx, &session, time.Now(), purgeBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired trash items: %w", err)
+	}
+
+	// 4. Purge them one by one
+	purged := 0
+	for i := range items {
+		_, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+			return nil, purge(sc, md, &session, &items[i])
+		})
+		if err != nil {
+			logger.NewErrMessage("Failed to purge expired trash item",
+				zap.Error(err),
+				zap.String("operation", "PurgeExpired"),
+				zap.String("item_id", items[i].ID),
+			)
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// RunTrashPurger периодически вызывает PurgeExpired до отмены контекста.
+func (l TrashMongoLogic) RunTrashPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := l.PurgeExpired()
+			if err != nil {
+				logger.NewErrMessage("Trash purge failed",
+					zap.Error(err),
+					zap.String("operation", "RunTrashPurger"),
+				)
+				continue
+			}
+			if purged > 0 {
+				logger.NewInfoMessage("Expired trash purged",
+					zap.String("operation", "RunTrashPurger"),
+					zap.Int("purged", purged),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package trashLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/permission"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// PurgeItem безвозвратно удаляет элемент корзины до истечения срока хранения; доступно только администраторам
+func (l TrashMongoLogic) PurgeItem(itemId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) error {
+	// 1. Validate input
+	if itemId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "PurgeItem"),
+		)
+		return errors.New("item, employee and company IDs cannot be empty")
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "PurgeItem"),
+		)
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 4. Check that the employee administers the company
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "PurgeItem"),
+		)
+		return fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "trash"); err != nil {
+		return err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "PurgeItem"),
+			zap.String("item_id", itemId.String()),
+		)
+		return fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "PurgeItem"),
+			zap.String("item_id", itemId.String()),
+		)
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Delete the stored documents and their permissions in one transaction
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		item, err := md.Trash.GetItem(sc, &session, itemId.String())
+		if err != nil {
+			return nil, err
+		}
+		if item.CompanyID != companyId.String() {
+			return nil, fmt.Errorf("trash item does not belong to the company: %w", permission.ErrForbidden)
+		}
+		return nil, purge(sc, md, &session, item)
+	})
+	if err != nil {
+		logger.NewWarnMessage("Failed to purge trash item",
+			zap.Error(err),
+			zap.String("operation", "PurgeItem"),
+			zap.String("item_id", itemId.String()),
+		)
+		return fmt.Errorf("failed to purge trash item: %w", err)
+	}
+
+	logger.NewInfoMessage("Trash item purged",
+		zap.String("operation", "PurgeItem"),
+		zap.String("item_id", itemId.String()),
+		zap.String("employee_id", caller.ID.String()),
+	)
+
+	return nil
+}
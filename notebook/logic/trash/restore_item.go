@@ -0,0 +1,208 @@
+package trashLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/trash"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RestoreItem возвращает папку или журнал из корзины на прежнее место.
+// Если исходной папки больше нет, объект восстанавливается на верхнем уровне отдела;
+// если папку перенесли в другой отдел, объект переходит в него вместе с ней.
+// Восстановить может администратор компании или сотрудник с правом записи на удаленный объект,
+// у которого с учетом правил исходной папки осталось право записи и на нее.
+func (l TrashMongoLogic) RestoreItem(itemId uuid.UUID, caller *employee.Employee, companyId uuid.UUID) (*trash.Item, error) {
+	// 1. Validate input
+	if itemId == uuid.Nil || caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "RestoreItem"),
+		)
+		return nil, errors.New("item, employee and company IDs cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 3. Administrators may restore anything in the company
+	admin, err := isCompanyAdmin(ctx, companyId, caller)
+	if err != nil {
+		logger.NewErrMessage("Failed to check company administrator",
+			zap.Error(err),
+			zap.String("operation", "RestoreItem"),
+		)
+		return nil, err
+	}
+
+	// 4. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "RestoreItem"),
+			zap.String("item_id", itemId.String()),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "RestoreItem"),
+			zap.String("item_id", itemId.String()),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 5. Put the documents back and relink them in one transaction
+	var restored trash.Item
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		item, err := md.Trash.GetItem(sc, &session, itemId.String())
+		if err != nil {
+			return nil, err
+		}
+		if item.CompanyID != companyId.String() {
+			return nil, fmt.Errorf("trash item does not belong to the company: %w", permission.ErrForbidden)
+		}
+
+		// The original folder may have been deleted or moved since
+		parent, err := restoreParent(sc, md, &session, item)
+		if err != nil {
+			return nil, err
+		}
+		if !admin {
+			if err := authorizeRestore(sc, md, &session, companyId, item, parent, caller); err != nil {
+				return nil, err
+			}
+		}
+
+		folders, notebooks, err := md.Trash.GetContents(sc, &session, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			item.ParentID = item.DivisionID
+		} else {
+			item.DivisionID = parent.Metadata.DivisionID
+		}
+
+		for i := range folders {
+			folders[i].Metadata.DivisionID = item.DivisionID
+			if folders[i].UuidID == item.ResourceID {
+				folders[i].ParentId = item.ParentID
+			}
+			if err := md.Folder.CreateFolder(sc, &session, &folders[i]); err != nil {
+				return nil, fmt.Errorf("failed to restore folder %s: %w", folders[i].UuidID, err)
+			}
+		}
+		for i := range notebooks {
+			notebooks[i].Metadata.DivisionID = item.DivisionID
+			if err := md.Notebook.CreateNotebook(sc, &session, &notebooks[i]); err != nil {
+				return nil, fmt.Errorf("failed to restore notebook %s: %w", notebooks[i].UuidID, err)
+			}
+		}
+
+		if parent != nil {
+			resourceId, err := uuid.Parse(item.ResourceID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid resource ID %q: %w", item.ResourceID, err)
+			}
+			var description string
+			if item.Kind == trash.KindFolder {
+				for _, folder := range folders {
+					if folder.UuidID == item.ResourceID {
+						description = folder.Metadata.Description
+					}
+				}
+				_, err = md.Folder.AddSubfolder(sc, &session, parent.UuidID, directory.NewFolder(resourceId, item.Title, description))
+			} else {
+				for _, notebook := range notebooks {
+					if notebook.UuidID == item.ResourceID {
+						description = notebook.Metadata.Description
+					}
+				}
+				_, err = md.Folder.AddFile(sc, &session, parent.UuidID, directory.NewFile(resourceId, item.Title, description))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		restored = *item
+		return nil, md.Trash.DeleteItem(sc, &session, item.ID)
+	})
+	if err != nil {
+		logger.NewWarnMessage("Failed to restore trash item",
+			zap.Error(err),
+			zap.String("operation", "RestoreItem"),
+			zap.String("item_id", itemId.String()),
+		)
+		return nil, fmt.Errorf("failed to restore trash item: %w", err)
+	}
+
+	logger.NewInfoMessage("Trash item restored",
+		zap.String("operation", "RestoreItem"),
+		zap.String("item_id", itemId.String()),
+		zap.String("resource_id", restored.ResourceID),
+		zap.String("parent_id", restored.ParentID),
+	)
+
+	return &restored, nil
+}
+
+// restoreParent возвращает папку, в которой лежал объект, или nil, если он лежал на верхнем уровне отдела
+// либо папка с тех пор удалена
+func restoreParent(ctx context.Context, md *m.MongoDB, session *mongo.Session, item *trash.Item) (*directory.Directory, error) {
+	if item.ParentID == item.DivisionID {
+		return nil, nil
+	}
+
+	parent, err := md.Folder.GetFolderByFolderId(ctx, session, item.ParentID)
+	if errors.Is(err, directory.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get original folder: %w", err)
+	}
+	if parent.Metadata.CompanyID != item.CompanyID {
+		return nil, nil
+	}
+	return parent, nil
+}
+
+// authorizeRestore проверяет право записи на объект корзины. В корзине у объекта остаются только собственные правила,
+// поэтому унаследованные проверяются по исходной папке: нужно право записи и на нее.
+// Если объект наследовал правила удаленной с тех пор папки, проверить их нельзя - восстановить может только администратор.
+func authorizeRestore(ctx context.Context, md *m.MongoDB, session *mongo.Session, companyId uuid.UUID, item *trash.Item, parent *directory.Directory, caller *employee.Employee) error {
+	if _, err := permissionLogic.Authorize(ctx, md, session, companyId.String(), item.ResourceID, caller, permission.AccessEdit); err != nil {
+		return err
+	}
+
+	perm, err := md.Permission.GetPermissionByUuidId(ctx, session, item.ResourceID)
+	if err != nil {
+		return fmt.Errorf("failed to read permission: %w", err)
+	}
+	if !perm.Inherit || item.ParentID == item.DivisionID {
+		return nil
+	}
+	if parent == nil {
+		return fmt.Errorf("original folder no longer exists, only a company administrator can restore: %w", permission.ErrForbidden)
+	}
+
+	_, err = permissionLogic.Authorize(ctx, md, session, companyId.String(), parent.UuidID, caller, permission.AccessEdit)
+	return err
+}
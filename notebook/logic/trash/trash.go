@@ -0,0 +1,76 @@
+package trashLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/trash"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// purgeBatch - сколько просроченных элементов удаляется за один проход очистки
+const purgeBatch = 100
+
+type TrashMongoLogic struct{}
+
+func NewTrashMongoLogic() TrashMongoLogic { return TrashMongoLogic{} }
+
+// isCompanyAdmin сообщает, что сотрудник администрирует компанию и видит корзину целиком
+func isCompanyAdmin(ctx context.Context, companyId uuid.UUID, caller *employee.Employee) (bool, error) {
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		return false, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return false, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "trash")
+	if errors.Is(err, permission.ErrForbidden) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// purge безвозвратно удаляет элемент корзины: сохраненные документы и права доступа на них.
+// Ревизии журналов остаются для аудита.
+func purge(ctx context.Context, md *m.MongoDB, session *mongo.Session, item *trash.Item) error {
+	folders, notebooks, err := md.Trash.GetContents(ctx, session, item.ID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(folders)+len(notebooks))
+	for _, folder := range folders {
+		ids = append(ids, folder.UuidID)
+	}
+	for _, notebook := range notebooks {
+		ids = append(ids, notebook.UuidID)
+	}
+	for _, id := range ids {
+		exists, err := md.Permission.ExistsPermission(ctx, session, id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if err := md.Permission.DeletePermission(ctx, session, id); err != nil {
+			return fmt.Errorf("failed to delete permission %s: %w", id, err)
+		}
+	}
+
+	return md.Trash.DeleteItem(ctx, session, item.ID)
+}
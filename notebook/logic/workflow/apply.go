@@ -0,0 +1,85 @@
+package workflowLogic
+
+import (
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/workflow"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+// Actor - сотрудник, выполняющий переход
+type Actor struct {
+	ID    string
+	Level int  // уровень должности в отделе журнала; 0 - не состоит в отделе
+	Owner bool // владелец компании: ограничения по должности и рецензенту на него не действуют
+}
+
+// Apply выполняет переход action над текущим статусом журнала и возвращает новый статус.
+// author - создатель журнала: он не может быть рецензентом. Принадлежность рецензента
+// к отделу проверяет вызывающий код; здесь проверяются только правила процесса.
+func Apply(
+	w workflow.Workflow,
+	current journal.Lifecycle,
+	action string,
+	actor Actor,
+	author, reviewer, note string,
+	now time.Time,
+) (journal.Lifecycle, error) {
+	if utf8.RuneCountInString(note) > workflow.MaxNoteLength {
+		return current, workflow.ErrInvalidNote
+	}
+
+	from := current.CurrentStatus()
+	t, err := w.Find(action, from)
+	if err != nil {
+		return current, err
+	}
+
+	if !actor.Owner && !t.AllowsLevel(actor.Level) {
+		return current, workflow.ErrInsufficientLevel
+	}
+
+	// решение по журналу на проверке принимает только назначенный рецензент
+	if from == journal.StatusInReview && !actor.Owner && current.Reviewer != actor.ID {
+		return current, workflow.ErrNotReviewer
+	}
+
+	if t.To == journal.StatusInReview {
+		if reviewer == "" {
+			return current, workflow.ErrReviewerRequired
+		}
+		if reviewer == author || reviewer == actor.ID {
+			return current, workflow.ErrSelfReview
+		}
+	} else {
+		reviewer = ""
+	}
+
+	next := journal.Lifecycle{
+		Status:   t.To,
+		Reviewer: reviewer,
+		ReadOnly: !w.IsEditable(t.To),
+		History: append(slices.Clone(current.History), journal.StatusChange{
+			Action:   action,
+			From:     from,
+			To:       t.To,
+			By:       actor.ID,
+			Reviewer: reviewer,
+			Note:     note,
+			At:       now,
+		}),
+	}
+	return next, nil
+}
+
+// CanReview сообщает, может ли сотрудник уровня level принимать решения по журналам на проверке:
+// ему должен быть доступен хотя бы один переход из статуса проверки
+func CanReview(w workflow.Workflow, level int) bool {
+	for _, t := range w.Transitions {
+		if slices.Contains(t.From, journal.StatusInReview) && t.AllowsLevel(level) {
+			return true
+		}
+	}
+	return false
+}
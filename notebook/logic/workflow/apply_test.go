@@ -0,0 +1,131 @@
+package workflowLogic
+
+import (
+	"errors"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/workflow"
+	"testing"
+	"time"
+)
+
+func TestApply(t *testing.T) {
+	w := workflow.Default("company")
+	author := Actor{ID: "author", Level: 3}
+	reviewer := Actor{ID: "reviewer", Level: 2}
+	now := time.Now()
+
+	submit := func(t *testing.T) journal.Lifecycle {
+		t.Helper()
+		next, err := Apply(w, journal.Lifecycle{}, workflow.ActionSubmit, author, "author", "reviewer", "Прошу проверить", now)
+		if err != nil {
+			t.Fatalf("Failed to submit: %v\n", err)
+		}
+		return next
+	}
+
+	t.Run("LegacyNotebookIsDraft", func(t *testing.T) {
+		next := submit(t)
+		if next.Status != journal.StatusInReview || next.Reviewer != "reviewer" || next.ReadOnly {
+			t.Errorf("Expected editable notebook in review by reviewer, got %+v\n", next)
+		}
+		if len(next.History) != 1 || next.History[0].From != journal.StatusDraft || next.History[0].Note != "Прошу проверить" {
+			t.Errorf("Expected submission in history, got %+v\n", next.History)
+		}
+	})
+
+	t.Run("SubmitRequiresReviewer", func(t *testing.T) {
+		_, err := Apply(w, journal.NewLifecycle(), workflow.ActionSubmit, author, "author", "", "", now)
+		if !errors.Is(err, workflow.ErrReviewerRequired) {
+			t.Errorf("Expected ErrReviewerRequired, got %v\n", err)
+		}
+	})
+
+	t.Run("NoSelfReview", func(t *testing.T) {
+		_, err := Apply(w, journal.NewLifecycle(), workflow.ActionSubmit, author, "author", "author", "", now)
+		if !errors.Is(err, workflow.ErrSelfReview) {
+			t.Errorf("Expected ErrSelfReview, got %v\n", err)
+		}
+	})
+
+	t.Run("ApproveLocksNotebook", func(t *testing.T) {
+		next, err := Apply(w, submit(t), workflow.ActionApprove, reviewer, "author", "", "", now)
+		if err != nil {
+			t.Fatalf("Failed to approve: %v\n", err)
+		}
+		if next.Status != journal.StatusApproved || !next.ReadOnly || next.Reviewer != "" || len(next.History) != 2 {
+			t.Errorf("Expected read-only approved notebook, got %+v\n", next)
+		}
+	})
+
+	t.Run("ReturnToDraft", func(t *testing.T) {
+		next, err := Apply(w, submit(t), workflow.ActionReturn, reviewer, "author", "", "Нет контроля", now)
+		if err != nil {
+			t.Fatalf("Failed to return: %v\n", err)
+		}
+		if next.Status != journal.StatusDraft || next.ReadOnly {
+			t.Errorf("Expected editable draft, got %+v\n", next)
+		}
+	})
+
+	t.Run("OnlyAssignedReviewerDecides", func(t *testing.T) {
+		other := Actor{ID: "other", Level: 1}
+		if _, err := Apply(w, submit(t), workflow.ActionApprove, other, "author", "", "", now); !errors.Is(err, workflow.ErrNotReviewer) {
+			t.Errorf("Expected ErrNotReviewer, got %v\n", err)
+		}
+
+		owner := Actor{ID: "owner", Owner: true}
+		if _, err := Apply(w, submit(t), workflow.ActionApprove, owner, "author", "", "", now); err != nil {
+			t.Errorf("Expected company owner to decide, got %v\n", err)
+		}
+	})
+
+	t.Run("LevelGuard", func(t *testing.T) {
+		junior := Actor{ID: "reviewer", Level: 3}
+		if _, err := Apply(w, submit(t), workflow.ActionApprove, junior, "author", "", "", now); !errors.Is(err, workflow.ErrInsufficientLevel) {
+			t.Errorf("Expected ErrInsufficientLevel, got %v\n", err)
+		}
+
+		outsider := Actor{ID: "reviewer"}
+		if _, err := Apply(w, submit(t), workflow.ActionApprove, outsider, "author", "", "", now); !errors.Is(err, workflow.ErrInsufficientLevel) {
+			t.Errorf("Expected ErrInsufficientLevel outside the department, got %v\n", err)
+		}
+	})
+
+	t.Run("InvalidTransition", func(t *testing.T) {
+		if _, err := Apply(w, journal.NewLifecycle(), workflow.ActionArchive, reviewer, "author", "", "", now); !errors.Is(err, workflow.ErrInvalidTransition) {
+			t.Errorf("Expected ErrInvalidTransition, got %v\n", err)
+		}
+	})
+
+	t.Run("CanReview", func(t *testing.T) {
+		if !CanReview(w, 2) || CanReview(w, 3) || CanReview(w, 0) {
+			t.Errorf("Expected only levels 1-2 to review with the default workflow\n")
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("DefaultIsValid", func(t *testing.T) {
+		if err := workflow.Default("company").Validate(); err != nil {
+			t.Errorf("Expected default workflow to be valid, got %v\n", err)
+		}
+	})
+
+	cases := map[string]func(w *workflow.Workflow){
+		"UnknownStatus":   func(w *workflow.Workflow) { w.Transitions[0].To = "published" },
+		"DuplicateAction": func(w *workflow.Workflow) { w.Transitions[1].Action = workflow.ActionSubmit },
+		"NoStatusChange":  func(w *workflow.Workflow) { w.Transitions[0].To = journal.StatusDraft },
+		"BadActionName":   func(w *workflow.Workflow) { w.Transitions[0].Action = "Submit!" },
+		"UnknownEditable": func(w *workflow.Workflow) { w.Editable = []string{"published"} },
+		"NoTransitions":   func(w *workflow.Workflow) { w.Transitions = nil },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := workflow.Default("company")
+			mutate(&w)
+			if err := w.Validate(); !errors.Is(err, workflow.ErrInvalidWorkflow) {
+				t.Errorf("Expected ErrInvalidWorkflow, got %v\n", err)
+			}
+		})
+	}
+}
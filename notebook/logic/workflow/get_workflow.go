@@ -0,0 +1,62 @@
+package workflowLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/notebook/models/workflow"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetWorkflow возвращает процесс согласования журналов компании
+func (l WorkflowMongoLogic) GetWorkflow(companyId uuid.UUID) (*workflow.Workflow, error) {
+	// 1. Validate input
+	if companyId == uuid.Nil {
+		logger.NewErrMessage("Empty company ID provided",
+			zap.String("operation", "GetWorkflow"),
+		)
+		return nil, errors.New("company ID cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "GetWorkflow"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "GetWorkflow"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Load company workflow or the default one
+	w, err := Load(ctx, md, &session, companyId.String())
+	if err != nil {
+		logger.NewErrMessage("Failed to load workflow",
+			zap.Error(err),
+			zap.String("operation", "GetWorkflow"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, fmt.Errorf("failed to load workflow: %w", err)
+	}
+
+	return w, nil
+}
@@ -0,0 +1,63 @@
+package workflowLogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/workflow"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListReviews возвращает журналы компании, отправленные сотруднику на проверку, начиная с давно ожидающих
+func (l WorkflowMongoLogic) ListReviews(caller *employee.Employee, companyId uuid.UUID) ([]workflow.ReviewItem, error) {
+	// 1. Validate input
+	if caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "ListReviews"),
+		)
+		return nil, errors.New("employee and company IDs cannot be empty")
+	}
+
+	// 2. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 3. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "ListReviews"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "ListReviews"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 4. Fetch notebooks awaiting the employee's decision
+	items, err := md.Workflow.GetReviewQueue(ctx, &session, companyId.String(), caller.ID.String())
+	if err != nil {
+		logger.NewErrMessage("Failed to get review queue",
+			zap.Error(err),
+			zap.String("operation", "ListReviews"),
+			zap.String("employee_id", caller.ID.String()),
+		)
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,112 @@
+package workflowLogic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	m "labyrinth/database/mongo"
+	"labyrinth/database/postgres"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	permissionLogic "labyrinth/notebook/logic/permission"
+	"labyrinth/notebook/models/workflow"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UpdateWorkflow заменяет процесс согласования компании; доступно только администраторам.
+// Новые правила применяются к следующим переходам: журналы сохраняют текущий статус и режим только для чтения.
+func (l WorkflowMongoLogic) UpdateWorkflow(caller *employee.Employee, companyId uuid.UUID, transitions []workflow.Transition, editable []string) (*workflow.Workflow, error) {
+	// 1. Validate input
+	if caller == nil || companyId == uuid.Nil {
+		logger.NewErrMessage("Empty identifier provided",
+			zap.String("operation", "UpdateWorkflow"),
+		)
+		return nil, errors.New("employee and company IDs cannot be empty")
+	}
+
+	if editable == nil {
+		editable = []string{}
+	}
+	w := workflow.Workflow{
+		CompanyID:   companyId.String(),
+		Transitions: transitions,
+		Editable:    editable,
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   caller.ID.String(),
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 2. Initialize PostgreSQL connection
+	db, err := sql.Open("postgres", postgres.GetConnection())
+	if err != nil {
+		logger.NewErrMessage("Database connection failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateWorkflow"),
+		)
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	defer db.Close()
+
+	// 3. Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 4. Check that the employee administers the company
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		logger.NewErrMessage("Transaction begin failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateWorkflow"),
+		)
+		return nil, fmt.Errorf("transaction begin failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := permissionLogic.RequireCompanyAdmin(ctx, tx, companyId, caller, "workflow"); err != nil {
+		return nil, err
+	}
+
+	// 5. Initialize MongoDB
+	md, err := m.NewMongoDB()
+	if err != nil {
+		logger.NewErrMessage("MongoDB initialization failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateWorkflow"),
+		)
+		return nil, fmt.Errorf("failed to initialize MongoDB: %w", err)
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		logger.NewErrMessage("MongoDB session start failed",
+			zap.Error(err),
+			zap.String("operation", "UpdateWorkflow"),
+		)
+		return nil, fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// 6. Save workflow
+	if err := md.Workflow.SaveWorkflow(ctx, &session, &w); err != nil {
+		logger.NewErrMessage("Failed to save workflow",
+			zap.Error(err),
+			zap.String("operation", "UpdateWorkflow"),
+			zap.String("company_id", companyId.String()),
+		)
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	logger.NewInfoMessage("Workflow updated successfully",
+		zap.String("operation", "UpdateWorkflow"),
+		zap.String("company_id", companyId.String()),
+		zap.Int("transitions", len(w.Transitions)),
+	)
+
+	return &w, nil
+}
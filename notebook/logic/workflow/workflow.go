@@ -0,0 +1,24 @@
+package workflowLogic
+
+import (
+	"context"
+	"errors"
+	m "labyrinth/database/mongo"
+	"labyrinth/notebook/models/workflow"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WorkflowMongoLogic struct{}
+
+func NewWorkflowMongoLogic() WorkflowMongoLogic { return WorkflowMongoLogic{} }
+
+// Load возвращает процесс согласования компании, а если компания его не настраивала - процесс по умолчанию
+func Load(ctx context.Context, md *m.MongoDB, session *mongo.Session, companyId string) (*workflow.Workflow, error) {
+	w, err := md.Workflow.GetWorkflow(ctx, session, companyId)
+	if errors.Is(err, workflow.ErrNotFound) {
+		def := workflow.Default(companyId)
+		return &def, nil
+	}
+	return w, err
+}
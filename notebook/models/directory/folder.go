@@ -1,18 +1,39 @@
 package directory
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Ограничения выдачи дерева папок
+const (
+	DefaultTreeDepth = 3
+	MaxTreeDepth     = 10
+	MaxTreeNodes     = 2000
+)
+
+var (
+	ErrNotFound      = errors.New("folder not found")
+	ErrInvalidTitle  = errors.New("folder title cannot be empty")
+	ErrInvalidParent = errors.New("parent folder belongs to another department")
+	ErrNoDivision    = errors.New("department not found in company")
+	ErrTreeTooLarge  = errors.New("folder tree is too large, request a smaller depth")
+
+	ErrMoveIntoDescendant = errors.New("folder cannot be moved or copied into itself or its subfolder")
+	ErrOtherCompany       = errors.New("target folder belongs to another company")
+	ErrPrimaryFolder      = errors.New("company root folder cannot be moved")
+)
+
 type Directory struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty"`
 	UuidID    string             `bson:"uuid_id"`
 	ParentId  string             `bson:"parent_uuid_id"`
 	IsPrimary bool               `bson:"isPrimary"`
 	Version   string             `bson:"version"`
+	Revision  int64              `bson:"revision"`
 	Metadata  Metadata           `bson:"metadata"`
 	Folders   []Folder           `bson:"folders"`
 	Files     []File             `bson:"files"`
@@ -56,6 +77,18 @@ type File struct {
 	Description string             `bson:"description"`
 }
 
+// Node - папка в дереве отдела: вложенные папки раскрыты до заданной глубины
+type Node struct {
+	FolderID    string
+	Title       string
+	Description string
+	Revision    int64
+	Tags        []string
+	Notebooks   []File
+	Folders     []Node
+	HasMore     bool // вложенные папки есть, но не раскрыты из-за ограничения глубины
+}
+
 func NewDirectory(employeeId, companyId, divisionId, generatedId, parentId uuid.UUID, version string, isPrimary bool, title, description string) Directory {
 	return Directory{
 		ID:        primitive.NewObjectID(),
@@ -0,0 +1,9 @@
+package journal
+
+// AuthorMigration - итог перевода авторов журналов и других объектов с ID пользователей на ID сотрудников
+type AuthorMigration struct {
+	Scanned  int      // просмотрено журналов
+	Updated  []string // журналы, авторы которых переведены (при пробном запуске - были бы переведены)
+	Skipped  []string // журналы, изменившиеся во время перевода или не сохраненные; переводятся повторным запуском
+	Replaced int64    // замен в папках, шаблонах, тегах, процессах согласования и корзине; при пробном запуске не выполняются
+}
@@ -1,17 +1,35 @@
 package journal
 
 import (
+	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MaxCommentLength - максимальная длина комментария в символах
+const MaxCommentLength = 10000
+
+var (
+	ErrInvalidComment  = errors.New("comment must be between 1 and 10000 characters")
+	ErrBlockNotFound   = errors.New("block not found")
+	ErrInvalidBlockId  = errors.New("block id is duplicated or unknown")
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrCommentIsReply  = errors.New("only the first comment of a thread can be resolved")
+)
+
 type Notebook struct {
 	ID       primitive.ObjectID `bson:"id"`
 	UuidID   string             `bson:"uuid_id"`
 	Version  string             `bson:"version"`
+	Revision int64              `bson:"revision"`
 	Metadata Metadata           `bson:"metadata"`
 	Blocks   []Block            `bson:"blocks"`
+	// Signatures меняются только подписанием: UpdateNotebook их не перезаписывает
+	Signatures []Signature `bson:"signatures"`
+	// Lifecycle меняется только переходами между статусами: UpdateNotebook его не перезаписывает
+	Lifecycle Lifecycle `bson:"lifecycle"`
 }
 
 type Metadata struct {
@@ -39,27 +57,36 @@ type Links struct {
 }
 
 type Block struct {
-	Id      int            `bson:"id"`
+	Id      string         `bson:"id"`
 	Type    string         `bson:"type"`
 	Body    map[string]any `bson:"body"`
 	Comment []Comment      `bson:"comments"`
 }
 
+// Comment - комментарий к блоку. Ветки строятся по ParentId, а не вложенностью,
+// поэтому ID комментария не меняется при ответах, правках и удалениях соседей.
 type Comment struct {
-	EmployeeId string    `bson:"employee_id"`
-	CreatedAt  time.Time `bson:"created_at"`
-	UpdatedAt  time.Time `bson:"updated_at"`
-	Comment    string    `bson:"comment"`
-	SubComment []Comment `bson:"sub_comments"`
+	Id         string     `bson:"id"`
+	ParentId   string     `bson:"parent_id"` // ID комментария, на который дан ответ; пусто для начала ветки
+	EmployeeId string     `bson:"employee_id"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	UpdatedAt  time.Time  `bson:"updated_at"`
+	Comment    string     `bson:"comment"`
+	Resolved   bool       `bson:"resolved"`
+	ResolvedBy string     `bson:"resolved_by"`
+	ResolvedAt *time.Time `bson:"resolved_at"`
+	Deleted    bool       `bson:"deleted"` // удалённый комментарий с ответами остаётся в ветке без текста
 }
 
 func NewNotebook(employeeId, companyId, divisionId, generatedId, title, description string) Notebook {
 	return Notebook{
-		ID:       primitive.NewObjectID(),
-		UuidID:   generatedId,
-		Version:  "1.0.0",
-		Metadata: NewMethadata(employeeId, companyId, divisionId, title, description),
-		Blocks:   []Block{},
+		ID:         primitive.NewObjectID(),
+		UuidID:     generatedId,
+		Version:    "1.0.0",
+		Metadata:   NewMethadata(employeeId, companyId, divisionId, title, description),
+		Blocks:     []Block{},
+		Signatures: []Signature{},
+		Lifecycle:  NewLifecycle(),
 	}
 }
 
@@ -83,3 +110,54 @@ func NewDateTimeAuthor(employeeId string) DateTimeAuthor {
 		Author: employeeId,
 	}
 }
+
+func NewBlock(blockType string, body map[string]any) Block {
+	return Block{
+		Id:      uuid.New().String(),
+		Type:    blockType,
+		Body:    body,
+		Comment: []Comment{},
+	}
+}
+
+func NewComment(employeeId, parentId, text string) Comment {
+	now := time.Now()
+	return Comment{
+		Id:         uuid.New().String(),
+		ParentId:   parentId,
+		EmployeeId: employeeId,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Comment:    text,
+	}
+}
+
+// FindBlock возвращает блок журнала по ID
+func (n *Notebook) FindBlock(blockId string) (*Block, error) {
+	for i := range n.Blocks {
+		if n.Blocks[i].Id == blockId {
+			return &n.Blocks[i], nil
+		}
+	}
+	return nil, ErrBlockNotFound
+}
+
+// FindComment возвращает комментарий блока по ID
+func (b *Block) FindComment(commentId string) (*Comment, error) {
+	for i := range b.Comment {
+		if b.Comment[i].Id == commentId {
+			return &b.Comment[i], nil
+		}
+	}
+	return nil, ErrCommentNotFound
+}
+
+// HasReplies сообщает, есть ли у комментария ответы
+func (b *Block) HasReplies(commentId string) bool {
+	for _, c := range b.Comment {
+		if c.ParentId == commentId {
+			return true
+		}
+	}
+	return false
+}
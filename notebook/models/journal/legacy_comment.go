@@ -0,0 +1,25 @@
+package journal
+
+// LegacyNotebook - журнал с комментариями в прежнем формате: без ID, ответы вложены в sub_comments.
+// Читается только для переноса комментариев в ветки по ParentId.
+type LegacyNotebook struct {
+	UuidID   string        `bson:"uuid_id"`
+	Revision int64         `bson:"revision"`
+	Blocks   []LegacyBlock `bson:"blocks"`
+}
+
+type LegacyBlock struct {
+	Comments []LegacyComment `bson:"comments"`
+}
+
+type LegacyComment struct {
+	Comment `bson:",inline"`
+	Replies []LegacyComment `bson:"sub_comments"`
+}
+
+// CommentMigration - итог переноса вложенных ответов в ветки по ParentId
+type CommentMigration struct {
+	Scanned int      // просмотрено журналов с комментариями в прежнем формате
+	Updated []string // журналы, комментарии которых перенесены (при пробном запуске - были бы перенесены)
+	Skipped []string // журналы, изменившиеся во время переноса; переносятся повторным запуском
+}
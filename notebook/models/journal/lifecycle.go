@@ -0,0 +1,55 @@
+package journal
+
+import (
+	"errors"
+	"time"
+)
+
+// Статусы жизненного цикла журнала
+const (
+	StatusDraft    = "draft"     // черновик, журнал редактируется
+	StatusInReview = "in_review" // отправлен на проверку назначенному рецензенту
+	StatusApproved = "approved"  // утвержден рецензентом
+	StatusArchived = "archived"  // перенесен в архив
+)
+
+// Statuses - все статусы жизненного цикла
+var Statuses = []string{StatusDraft, StatusInReview, StatusApproved, StatusArchived}
+
+var ErrReadOnly = errors.New("notebook is read-only in its current status")
+
+// Lifecycle - текущий статус журнала и история переходов.
+// ReadOnly фиксируется при переходе по настройкам процесса компании,
+// чтобы проверка при каждом изменении не требовала чтения настроек.
+type Lifecycle struct {
+	Status   string         `bson:"status"`
+	Reviewer string         `bson:"reviewer"` // назначенный рецензент, пока журнал на проверке
+	ReadOnly bool           `bson:"read_only"`
+	History  []StatusChange `bson:"history"`
+}
+
+// StatusChange - один переход журнала между статусами
+type StatusChange struct {
+	Action   string    `bson:"action"`
+	From     string    `bson:"from"`
+	To       string    `bson:"to"`
+	By       string    `bson:"by"`
+	Reviewer string    `bson:"reviewer"`
+	Note     string    `bson:"note"`
+	At       time.Time `bson:"at"`
+}
+
+func NewLifecycle() Lifecycle {
+	return Lifecycle{
+		Status:  StatusDraft,
+		History: []StatusChange{},
+	}
+}
+
+// CurrentStatus возвращает статус журнала; журналы, созданные до появления статусов, считаются черновиками
+func (l Lifecycle) CurrentStatus() string {
+	if l.Status == "" {
+		return StatusDraft
+	}
+	return l.Status
+}
@@ -0,0 +1,100 @@
+package journal
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Виды подписей
+const (
+	SignatureAuthor  = "author"  // подпись автора записи
+	SignatureWitness = "witness" // подпись свидетеля, заверяющего подпись автора
+)
+
+// Статусы подписи журнала
+const (
+	SignatureStatusUnsigned  = "unsigned"  // подписей нет
+	SignatureStatusSigned    = "signed"    // есть подпись автора без подписи свидетеля
+	SignatureStatusWitnessed = "witnessed" // все подписи автора заверены свидетелями
+)
+
+// MaxMeaningLength - максимальная длина смысла подписи в символах
+const MaxMeaningLength = 500
+
+var (
+	ErrSignedContent      = errors.New("signed content cannot be modified")
+	ErrSignatureNotFound  = errors.New("signature not found")
+	ErrInvalidMeaning     = errors.New("signature meaning must be between 1 and 500 characters")
+	ErrInvalidPassword    = errors.New("password confirmation failed")
+	ErrNotAuthorSignature = errors.New("only an author signature can be witnessed")
+	ErrAlreadyWitnessed   = errors.New("signature is already witnessed")
+	ErrSelfWitness        = errors.New("author cannot witness their own signature")
+)
+
+// Signature - электронная подпись журнала или диапазона его блоков.
+// Hash фиксирует подписанное содержимое: пока подпись существует, оно не может измениться.
+type Signature struct {
+	Id        string    `bson:"id"`
+	Kind      string    `bson:"kind"`
+	SignerID  string    `bson:"signer_id"`
+	Meaning   string    `bson:"meaning"`   // смысл подписи: "Автор записи", "Проверено" и т.п.
+	BlockIDs  []string  `bson:"block_ids"` // подписанные блоки; пусто - весь журнал
+	Hash      string    `bson:"hash"`      // SHA-256 подписанного содержимого
+	Revision  int64     `bson:"revision"`  // ревизия журнала в момент подписи
+	SignedAt  time.Time `bson:"signed_at"`
+	WitnessOf string    `bson:"witness_of"` // ID заверенной подписи автора; пусто для подписи автора
+}
+
+func NewSignature(kind, signerId, meaning, hash, witnessOf string, blockIds []string, revision int64) Signature {
+	if blockIds == nil {
+		blockIds = []string{}
+	}
+	return Signature{
+		Id:        uuid.New().String(),
+		Kind:      kind,
+		SignerID:  signerId,
+		Meaning:   meaning,
+		BlockIDs:  blockIds,
+		Hash:      hash,
+		Revision:  revision,
+		SignedAt:  time.Now(),
+		WitnessOf: witnessOf,
+	}
+}
+
+// FindSignature возвращает подпись журнала по ID
+func (n *Notebook) FindSignature(signatureId string) (*Signature, error) {
+	for i := range n.Signatures {
+		if n.Signatures[i].Id == signatureId {
+			return &n.Signatures[i], nil
+		}
+	}
+	return nil, ErrSignatureNotFound
+}
+
+// WitnessOf возвращает подпись свидетеля, заверившую подпись автора, или nil
+func (n *Notebook) WitnessOf(signatureId string) *Signature {
+	for i := range n.Signatures {
+		if n.Signatures[i].Kind == SignatureWitness && n.Signatures[i].WitnessOf == signatureId {
+			return &n.Signatures[i]
+		}
+	}
+	return nil
+}
+
+// SignatureStatus возвращает статус подписи журнала
+func (n *Notebook) SignatureStatus() string {
+	status := SignatureStatusUnsigned
+	for _, s := range n.Signatures {
+		if s.Kind != SignatureAuthor {
+			continue
+		}
+		if n.WitnessOf(s.Id) == nil {
+			return SignatureStatusSigned
+		}
+		status = SignatureStatusWitnessed
+	}
+	return status
+}
@@ -0,0 +1,27 @@
+package permission
+
+// Source - ресурс, правила которого действуют для журнала или папки: сам ресурс или папка, от которой он наследует
+type Source struct {
+	ResourceID   string
+	ResourceType string
+	AccessLevel  string
+	Inherit      bool // наследует ли источник правила следующей папки цепочки
+}
+
+// Grant - действующее право сотрудника на ресурс и источник, который его дает.
+// Если право дают несколько источников, указывается ближайший к ресурсу из дающих наибольшее право.
+type Grant struct {
+	EmployeeID string
+	Access     string // "read", "comment" или "edit"
+	SourceID   string
+	SourceType string
+	Inherited  bool // право получено от папки, а не задано на самом ресурсе
+}
+
+// Effective - действующие права на журнал или папку с учетом наследования от папок
+type Effective struct {
+	ResourceID string
+	Sources    []Source // сам ресурс, затем папки от ближайшей к верхней
+	PublicFrom string   // источник с публичным уровнем доступа: все сотрудники компании могут читать ресурс; пусто, если такого нет
+	Grants     []Grant
+}
@@ -0,0 +1,9 @@
+package permission
+
+// Migration - итог перевода списков разрешений с ID пользователей на ID сотрудников
+type Migration struct {
+	Scanned    int      // просмотрено разрешений
+	Updated    []string // разрешения, списки которых изменены (при пробном запуске - были бы изменены)
+	Skipped    []string // разрешения, оставленные как есть: компания ресурса не найдена или разрешение изменилось во время переноса
+	Unresolved []string // "разрешение: ID" - ID из списков, не найденные ни среди сотрудников, ни среди пользователей компании
+}
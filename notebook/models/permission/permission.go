@@ -13,16 +13,18 @@ type Permission struct {
 	ResourceID   primitive.ObjectID `bson:"resource_id"`   // ID ресурса в MongoDB
 	ResourceUuid string             `bson:"resource_uuid"` // UUID ресурса (альтернативный идентификатор)
 	Rules        PermissionRules    `bson:"rules"`         // Правила доступа
+	Inherit      bool               `bson:"inherit"`       // Наследовать правила папки, в которой лежит ресурс
 	CreatedAt    time.Time          `bson:"created_at"`    // Время создания
 	UpdatedAt    time.Time          `bson:"updated_at"`    // Время последнего обновления
-	CreatedBy    string             `bson:"created_by"`    // Кто создал (user_id/uuid)
-	Version      string             `bson:"version"`       // Версия для оптимистичной блокировки
+	CreatedBy    string             `bson:"created_by"`    // Кто создал (ID сотрудника)
+	Version      string             `bson:"version"`       // Версия схемы документа
+	Revision     int64              `bson:"revision"`      // Ревизия для оптимистичной блокировки
 }
 
 type PermissionRules struct {
-	AccessAllowed []string `bson:"access_allowed"` // Список ID пользователей с разрешенным доступом
-	CommentOnly   []string `bson:"comment_only"`   // Список ID пользователей с доступом только для комментирования
-	ReadOnly      []string `bson:"read_only"`      // Список ID пользователей с доступом только для чтения
+	AccessAllowed []string `bson:"access_allowed"` // Список ID сотрудников с разрешенным доступом
+	CommentOnly   []string `bson:"comment_only"`   // Список ID сотрудников с доступом только для комментирования
+	ReadOnly      []string `bson:"read_only"`      // Список ID сотрудников с доступом только для чтения
 	AccessLevel   string   `bson:"access_level"`   // Общий уровень доступа: "public", "private", "restricted"
 }
 
@@ -34,6 +36,7 @@ func NewPermission(employeeId, generatedId, generatedResourceUuid, resourceType
 		ResourceID:   resourceId,
 		ResourceUuid: generatedId,
 		Rules:        NewPermissionRules(employeeId),
+		Inherit:      true,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 		CreatedBy:    employeeId,
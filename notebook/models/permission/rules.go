@@ -0,0 +1,97 @@
+package permission
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrForbidden возвращается, когда правила доступа не разрешают операцию
+var ErrForbidden = errors.New("access denied")
+
+// ErrInvalidAccessLevel возвращается при неизвестном общем уровне доступа
+var ErrInvalidAccessLevel = errors.New("access level must be public, private or restricted")
+
+// Общие уровни доступа к ресурсу (PermissionRules.AccessLevel)
+const (
+	// LevelRestricted - доступ только у сотрудников из списков, каждому по его списку. Пустой уровень означает то же самое
+	LevelRestricted = "restricted"
+	// LevelPrivate - доступ только у сотрудников с полным доступом; списки CommentOnly и ReadOnly не действуют,
+	// но сохраняются и снова применяются при смене уровня
+	LevelPrivate = "private"
+	// LevelPublic - любой сотрудник компании может читать ресурс, списки дают больше прав
+	LevelPublic = "public"
+)
+
+// Типы ресурсов, на которые выдаются разрешения
+const (
+	ResourceFolder   = "folder"
+	ResourceNotebook = "file"
+)
+
+// Access - право сотрудника на ресурс; каждое следующее включает предыдущие
+type Access int
+
+const (
+	AccessNone Access = iota
+	AccessRead
+	AccessComment
+	AccessEdit
+)
+
+// String возвращает название права для сообщений об ошибках и ответов API
+func (a Access) String() string {
+	switch a {
+	case AccessRead:
+		return "read"
+	case AccessComment:
+		return "comment"
+	case AccessEdit:
+		return "edit"
+	}
+	return "none"
+}
+
+// Validate проверяет общий уровень доступа
+func (r PermissionRules) Validate() error {
+	switch r.AccessLevel {
+	case "", LevelRestricted, LevelPrivate, LevelPublic:
+		return nil
+	}
+	return ErrInvalidAccessLevel
+}
+
+// AccessFor возвращает право сотрудника на ресурс с учетом списков и общего уровня доступа
+func (r PermissionRules) AccessFor(employeeId string) Access {
+	switch {
+	case slices.Contains(r.AccessAllowed, employeeId):
+		return AccessEdit
+	case r.AccessLevel == LevelPrivate:
+		return AccessNone
+	case slices.Contains(r.CommentOnly, employeeId):
+		return AccessComment
+	case slices.Contains(r.ReadOnly, employeeId), r.AccessLevel == LevelPublic:
+		return AccessRead
+	}
+	return AccessNone
+}
+
+// CanComment сообщает, может ли сотрудник комментировать ресурс:
+// это разрешено при полном доступе и доступе только для комментирования
+func (r PermissionRules) CanComment(employeeId string) bool {
+	return r.AccessFor(employeeId) >= AccessComment
+}
+
+// CanModerate сообщает, может ли сотрудник управлять чужими комментариями (полный доступ)
+func (r PermissionRules) CanModerate(employeeId string) bool {
+	return r.AccessFor(employeeId) >= AccessEdit
+}
+
+// CanEdit сообщает, может ли сотрудник изменять ресурс и его метаданные (полный доступ)
+func (r PermissionRules) CanEdit(employeeId string) bool {
+	return r.AccessFor(employeeId) >= AccessEdit
+}
+
+// CanRead сообщает, может ли сотрудник читать ресурс: при любом уровне доступа или публичном ресурсе
+func (r PermissionRules) CanRead(employeeId string) bool {
+	return r.AccessFor(employeeId) >= AccessRead
+}
@@ -0,0 +1,123 @@
+package revision
+
+import (
+	"labyrinth/notebook/models/journal"
+	"reflect"
+)
+
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+	ChangeMoved    = "moved"
+)
+
+type BlockChange struct {
+	BlockID      string         `json:"block_id"`
+	Change       string         `json:"change"`
+	FromPosition *int           `json:"from_position,omitempty"`
+	ToPosition   *int           `json:"to_position,omitempty"`
+	Before       *journal.Block `json:"before,omitempty"`
+	After        *journal.Block `json:"after,omitempty"`
+}
+
+// DiffBlocks сравнивает два состояния журнала поблочно.
+// Блок считается перемещённым, если он выпал из наибольшей общей подпоследовательности ID,
+// поэтому вставка одного блока не помечает все последующие как перемещённые.
+func DiffBlocks(from, to []journal.Block) []BlockChange {
+	fromIdx := make(map[string]int, len(from))
+	for i, b := range from {
+		fromIdx[b.Id] = i
+	}
+	toIdx := make(map[string]int, len(to))
+	for i, b := range to {
+		toIdx[b.Id] = i
+	}
+
+	stable := stableBlocks(from, to, toIdx)
+
+	changes := []BlockChange{}
+	for i := range to {
+		after := to[i]
+		j, ok := fromIdx[after.Id]
+		if !ok {
+			changes = append(changes, BlockChange{BlockID: after.Id, Change: ChangeAdded, ToPosition: intPtr(i), After: &after})
+			continue
+		}
+
+		before := from[j]
+		switch {
+		case !sameContent(before, after):
+			changes = append(changes, BlockChange{BlockID: after.Id, Change: ChangeModified, FromPosition: intPtr(j), ToPosition: intPtr(i), Before: &before, After: &after})
+		case !stable[after.Id]:
+			changes = append(changes, BlockChange{BlockID: after.Id, Change: ChangeMoved, FromPosition: intPtr(j), ToPosition: intPtr(i)})
+		}
+	}
+
+	for j := range from {
+		before := from[j]
+		if _, ok := toIdx[before.Id]; !ok {
+			changes = append(changes, BlockChange{BlockID: before.Id, Change: ChangeRemoved, FromPosition: intPtr(j), Before: &before})
+		}
+	}
+
+	return changes
+}
+
+// ChangedBlockIDs возвращает ID блоков, затронутых изменением
+func ChangedBlockIDs(changes []BlockChange) []string {
+	ids := make([]string, 0, len(changes))
+	for _, c := range changes {
+		ids = append(ids, c.BlockID)
+	}
+	return ids
+}
+
+func sameContent(a, b journal.Block) bool {
+	return a.Type == b.Type && reflect.DeepEqual(a.Body, b.Body) && reflect.DeepEqual(a.Comment, b.Comment)
+}
+
+// stableBlocks возвращает ID блоков из наибольшей общей подпоследовательности порядка
+func stableBlocks(from, to []journal.Block, toIdx map[string]int) map[string]bool {
+	common := make([]journal.Block, 0, len(from))
+	for _, b := range from {
+		if _, ok := toIdx[b.Id]; ok {
+			common = append(common, b)
+		}
+	}
+
+	n, m := len(common), len(to)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if common[i].Id == to[j].Id {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	stable := make(map[string]bool, lcs[0][0])
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case common[i].Id == to[j].Id:
+			stable[common[i].Id] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return stable
+}
+
+func intPtr(v int) *int {
+	return &v
+}
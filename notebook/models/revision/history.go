@@ -0,0 +1,88 @@
+package revision
+
+import (
+	"errors"
+	"labyrinth/notebook/models/journal"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrNotFound = errors.New("revision not found")
+
+const (
+	ActionCreate      = "create"
+	ActionUpdate      = "update"
+	ActionInsertBlock = "insert_block"
+	ActionUpdateBlock = "update_block"
+	ActionMoveBlock   = "move_block"
+	ActionDeleteBlock = "delete_block"
+	ActionRestore     = "restore"
+	ActionImport      = "import"
+	ActionCopy        = "copy"
+
+	ActionAddComment     = "add_comment"
+	ActionUpdateComment  = "update_comment"
+	ActionDeleteComment  = "delete_comment"
+	ActionResolveComment = "resolve_comment"
+
+	ActionSign    = "sign"
+	ActionWitness = "witness"
+
+	ActionStatusChange = "status_change"
+
+	// ActionMigrateAuthors - перевод авторов журнала с ID пользователей на ID сотрудников; содержимое не меняется
+	ActionMigrateAuthors = "migrate_authors"
+)
+
+// contentActions - изменения содержимого журнала; в статусе только для чтения они запрещены,
+// а комментарии, подписи и переходы между статусами остаются доступны
+var contentActions = map[string]bool{
+	ActionUpdate:      true,
+	ActionInsertBlock: true,
+	ActionUpdateBlock: true,
+	ActionMoveBlock:   true,
+	ActionDeleteBlock: true,
+	ActionRestore:     true,
+	ActionImport:      true,
+	ActionCopy:        true,
+}
+
+// ChangesContent сообщает, изменяет ли действие содержимое журнала
+func ChangesContent(action string) bool {
+	return contentActions[action]
+}
+
+// Revision - неизменяемый снимок журнала после очередного изменения
+type Revision struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	NotebookID    string             `bson:"notebook_uuid_id"`
+	Revision      int64              `bson:"revision"`
+	Action        string             `bson:"action"`
+	Author        string             `bson:"author"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	ChangedBlocks []string           `bson:"changed_blocks"`
+	RestoredFrom  *int64             `bson:"restored_from,omitempty"`
+	Snapshot      *journal.Notebook  `bson:"snapshot,omitempty"`
+	// Цепочка хешей: Hash считается по ревизии вместе с PrevHash предыдущей ревизии,
+	// поэтому изменение или удаление любой ревизии задним числом обнаруживается при проверке.
+	// У ревизий, записанных до появления цепочки, оба поля пусты.
+	PrevHash string `bson:"prev_hash"`
+	Hash     string `bson:"hash"`
+}
+
+func NewRevision(snapshot journal.Notebook, action, author string, changedBlocks []string) Revision {
+	if changedBlocks == nil {
+		changedBlocks = []string{}
+	}
+	return Revision{
+		ID:            primitive.NewObjectID(),
+		NotebookID:    snapshot.UuidID,
+		Revision:      snapshot.Revision,
+		Action:        action,
+		Author:        author,
+		CreatedAt:     time.Now(),
+		ChangedBlocks: changedBlocks,
+		Snapshot:      &snapshot,
+	}
+}
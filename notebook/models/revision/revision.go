@@ -0,0 +1,21 @@
+package revision
+
+import "fmt"
+
+// ConflictError возвращается, когда ревизия документа не совпала с ожидаемой клиентом
+type ConflictError struct {
+	Current int64
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("revision conflict: current revision is %d", e.Current)
+}
+
+// Match возвращает условие фильтра по ревизии.
+// Документы, созданные до появления поля revision, считаются ревизией 0.
+func Match(expected int64) any {
+	if expected == 0 {
+		return map[string]any{"$in": []any{int64(0), nil}}
+	}
+	return expected
+}
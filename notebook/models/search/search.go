@@ -0,0 +1,83 @@
+package search
+
+import (
+	"errors"
+	"time"
+)
+
+// Виды найденных объектов
+const (
+	KindNotebook = "notebook"
+	KindFolder   = "folder"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+	// MaxOffset ограничивает глубину выдачи: результаты двух коллекций сливаются в памяти
+	MaxOffset = 1000
+)
+
+var ErrEmptyQuery = errors.New("search query cannot be empty")
+
+// Query - поисковый запрос с фильтрами. Пустые фильтры не применяются.
+// Author - ID сотрудника, создавшего журнал или папку: журналы и папки хранят автора одинаково.
+type Query struct {
+	Text       string
+	Kind       string
+	CompanyID  string
+	EmployeeID string
+	DivisionID string
+	Author     string
+	Tag        string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Offset     int
+}
+
+// Document - найденный журнал или папка вместе с текстом для подсветки
+type Document struct {
+	Kind       string
+	UuidID     string
+	DivisionID string
+	Title      string
+	Author     string
+	Tags       []string
+	Created    time.Time
+	Score      float64
+	Fields     []Field
+}
+
+// Field - фрагмент текста документа, в котором ищется совпадение.
+// ID указывает на блок журнала или журнал внутри папки.
+type Field struct {
+	Name string
+	ID   string
+	Text string
+}
+
+// Hit - результат поиска для клиента
+type Hit struct {
+	Kind       string      `json:"kind"`
+	ID         string      `json:"id"`
+	DivisionID string      `json:"division_id"`
+	Title      string      `json:"title"`
+	Author     string      `json:"author"` // ID сотрудника
+	Tags       []string    `json:"tags"`
+	Created    time.Time   `json:"created"`
+	Score      float64     `json:"score"`
+	Highlights []Highlight `json:"highlights"`
+}
+
+// Highlight - фрагмент с совпадением; совпавшие слова обрамлены <mark></mark>, остальной текст экранирован
+type Highlight struct {
+	Field    string `json:"field"`
+	ID       string `json:"id,omitempty"`
+	Fragment string `json:"fragment"`
+}
+
+type Result struct {
+	Hits  []Hit `json:"hits"`
+	Total int64 `json:"total"`
+}
@@ -0,0 +1,112 @@
+package tag
+
+import (
+	"errors"
+	"labyrinth/notebook/models/journal"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Объекты, которые можно отмечать тегами
+const (
+	ResourceNotebook = "notebook"
+	ResourceFolder   = "folder"
+)
+
+const (
+	MaxNameLength = 50
+	DefaultColor  = "#9e9e9e"
+)
+
+var (
+	ErrNotFound     = errors.New("tag not found")
+	ErrDuplicate    = errors.New("tag with this name already exists")
+	ErrInvalidName  = errors.New("tag name must be between 1 and 50 characters")
+	ErrInvalidColor = errors.New("tag colour must be a hex colour like #1e88e5")
+	ErrSelfMerge    = errors.New("tag cannot be merged into itself")
+)
+
+var hexColor = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// Tag - тег из словаря компании. Журналы и папки хранят имя тега в Metadata.Tags,
+// поэтому переименование и слияние переписывают имя во всех отмеченных объектах.
+type Tag struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	UuidID     string             `bson:"uuid_id"`
+	CompanyID  string             `bson:"company_id"`
+	Name       string             `bson:"name"`
+	Key        string             `bson:"key"` // имя в нижнем регистре: уникально в компании и используется для автодополнения
+	Color      string             `bson:"color"`
+	Created    time.Time          `bson:"created"`
+	CreatedBy  string             `bson:"created_by"`
+	LastUpdate time.Time          `bson:"last_update"`
+}
+
+// Usage - тег и число журналов с ним, доступных сотруднику
+type Usage struct {
+	Tag
+	Notebooks int64
+}
+
+// TaggedNotebook - журнал в списке по тегу, без блоков
+type TaggedNotebook struct {
+	UuidID     string
+	DivisionID string
+	Title      string
+	Tags       []string
+	Created    journal.DateTimeAuthor
+	LastUpdate journal.DateTimeAuthor
+}
+
+func NewTag(employeeId, companyId, generatedId, name, color string) (Tag, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return Tag{}, err
+	}
+	if color == "" {
+		color = DefaultColor
+	}
+	if color, err = NormalizeColor(color); err != nil {
+		return Tag{}, err
+	}
+
+	now := time.Now()
+	return Tag{
+		ID:         primitive.NewObjectID(),
+		UuidID:     generatedId,
+		CompanyID:  companyId,
+		Name:       name,
+		Key:        Key(name),
+		Color:      color,
+		Created:    now,
+		CreatedBy:  employeeId,
+		LastUpdate: now,
+	}, nil
+}
+
+// NormalizeName убирает лишние пробелы и проверяет длину имени
+func NormalizeName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
+
+// NormalizeColor приводит цвет к виду #rrggbb
+func NormalizeColor(color string) (string, error) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if !hexColor.MatchString(color) {
+		return "", ErrInvalidColor
+	}
+	return color, nil
+}
+
+// Key - ключ уникальности и поиска тега
+func Key(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,138 @@
+package template
+
+import (
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Встроенные переменные, которые сервер заполняет сам
+const (
+	VarDate     = "date"
+	VarTime     = "time"
+	VarAuthor   = "author"
+	VarSampleID = "sample_id"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+
+// MissingVariablesError возвращается, когда для переменных шаблона не переданы значения
+type MissingVariablesError struct {
+	Names []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("missing template variables: %s", strings.Join(e.Names, ", "))
+}
+
+// Variables возвращает отсортированный список переменных в заголовке и текстах блоков
+func Variables(title string, blocks []journal.Block) []string {
+	found := map[string]struct{}{}
+	collect := func(s string) {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+			found[m[1]] = struct{}{}
+		}
+	}
+
+	collect(title)
+	for _, b := range blocks {
+		walkStrings(b.Body, func(s string) string {
+			collect(s)
+			return s
+		})
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fill подставляет значения переменных в строку; переменные без значения остаются как есть
+func Fill(s string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// Instantiate возвращает заголовок и блоки нового журнала с подставленными переменными.
+// Блоки получают новые ID, чтобы журналы из одного шаблона не делили идентификаторы.
+func (t *Template) Instantiate(title string, values map[string]string) (string, []journal.Block, error) {
+	if strings.TrimSpace(title) == "" {
+		title = t.Title
+	}
+
+	var missing []string
+	for _, name := range Variables(title, t.Blocks) {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", nil, &MissingVariablesError{Names: missing}
+	}
+
+	blocks := make([]journal.Block, 0, len(t.Blocks))
+	for _, b := range t.Blocks {
+		body, _ := walkStrings(b.Body, func(s string) string { return Fill(s, values) }).(map[string]any)
+		if body == nil {
+			body = map[string]any{}
+		}
+		blocks = append(blocks, journal.Block{
+			Id:      uuid.New().String(),
+			Type:    b.Type,
+			Body:    body,
+			Comment: []journal.Comment{},
+		})
+	}
+
+	return Fill(title, values), blocks, nil
+}
+
+// walkStrings возвращает копию значения, в которой ко всем строкам применена fn
+func walkStrings(v any, fn func(string) string) any {
+	switch val := v.(type) {
+	case string:
+		return fn(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = walkStrings(item, fn)
+		}
+		return out
+	case primitive.M:
+		return walkStrings(map[string]any(val), fn)
+	case primitive.D:
+		out := make(map[string]any, len(val))
+		for _, e := range val {
+			out[e.Key] = walkStrings(e.Value, fn)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = walkStrings(item, fn)
+		}
+		return out
+	case primitive.A:
+		return walkStrings([]any(val), fn)
+	case []string:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = fn(item)
+		}
+		return out
+	}
+	return v
+}
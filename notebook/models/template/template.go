@@ -0,0 +1,73 @@
+package template
+
+import (
+	"errors"
+	"labyrinth/notebook/models/journal"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrNotFound = errors.New("template not found")
+
+// Template - заготовка журнала отдела: блоки и теги без комментариев.
+// В текстах блоков допускаются переменные вида {{sample_id}}, которые заполняются при создании журнала.
+type Template struct {
+	ID          primitive.ObjectID     `bson:"_id,omitempty"`
+	UuidID      string                 `bson:"uuid_id"`
+	Revision    int64                  `bson:"revision"`
+	CompanyID   string                 `bson:"company_id"`
+	DivisionID  string                 `bson:"division_id"` // пусто - шаблон доступен всем отделам компании
+	Title       string                 `bson:"title"`
+	Description string                 `bson:"description"`
+	Tags        []string               `bson:"tags"`
+	Blocks      []journal.Block        `bson:"blocks"`
+	Variables   []string               `bson:"variables"` // переменные, найденные в заголовке и блоках
+	SourceID    string                 `bson:"source_notebook_uuid_id"`
+	Created     journal.DateTimeAuthor `bson:"created"`
+	LastUpdate  journal.DateTimeAuthor `bson:"last_update"`
+}
+
+// NewTemplate создает шаблон из журнала; комментарии блоков в шаблон не переносятся
+func NewTemplate(employeeId, companyId, divisionId, generatedId string, source *journal.Notebook, title, description string, tags []string) Template {
+	if tags == nil {
+		tags = source.Metadata.Tags
+	}
+
+	blocks := CleanBlocks(source.Blocks)
+	return Template{
+		ID:          primitive.NewObjectID(),
+		UuidID:      generatedId,
+		CompanyID:   companyId,
+		DivisionID:  divisionId,
+		Title:       title,
+		Description: description,
+		Tags:        append([]string{}, tags...),
+		Blocks:      blocks,
+		Variables:   Variables(title, blocks),
+		SourceID:    source.UuidID,
+		Created:     journal.NewDateTimeAuthor(employeeId),
+		LastUpdate:  journal.NewDateTimeAuthor(employeeId),
+	}
+}
+
+// CleanBlocks копирует блоки без комментариев
+func CleanBlocks(blocks []journal.Block) []journal.Block {
+	cleaned := make([]journal.Block, 0, len(blocks))
+	for _, b := range blocks {
+		cleaned = append(cleaned, journal.Block{
+			Id:      b.Id,
+			Type:    b.Type,
+			Body:    b.Body,
+			Comment: []journal.Comment{},
+		})
+	}
+	return cleaned
+}
+
+// AvailableIn сообщает, можно ли использовать шаблон в отделе компании
+func (t *Template) AvailableIn(companyId, divisionId string) bool {
+	if t.CompanyID != companyId {
+		return false
+	}
+	return t.DivisionID == "" || t.DivisionID == divisionId
+}
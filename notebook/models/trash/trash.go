@@ -0,0 +1,69 @@
+package trash
+
+import (
+	"errors"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Виды удаленных объектов
+const (
+	KindFolder   = "folder"
+	KindNotebook = "notebook"
+)
+
+// Ограничения выдачи корзины
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var ErrNotFound = errors.New("trash item not found")
+
+// Item - папка или журнал в корзине отдела. Документы удаленного поддерева хранятся отдельно
+// (Folder, Notebook) и возвращаются на место при восстановлении.
+type Item struct {
+	ID         string    `bson:"uuid_id"`
+	Kind       string    `bson:"kind"`
+	ResourceID string    `bson:"resource_id"`
+	CompanyID  string    `bson:"company_id"`
+	DivisionID string    `bson:"division_id"`
+	Title      string    `bson:"title"`
+	ParentID   string    `bson:"parent_id"` // исходное место: папка или отдел
+	DeletedBy  string    `bson:"deleted_by"`
+	DeletedAt  time.Time `bson:"deleted_at"`
+	PurgeAt    time.Time `bson:"purge_at"` // после этого момента элемент удаляется безвозвратно
+	Folders    int       `bson:"folders"`  // папок в удаленном поддереве, включая саму папку
+	Notebooks  int       `bson:"notebooks"`
+}
+
+// Folder - документ папки, убранный в корзину вместе с элементом ItemID
+type Folder struct {
+	ItemID   string              `bson:"item_id"`
+	Document directory.Directory `bson:"document"`
+}
+
+// Notebook - документ журнала, убранный в корзину вместе с элементом ItemID
+type Notebook struct {
+	ItemID   string           `bson:"item_id"`
+	Document journal.Notebook `bson:"document"`
+}
+
+func NewItem(kind, resourceId, companyId, divisionId, title, parentId, deletedBy string, retention time.Duration) Item {
+	now := time.Now()
+	return Item{
+		ID:         uuid.New().String(),
+		Kind:       kind,
+		ResourceID: resourceId,
+		CompanyID:  companyId,
+		DivisionID: divisionId,
+		Title:      title,
+		ParentID:   parentId,
+		DeletedBy:  deletedBy,
+		DeletedAt:  now,
+		PurgeAt:    now.Add(retention),
+	}
+}
@@ -0,0 +1,152 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"labyrinth/notebook/models/journal"
+	"regexp"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Переходы процесса по умолчанию
+const (
+	ActionSubmit  = "submit"  // черновик отправляется на проверку
+	ActionApprove = "approve" // рецензент утверждает журнал
+	ActionReturn  = "return"  // рецензент возвращает журнал на доработку
+	ActionArchive = "archive" // утвержденный журнал переносится в архив
+)
+
+const (
+	MaxNoteLength   = 2000
+	MaxTransitions  = 20
+	DefaultMaxLevel = 2 // по умолчанию утверждать и архивировать могут должности уровня 1-2 (руководители отдела)
+)
+
+var (
+	ErrNotFound          = errors.New("workflow not found")
+	ErrInvalidWorkflow   = errors.New("invalid workflow")
+	ErrInvalidTransition = errors.New("transition is not allowed from the current status")
+	ErrReviewerRequired  = errors.New("reviewer is required for this transition")
+	ErrInvalidReviewer   = errors.New("reviewer must be an active employee of the notebook department")
+	ErrSelfReview        = errors.New("author cannot review their own notebook")
+	ErrNotReviewer       = errors.New("only the assigned reviewer can decide on the notebook")
+	ErrInsufficientLevel = errors.New("department position level does not allow this transition")
+	ErrInvalidNote       = errors.New("note must be at most 2000 characters")
+)
+
+var actionName = regexp.MustCompile(`^[a-z][a-z_]{0,31}$`)
+
+// Transition - разрешенный переход между статусами журнала.
+// Уровни должностей отдела считаются от 1 (самая старшая), поэтому переход разрешен
+// должностям с уровнем не больше MaxLevel; 0 - без ограничения по должности.
+type Transition struct {
+	Action   string   `bson:"action"`
+	From     []string `bson:"from"`
+	To       string   `bson:"to"`
+	MaxLevel int      `bson:"max_level"`
+}
+
+// Workflow - процесс согласования журналов компании.
+// Пока компания не настроила свой процесс, действует Default.
+type Workflow struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	CompanyID   string             `bson:"company_id"`
+	Transitions []Transition       `bson:"transitions"`
+	Editable    []string           `bson:"editable"` // статусы, в которых содержимое журнала можно изменять
+	UpdatedAt   time.Time          `bson:"updated_at"`
+	UpdatedBy   string             `bson:"updated_by"`
+}
+
+// ReviewItem - журнал в очереди рецензента, без блоков
+type ReviewItem struct {
+	NotebookID  string
+	DivisionID  string
+	Title       string
+	Author      string
+	SubmittedBy string
+	SubmittedAt time.Time
+	Note        string
+	Revision    int64
+}
+
+// Default возвращает процесс по умолчанию:
+// черновик -> на проверке -> утвержден или возвращен в черновик -> архив
+func Default(companyId string) Workflow {
+	return Workflow{
+		CompanyID: companyId,
+		Transitions: []Transition{
+			{Action: ActionSubmit, From: []string{journal.StatusDraft}, To: journal.StatusInReview},
+			{Action: ActionApprove, From: []string{journal.StatusInReview}, To: journal.StatusApproved, MaxLevel: DefaultMaxLevel},
+			{Action: ActionReturn, From: []string{journal.StatusInReview}, To: journal.StatusDraft, MaxLevel: DefaultMaxLevel},
+			{Action: ActionArchive, From: []string{journal.StatusApproved}, To: journal.StatusArchived, MaxLevel: DefaultMaxLevel},
+		},
+		Editable: []string{journal.StatusDraft, journal.StatusInReview},
+	}
+}
+
+// Find возвращает переход action, допустимый из статуса from
+func (w Workflow) Find(action, from string) (*Transition, error) {
+	for i := range w.Transitions {
+		if w.Transitions[i].Action == action && slices.Contains(w.Transitions[i].From, from) {
+			return &w.Transitions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s from %s", ErrInvalidTransition, action, from)
+}
+
+// IsEditable сообщает, можно ли изменять журнал в статусе status
+func (w Workflow) IsEditable(status string) bool {
+	return slices.Contains(w.Editable, status)
+}
+
+// AllowsLevel сообщает, разрешен ли переход должности отдела уровня level
+func (t Transition) AllowsLevel(level int) bool {
+	return t.MaxLevel == 0 || (level > 0 && level <= t.MaxLevel)
+}
+
+// Validate проверяет, что процесс ссылается только на известные статусы,
+// а имена переходов уникальны
+func (w Workflow) Validate() error {
+	if len(w.Transitions) == 0 || len(w.Transitions) > MaxTransitions {
+		return fmt.Errorf("%w: between 1 and %d transitions are required", ErrInvalidWorkflow, MaxTransitions)
+	}
+
+	seen := make(map[string]bool, len(w.Transitions))
+	for _, t := range w.Transitions {
+		if !actionName.MatchString(t.Action) {
+			return fmt.Errorf("%w: action %q must be lowercase letters and underscores", ErrInvalidWorkflow, t.Action)
+		}
+		if seen[t.Action] {
+			return fmt.Errorf("%w: duplicate action %q", ErrInvalidWorkflow, t.Action)
+		}
+		seen[t.Action] = true
+
+		if !slices.Contains(journal.Statuses, t.To) {
+			return fmt.Errorf("%w: unknown status %q", ErrInvalidWorkflow, t.To)
+		}
+		if len(t.From) == 0 {
+			return fmt.Errorf("%w: action %q has no source status", ErrInvalidWorkflow, t.Action)
+		}
+		for _, from := range t.From {
+			if !slices.Contains(journal.Statuses, from) {
+				return fmt.Errorf("%w: unknown status %q", ErrInvalidWorkflow, from)
+			}
+			if from == t.To {
+				return fmt.Errorf("%w: action %q does not change status", ErrInvalidWorkflow, t.Action)
+			}
+		}
+		if t.MaxLevel < 0 {
+			return fmt.Errorf("%w: action %q has negative max_level", ErrInvalidWorkflow, t.Action)
+		}
+	}
+
+	for _, status := range w.Editable {
+		if !slices.Contains(journal.Statuses, status) {
+			return fmt.Errorf("%w: unknown status %q", ErrInvalidWorkflow, status)
+		}
+	}
+	return nil
+}
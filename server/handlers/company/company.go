@@ -10,7 +10,10 @@ import (
 )
 
 const (
-	userIDKey string = "id"
+	userIDKey   string = "id"
+	employeeKey string = "employee"
+
+	activeCompanyTTL = 24 * time.Hour
 )
 
 var bl *logic.BusinessLogic = logic.NewBusinessLogic()
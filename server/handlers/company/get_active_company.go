@@ -0,0 +1,51 @@
+package company
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+func (c CompanyHandlers) GetActiveCompanyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Получение сотрудника активной компании из контекста
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "GetActiveCompanyHandler"),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Получение данных компании
+	activeCompany, err := bl.Company.GetCompany(currentEmployee.UserID, currentEmployee.CompanyID)
+	if err != nil {
+		logger.NewErrMessage("Failed to get active company",
+			zap.String("operation", "GetActiveCompanyHandler"),
+			zap.String("company_id", currentEmployee.CompanyID.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to get company", http.StatusInternalServerError)
+		return
+	}
+
+	// 3. Отправка ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "success",
+		"employee": currentEmployee,
+		"company":  companyToCompanyResponse(activeCompany),
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetActiveCompanyHandler"),
+			zap.Error(err),
+		)
+		return
+	}
+}
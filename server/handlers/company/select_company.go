@@ -0,0 +1,131 @@
+package company
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/server/middleware"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func (c CompanyHandlers) SelectCompanyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "SelectCompanyHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг user_id из пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "SelectCompanyHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "SelectCompanyHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг company_id из пути
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID format",
+			zap.String("operation", "SelectCompanyHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Проверка членства пользователя в компании
+	selectedEmployee, err := bl.Company.SelectCompany(userID, companyId)
+	if err != nil {
+		logger.NewWarnMessage("Failed to select company",
+			zap.String("operation", "SelectCompanyHandler"),
+			zap.String("user_id", userID.String()),
+			zap.String("company_id", companyId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Forbidden: company is not available", http.StatusForbidden)
+		return
+	}
+
+	// 6. Выпуск токена активной компании
+	expiresAt := time.Now().Add(activeCompanyTTL)
+	token := bl.Jwt.NewToken(jwt.MapClaims{
+		"id":          selectedEmployee.ID,
+		"user_id":     userID,
+		"company_id":  selectedEmployee.CompanyID,
+		"position_id": selectedEmployee.PositionID,
+		"scope":       middleware.EmployeeTokenScope,
+		"exp":         expiresAt.Unix(),
+		"iat":         time.Now().Unix(),
+	})
+	if token == "" {
+		logger.NewErrMessage("Failed to issue active company token",
+			zap.String("operation", "SelectCompanyHandler"),
+			zap.String("employee_id", selectedEmployee.ID.String()),
+		)
+		http.Error(w, "Failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.EmployeeCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Expires:  expiresAt,
+		MaxAge:   int(activeCompanyTTL.Seconds()),
+	})
+
+	// 7. Отправка ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "success",
+		"employee_id": selectedEmployee.ID,
+		"company_id":  selectedEmployee.CompanyID,
+		"position_id": selectedEmployee.PositionID,
+		"expires_at":  expiresAt,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "SelectCompanyHandler"),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.NewInfoMessage("Active company selected",
+		zap.String("operation", "SelectCompanyHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("company_id", companyId.String()),
+		zap.String("employee_id", selectedEmployee.ID.String()),
+	)
+}
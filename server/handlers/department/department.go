@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	userIDKey string = "id"
+	userIDKey   string = "id"
+	employeeKey string = "employee"
 )
 
 type DepartmentHandlers struct{}
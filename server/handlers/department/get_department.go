@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/server/handlers/internal/halper"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -47,6 +49,16 @@ func (d DepartmentHandlers) GetDepartmentHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "GetDepartmentHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
 	companyId, err := uuid.Parse(vars["company_id"])
 	if err != nil {
 		logger.NewWarnMessage("Invalid company ID format",
@@ -89,8 +101,18 @@ func (d DepartmentHandlers) GetDepartmentHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	fetchedDir, err := fsl.Folder.GetFolder(departmentId)
+	// Папку департамента видит только сотрудник с правом ее чтения; вложенные папки и журналы без права скрываются
+	fetchedDir, err := fsl.Folder.ReadFolder(departmentId, currentEmployee)
 	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Department folder access denied",
+				zap.String("operation", "GetDepartmentHandler"),
+				zap.String("department_id", departmentId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
 		logger.NewErrMessage("Failed to get department folder",
 			zap.String("operation", "GetDepartmentHandler"),
 			zap.String("department_id", departmentId.String()),
@@ -107,6 +129,7 @@ func (d DepartmentHandlers) GetDepartmentHandler(w http.ResponseWriter, r *http.
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(fetchedDir.Revision))
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.NewErrMessage("Failed to encode response",
 			zap.String("operation", "GetDepartmentHandler"),
@@ -1,10 +1,9 @@
 package department
 
 import (
-	"database/sql"
 	"encoding/json"
-	"errors"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
 	"net/http"
 	"strings"
 
@@ -88,26 +87,15 @@ func (d DepartmentHandlers) NewDepartmentHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// 7. Получение данных сотрудника
-	fetchedEmployee, err := bl.Employee.GetEmployee(userID, companyId)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			logger.NewWarnMessage("Employee not found",
-				zap.String("operation", "NewDepartmentHandler"),
-				zap.String("user_id", userID.String()),
-				zap.String("company_id", companyId.String()),
-			)
-			http.Error(w, "Employee not found", http.StatusNotFound)
-			return
-		}
-
-		logger.NewErrMessage("Failed to get employee",
+	// 7. Сотрудник активной компании из контекста (загружен EmployeeMiddleware)
+	fetchedEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || fetchedEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
 			zap.String("operation", "NewDepartmentHandler"),
 			zap.String("user_id", userID.String()),
 			zap.String("company_id", companyId.String()),
-			zap.Error(err),
 		)
-		http.Error(w, "Failed to get employee data", http.StatusInternalServerError)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
 		return
 	}
 
@@ -125,8 +113,8 @@ func (d DepartmentHandlers) NewDepartmentHandler(w http.ResponseWriter, r *http.
 	}
 
 	// 9. Создание папки департамента
-	if err := fsl.Folder.CreateFolder(
-		fetchedEmployee.ID,
+	if _, err := fsl.Folder.CreateFolder(
+		fetchedEmployee,
 		companyId,
 		requestData.ParentId,
 		requestData.ParentId,
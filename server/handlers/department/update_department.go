@@ -1,11 +1,13 @@
 package department
 
 import (
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
 	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/server/handlers/internal/halper"
 	"net/http"
 	"strings"
 
@@ -52,6 +54,16 @@ func (d DepartmentHandlers) UpdateDepartmentHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "UpdateDepartmentHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
 	// 4. Парсинг company_id и department_id из пути
 	companyId, err := uuid.Parse(vars["company_id"])
 	if err != nil {
@@ -75,6 +87,22 @@ func (d DepartmentHandlers) UpdateDepartmentHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// Ожидаемая ревизия папки департамента из If-Match
+	expectedRevision, err := halper.ParseIfMatch(r)
+	if err != nil {
+		logger.NewWarnMessage("Invalid If-Match header",
+			zap.String("operation", "UpdateDepartmentHandler"),
+			zap.String("department_id", departmentId.String()),
+			zap.Error(err),
+		)
+		if errors.Is(err, halper.ErrIfMatchRequired) {
+			http.Error(w, err.Error(), http.StatusPreconditionRequired)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// 6. Парсинг тела запроса
 	var updatedDepartment directory.Directory
 	if err := json.NewDecoder(r.Body).Decode(&updatedDepartment); err != nil {
@@ -98,9 +126,31 @@ func (d DepartmentHandlers) UpdateDepartmentHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
-	// 8. Обновление папки департамента
-	if err := fsl.Folder.UpdateFolder(departmentId, &updatedDepartment); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+	// 8. Переименование папки департамента: меняются только название и описание, нужно право изменения папки
+	newRevision, err := fsl.Folder.RenameFolder(departmentId, currentEmployee, updatedDepartment.Metadata.Title, updatedDepartment.Metadata.Description, expectedRevision)
+	if err != nil {
+		var conflict *revision.ConflictError
+		if errors.As(err, &conflict) {
+			logger.NewWarnMessage("Department folder revision conflict",
+				zap.String("operation", "UpdateDepartmentHandler"),
+				zap.String("department_id", departmentId.String()),
+				zap.Int64("expected_revision", expectedRevision),
+				zap.Int64("current_revision", conflict.Current),
+			)
+			halper.WritePreconditionFailed(w, conflict.Current)
+			return
+		}
+
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Department folder access denied",
+				zap.String("operation", "UpdateDepartmentHandler"),
+				zap.String("department_id", departmentId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
+		if errors.Is(err, directory.ErrNotFound) {
 			logger.NewWarnMessage("Department folder not found",
 				zap.String("operation", "UpdateDepartmentHandler"),
 				zap.String("department_id", departmentId.String()),
@@ -120,12 +170,14 @@ func (d DepartmentHandlers) UpdateDepartmentHandler(w http.ResponseWriter, r *ht
 
 	// 9. Формирование успешного ответа
 	response := map[string]interface{}{
-		"status":  "success",
-		"message": "Department updated successfully",
+		"status":   "success",
+		"message":  "Department updated successfully",
+		"revision": newRevision,
 		// "department_id": departmentId.String(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(newRevision))
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.NewErrMessage("Failed to encode response",
 			zap.String("operation", "UpdateDepartmentHandler"),
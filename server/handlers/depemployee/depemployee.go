@@ -1,7 +1,9 @@
 package depemployee
 
 import (
+	"fmt"
 	"labyrinth/logic"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,3 +22,43 @@ type depemployeeData struct {
 	EmployeeId uuid.UUID `json: "employee_id"`
 	PositionId uuid.UUID `json: "position_id"`
 }
+
+type transferData struct {
+	EmployeeId     uuid.UUID `json:"employee_id"`
+	ToDepartmentId uuid.UUID `json:"to_department_id"`
+	PositionId     uuid.UUID `json:"position_id"`
+	EffectiveAt    string    `json:"effective_at"` // RFC3339 или YYYY-MM-DD, по умолчанию текущий момент
+}
+
+// parseDate разбирает дату в формате RFC3339 или YYYY-MM-DD; dateOnly сообщает, что время не указано.
+// Пустое значение означает текущий момент.
+func parseDate(value string) (t time.Time, dateOnly bool, err error) {
+	if value == "" {
+		return time.Now(), false, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date %q: expected RFC3339 or YYYY-MM-DD", value)
+	}
+	return t, true, nil
+}
+
+// parseEffectiveDate разбирает момент вступления перевода в силу.
+// Дата без времени трактуется как начало суток: с этого дня сотрудник числится в новом отделе.
+func parseEffectiveDate(value string) (time.Time, error) {
+	t, _, err := parseDate(value)
+	return t, err
+}
+
+// parseSnapshotDate разбирает дату, на которую запрашивается состав отдела.
+// Дата без времени трактуется как конец суток, чтобы учесть всех, кто состоял в отделе в этот день.
+func parseSnapshotDate(value string) (time.Time, error) {
+	t, dateOnly, err := parseDate(value)
+	if err != nil || !dateOnly {
+		return t, err
+	}
+	return t.Add(24*time.Hour - time.Nanosecond), nil
+}
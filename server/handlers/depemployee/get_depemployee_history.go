@@ -0,0 +1,108 @@
+package depemployee
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func (d DepEmployeeHandlers) GetDepEmployeeHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "GetDepEmployeeHistoryHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг user_id из пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "GetDepEmployeeHistoryHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "GetDepEmployeeHistoryHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг company_id и employee_id из пути
+	_, err = uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID format",
+			zap.String("operation", "GetDepEmployeeHistoryHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	employeeId, err := uuid.Parse(vars["employee_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid employee ID format",
+			zap.String("operation", "GetDepEmployeeHistoryHandler"),
+			zap.String("variable", "employee_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid employee ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Получение истории членства сотрудника в департаментах
+	history, err := bl.DepartmentEmployee.GetDepEmployeeHistory(employeeId)
+	if err != nil {
+		logger.NewErrMessage("Failed to get department membership history",
+			zap.String("operation", "GetDepEmployeeHistoryHandler"),
+			zap.String("employee_id", employeeId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to get department membership history", http.StatusInternalServerError)
+		return
+	}
+
+	// 6. Отправка ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"result":  "success",
+		"history": history,
+		"count":   len(*history),
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetDepEmployeeHistoryHandler"),
+			zap.String("employee_id", employeeId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Department membership history retrieved successfully",
+		zap.String("operation", "GetDepEmployeeHistoryHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("employee_id", employeeId.String()),
+	)
+}
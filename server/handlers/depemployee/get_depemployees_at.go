@@ -0,0 +1,122 @@
+package depemployee
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func (d DepEmployeeHandlers) GetDepEmployeesAtHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг user_id из пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг company_id и department_id из пути
+	_, err = uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID format",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID format",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Парсинг даты из query-параметра
+	at, err := parseSnapshotDate(r.URL.Query().Get("date"))
+	if err != nil {
+		logger.NewWarnMessage("Invalid date query parameter",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.String("date", r.URL.Query().Get("date")),
+			zap.Error(err),
+		)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 6. Получение состава департамента на дату
+	employees, err := bl.DepartmentEmployee.GetDepEmployeesAt(departmentId, at)
+	if err != nil {
+		logger.NewErrMessage("Failed to get department employees at date",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.String("department_id", departmentId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to get department employees", http.StatusInternalServerError)
+		return
+	}
+
+	// 7. Отправка ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"result":    "success",
+		"date":      at,
+		"employees": employees,
+		"count":     len(*employees),
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetDepEmployeesAtHandler"),
+			zap.String("department_id", departmentId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Department employees at date retrieved successfully",
+		zap.String("operation", "GetDepEmployeesAtHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("department_id", departmentId.String()),
+		zap.Time("date", at),
+	)
+}
@@ -0,0 +1,154 @@
+package depemployee
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func (d DepEmployeeHandlers) TransferDepEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг user_id из пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг company_id и department_id из пути
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID format",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID format",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Парсинг тела запроса
+	var requestData transferData
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("department_id", departmentId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	// 6. Валидация данных
+	if requestData.EmployeeId == uuid.Nil || requestData.ToDepartmentId == uuid.Nil || requestData.PositionId == uuid.Nil {
+		logger.NewWarnMessage("Empty identifiers in transfer request",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("department_id", departmentId.String()),
+		)
+		http.Error(w, "Employee ID, target department ID and position ID are required", http.StatusBadRequest)
+		return
+	}
+
+	effectiveAt, err := parseEffectiveDate(requestData.EffectiveAt)
+	if err != nil {
+		logger.NewWarnMessage("Invalid effective date",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("effective_at", requestData.EffectiveAt),
+			zap.Error(err),
+		)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 7. Перевод сотрудника: закрытие старого членства и открытие нового
+	newDepEmployeeId, err := bl.DepartmentEmployee.TransferDepEmployee(
+		requestData.EmployeeId,
+		departmentId,
+		requestData.ToDepartmentId,
+		requestData.PositionId,
+		effectiveAt,
+	)
+	if err != nil {
+		logger.NewErrMessage("Failed to transfer department employee",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.String("employee_id", requestData.EmployeeId.String()),
+			zap.String("from_department_id", departmentId.String()),
+			zap.String("to_department_id", requestData.ToDepartmentId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to transfer employee", http.StatusInternalServerError)
+		return
+	}
+
+	// 8. Формирование успешного ответа
+	response := map[string]interface{}{
+		"status":         "success",
+		"message":        "Employee transferred successfully",
+		"depemployee_id": newDepEmployeeId,
+		"effective_at":   effectiveAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "TransferDepEmployeeHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Employee transferred successfully",
+		zap.String("operation", "TransferDepEmployeeHandler"),
+		zap.String("admin_user_id", userID.String()),
+		zap.String("company_id", companyId.String()),
+		zap.String("from_department_id", departmentId.String()),
+		zap.String("to_department_id", requestData.ToDepartmentId.String()),
+		zap.String("employee_id", requestData.EmployeeId.String()),
+	)
+}
@@ -0,0 +1,156 @@
+package employee
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func (e EmployeeHandlers) GetDirectoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "GetDirectoryHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг user_id из пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "GetDirectoryHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "GetDirectoryHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг company_id из пути
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID format",
+			zap.String("operation", "GetDirectoryHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Парсинг параметров поиска
+	filter, err := parseDirectoryFilter(r.URL.Query())
+	if err != nil {
+		logger.NewWarnMessage("Invalid directory query",
+			zap.String("operation", "GetDirectoryHandler"),
+			zap.String("query", r.URL.RawQuery),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid query parameters", http.StatusBadRequest)
+		return
+	}
+
+	// 6. Поиск по справочнику сотрудников
+	entries, total, err := bl.Employee.SearchDirectory(userID, companyId, filter)
+	if err != nil {
+		logger.NewErrMessage("Failed to search directory",
+			zap.String("operation", "GetDirectoryHandler"),
+			zap.String("company_id", companyId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to search directory", http.StatusInternalServerError)
+		return
+	}
+
+	// 7. Формирование ответа
+	response := map[string]interface{}{
+		"status":    "success",
+		"employees": entries,
+		"count":     len(*entries),
+		"total":     total,
+		"offset":    filter.Offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetDirectoryHandler"),
+			zap.String("company_id", companyId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Directory retrieved successfully",
+		zap.String("operation", "GetDirectoryHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("company_id", companyId.String()),
+	)
+}
+
+// parseDirectoryFilter собирает фильтр справочника из query-параметров:
+// q, department_id, position_id, is_active, sort, order (asc|desc), limit, offset.
+func parseDirectoryFilter(query url.Values) (employee.DirectoryFilter, error) {
+	filter := employee.DirectoryFilter{
+		Search:   query.Get("q"),
+		SortBy:   query.Get("sort"),
+		SortDesc: query.Get("order") == "desc",
+	}
+
+	var err error
+	if value := query.Get("department_id"); value != "" {
+		if filter.DepartmentID, err = uuid.Parse(value); err != nil {
+			return filter, err
+		}
+	}
+	if value := query.Get("position_id"); value != "" {
+		if filter.PositionID, err = uuid.Parse(value); err != nil {
+			return filter, err
+		}
+	}
+	if value := query.Get("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.IsActive = &isActive
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, err
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
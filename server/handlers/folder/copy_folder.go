@@ -0,0 +1,148 @@
+package folder
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// CopyFolderHandler копирует папку со всем содержимым; без папки назначения копия остается рядом с оригиналом
+func (f FolderHandlers) CopyFolderHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	folderId, err := uuid.Parse(vars["folder_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid folder ID",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.String("variable", "folder_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid folder ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Парсинг тела запроса
+	var requestData copyRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	parentId := uuid.Nil
+	if requestData.TargetFolderID != "" {
+		if parentId, err = uuid.Parse(requestData.TargetFolderID); err != nil {
+			http.Error(w, "Invalid target folder ID format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 5. Копирование папки
+	copyId, err := fsl.Folder.CopyFolder(folderId, currentEmployee, companyId, departmentId, parentId, requestData.Title)
+	if err != nil {
+		status := folderErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to copy folder",
+				zap.String("operation", "CopyFolderHandler"),
+				zap.String("folder_id", folderId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// 6. Формирование ответа
+	response := map[string]interface{}{
+		"status":    "success",
+		"message":   "Folder copied successfully",
+		"folder_id": copyId,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "CopyFolderHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
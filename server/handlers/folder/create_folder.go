@@ -0,0 +1,154 @@
+package folder
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// CreateFolderHandler создает папку в отделе: на верхнем уровне или внутри другой папки отдела
+func (f FolderHandlers) CreateFolderHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Парсинг тела запроса
+	var requestData createFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	parentId := departmentId
+	if requestData.ParentID != "" {
+		if parentId, err = uuid.Parse(requestData.ParentID); err != nil {
+			http.Error(w, "Invalid parent folder ID format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 5. Проверка доступа: создавать папки может только сотрудник компании (его загрузил EmployeeMiddleware),
+	// в папку нужно еще и право изменения. Автором папки и владельцем ее правил становится сотрудник, а не пользователь
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+	if parentId != departmentId {
+		if err := fsl.Permission.CheckAccess(parentId, currentEmployee, permission.AccessEdit); err != nil {
+			status := folderErrorStatus(err)
+			if status == http.StatusInternalServerError {
+				logger.NewErrMessage("Failed to check folder access",
+					zap.String("operation", "CreateFolderHandler"),
+					zap.String("parent_id", parentId.String()),
+					zap.Error(err),
+				)
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+
+	// 6. Создание папки
+	folderId, err := fsl.Folder.CreateFolder(currentEmployee, companyId, departmentId, parentId, false, requestData.Title, requestData.Description)
+	if err != nil {
+		status := folderErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to create folder",
+				zap.String("operation", "CreateFolderHandler"),
+				zap.String("department_id", departmentId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// 7. Формирование ответа
+	response := map[string]interface{}{
+		"status":    "success",
+		"message":   "Folder created successfully",
+		"folder_id": folderId,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "CreateFolderHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,114 @@
+package folder
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// DeleteFolderHandler убирает папку вместе с вложенными папками и журналами в корзину отдела
+func (f FolderHandlers) DeleteFolderHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "DeleteFolderHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "DeleteFolderHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "DeleteFolderHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "DeleteFolderHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "DeleteFolderHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	folderId, err := uuid.Parse(vars["folder_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid folder ID",
+			zap.String("operation", "DeleteFolderHandler"),
+			zap.String("variable", "folder_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid folder ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Удаление папки
+	if err := fsl.Folder.DeleteFolder(folderId, currentEmployee, companyId); err != nil {
+		status := folderErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to delete folder",
+				zap.String("operation", "DeleteFolderHandler"),
+				zap.String("folder_id", folderId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// 5. Формирование ответа
+	response := map[string]interface{}{
+		"status":  "success",
+		"message": "Folder moved to trash",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "DeleteFolderHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
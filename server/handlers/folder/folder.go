@@ -0,0 +1,81 @@
+package folder
+
+import (
+	"errors"
+	notebookLogic "labyrinth/notebook/logic"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/trash"
+	"net/http"
+	"strconv"
+)
+
+const (
+	userIDKey   string = "id"
+	employeeKey string = "employee"
+)
+
+var fsl *notebookLogic.FileSystem = notebookLogic.NewFileSystem()
+
+type FolderHandlers struct{}
+
+func NewFolderHandlers() FolderHandlers { return FolderHandlers{} }
+
+type createFolderRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ParentID    string `json:"parent_id"` // родительская папка отдела; пусто - верхний уровень отдела
+}
+
+type renameFolderRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type moveRequest struct {
+	TargetFolderID string `json:"target_folder_id"` // папка назначения; пусто - верхний уровень отдела
+}
+
+type copyRequest struct {
+	TargetFolderID string `json:"target_folder_id"` // папка назначения; пусто - рядом с оригиналом
+	Title          string `json:"title"`            // по умолчанию - название оригинала, у дубликата с пометкой "(копия)"
+}
+
+// parseDepth разбирает глубину дерева из запроса; пусто - глубина по умолчанию
+func parseDepth(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("depth")
+	if raw == "" {
+		return directory.DefaultTreeDepth, nil
+	}
+	depth, err := strconv.Atoi(raw)
+	if err != nil || depth < 1 || depth > directory.MaxTreeDepth {
+		return 0, errors.New("depth must be between 1 and " + strconv.Itoa(directory.MaxTreeDepth))
+	}
+	return depth, nil
+}
+
+// folderErrorStatus сопоставляет ошибки логики папок с HTTP-статусами
+func folderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, permission.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, directory.ErrNotFound), errors.Is(err, directory.ErrNoDivision):
+		return http.StatusNotFound
+	case errors.Is(err, directory.ErrInvalidTitle), errors.Is(err, directory.ErrInvalidParent), errors.Is(err, directory.ErrTreeTooLarge),
+		errors.Is(err, directory.ErrOtherCompany), errors.Is(err, directory.ErrPrimaryFolder):
+		return http.StatusBadRequest
+	case errors.Is(err, directory.ErrMoveIntoDescendant), errors.Is(err, journal.ErrReadOnly),
+		errors.Is(err, journal.ErrSignedContent):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// trashErrorStatus сопоставляет ошибки корзины с HTTP-статусами
+func trashErrorStatus(err error) int {
+	if errors.Is(err, trash.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return folderErrorStatus(err)
+}
@@ -0,0 +1,126 @@
+package folder
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GetDepartmentTreeHandler возвращает дерево папок отдела с журналами до заданной глубины
+func (f FolderHandlers) GetDepartmentTreeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	depth, err := parseDepth(r)
+	if err != nil {
+		logger.NewWarnMessage("Invalid depth",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 4. Дерево папок отдела
+	nodes, err := fsl.Folder.GetDepartmentTree(departmentId, currentEmployee, companyId, depth)
+	if err != nil {
+		status := folderErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to get department tree",
+				zap.String("operation", "GetDepartmentTreeHandler"),
+				zap.String("department_id", departmentId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// 5. Формирование ответа
+	response := map[string]interface{}{
+		"status": "success",
+		"data":   nodes,
+		"depth":  depth,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetDepartmentTreeHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
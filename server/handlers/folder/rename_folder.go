@@ -0,0 +1,147 @@
+package folder
+
+import (
+	"encoding/json"
+	"errors"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// RenameFolderHandler меняет название и описание папки; ревизия передается в If-Match
+func (f FolderHandlers) RenameFolderHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	folderId, err := uuid.Parse(vars["folder_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid folder ID",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.String("variable", "folder_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid folder ID format", http.StatusBadRequest)
+		return
+	}
+
+	expectedRevision, err := halper.ParseIfMatch(r)
+	if err != nil {
+		logger.NewWarnMessage("Invalid If-Match header",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.String("folder_id", folderId.String()),
+			zap.Error(err),
+		)
+		if errors.Is(err, halper.ErrIfMatchRequired) {
+			http.Error(w, err.Error(), http.StatusPreconditionRequired)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 4. Парсинг тела запроса
+	var requestData renameFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	// 5. Переименование
+	newRevision, err := fsl.Folder.RenameFolder(folderId, currentEmployee, requestData.Title, requestData.Description, expectedRevision)
+	if err != nil {
+		var conflict *revision.ConflictError
+		if errors.As(err, &conflict) {
+			logger.NewWarnMessage("Folder revision conflict",
+				zap.String("operation", "RenameFolderHandler"),
+				zap.String("folder_id", folderId.String()),
+				zap.Int64("expected_revision", expectedRevision),
+				zap.Int64("current_revision", conflict.Current),
+			)
+			halper.WritePreconditionFailed(w, conflict.Current)
+			return
+		}
+		status := folderErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to rename folder",
+				zap.String("operation", "RenameFolderHandler"),
+				zap.String("folder_id", folderId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// 6. Формирование ответа
+	response := map[string]interface{}{
+		"status":   "success",
+		"message":  "Folder renamed successfully",
+		"revision": newRevision,
+	}
+
+	w.Header().Set("ETag", halper.ETag(newRevision))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "RenameFolderHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
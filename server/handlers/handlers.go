@@ -7,9 +7,13 @@ import (
 	"labyrinth/server/handlers/depemployee"
 	"labyrinth/server/handlers/depposition"
 	"labyrinth/server/handlers/employee"
+	"labyrinth/server/handlers/folder"
 	"labyrinth/server/handlers/journal"
+	"labyrinth/server/handlers/notification"
 	"labyrinth/server/handlers/permission"
 	"labyrinth/server/handlers/position"
+	"labyrinth/server/handlers/presence"
+	"labyrinth/server/handlers/tag"
 	"labyrinth/server/handlers/user"
 	"net/http"
 )
@@ -30,12 +34,15 @@ type companyInterface interface {
 	NewCompanyHandler(w http.ResponseWriter, r *http.Request)
 	UpdateCompanyProfileHandler(w http.ResponseWriter, r *http.Request)
 	GetCompanyProfileHandler(w http.ResponseWriter, r *http.Request)
+	SelectCompanyHandler(w http.ResponseWriter, r *http.Request)
+	GetActiveCompanyHandler(w http.ResponseWriter, r *http.Request)
 }
 
 type employeeInterface interface {
 	GetAllEmployeeHandler(w http.ResponseWriter, r *http.Request)
 	NewEmployeeHandler(w http.ResponseWriter, r *http.Request)
 	UpdateEmployeeHandler(w http.ResponseWriter, r *http.Request)
+	GetDirectoryHandler(w http.ResponseWriter, r *http.Request)
 }
 
 type positionInterface interface {
@@ -56,6 +63,9 @@ type depemployeeInterface interface {
 	GetAllDepEmployeeHandler(w http.ResponseWriter, r *http.Request)
 	NewDepEmployeeHandler(w http.ResponseWriter, r *http.Request)
 	UpdateDepEmployeeHandler(w http.ResponseWriter, r *http.Request)
+	TransferDepEmployeeHandler(w http.ResponseWriter, r *http.Request)
+	GetDepEmployeesAtHandler(w http.ResponseWriter, r *http.Request)
+	GetDepEmployeeHistoryHandler(w http.ResponseWriter, r *http.Request)
 }
 
 type depemployeePosInterface interface {
@@ -68,11 +78,87 @@ type notebookInterface interface {
 	NewNotebookHandler(w http.ResponseWriter, r *http.Request)
 	GetNotebookHandler(w http.ResponseWriter, r *http.Request)
 	UpdateNotebookHandler(w http.ResponseWriter, r *http.Request)
+	DeleteNotebookHandler(w http.ResponseWriter, r *http.Request)
+	InsertBlockHandler(w http.ResponseWriter, r *http.Request)
+	UpdateBlockHandler(w http.ResponseWriter, r *http.Request)
+	MoveBlockHandler(w http.ResponseWriter, r *http.Request)
+	DeleteBlockHandler(w http.ResponseWriter, r *http.Request)
+	ListRevisionsHandler(w http.ResponseWriter, r *http.Request)
+	GetRevisionHandler(w http.ResponseWriter, r *http.Request)
+	DiffRevisionsHandler(w http.ResponseWriter, r *http.Request)
+	RestoreRevisionHandler(w http.ResponseWriter, r *http.Request)
+	VerifyRevisionsHandler(w http.ResponseWriter, r *http.Request)
+	CollaborateHandler(w http.ResponseWriter, r *http.Request)
+	GetBlockTypesHandler(w http.ResponseWriter, r *http.Request)
+	AddCommentHandler(w http.ResponseWriter, r *http.Request)
+	ReplyCommentHandler(w http.ResponseWriter, r *http.Request)
+	UpdateCommentHandler(w http.ResponseWriter, r *http.Request)
+	ResolveCommentHandler(w http.ResponseWriter, r *http.Request)
+	DeleteCommentHandler(w http.ResponseWriter, r *http.Request)
+	ListTemplatesHandler(w http.ResponseWriter, r *http.Request)
+	SaveTemplateHandler(w http.ResponseWriter, r *http.Request)
+	GetTemplateHandler(w http.ResponseWriter, r *http.Request)
+	UpdateTemplateHandler(w http.ResponseWriter, r *http.Request)
+	DeleteTemplateHandler(w http.ResponseWriter, r *http.Request)
+	NewNotebookFromTemplateHandler(w http.ResponseWriter, r *http.Request)
+	ExportNotebookHandler(w http.ResponseWriter, r *http.Request)
+	ImportNotebookHandler(w http.ResponseWriter, r *http.Request)
+	SignNotebookHandler(w http.ResponseWriter, r *http.Request)
+	WitnessSignatureHandler(w http.ResponseWriter, r *http.Request)
+	SearchHandler(w http.ResponseWriter, r *http.Request)
+	ChangeStatusHandler(w http.ResponseWriter, r *http.Request)
+	MoveNotebookHandler(w http.ResponseWriter, r *http.Request)
+	CopyNotebookHandler(w http.ResponseWriter, r *http.Request)
+	ListReviewsHandler(w http.ResponseWriter, r *http.Request)
+	GetWorkflowHandler(w http.ResponseWriter, r *http.Request)
+	UpdateWorkflowHandler(w http.ResponseWriter, r *http.Request)
 }
 
 type permissionInterface interface {
 	GetPermissionHandler(w http.ResponseWriter, r *http.Request)
 	UpdatePermissionHandler(w http.ResponseWriter, r *http.Request)
+	GetEffectivePermissionHandler(w http.ResponseWriter, r *http.Request)
+}
+
+type presenceInterface interface {
+	HeartbeatHandler(w http.ResponseWriter, r *http.Request)
+	GetDepartmentOnlineHandler(w http.ResponseWriter, r *http.Request)
+}
+
+type notificationInterface interface {
+	ListNotificationsHandler(w http.ResponseWriter, r *http.Request)
+	MarkNotificationsReadHandler(w http.ResponseWriter, r *http.Request)
+	MarkNotificationReadHandler(w http.ResponseWriter, r *http.Request)
+	NotificationStreamHandler(w http.ResponseWriter, r *http.Request)
+}
+
+type tagInterface interface {
+	ListTagsHandler(w http.ResponseWriter, r *http.Request)
+	CreateTagHandler(w http.ResponseWriter, r *http.Request)
+	SuggestTagsHandler(w http.ResponseWriter, r *http.Request)
+	UpdateTagHandler(w http.ResponseWriter, r *http.Request)
+	MergeTagsHandler(w http.ResponseWriter, r *http.Request)
+	DeleteTagHandler(w http.ResponseWriter, r *http.Request)
+	ListTaggedNotebooksHandler(w http.ResponseWriter, r *http.Request)
+	TagNotebookHandler(w http.ResponseWriter, r *http.Request)
+	UntagNotebookHandler(w http.ResponseWriter, r *http.Request)
+	TagFolderHandler(w http.ResponseWriter, r *http.Request)
+	UntagFolderHandler(w http.ResponseWriter, r *http.Request)
+}
+
+type folderInterface interface {
+	CreateFolderHandler(w http.ResponseWriter, r *http.Request)
+	GetDepartmentTreeHandler(w http.ResponseWriter, r *http.Request)
+	GetFolderHandler(w http.ResponseWriter, r *http.Request)
+	ListChildrenHandler(w http.ResponseWriter, r *http.Request)
+	GetFolderTreeHandler(w http.ResponseWriter, r *http.Request)
+	RenameFolderHandler(w http.ResponseWriter, r *http.Request)
+	DeleteFolderHandler(w http.ResponseWriter, r *http.Request)
+	MoveFolderHandler(w http.ResponseWriter, r *http.Request)
+	CopyFolderHandler(w http.ResponseWriter, r *http.Request)
+	ListTrashHandler(w http.ResponseWriter, r *http.Request)
+	RestoreTrashItemHandler(w http.ResponseWriter, r *http.Request)
+	PurgeTrashItemHandler(w http.ResponseWriter, r *http.Request)
 }
 
 type Handlers struct {
@@ -86,6 +172,10 @@ type Handlers struct {
 	DepartmentEmployeePosition depemployeePosInterface
 	Notebook                   notebookInterface
 	Permission                 permissionInterface
+	Presence                   presenceInterface
+	Notification               notificationInterface
+	Tag                        tagInterface
+	Folder                     folderInterface
 }
 
 func NewHandlers() Handlers {
@@ -100,5 +190,9 @@ func NewHandlers() Handlers {
 		DepartmentEmployeePosition: depposition.NewDepPositionHandlers(),
 		Notebook:                   journal.NewJournalHandler(),
 		Permission:                 permission.NewPermissionHandlers(),
+		Presence:                   presence.NewPresenceHandlers(),
+		Notification:               notification.NewNotificationHandlers(),
+		Tag:                        tag.NewTagHandlers(),
+		Folder:                     folder.NewFolderHandlers(),
 	}
 }
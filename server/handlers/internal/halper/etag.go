@@ -0,0 +1,47 @@
+package halper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var ErrIfMatchRequired = errors.New("If-Match header is required")
+
+// ETag форматирует ревизию документа как сильный ETag
+func ETag(revision int64) string {
+	return strconv.Quote(strconv.FormatInt(revision, 10))
+}
+
+// ParseIfMatch извлекает ожидаемую ревизию из заголовка If-Match
+func ParseIfMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, ErrIfMatchRequired
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+
+	revision, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || revision < 0 {
+		return 0, fmt.Errorf("invalid If-Match value %q", r.Header.Get("If-Match"))
+	}
+
+	return revision, nil
+}
+
+// WritePreconditionFailed отвечает 412 с текущей ревизией документа
+func WritePreconditionFailed(w http.ResponseWriter, current int64) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", ETag(current))
+	w.WriteHeader(http.StatusPreconditionFailed)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":           "error",
+		"message":          "Revision mismatch",
+		"current_revision": current,
+	})
+}
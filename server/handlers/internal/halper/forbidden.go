@@ -0,0 +1,17 @@
+package halper
+
+import (
+	"errors"
+	"labyrinth/notebook/models/permission"
+	"net/http"
+)
+
+// WriteForbidden отвечает 403, если доступ к журналу или папке запрещен правилами доступа.
+// Возвращает false, если ошибка другая и ответ не записан.
+func WriteForbidden(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, permission.ErrForbidden) {
+		return false
+	}
+	http.Error(w, err.Error(), http.StatusForbidden)
+	return true
+}
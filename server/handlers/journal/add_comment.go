@@ -0,0 +1,133 @@
+package journal
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// AddCommentHandler добавляет комментарий к блоку журнала
+func (j JournalHandler) AddCommentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "AddCommentHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "AddCommentHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "AddCommentHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "AddCommentHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "AddCommentHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	blockId := vars["block_id"]
+	if _, err := uuid.Parse(blockId); err != nil {
+		logger.NewWarnMessage("Invalid block ID",
+			zap.String("operation", "AddCommentHandler"),
+			zap.String("variable", "block_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid block ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Парсинг тела запроса
+	var requestData commentRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "AddCommentHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	// 5. Добавление комментария
+	comment, err := fsl.File.AddComment(notebookId, currentEmployee, blockId, "", requestData.Comment)
+	if err != nil {
+		logger.NewErrMessage("Failed to add comment",
+			zap.String("operation", "AddCommentHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("block_id", blockId),
+			zap.Error(err),
+		)
+
+		http.Error(w, err.Error(), commentErrorStatus(err))
+		return
+	}
+
+	// 6. Формирование ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"data":   comment,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "AddCommentHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Comment added successfully",
+		zap.String("operation", "AddCommentHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("comment_id", comment.Id),
+	)
+}
@@ -0,0 +1,125 @@
+package journal
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// ChangeStatusHandler переводит журнал в другой статус: отправка на проверку, утверждение, возврат, архивирование
+func (j JournalHandler) ChangeStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "ChangeStatusHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "ChangeStatusHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "ChangeStatusHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "ChangeStatusHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "ChangeStatusHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Парсинг тела запроса
+	var requestData statusRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "ChangeStatusHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	reviewerId := uuid.Nil
+	if requestData.ReviewerID != "" {
+		if reviewerId, err = uuid.Parse(requestData.ReviewerID); err != nil {
+			http.Error(w, "Invalid reviewer ID format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 5. Переход
+	lifecycle, err := fsl.File.ChangeStatus(notebookId, currentEmployee, requestData.Action, reviewerId, requestData.Note)
+	if err != nil {
+		status := statusErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to change notebook status",
+				zap.String("operation", "ChangeStatusHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	// 6. Формирование ответа
+	response := map[string]interface{}{
+		"status": "success",
+		"data":   lifecycle,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "ChangeStatusHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,114 @@
+package journal
+
+import (
+	"fmt"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+// CollaborateHandler переводит соединение в WebSocket для совместного редактирования журнала
+func (j JournalHandler) CollaborateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "CollaborateHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "CollaborateHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "CollaborateHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "CollaborateHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "CollaborateHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Проверка доступа: читать журнал нужно для подключения, изменять - для правок
+	if err := fsl.Permission.CheckAccess(notebookId, currentEmployee, permission.AccessRead); err != nil {
+		if !halper.WriteForbidden(w, err) {
+			logger.NewErrMessage("Failed to check notebook access",
+				zap.String("operation", "CollaborateHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	canEdit := fsl.Permission.CheckAccess(notebookId, currentEmployee, permission.AccessEdit) == nil
+
+	// 5. Переход на WebSocket
+	server := websocket.Server{
+		// Аутентификация по cookie уже пройдена, поэтому принимаем только same-origin соединения
+		Handshake: func(cfg *websocket.Config, req *http.Request) error {
+			origin, err := websocket.Origin(cfg, req)
+			if err != nil || origin == nil || origin.Host != req.Host {
+				return fmt.Errorf("cross-origin websocket is not allowed")
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			collab.Serve(ws, notebookId, currentEmployee, canEdit)
+		},
+	}
+
+	logger.NewInfoMessage("Collaboration session requested",
+		zap.String("operation", "CollaborateHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookId.String()),
+		zap.Bool("can_edit", canEdit),
+	)
+
+	server.ServeHTTP(w, r)
+}
@@ -0,0 +1,137 @@
+package journal
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func (j JournalHandler) DeleteBlockHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	blockId := vars["block_id"]
+	if _, err := uuid.Parse(blockId); err != nil {
+		logger.NewWarnMessage("Invalid block ID",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.String("variable", "block_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid block ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Удаление блока
+	if err := fsl.File.DeleteBlock(notebookId, currentEmployee, blockId); err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Notebook access denied",
+				zap.String("operation", "DeleteBlockHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
+		if isLockedContent(err) {
+			logger.NewWarnMessage("Notebook content is locked",
+				zap.String("operation", "DeleteBlockHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		logger.NewErrMessage("Failed to delete block",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 5. Формирование ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "success",
+		"message": "Block deleted successfully",
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "DeleteBlockHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Block deleted successfully",
+		zap.String("operation", "DeleteBlockHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("block_id", blockId),
+	)
+}
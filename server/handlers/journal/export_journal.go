@@ -0,0 +1,130 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	exportLogic "labyrinth/notebook/logic/export"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// ExportNotebookHandler отдает журнал файлом: ?format=pdf|html|md, ?comments=true добавляет комментарии
+func (j JournalHandler) ExportNotebookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "ExportNotebookHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "ExportNotebookHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "ExportNotebookHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "ExportNotebookHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "ExportNotebookHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Параметры экспорта
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = exportLogic.FormatPDF
+	}
+	includeComments := false
+	if v := query.Get("comments"); v != "" {
+		if includeComments, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "Invalid comments flag", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 5. Формирование файла
+	file, err := fsl.File.ExportNotebook(notebookId, currentEmployee, format, includeComments)
+	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Notebook access denied",
+				zap.String("operation", "ExportNotebookHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
+		if errors.Is(err, exportLogic.ErrUnknownFormat) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logger.NewErrMessage("Failed to export notebook",
+			zap.String("operation", "ExportNotebookHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 6. Отдача файла
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"notebook%s\"; filename*=UTF-8''%s", path.Ext(file.Name), url.PathEscape(file.Name)))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file.Data); err != nil {
+		logger.NewErrMessage("Failed to write export",
+			zap.String("operation", "ExportNotebookHandler"),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,44 @@
+package journal
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetBlockTypesHandler возвращает реестр типов блоков с JSON-схемами их тела
+func (j JournalHandler) GetBlockTypesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "GetBlockTypesHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Формирование ответа
+	kinds := blocksLogic.Kinds()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"data":   kinds,
+		"count":  len(kinds),
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetBlockTypesHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
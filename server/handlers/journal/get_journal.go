@@ -3,6 +3,8 @@ package journal
 import (
 	"encoding/json"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/server/handlers/internal/halper"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -48,6 +50,16 @@ func (j JournalHandler) GetNotebookHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "GetNotebookHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
 	notebookId, err := uuid.Parse(vars["notebook_id"])
 	if err != nil {
 		logger.NewWarnMessage("Invalid notebook ID",
@@ -59,8 +71,17 @@ func (j JournalHandler) GetNotebookHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	notebook, err := fsl.File.GetNotebook(notebookId)
+	notebook, err := fsl.File.GetNotebook(notebookId, currentEmployee)
 	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Notebook access denied",
+				zap.String("operation", "GetNotebookHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
 		logger.NewErrMessage("Failed to get notebook",
 			zap.String("operation", "GetNotebookHandler"),
 			zap.String("notebook_id", notebookId.String()),
@@ -72,11 +93,14 @@ func (j JournalHandler) GetNotebookHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	response := map[string]interface{}{
-		"status": "success",
-		"data":   notebook,
+		"status":           "success",
+		"data":             notebook,
+		"signature_status": notebook.SignatureStatus(),
+		"lifecycle_status": notebook.Lifecycle.CurrentStatus(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(notebook.Revision))
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.NewErrMessage("Failed to encode response",
 			zap.String("operation", "GetNotebookHandler"),
@@ -0,0 +1,136 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GetRevisionHandler возвращает журнал в состоянии указанной ревизии
+func (j JournalHandler) GetRevisionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	number, err := parseRevision(vars["revision"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid revision number",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.String("variable", "revision"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid revision number", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Получение снимка журнала
+	rev, err := fsl.File.GetRevision(notebookId, currentEmployee, number)
+	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Notebook access denied",
+				zap.String("operation", "GetRevisionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
+		logger.NewErrMessage("Failed to get revision",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Int64("revision", number),
+			zap.Error(err),
+		)
+		if errors.Is(err, revision.ErrNotFound) {
+			http.Error(w, "Revision not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 5. Формирование ответа
+	response := map[string]interface{}{
+		"status": "success",
+		"data":   rev,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetRevisionHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Revision retrieved successfully",
+		zap.String("operation", "GetRevisionHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookId.String()),
+		zap.Int64("revision", number),
+	)
+}
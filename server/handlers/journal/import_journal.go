@@ -0,0 +1,181 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	importLogic "labyrinth/notebook/logic/importer"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/permission"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// ImportNotebookHandler создает журнал из файла Markdown, DOCX или Jupyter в выбранной папке отдела
+func (j JournalHandler) ImportNotebookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Чтение файла из multipart/form-data: поле file и ID папки folder_id
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		logger.NewWarnMessage("Failed to parse multipart form",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid multipart form or file is too large", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	folderId, err := uuid.Parse(r.FormValue("folder_id"))
+	if err != nil {
+		logger.NewWarnMessage("Invalid folder ID",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.String("variable", "folder_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid folder ID format", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		logger.NewWarnMessage("Missing import file",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "File is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		logger.NewErrMessage("Failed to read import file",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Импорт
+	report, err := fsl.File.ImportNotebook(currentEmployee, companyId, departmentId, folderId, header.Filename, data)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, importLogic.ErrUnsupportedFormat):
+			status = http.StatusUnsupportedMediaType
+		case errors.Is(err, importLogic.ErrInvalidFile):
+			status = http.StatusBadRequest
+		case errors.Is(err, directory.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, permission.ErrForbidden):
+			status = http.StatusForbidden
+		}
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to import notebook",
+				zap.String("operation", "ImportNotebookHandler"),
+				zap.String("file_name", header.Filename),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "success",
+		"message":     "Notebook imported successfully",
+		"notebook_id": report.NotebookID,
+		"report":      report,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "ImportNotebookHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Notebook imported",
+		zap.String("operation", "ImportNotebookHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", report.NotebookID),
+		zap.Int("issues", len(report.Issues)),
+	)
+}
@@ -1,15 +1,34 @@
 package journal
 
 import (
+	"errors"
+	"fmt"
 	notebookLogic "labyrinth/notebook/logic"
+	blocksLogic "labyrinth/notebook/logic/blocks"
+	collabLogic "labyrinth/notebook/logic/collab"
+	"labyrinth/notebook/models/directory"
+	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/template"
+	"labyrinth/notebook/models/workflow"
+	"net/http"
+	"strconv"
 )
 
 const (
-	userIDKey string = "id"
+	userIDKey   string = "id"
+	employeeKey string = "employee"
+
+	// maxImportSize - предел размера импортируемого файла вместе с изображениями
+	maxImportSize int64 = 32 << 20
 )
 
 var fsl *notebookLogic.FileSystem = notebookLogic.NewFileSystem()
 
+// collab - общий для всех соединений хаб совместного редактирования журналов
+var collab = collabLogic.NewHub(fsl.File)
+
 type JournalHandler struct{}
 
 func NewJournalHandler() JournalHandler { return JournalHandler{} }
@@ -18,3 +37,184 @@ type notebookRequest struct {
 	Name        string `json: "name"`
 	Description string `json: "description"`
 }
+
+type blockRequest struct {
+	Type     string         `json:"type"`
+	Body     map[string]any `json:"body"`
+	Position *int           `json:"position"` // позиция вставки, по умолчанию - в конец
+}
+
+type commentRequest struct {
+	Comment string `json:"comment"`
+}
+
+type resolveCommentRequest struct {
+	Resolved *bool `json:"resolved"` // по умолчанию - true
+}
+
+type saveTemplateRequest struct {
+	NotebookID  string   `json:"notebook_id"`
+	Title       string   `json:"title"` // по умолчанию - название журнала
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`         // по умолчанию - теги журнала
+	CompanyWide bool     `json:"company_wide"` // шаблон доступен всем отделам компании
+}
+
+type updateTemplateRequest struct {
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Tags        []string        `json:"tags"`   // null - оставить без изменений
+	Blocks      []journal.Block `json:"blocks"` // null - оставить без изменений
+}
+
+type instantiateTemplateRequest struct {
+	Title  string            `json:"title"` // по умолчанию - название шаблона
+	Values map[string]string `json:"values"`
+}
+
+type signRequest struct {
+	Password string   `json:"password"` // пароль вводится повторно при каждой подписи
+	Meaning  string   `json:"meaning"`
+	BlockIDs []string `json:"block_ids"` // пусто - подписать весь журнал
+}
+
+type statusRequest struct {
+	Action     string `json:"action"`      // переход процесса: submit, approve, return, archive или настроенный компанией
+	ReviewerID string `json:"reviewer_id"` // ID сотрудника отдела журнала - рецензента, обязателен при отправке на проверку
+	Note       string `json:"note"`
+}
+
+type transitionRequest struct {
+	Action   string   `json:"action"`
+	From     []string `json:"from"`
+	To       string   `json:"to"`
+	MaxLevel int      `json:"max_level"` // наименее старший уровень должности в отделе; 0 - без ограничения
+}
+
+type workflowRequest struct {
+	Transitions []transitionRequest `json:"transitions"`
+	Editable    []string            `json:"editable"` // статусы, в которых журнал можно изменять
+}
+
+type moveBlockRequest struct {
+	Position *int `json:"position"`
+}
+
+type moveRequest struct {
+	TargetFolderID string `json:"target_folder_id"` // папка назначения; пусто - верхний уровень отдела
+}
+
+type copyRequest struct {
+	TargetFolderID string `json:"target_folder_id"` // папка назначения; пусто - рядом с оригиналом
+	Title          string `json:"title"`            // по умолчанию - название оригинала, у дубликата с пометкой "(копия)"
+}
+
+// parseRevision разбирает неотрицательный номер ревизии
+func parseRevision(raw string) (int64, error) {
+	number, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if number < 0 {
+		return 0, fmt.Errorf("revision number cannot be negative: %d", number)
+	}
+	return number, nil
+}
+
+// isInvalidBlock сообщает, что тело или тип блока не прошли проверку по реестру типов
+// или ID блока повторяется либо не принадлежит журналу
+func isInvalidBlock(err error) bool {
+	var invalid *blocksLogic.ValidationError
+	return errors.As(err, &invalid) || errors.Is(err, blocksLogic.ErrUnknownType) || errors.Is(err, journal.ErrInvalidBlockId)
+}
+
+// isLockedContent сообщает, что изменение затронуло подписанное содержимое журнала
+// или журнал в статусе только для чтения
+func isLockedContent(err error) bool {
+	return errors.Is(err, journal.ErrSignedContent) || errors.Is(err, journal.ErrReadOnly)
+}
+
+// statusErrorStatus подбирает HTTP-статус для ошибки перехода между статусами журнала
+func statusErrorStatus(err error) int {
+	var conflict *revision.ConflictError
+	switch {
+	case errors.Is(err, permission.ErrForbidden), errors.Is(err, workflow.ErrNotReviewer),
+		errors.Is(err, workflow.ErrInsufficientLevel), errors.Is(err, workflow.ErrSelfReview):
+		return http.StatusForbidden
+	case errors.Is(err, workflow.ErrReviewerRequired), errors.Is(err, workflow.ErrInvalidReviewer),
+		errors.Is(err, workflow.ErrInvalidNote):
+		return http.StatusBadRequest
+	case errors.Is(err, workflow.ErrInvalidTransition), errors.As(err, &conflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// commentErrorStatus подбирает HTTP-статус для ошибки операции с комментарием
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, permission.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, journal.ErrBlockNotFound), errors.Is(err, journal.ErrCommentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, journal.ErrInvalidComment), errors.Is(err, journal.ErrCommentIsReply):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// signatureErrorStatus подбирает HTTP-статус для ошибки подписи
+func signatureErrorStatus(err error) int {
+	var conflict *revision.ConflictError
+	switch {
+	case errors.Is(err, journal.ErrInvalidPassword):
+		return http.StatusUnauthorized
+	case errors.Is(err, permission.ErrForbidden), errors.Is(err, journal.ErrSelfWitness):
+		return http.StatusForbidden
+	case errors.Is(err, journal.ErrBlockNotFound), errors.Is(err, journal.ErrSignatureNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, journal.ErrInvalidMeaning), errors.Is(err, journal.ErrNotAuthorSignature):
+		return http.StatusBadRequest
+	case errors.Is(err, journal.ErrAlreadyWitnessed), isLockedContent(err), errors.As(err, &conflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// workflowErrorStatus подбирает HTTP-статус для ошибки настройки процесса согласования
+func workflowErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, permission.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, workflow.ErrInvalidWorkflow):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// relocateErrorStatus подбирает HTTP-статус для ошибки переноса или копирования журнала
+func relocateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, permission.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, directory.ErrNotFound), errors.Is(err, directory.ErrNoDivision):
+		return http.StatusNotFound
+	case errors.Is(err, directory.ErrOtherCompany):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// templateErrorStatus подбирает HTTP-статус для ошибки операции с шаблоном
+func templateErrorStatus(err error) int {
+	var missing *template.MissingVariablesError
+	switch {
+	case errors.Is(err, permission.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, template.ErrNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &missing), isInvalidBlock(err):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -3,6 +3,8 @@ package journal
 import (
 	"encoding/json"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/server/handlers/internal/halper"
 	"net/http"
 	"strings"
 
@@ -49,6 +51,16 @@ func (j JournalHandler) NewNotebookHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "NewNotebookHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
 	companyId, err := uuid.Parse(vars["company_id"])
 	if err != nil {
 		logger.NewWarnMessage("Invalid company ID",
@@ -94,7 +106,16 @@ func (j JournalHandler) NewNotebookHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	// 6. Создание блокнота
-	if err := fsl.File.NewNotebook(userID, companyId, departmentId, requestData.Name, requestData.Description); err != nil {
+	if err := fsl.File.NewNotebook(currentEmployee, companyId, departmentId, requestData.Name, requestData.Description); err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Company access denied",
+				zap.String("operation", "NewNotebookHandler"),
+				zap.String("company_id", companyId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
 		logger.NewErrMessage("Failed to create notebook",
 			zap.String("operation", "NewNotebookHandler"),
 			zap.String("user_id", userID.String()),
@@ -0,0 +1,145 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// NewNotebookFromTemplateHandler создает журнал отдела из шаблона с подстановкой переменных
+func (j JournalHandler) NewNotebookFromTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	templateId, err := uuid.Parse(vars["template_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid template ID",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.String("variable", "template_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid template ID format", http.StatusBadRequest)
+		return
+	}
+
+	// Тело необязательно: без него используются название шаблона и встроенные переменные
+	var requestData instantiateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil && !errors.Is(err, io.EOF) {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	notebookId, err := fsl.File.NewNotebookFromTemplate(currentEmployee, companyId, departmentId, templateId, requestData.Title, requestData.Values)
+	if err != nil {
+		status := templateErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to create notebook from template",
+				zap.String("operation", "NewNotebookFromTemplateHandler"),
+				zap.String("template_id", templateId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "success",
+		"message":     "Notebook created successfully",
+		"notebook_id": notebookId,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "NewNotebookFromTemplateHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Notebook created from template",
+		zap.String("operation", "NewNotebookFromTemplateHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("template_id", templateId.String()),
+		zap.String("notebook_id", notebookId.String()),
+	)
+}
@@ -0,0 +1,152 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// ResolveCommentHandler закрывает ветку комментариев или открывает её снова (resolved: false)
+func (j JournalHandler) ResolveCommentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	blockId := vars["block_id"]
+	if _, err := uuid.Parse(blockId); err != nil {
+		logger.NewWarnMessage("Invalid block ID",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("variable", "block_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid block ID format", http.StatusBadRequest)
+		return
+	}
+
+	commentId := vars["comment_id"]
+	if _, err := uuid.Parse(commentId); err != nil {
+		logger.NewWarnMessage("Invalid comment ID",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("variable", "comment_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid comment ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Парсинг тела запроса (тело необязательно)
+	var requestData resolveCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil && !errors.Is(err, io.EOF) {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	resolved := true
+	if requestData.Resolved != nil {
+		resolved = *requestData.Resolved
+	}
+
+	// 5. Изменение состояния ветки
+	comment, err := fsl.File.ResolveComment(notebookId, currentEmployee, blockId, commentId, resolved)
+	if err != nil {
+		logger.NewErrMessage("Failed to resolve comment",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.String("comment_id", commentId),
+			zap.Error(err),
+		)
+
+		http.Error(w, err.Error(), commentErrorStatus(err))
+		return
+	}
+
+	// 6. Формирование ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"data":   comment,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "ResolveCommentHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Comment thread state changed",
+		zap.String("operation", "ResolveCommentHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookId.String()),
+		zap.String("comment_id", commentId),
+		zap.Bool("resolved", resolved),
+	)
+}
@@ -0,0 +1,178 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// RestoreRevisionHandler восстанавливает старую ревизию как новую; требует If-Match с текущей ревизией
+func (j JournalHandler) RestoreRevisionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	notebookId, err := uuid.Parse(vars["notebook_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notebook ID",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.String("variable", "notebook_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notebook ID format", http.StatusBadRequest)
+		return
+	}
+
+	number, err := parseRevision(vars["revision"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid revision number",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.String("variable", "revision"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid revision number", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Ожидаемая текущая ревизия
+	expectedRevision, err := halper.ParseIfMatch(r)
+	if err != nil {
+		logger.NewWarnMessage("Invalid If-Match header",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+		if errors.Is(err, halper.ErrIfMatchRequired) {
+			http.Error(w, err.Error(), http.StatusPreconditionRequired)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 5. Восстановление
+	newRevision, err := fsl.File.RestoreRevision(notebookId, currentEmployee, number, expectedRevision)
+	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Notebook access denied",
+				zap.String("operation", "RestoreRevisionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
+		var conflict *revision.ConflictError
+		if errors.As(err, &conflict) {
+			logger.NewWarnMessage("Notebook revision conflict",
+				zap.String("operation", "RestoreRevisionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Int64("expected_revision", expectedRevision),
+				zap.Int64("current_revision", conflict.Current),
+			)
+			halper.WritePreconditionFailed(w, conflict.Current)
+			return
+		}
+
+		if isLockedContent(err) {
+			logger.NewWarnMessage("Notebook content is locked",
+				zap.String("operation", "RestoreRevisionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		logger.NewErrMessage("Failed to restore revision",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Int64("revision", number),
+			zap.Error(err),
+		)
+		if errors.Is(err, revision.ErrNotFound) {
+			http.Error(w, "Revision not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 6. Формирование ответа
+	response := map[string]interface{}{
+		"status":        "success",
+		"message":       "Revision restored successfully",
+		"restored_from": number,
+		"revision":      newRevision,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(newRevision))
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "RestoreRevisionHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Revision restored successfully",
+		zap.String("operation", "RestoreRevisionHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookId.String()),
+		zap.Int64("restored_from", number),
+		zap.Int64("revision", newRevision),
+	)
+}
@@ -0,0 +1,184 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/search"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// SearchHandler ищет журналы и папки компании, доступные пользователю, с подсветкой совпадений
+func (j JournalHandler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "SearchHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "SearchHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "SearchHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "SearchHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "SearchHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 4. Поисковая строка, фильтры и пагинация
+	params := r.URL.Query()
+	query := search.Query{
+		Text: params.Get("q"),
+		Kind: params.Get("type"),
+		Tag:  params.Get("tag"),
+	}
+	if query.Kind != "" && query.Kind != search.KindNotebook && query.Kind != search.KindFolder {
+		http.Error(w, "Invalid type: expected notebook or folder", http.StatusBadRequest)
+		return
+	}
+	if v := params.Get("department_id"); v != "" {
+		if _, err := uuid.Parse(v); err != nil {
+			http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+			return
+		}
+		query.DivisionID = v
+	}
+	if v := params.Get("author"); v != "" {
+		if _, err := uuid.Parse(v); err != nil {
+			http.Error(w, "Invalid author ID format", http.StatusBadRequest)
+			return
+		}
+		query.Author = v
+	}
+	if query.From, err = parseSearchDate(params.Get("from"), false); err != nil {
+		http.Error(w, "Invalid from: expected RFC 3339 or YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if query.To, err = parseSearchDate(params.Get("to"), true); err != nil {
+		http.Error(w, "Invalid to: expected RFC 3339 or YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if v := params.Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil || query.Limit < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := params.Get("offset"); v != "" {
+		if query.Offset, err = strconv.Atoi(v); err != nil || query.Offset < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// 5. Поиск
+	result, err := fsl.Search.Search(currentEmployee, companyId, query)
+	if err != nil {
+		if errors.Is(err, search.ErrEmptyQuery) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if halper.WriteForbidden(w, err) {
+			return
+		}
+		logger.NewErrMessage("Search failed",
+			zap.String("operation", "SearchHandler"),
+			zap.String("company_id", companyId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 6. Формирование ответа
+	response := map[string]interface{}{
+		"status": "success",
+		"data":   result.Hits,
+		"total":  result.Total,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "SearchHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Search completed successfully",
+		zap.String("operation", "SearchHandler"),
+		zap.String("user_id", userID.String()),
+		zap.Int64("total", result.Total),
+	)
+}
+
+// parseSearchDate принимает RFC 3339 или дату YYYY-MM-DD; дата без времени как верхняя граница включает весь день
+func parseSearchDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
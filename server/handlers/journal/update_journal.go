@@ -2,8 +2,12 @@ package journal
 
 import (
 	"encoding/json"
+	"errors"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
 	"labyrinth/notebook/models/journal"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/server/handlers/internal/halper"
 	"net/http"
 	"strings"
 
@@ -47,6 +51,16 @@ func (j JournalHandler) UpdateNotebookHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "UpdateNotebookHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
 	notebookId, err := uuid.Parse(vars["notebook_id"])
 	if err != nil {
 		logger.NewWarnMessage("Invalid notebook ID",
@@ -58,6 +72,21 @@ func (j JournalHandler) UpdateNotebookHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	expectedRevision, err := halper.ParseIfMatch(r)
+	if err != nil {
+		logger.NewWarnMessage("Invalid If-Match header",
+			zap.String("operation", "UpdateNotebookHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+		if errors.Is(err, halper.ErrIfMatchRequired) {
+			http.Error(w, err.Error(), http.StatusPreconditionRequired)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var requestData journal.Notebook
 	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
 		logger.NewWarnMessage("Failed to decode request body",
@@ -78,7 +107,49 @@ func (j JournalHandler) UpdateNotebookHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if err := fsl.File.UpdateNotebook(notebookId, &requestData); err != nil {
+	newRevision, err := fsl.File.UpdateNotebook(notebookId, currentEmployee, &requestData, expectedRevision)
+	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Notebook access denied",
+				zap.String("operation", "UpdateNotebookHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
+		var conflict *revision.ConflictError
+		if errors.As(err, &conflict) {
+			logger.NewWarnMessage("Notebook revision conflict",
+				zap.String("operation", "UpdateNotebookHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Int64("expected_revision", expectedRevision),
+				zap.Int64("current_revision", conflict.Current),
+			)
+			halper.WritePreconditionFailed(w, conflict.Current)
+			return
+		}
+
+		if isInvalidBlock(err) {
+			logger.NewWarnMessage("Invalid block body",
+				zap.String("operation", "UpdateNotebookHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if isLockedContent(err) {
+			logger.NewWarnMessage("Notebook content is locked",
+				zap.String("operation", "UpdateNotebookHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
 		logger.NewErrMessage("Failed to update notebook",
 			zap.String("operation", "UpdateNotebookHandler"),
 			zap.String("notebook_id", notebookId.String()),
@@ -90,11 +161,13 @@ func (j JournalHandler) UpdateNotebookHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	response := map[string]interface{}{
-		"status":  "success",
-		"message": "Notebook updated successfully",
+		"status":   "success",
+		"message":  "Notebook updated successfully",
+		"revision": newRevision,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(newRevision))
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.NewErrMessage("Failed to encode response",
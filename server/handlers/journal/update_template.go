@@ -0,0 +1,167 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/notebook/models/template"
+	"labyrinth/server/handlers/internal/halper"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// UpdateTemplateHandler изменяет шаблон; требует If-Match с текущей ревизией
+func (j JournalHandler) UpdateTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	templateId, err := uuid.Parse(vars["template_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid template ID",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.String("variable", "template_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid template ID format", http.StatusBadRequest)
+		return
+	}
+
+	expectedRevision, err := halper.ParseIfMatch(r)
+	if err != nil {
+		logger.NewWarnMessage("Invalid If-Match header",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.String("template_id", templateId.String()),
+			zap.Error(err),
+		)
+		if errors.Is(err, halper.ErrIfMatchRequired) {
+			http.Error(w, err.Error(), http.StatusPreconditionRequired)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var requestData updateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	updated := template.Template{
+		Title:       requestData.Title,
+		Description: requestData.Description,
+		Tags:        requestData.Tags,
+		Blocks:      requestData.Blocks,
+	}
+
+	newRevision, err := fsl.Template.UpdateTemplate(templateId, currentEmployee, companyId, departmentId, &updated, expectedRevision)
+	if err != nil {
+		var conflict *revision.ConflictError
+		if errors.As(err, &conflict) {
+			halper.WritePreconditionFailed(w, conflict.Current)
+			return
+		}
+		status := templateErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			logger.NewErrMessage("Failed to update template",
+				zap.String("operation", "UpdateTemplateHandler"),
+				zap.String("template_id", templateId.String()),
+				zap.Error(err),
+			)
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(newRevision))
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "success",
+		"message":  "Template updated successfully",
+		"revision": newRevision,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "UpdateTemplateHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
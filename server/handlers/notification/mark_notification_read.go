@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"encoding/json"
+	"labyrinth/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// MarkNotificationReadHandler отмечает прочитанным одно уведомление
+func (n NotificationHandlers) MarkNotificationReadHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "MarkNotificationReadHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "MarkNotificationReadHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "MarkNotificationReadHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг notification_id из пути
+	notificationId, err := uuid.Parse(vars["notification_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid notification ID",
+			zap.String("operation", "MarkNotificationReadHandler"),
+			zap.String("variable", "notification_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid notification ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Отметка уведомлений
+	affected, err := bl.Notification.MarkRead(userID, []uuid.UUID{notificationId})
+	if err != nil {
+		logger.NewErrMessage("Failed to mark notifications read",
+			zap.String("operation", "MarkNotificationReadHandler"),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to mark notifications read", http.StatusInternalServerError)
+		return
+	}
+
+	// 6. Формирование ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "success",
+		"updated": affected,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "MarkNotificationReadHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"labyrinth/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// MarkNotificationsReadHandler отмечает прочитанными перечисленные уведомления или все, если список пуст
+func (n NotificationHandlers) MarkNotificationsReadHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "MarkNotificationsReadHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "MarkNotificationsReadHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "MarkNotificationsReadHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг тела запроса (тело необязательно)
+	var requestData markReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil && !errors.Is(err, io.EOF) {
+		logger.NewWarnMessage("Failed to decode request body",
+			zap.String("operation", "MarkNotificationsReadHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	ids := make([]uuid.UUID, 0, len(requestData.IDs))
+	for _, raw := range requestData.IDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			http.Error(w, "Invalid notification ID format", http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	// 5. Отметка уведомлений
+	affected, err := bl.Notification.MarkRead(userID, ids)
+	if err != nil {
+		logger.NewErrMessage("Failed to mark notifications read",
+			zap.String("operation", "MarkNotificationsReadHandler"),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to mark notifications read", http.StatusInternalServerError)
+		return
+	}
+
+	// 6. Формирование ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "success",
+		"updated": affected,
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "MarkNotificationsReadHandler"),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+}
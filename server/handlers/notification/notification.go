@@ -0,0 +1,19 @@
+package notification
+
+import (
+	"labyrinth/logic"
+)
+
+const (
+	userIDKey string = "id"
+)
+
+var bl *logic.BusinessLogic = logic.NewBusinessLogic()
+
+type NotificationHandlers struct{}
+
+func NewNotificationHandlers() NotificationHandlers { return NotificationHandlers{} }
+
+type markReadRequest struct {
+	IDs []string `json:"ids"` // пусто - отметить прочитанными все уведомления
+}
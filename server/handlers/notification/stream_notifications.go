@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"labyrinth/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+// NotificationStreamHandler переводит соединение в WebSocket и передает новые уведомления пользователя
+func (n NotificationHandlers) NotificationStreamHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "NotificationStreamHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг параметров пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "NotificationStreamHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "NotificationStreamHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Переход на WebSocket
+	server := websocket.Server{
+		// Аутентификация по cookie уже пройдена, поэтому принимаем только same-origin соединения
+		Handshake: func(cfg *websocket.Config, req *http.Request) error {
+			origin, err := websocket.Origin(cfg, req)
+			if err != nil || origin == nil || origin.Host != req.Host {
+				return fmt.Errorf("cross-origin websocket is not allowed")
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			streamCtx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := bl.Notification.Subscribe(streamCtx, userID)
+			if err != nil {
+				logger.NewErrMessage("Failed to subscribe to notifications",
+					zap.String("operation", "NotificationStreamHandler"),
+					zap.String("user_id", userID.String()),
+					zap.Error(err),
+				)
+				return
+			}
+
+			// Клиент ничего не присылает, чтение нужно только для обнаружения разрыва соединения
+			go func() {
+				defer cancel()
+				var discard string
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+			}()
+
+			for payload := range stream {
+				if err := websocket.Message.Send(ws, string(payload)); err != nil {
+					return
+				}
+			}
+		},
+	}
+
+	logger.NewInfoMessage("Notification stream requested",
+		zap.String("operation", "NotificationStreamHandler"),
+		zap.String("user_id", userID.String()),
+	)
+
+	server.ServeHTTP(w, r)
+}
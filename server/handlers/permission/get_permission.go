@@ -3,6 +3,8 @@ package permission
 import (
 	"encoding/json"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"labyrinth/server/handlers/internal/halper"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -45,6 +47,16 @@ func (p PermissionHandlers) GetPermissionHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "GetPermissionHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
 	notebookId, err := uuid.Parse(vars["notebook_id"])
 	if err != nil {
 		logger.NewWarnMessage("Invalid notebook ID",
@@ -56,8 +68,17 @@ func (p PermissionHandlers) GetPermissionHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	fetchedPermission, err := fsl.Permission.GetPermission(notebookId)
+	fetchedPermission, err := fsl.Permission.GetPermission(notebookId, currentEmployee)
 	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Permission access denied",
+				zap.String("operation", "GetPermissionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
 		logger.NewErrMessage("Failed to get permission",
 			zap.String("operation", "GetPermissionHandler"),
 			zap.String("notebook_id", notebookId.String()),
@@ -74,6 +95,7 @@ func (p PermissionHandlers) GetPermissionHandler(w http.ResponseWriter, r *http.
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(fetchedPermission.Revision))
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.NewErrMessage("Failed to encode response",
@@ -5,7 +5,8 @@ import (
 )
 
 const (
-	userIDKey string = "id"
+	userIDKey   string = "id"
+	employeeKey string = "employee"
 )
 
 var fsl *notebookLogic.FileSystem = notebookLogic.NewFileSystem()
@@ -2,8 +2,12 @@ package permission
 
 import (
 	"encoding/json"
+	"errors"
 	"labyrinth/logger"
+	"labyrinth/models/employee"
 	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/revision"
+	"labyrinth/server/handlers/internal/halper"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -46,6 +50,16 @@ func (p PermissionHandlers) UpdatePermissionHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	currentEmployee, ok := ctx.Value(employeeKey).(*employee.Employee)
+	if !ok || currentEmployee == nil {
+		logger.NewErrMessage("Invalid employee in context",
+			zap.String("operation", "UpdatePermissionHandler"),
+			zap.String("user_id", userID.String()),
+		)
+		http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+		return
+	}
+
 	notebookId, err := uuid.Parse(vars["notebook_id"])
 	if err != nil {
 		logger.NewWarnMessage("Invalid notebook ID",
@@ -57,6 +71,21 @@ func (p PermissionHandlers) UpdatePermissionHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	expectedRevision, err := halper.ParseIfMatch(r)
+	if err != nil {
+		logger.NewWarnMessage("Invalid If-Match header",
+			zap.String("operation", "UpdatePermissionHandler"),
+			zap.String("notebook_id", notebookId.String()),
+			zap.Error(err),
+		)
+		if errors.Is(err, halper.ErrIfMatchRequired) {
+			http.Error(w, err.Error(), http.StatusPreconditionRequired)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var requestData permission.Permission
 	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
 		logger.NewWarnMessage("Failed to decode request body",
@@ -67,8 +96,39 @@ func (p PermissionHandlers) UpdatePermissionHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
-	err = fsl.Permission.UpdatePermission(notebookId, &requestData)
+	newRevision, err := fsl.Permission.UpdatePermission(notebookId, currentEmployee, &requestData, expectedRevision)
 	if err != nil {
+		if halper.WriteForbidden(w, err) {
+			logger.NewWarnMessage("Permission access denied",
+				zap.String("operation", "UpdatePermissionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			return
+		}
+
+		var conflict *revision.ConflictError
+		if errors.As(err, &conflict) {
+			logger.NewWarnMessage("Permission revision conflict",
+				zap.String("operation", "UpdatePermissionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Int64("expected_revision", expectedRevision),
+				zap.Int64("current_revision", conflict.Current),
+			)
+			halper.WritePreconditionFailed(w, conflict.Current)
+			return
+		}
+
+		if errors.Is(err, permission.ErrInvalidAccessLevel) {
+			logger.NewWarnMessage("Invalid access level",
+				zap.String("operation", "UpdatePermissionHandler"),
+				zap.String("notebook_id", notebookId.String()),
+				zap.Error(err),
+			)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		logger.NewErrMessage("Failed to update permission",
 			zap.String("operation", "UpdatePermissionHandler"),
 			zap.String("notebook_id", notebookId.String()),
@@ -80,11 +140,13 @@ func (p PermissionHandlers) UpdatePermissionHandler(w http.ResponseWriter, r *ht
 	}
 
 	response := map[string]interface{}{
-		"status":  "success",
-		"message": "Notebook permission updated successfully",
+		"status":   "success",
+		"message":  "Notebook permission updated successfully",
+		"revision": newRevision,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", halper.ETag(newRevision))
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		logger.NewErrMessage("Failed to encode response",
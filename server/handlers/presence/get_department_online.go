@@ -0,0 +1,128 @@
+package presence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"labyrinth/logger"
+	"labyrinth/models/employee"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func (p PresenceHandlers) GetDepartmentOnlineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 1. Проверка аутентификации пользователя
+	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		logger.NewErrMessage("Invalid user ID in context",
+			zap.String("operation", "GetDepartmentOnlineHandler"),
+			zap.Any("context_values", ctx.Value(userIDKey)),
+		)
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// 2. Парсинг user_id из пути
+	vars := mux.Vars(r)
+	userPathId, err := uuid.Parse(vars["user_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid path variable",
+			zap.String("operation", "GetDepartmentOnlineHandler"),
+			zap.String("variable", "user_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid user ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 3. Проверка соответствия user_id в пути и в контексте
+	if userPathId != userID {
+		logger.NewWarnMessage("User ID mismatch",
+			zap.String("operation", "GetDepartmentOnlineHandler"),
+			zap.String("context_user_id", userID.String()),
+			zap.String("path_user_id", userPathId.String()),
+		)
+		http.Error(w, "Forbidden: user ID mismatch", http.StatusForbidden)
+		return
+	}
+
+	// 4. Парсинг company_id и department_id из пути
+	companyId, err := uuid.Parse(vars["company_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid company ID format",
+			zap.String("operation", "GetDepartmentOnlineHandler"),
+			zap.String("variable", "company_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid company ID format", http.StatusBadRequest)
+		return
+	}
+
+	departmentId, err := uuid.Parse(vars["department_id"])
+	if err != nil {
+		logger.NewWarnMessage("Invalid department ID format",
+			zap.String("operation", "GetDepartmentOnlineHandler"),
+			zap.String("variable", "department_id"),
+			zap.Error(err),
+		)
+		http.Error(w, "Invalid department ID format", http.StatusBadRequest)
+		return
+	}
+
+	// 5. Получение сотрудников департамента в сети (только для активных сотрудников этого департамента)
+	online, err := bl.Presence.GetDepartmentOnline(userID, companyId, departmentId)
+	if err != nil {
+		if errors.Is(err, employee.ErrNotDepartmentMember) {
+			logger.NewWarnMessage("Department presence access denied",
+				zap.String("operation", "GetDepartmentOnlineHandler"),
+				zap.String("user_id", userID.String()),
+				zap.String("department_id", departmentId.String()),
+			)
+			http.Error(w, "Forbidden: not a member of the department", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.NewWarnMessage("Department not found",
+				zap.String("operation", "GetDepartmentOnlineHandler"),
+				zap.String("department_id", departmentId.String()),
+			)
+			http.Error(w, "Department not found", http.StatusNotFound)
+			return
+		}
+		logger.NewErrMessage("Failed to get department presence",
+			zap.String("operation", "GetDepartmentOnlineHandler"),
+			zap.String("department_id", departmentId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to get department presence", http.StatusInternalServerError)
+		return
+	}
+
+	// 6. Отправка ответа
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"result":    "success",
+		"employees": online,
+		"count":     len(*online),
+	}); err != nil {
+		logger.NewErrMessage("Failed to encode response",
+			zap.String("operation", "GetDepartmentOnlineHandler"),
+			zap.String("department_id", departmentId.String()),
+			zap.Error(err),
+		)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	logger.NewInfoMessage("Department presence retrieved successfully",
+		zap.String("operation", "GetDepartmentOnlineHandler"),
+		zap.String("user_id", userID.String()),
+		zap.String("department_id", departmentId.String()),
+	)
+}
@@ -0,0 +1,15 @@
+package presence
+
+import (
+	"labyrinth/logic"
+)
+
+const (
+	userIDKey string = "id"
+)
+
+var bl *logic.BusinessLogic = logic.NewBusinessLogic()
+
+type PresenceHandlers struct{}
+
+func NewPresenceHandlers() PresenceHandlers { return PresenceHandlers{} }
@@ -0,0 +1,44 @@
+package tag
+
+import (
+	"errors"
+	notebookLogic "labyrinth/notebook/logic"
+	"labyrinth/notebook/models/permission"
+	"labyrinth/notebook/models/tag"
+	"net/http"
+)
+
+const (
+	userIDKey   string = "id"
+	employeeKey string = "employee"
+)
+
+var fsl *notebookLogic.FileSystem = notebookLogic.NewFileSystem()
+
+type TagHandlers struct{}
+
+func NewTagHandlers() TagHandlers { return TagHandlers{} }
+
+type tagRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type mergeTagsRequest struct {
+	TargetID string `json:"target_id"`
+}
+
+// tagErrorStatus сопоставляет ошибки логики тегов с HTTP-статусами
+func tagErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, permission.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, tag.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, tag.ErrDuplicate):
+		return http.StatusConflict
+	case errors.Is(err, tag.ErrInvalidName), errors.Is(err, tag.ErrInvalidColor), errors.Is(err, tag.ErrSelfMerge):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -1,64 +1,142 @@
 package middleware
 
-// import (
-// 	"context"
-// 	"errors"
-// 	"labyrinth/entity/employee"
-// 	ownJwt "labyrinth/jwt"
-// 	"net/http"
-
-// 	"github.com/golang-jwt/jwt/v5"
-// 	"github.com/google/uuid"
-// )
-
-// func AuthMiddlewareEmployee(next http.HandlerFunc) http.HandlerFunc {
-// 	return func(w http.ResponseWriter, r *http.Request) {
-// 		cookie, err := r.Cookie("jwt_token_employee")
-// 		if err != nil {
-// 			http.Error(w, "Missing or invalid JWT cookie", http.StatusUnauthorized)
-// 			return
-// 		}
-
-// 		claims, err := ownJwt.VerifyToken(cookie.Value)
-// 		if err != nil {
-// 			http.Error(w, "Invalid or expired JWT token", http.StatusUnauthorized)
-// 			return
-// 		}
-// 		var currentEmployee employee.Employee
-// 		err = getEmployeeDataFromCookie(claims, &currentEmployee)
-// 		if err != nil {
-// 			http.Error(w, err.Error(), http.StatusBadRequest)
-// 			return
-// 		}
-
-// 		ctx := context.WithValue(r.Context(), "currentEmployee", currentEmployee)
-
-// 		next.ServeHTTP(w, r.WithContext(ctx))
-// 	}
-// }
-
-// func getEmployeeDataFromCookie(claims jwt.MapClaims, employeeData *employee.Employee) error {
-// 	employeeId, ok := claims["id"].(uuid.UUID)
-// 	if !ok {
-// 		return errors.New("invalid user ID in token")
-// 	}
-// 	userId, ok := claims["userId"].(uuid.UUID)
-// 	if !ok {
-// 		return errors.New("invalid userId in token")
-// 	}
-// 	companyId, ok := claims["companyId"].(uuid.UUID)
-// 	if !ok {
-// 		return errors.New("invalid companyId in token")
-// 	}
-// 	positionId, ok := claims["positionId"].(uuid.UUID)
-// 	if !ok {
-// 		return errors.New("invalid positionId in token")
-// 	}
-
-// 	employeeData.Id = employeeId
-// 	employeeData.UserId = userId
-// 	employeeData.CompanyId = companyId
-// 	employeeData.PositionId = positionId
-
-// 	return nil
-// }
+import (
+	"context"
+	"errors"
+	"fmt"
+	"labyrinth/logger"
+	"labyrinth/logic"
+	"net/http"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	employeeKey string = "employee"
+
+	// EmployeeCookieName - cookie с токеном активной компании
+	EmployeeCookieName string = "labyrinth_employee"
+	// EmployeeTokenScope - значение claim "scope" в токене активной компании
+	EmployeeTokenScope string = "employee"
+)
+
+// EmployeeMiddleware проверяет токен активной компании и кладет в контекст
+// актуальные данные сотрудника. Должен вызываться после AuthMiddleware.
+func EmployeeMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+		if !ok || userID == uuid.Nil {
+			logger.NewWarnMessage("Missing user in context for employee middleware")
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		cookie, err := r.Cookie(EmployeeCookieName)
+		if err != nil || cookie.Value == "" {
+			logger.NewWarnMessage("Missing active company cookie",
+				zap.String("user_id", userID.String()),
+			)
+			http.Error(w, "Active company is not selected", http.StatusUnauthorized)
+			return
+		}
+
+		bl := logic.NewBusinessLogic()
+		claims, err := bl.Jwt.VerifyToken(cookie.Value)
+		if err != nil {
+			logger.NewWarnMessage("Invalid active company token",
+				zap.Error(err),
+			)
+			http.Error(w, "Invalid or expired active company token", http.StatusUnauthorized)
+			return
+		}
+
+		employeeID, companyID, positionID, err := parseEmployeeClaims(claims, userID)
+		if err != nil {
+			logger.NewWarnMessage("Invalid active company claims",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+			)
+			http.Error(w, "Invalid active company token", http.StatusUnauthorized)
+			return
+		}
+
+		// company_id в пути (если есть) должен совпадать с активной компанией
+		if pathCompany, ok := mux.Vars(r)["company_id"]; ok && pathCompany != companyID.String() {
+			logger.NewWarnMessage("Company ID mismatch with active company",
+				zap.String("path_company_id", pathCompany),
+				zap.String("active_company_id", companyID.String()),
+			)
+			http.Error(w, "Forbidden: company is not active", http.StatusForbidden)
+			return
+		}
+
+		currentEmployee, err := bl.Employee.GetEmployee(userID, companyID)
+		if err != nil {
+			logger.NewWarnMessage("Failed to load active employee",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("company_id", companyID.String()),
+			)
+			http.Error(w, "Forbidden: employee not found", http.StatusForbidden)
+			return
+		}
+
+		if !currentEmployee.IsActive || currentEmployee.ID != employeeID {
+			logger.NewWarnMessage("Active employee is no longer valid",
+				zap.String("employee_id", employeeID.String()),
+				zap.Bool("is_active", currentEmployee.IsActive),
+			)
+			http.Error(w, "Forbidden: employee is not active", http.StatusForbidden)
+			return
+		}
+
+		// токен выпущен до смены должности - требуется повторный выбор компании
+		if currentEmployee.PositionID != positionID {
+			logger.NewWarnMessage("Stale position in active company token",
+				zap.String("employee_id", employeeID.String()),
+				zap.String("token_position_id", positionID.String()),
+				zap.String("current_position_id", currentEmployee.PositionID.String()),
+			)
+			http.Error(w, "Active company token is outdated", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), employeeKey, currentEmployee)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
+
+// CompanyMiddleware - цепочка для подмаршрутизатора компании (/labyrinth/user/{user_id}/company/{company_id}/...):
+// AuthMiddleware, затем EmployeeMiddleware. Обработчики берут сотрудника из контекста по ключу "employee".
+func CompanyMiddleware(next http.Handler) http.Handler {
+	return AuthMiddleware(EmployeeMiddleware(next.ServeHTTP))
+}
+
+func parseEmployeeClaims(claims jwt.MapClaims, userID uuid.UUID) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	if scope, _ := claims["scope"].(string); scope != EmployeeTokenScope {
+		return uuid.Nil, uuid.Nil, uuid.Nil, errors.New("token is not an active company token")
+	}
+
+	ids := make(map[string]uuid.UUID, 4)
+	for _, key := range []string{"id", "user_id", "company_id", "position_id"} {
+		raw, ok := claims[key].(string)
+		if !ok {
+			return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("missing %s in token", key)
+		}
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid %s in token: %w", key, err)
+		}
+		ids[key] = parsed
+	}
+
+	if ids["user_id"] != userID {
+		return uuid.Nil, uuid.Nil, uuid.Nil, errors.New("token belongs to another user")
+	}
+
+	return ids["id"], ids["company_id"], ids["position_id"], nil
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"labyrinth/config"
+	"labyrinth/logger"
+	"labyrinth/logic"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// presenceTracker ограничивает частоту отметок присутствия: каждая пара пользователь/компания
+// (то есть сотрудник) отмечается не чаще config.Conf.Presence.TouchInterval
+type presenceTracker struct {
+	bl     *logic.BusinessLogic
+	mu     sync.Mutex
+	last   map[string]time.Time
+	pruned time.Time
+}
+
+var presence = &presenceTracker{
+	bl:   logic.NewBusinessLogic(),
+	last: make(map[string]time.Time),
+}
+
+// allow резервирует отметку присутствия для key, если с прошлой прошло не меньше interval.
+// Раз в interval устаревшие записи вычищаются, чтобы карта не росла вместе с числом когда-либо заходивших сотрудников.
+func (p *presenceTracker) allow(key string, now time.Time, interval time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if at, ok := p.last[key]; ok && now.Sub(at) < interval {
+		return false
+	}
+	if now.Sub(p.pruned) >= interval {
+		for k, at := range p.last {
+			if now.Sub(at) >= interval {
+				delete(p.last, k)
+			}
+		}
+		p.pruned = now
+	}
+	p.last[key] = now
+
+	return true
+}
+
+// PresenceMiddleware отмечает активность сотрудника компании из пути запроса.
+// Должен вызываться после AuthMiddleware, ошибки не прерывают обработку запроса.
+func PresenceMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := r.Context().Value(userIDKey).(uuid.UUID)
+		companyID, err := uuid.Parse(mux.Vars(r)["company_id"])
+		if ok && userID != uuid.Nil && err == nil &&
+			presence.allow(companyID.String()+":"+userID.String(), time.Now(), config.Conf.Presence.TouchInterval) {
+			go func() {
+				if _, err := presence.bl.Presence.TouchPresence(userID, companyID); err != nil {
+					logger.NewWarnMessage("Failed to track presence",
+						zap.Error(err),
+						zap.String("user_id", userID.String()),
+						zap.String("company_id", companyID.String()),
+					)
+				}
+			}()
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
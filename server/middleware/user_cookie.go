@@ -42,6 +42,13 @@ func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// токен активной компании не является пользовательским
+		if scope, _ := claims["scope"].(string); scope == EmployeeTokenScope {
+			logger.NewWarnMessage("Active company token used as user token")
+			http.Error(w, "Invalid authentication token", http.StatusUnauthorized)
+			return
+		}
+
 		userID, ok := claims["id"].(string)
 		if !ok {
 			logger.NewWarnMessage("Invalid user ID type in token",
@@ -17,28 +17,88 @@ labyrinth/
 │
 ├── ping # GET
 │
+├── company/active # GET (по токену активной компании)
+│
+├── notebook/block-types # GET
 │
 └── user/ # POST
     ├── {user_id}/ # GET, POST, DELETE
-    │   │  └── profile # GET, POST, DELETE
+    │   │  ├── profile # GET, POST, DELETE
+    │   │  └── notifications/ # GET
+    │   │      ├── read # POST
+    │   │      ├── ws # GET (WebSocket)
+    │   │      └── {notification_id}/read # POST
     │   │
     │   └── company/ # GET, POST
-    │       └──  {company_id}/ # GET
+    │       └──  {company_id}/ # GET (здесь и ниже, кроме select, - по токену активной компании)
 	│				   ├── profile # GET, POST,  DELETE
+	│				   ├── select  # POST
 	│				   ├── invite  # GET, POST
+	│				   ├── presence/heartbeat  # POST
+	│				   ├── directory  # GET
+	│				   ├── search  # GET (?q=&type=&department_id=&author=&from=&to=&tag=)
+	│				   ├── reviews  # GET (журналы, ожидающие моей проверки)
+	│				   ├── workflow  # GET, POST (процесс согласования журналов)
+	│				   ├── trash/{item_id}  # DELETE (окончательно, только администратор)
+	│				   │ 	 └── restore  # POST (на прежнее место или на верхний уровень отдела)
+	│				   ├── tag/  # GET (со счетчиками), POST
+	│				   │ 	 ├── suggest  # GET (?q=&limit=)
+	│				   │ 	 └── {tag_id}  # POST, DELETE
+	│				   │ 	         ├── merge  # POST
+	│				   │ 	         └── notebooks  # GET (?limit=&offset=)
 	│				   ├──	employee/  # GET, POST
 	│				   │ 		└── {employee_id}   # GET, POST, DELETE
+	│				   │ 		        └── department/history # GET
 	│				   │
     │                  ├── department/ # GET, POST
     │                  │   └── {department_id} # GET, POST, DELETE
 	│								 ├── profile # GET, POST
+	│								 ├── online # GET
+	│								 ├── trash # GET (корзина отдела, ?limit=&offset=)
     │                  │             │
 	│                  │             └── depemployee/ # GET, POST
+	│		           │                      ├── transfer # POST
+	│		           │                      ├── history # GET
 	│		           │                      └──{depemployee_id} # GET, POST, PUT, DELETE
+	│		           │
+	│		           ├── folder/ # GET (дерево отдела, ?depth=), POST
+	│		           │     └── {folder_id} # GET, POST (переименование, If-Match), DELETE (в корзину)
+	│		           │           ├── children # GET (вложенные папки и журналы)
+	│		           │           ├── tree # GET (?depth=)
+	│		           │           ├── move # POST (в другую папку или на верхний уровень отдела)
+	│		           │           ├── copy # POST (копия со всем содержимым)
+	│		           │           └── tags/{tag_id} # POST, DELETE
+	│		           │
+	│		           ├── template/ # GET, POST
+	│		           │     └── {template_id} # GET, POST, DELETE
+	│		           │           └── notebook # POST (журнал из шаблона)
 	│			       │
 	│			       │
     │                  └── notebook/ # GET, POST
-    │                      └── {notebook_id} # GET, POST, DELETE
+    │                      ├── import # POST (Markdown, DOCX, Jupyter)
+    │                      └── {notebook_id} # GET, POST, DELETE (в корзину)
+    │                          ├── collab # GET (WebSocket)
+    │                          ├── export # GET (?format=pdf|html|md)
+    │                          ├── tags/{tag_id} # POST, DELETE
+    │                          ├── status # POST (переход: submit, approve, return, archive)
+    │                          ├── move # POST (в другую папку отдела)
+    │                          ├── copy # POST (копия с правами и вложениями)
+    │                          ├── sign # POST (подпись автора, пароль)
+    │                          ├── signatures/{signature_id}/witness # POST (подпись свидетеля)
+    │                          ├── permission # GET, POST (If-Match; для журнала или папки по ее ID)
+    │                          │   └── effective # GET (действующие права с учетом папок и их источники)
+    │                          ├── block/ # POST
+    │                          │   └── {block_id} # POST, DELETE
+    │                          │       ├── move # POST
+    │                          │       └── comment/ # POST
+    │                          │           └── {comment_id} # POST, DELETE
+    │                          │               ├── reply # POST
+    │                          │               └── resolve # POST
+    │                          └── revisions/ # GET
+    │                              ├── diff # GET
+    │                              ├── verify # GET (проверка цепочки хешей)
+    │                              └── {revision} # GET
+    │                                  └── restore # POST
     │
     └── тут будет онбординг ?
 
@@ -61,18 +121,34 @@ func NewRouter() *mux.Router {
 	r.HandleFunc("/labyrinth/user/{user_id}/profile", middleware.AuthMiddleware(manager.UserProfile.UpdateUserProfileHandler)).Methods("POST")
 	// r.HandleFunc("labyrinth/user/{user_id}/profile", user.DeleteUserProfileHandler).Methods("DLETE")
 
+	// уведомления пользователя
+	r.HandleFunc("/labyrinth/user/{user_id}/notifications", middleware.AuthMiddleware(manager.Notification.ListNotificationsHandler)).Methods("GET")
+	r.HandleFunc("/labyrinth/user/{user_id}/notifications/read", middleware.AuthMiddleware(manager.Notification.MarkNotificationsReadHandler)).Methods("POST")
+	r.HandleFunc("/labyrinth/user/{user_id}/notifications/ws", middleware.AuthMiddleware(manager.Notification.NotificationStreamHandler)).Methods("GET")
+	r.HandleFunc("/labyrinth/user/{user_id}/notifications/{notification_id}/read", middleware.AuthMiddleware(manager.Notification.MarkNotificationReadHandler)).Methods("POST")
+
 	// работа с компанией
 	r.HandleFunc("/labyrinth/user/{user_id}/company", middleware.AuthMiddleware(manager.Company.NewCompanyHandler)).Methods("POST")
 	r.HandleFunc("/labyrinth/user/{user_id}/company", middleware.AuthMiddleware(manager.Company.GetAllCompaniesHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}", middleware.AuthMiddleware(manager.Company.GetCompanyHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/profile", middleware.AuthMiddleware(manager.Company.GetCompanyProfileHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/profile", middleware.AuthMiddleware(manager.Company.UpdateCompanyProfileHandler)).Methods("POST")
+	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/select", middleware.AuthMiddleware(manager.Company.SelectCompanyHandler)).Methods("POST")
+
+	// маршруты компании работают в контексте сотрудника из токена активной компании:
+	// аутентификация и проверка сотрудника выполняются один раз для всего подмаршрутизатора
+	company := r.PathPrefix("/labyrinth/user/{user_id}/company/{company_id}").Subrouter()
+	company.Use(middleware.CompanyMiddleware)
+
+	company.HandleFunc("", middleware.PresenceMiddleware(manager.Company.GetCompanyHandler)).Methods("GET")
+	company.HandleFunc("/profile", middleware.PresenceMiddleware(manager.Company.GetCompanyProfileHandler)).Methods("GET")
+	company.HandleFunc("/profile", middleware.PresenceMiddleware(manager.Company.UpdateCompanyProfileHandler)).Methods("POST")
+
+	// работа с активной компанией (контекст сотрудника из токена активной компании)
+	r.HandleFunc("/labyrinth/company/active", middleware.AuthMiddleware(middleware.EmployeeMiddleware(manager.Company.GetActiveCompanyHandler))).Methods("GET")
 	// r.HandleFunc("labyrinth/user/{user_id}/company/{company_id}/profile", company.DeletCompanyProfileHandler).Methods("DELETE")
 
 	// работа с позициями
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/position", middleware.AuthMiddleware(manager.Position.GetAllPositionHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/position", middleware.AuthMiddleware(manager.Position.NewPositionHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/position/{position_id}", middleware.AuthMiddleware(manager.Position.UpdatePositionHandler)).Methods("POST")
+	company.HandleFunc("/position", middleware.PresenceMiddleware(manager.Position.GetAllPositionHandler)).Methods("GET")
+	company.HandleFunc("/position", middleware.PresenceMiddleware(manager.Position.NewPositionHandler)).Methods("POST")
+	company.HandleFunc("/position/{position_id}", middleware.PresenceMiddleware(manager.Position.UpdatePositionHandler)).Methods("POST")
 
 	// работа с инвайтами
 	// r.HandleFunc("labyrinth/user/{user_id}/compnay/{company_id}/invite", handler).Methods("GET")
@@ -80,37 +156,136 @@ func NewRouter() *mux.Router {
 	// r.HandleFunc("labyrinth/user/{user_id}/compnay/{company_id}/invite/{invite_id}", handler).Methods("DELETE")
 
 	// работа с работниками
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/employee", middleware.AuthMiddleware(manager.Employee.GetAllEmployeeHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/employee", middleware.AuthMiddleware(manager.Employee.NewEmployeeHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/employee/{employee_id}", middleware.AuthMiddleware(manager.Employee.UpdateEmployeeHandler)).Methods("POST")
+	company.HandleFunc("/employee", middleware.PresenceMiddleware(manager.Employee.GetAllEmployeeHandler)).Methods("GET")
+	company.HandleFunc("/employee", middleware.PresenceMiddleware(manager.Employee.NewEmployeeHandler)).Methods("POST")
+	company.HandleFunc("/directory", middleware.PresenceMiddleware(manager.Employee.GetDirectoryHandler)).Methods("GET")
+	company.HandleFunc("/employee/{employee_id}", middleware.PresenceMiddleware(manager.Employee.UpdateEmployeeHandler)).Methods("POST")
+	company.HandleFunc("/employee/{employee_id}/department/history", middleware.PresenceMiddleware(manager.DepartmentEmployee.GetDepEmployeeHistoryHandler)).Methods("GET")
 	// r.HandleFunc("labyrinth/user/{user_id}/company/{company_id}/employee/{employee_id}", employee.DeleteEmployeeHandler).Methods("DELETE")
 
 	// работа с департаментами
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department", middleware.AuthMiddleware(manager.Department.NewDepartmentHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}", middleware.AuthMiddleware(manager.Department.GetDepartmentHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}", middleware.AuthMiddleware(manager.Department.UpdateDepartmentHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/profile", middleware.AuthMiddleware(manager.Department.GetDepartmentProfileHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/profile", middleware.AuthMiddleware(manager.Department.UpdateDepartmentProfileHandler)).Methods("POST")
+	company.HandleFunc("/department", middleware.PresenceMiddleware(manager.Department.NewDepartmentHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}", middleware.PresenceMiddleware(manager.Department.GetDepartmentHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}", middleware.PresenceMiddleware(manager.Department.UpdateDepartmentHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/profile", middleware.PresenceMiddleware(manager.Department.GetDepartmentProfileHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/profile", middleware.PresenceMiddleware(manager.Department.UpdateDepartmentProfileHandler)).Methods("POST")
 	// r.HandleFunc("labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/profile", department.DeleteDepartmentProfileHandler).Methods("DELETE")
 
 	// работа с работниками департаментов
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/depemployee", middleware.AuthMiddleware(manager.DepartmentEmployee.GetAllDepEmployeeHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/depemployee", middleware.AuthMiddleware(manager.DepartmentEmployee.NewDepEmployeeHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/depemployee/{depemployee_id}", middleware.AuthMiddleware(manager.DepartmentEmployee.UpdateDepEmployeeHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/depemployee", middleware.PresenceMiddleware(manager.DepartmentEmployee.GetAllDepEmployeeHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/depemployee", middleware.PresenceMiddleware(manager.DepartmentEmployee.NewDepEmployeeHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/depemployee/transfer", middleware.PresenceMiddleware(manager.DepartmentEmployee.TransferDepEmployeeHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/depemployee/history", middleware.PresenceMiddleware(manager.DepartmentEmployee.GetDepEmployeesAtHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/depemployee/{depemployee_id}", middleware.PresenceMiddleware(manager.DepartmentEmployee.UpdateDepEmployeeHandler)).Methods("POST")
 	// r.HandleFunc("labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/depemployee/{depemployee_id}", depemployee.DeleteDepEmployeeHandler).Methods("DELETE")
 
 	// работа с позициями работников департаментов
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/depposition", middleware.AuthMiddleware(manager.DepartmentEmployeePosition.GetAllDepPositionHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/depposition", middleware.AuthMiddleware(manager.DepartmentEmployeePosition.NewDepPositionHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/depposition/{depposition_id}", middleware.AuthMiddleware(manager.DepartmentEmployeePosition.UpdateDepPositionHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/depposition", middleware.PresenceMiddleware(manager.DepartmentEmployeePosition.GetAllDepPositionHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/depposition", middleware.PresenceMiddleware(manager.DepartmentEmployeePosition.NewDepPositionHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/depposition/{depposition_id}", middleware.PresenceMiddleware(manager.DepartmentEmployeePosition.UpdateDepPositionHandler)).Methods("POST")
+
+	// присутствие сотрудников в сети
+	company.HandleFunc("/presence/heartbeat", manager.Presence.HeartbeatHandler).Methods("POST")
+	company.HandleFunc("/department/{department_id}/online", middleware.PresenceMiddleware(manager.Presence.GetDepartmentOnlineHandler)).Methods("GET")
 
 	// работа с лабораторными  журналами
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/notebook", middleware.AuthMiddleware(manager.Notebook.NewNotebookHandler)).Methods("POST")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/notebook/{notebook_id}", middleware.AuthMiddleware(manager.Notebook.GetNotebookHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/notebook/{notebook_id}", middleware.AuthMiddleware(manager.Notebook.UpdateNotebookHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook", middleware.PresenceMiddleware(manager.Notebook.NewNotebookHandler)).Methods("POST")
+	// импорт регистрируется раньше {notebook_id}, иначе путь перехватит UpdateNotebookHandler
+	company.HandleFunc("/department/{department_id}/notebook/import", middleware.PresenceMiddleware(manager.Notebook.ImportNotebookHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}", middleware.PresenceMiddleware(manager.Notebook.GetNotebookHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}", middleware.PresenceMiddleware(manager.Notebook.UpdateNotebookHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}", middleware.PresenceMiddleware(manager.Notebook.DeleteNotebookHandler)).Methods("DELETE")
+
+	// реестр типов блоков журнала
+	r.HandleFunc("/labyrinth/notebook/block-types", middleware.AuthMiddleware(manager.Notebook.GetBlockTypesHandler)).Methods("GET")
+
+	// работа с блоками журнала
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block", middleware.PresenceMiddleware(manager.Notebook.InsertBlockHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}", middleware.PresenceMiddleware(manager.Notebook.UpdateBlockHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}/move", middleware.PresenceMiddleware(manager.Notebook.MoveBlockHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}", middleware.PresenceMiddleware(manager.Notebook.DeleteBlockHandler)).Methods("DELETE")
+
+	// комментарии к блокам журнала
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}/comment", middleware.PresenceMiddleware(manager.Notebook.AddCommentHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}/comment/{comment_id}", middleware.PresenceMiddleware(manager.Notebook.UpdateCommentHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}/comment/{comment_id}", middleware.PresenceMiddleware(manager.Notebook.DeleteCommentHandler)).Methods("DELETE")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}/comment/{comment_id}/reply", middleware.PresenceMiddleware(manager.Notebook.ReplyCommentHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/block/{block_id}/comment/{comment_id}/resolve", middleware.PresenceMiddleware(manager.Notebook.ResolveCommentHandler)).Methods("POST")
+
+	// совместное редактирование журнала по WebSocket
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/collab", middleware.PresenceMiddleware(manager.Notebook.CollaborateHandler)).Methods("GET")
+
+	// экспорт журнала в PDF, HTML и Markdown
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/export", middleware.PresenceMiddleware(manager.Notebook.ExportNotebookHandler)).Methods("GET")
+
+	// статус журнала в процессе согласования
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/status", middleware.PresenceMiddleware(manager.Notebook.ChangeStatusHandler)).Methods("POST")
+
+	// перенос и копирование журнала
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/move", middleware.PresenceMiddleware(manager.Notebook.MoveNotebookHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/copy", middleware.PresenceMiddleware(manager.Notebook.CopyNotebookHandler)).Methods("POST")
+
+	// электронные подписи журнала
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/sign", middleware.PresenceMiddleware(manager.Notebook.SignNotebookHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/signatures/{signature_id}/witness", middleware.PresenceMiddleware(manager.Notebook.WitnessSignatureHandler)).Methods("POST")
+
+	// история ревизий журнала
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/revisions", middleware.PresenceMiddleware(manager.Notebook.ListRevisionsHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/revisions/diff", middleware.PresenceMiddleware(manager.Notebook.DiffRevisionsHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/revisions/verify", middleware.PresenceMiddleware(manager.Notebook.VerifyRevisionsHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/revisions/{revision}", middleware.PresenceMiddleware(manager.Notebook.GetRevisionHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/revisions/{revision}/restore", middleware.PresenceMiddleware(manager.Notebook.RestoreRevisionHandler)).Methods("POST")
+
+	// шаблоны журналов отдела
+	company.HandleFunc("/department/{department_id}/template", middleware.PresenceMiddleware(manager.Notebook.ListTemplatesHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/template", middleware.PresenceMiddleware(manager.Notebook.SaveTemplateHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/template/{template_id}", middleware.PresenceMiddleware(manager.Notebook.GetTemplateHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/template/{template_id}", middleware.PresenceMiddleware(manager.Notebook.UpdateTemplateHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/template/{template_id}", middleware.PresenceMiddleware(manager.Notebook.DeleteTemplateHandler)).Methods("DELETE")
+	company.HandleFunc("/department/{department_id}/template/{template_id}/notebook", middleware.PresenceMiddleware(manager.Notebook.NewNotebookFromTemplateHandler)).Methods("POST")
+
+	// полнотекстовый поиск по журналам и папкам компании
+	company.HandleFunc("/search", middleware.PresenceMiddleware(manager.Notebook.SearchHandler)).Methods("GET")
+
+	// процесс согласования журналов и очередь рецензента
+	company.HandleFunc("/reviews", middleware.PresenceMiddleware(manager.Notebook.ListReviewsHandler)).Methods("GET")
+	company.HandleFunc("/workflow", middleware.PresenceMiddleware(manager.Notebook.GetWorkflowHandler)).Methods("GET")
+	company.HandleFunc("/workflow", middleware.PresenceMiddleware(manager.Notebook.UpdateWorkflowHandler)).Methods("POST")
+
+	// словарь тегов компании и просмотр журналов по тегу
+	company.HandleFunc("/tag", middleware.PresenceMiddleware(manager.Tag.ListTagsHandler)).Methods("GET")
+	company.HandleFunc("/tag", middleware.PresenceMiddleware(manager.Tag.CreateTagHandler)).Methods("POST")
+	company.HandleFunc("/tag/suggest", middleware.PresenceMiddleware(manager.Tag.SuggestTagsHandler)).Methods("GET")
+	company.HandleFunc("/tag/{tag_id}", middleware.PresenceMiddleware(manager.Tag.UpdateTagHandler)).Methods("POST")
+	company.HandleFunc("/tag/{tag_id}", middleware.PresenceMiddleware(manager.Tag.DeleteTagHandler)).Methods("DELETE")
+	company.HandleFunc("/tag/{tag_id}/merge", middleware.PresenceMiddleware(manager.Tag.MergeTagsHandler)).Methods("POST")
+	company.HandleFunc("/tag/{tag_id}/notebooks", middleware.PresenceMiddleware(manager.Tag.ListTaggedNotebooksHandler)).Methods("GET")
+
+	// дерево папок отдела
+	company.HandleFunc("/department/{department_id}/folder", middleware.PresenceMiddleware(manager.Folder.GetDepartmentTreeHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/folder", middleware.PresenceMiddleware(manager.Folder.CreateFolderHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}", middleware.PresenceMiddleware(manager.Folder.GetFolderHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}", middleware.PresenceMiddleware(manager.Folder.RenameFolderHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}", middleware.PresenceMiddleware(manager.Folder.DeleteFolderHandler)).Methods("DELETE")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}/children", middleware.PresenceMiddleware(manager.Folder.ListChildrenHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}/tree", middleware.PresenceMiddleware(manager.Folder.GetFolderTreeHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}/move", middleware.PresenceMiddleware(manager.Folder.MoveFolderHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}/copy", middleware.PresenceMiddleware(manager.Folder.CopyFolderHandler)).Methods("POST")
+
+	// корзина отдела
+	company.HandleFunc("/department/{department_id}/trash", middleware.PresenceMiddleware(manager.Folder.ListTrashHandler)).Methods("GET")
+	company.HandleFunc("/trash/{item_id}/restore", middleware.PresenceMiddleware(manager.Folder.RestoreTrashItemHandler)).Methods("POST")
+	company.HandleFunc("/trash/{item_id}", middleware.PresenceMiddleware(manager.Folder.PurgeTrashItemHandler)).Methods("DELETE")
+
+	// отметка журналов и папок тегами
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/tags/{tag_id}", middleware.PresenceMiddleware(manager.Tag.TagNotebookHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/tags/{tag_id}", middleware.PresenceMiddleware(manager.Tag.UntagNotebookHandler)).Methods("DELETE")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}/tags/{tag_id}", middleware.PresenceMiddleware(manager.Tag.TagFolderHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/folder/{folder_id}/tags/{tag_id}", middleware.PresenceMiddleware(manager.Tag.UntagFolderHandler)).Methods("DELETE")
 
 	// работа с разрешениями журнала
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/notebook/{notebook_id}/permission", middleware.AuthMiddleware(manager.Permission.GetPermissionHandler)).Methods("GET")
-	r.HandleFunc("/labyrinth/user/{user_id}/company/{company_id}/department/{department_id}/notebook/{notebook_id}/permission", middleware.AuthMiddleware(manager.Permission.UpdatePermissionHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/permission", middleware.PresenceMiddleware(manager.Permission.GetPermissionHandler)).Methods("GET")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/permission", middleware.PresenceMiddleware(manager.Permission.UpdatePermissionHandler)).Methods("POST")
+	company.HandleFunc("/department/{department_id}/notebook/{notebook_id}/permission/effective", middleware.PresenceMiddleware(manager.Permission.GetEffectivePermissionHandler)).Methods("GET")
 	return r
 }